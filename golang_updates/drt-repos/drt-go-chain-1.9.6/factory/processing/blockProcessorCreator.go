@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	dataBlock "github.com/TerraDharitri/drt-go-chain-core/data/block"
 	logger "github.com/TerraDharitri/drt-go-chain-logger"
 	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
@@ -159,6 +160,7 @@ func (pcf *processComponentsFactory) newShardBlockProcessor(
 		pcf.config.SmartContractsStorage,
 		builtInFuncFactory.NFTStorageHandler(),
 		builtInFuncFactory.DCDTGlobalSettingsHandler(),
+		pcf.executionTracer,
 	)
 	if err != nil {
 		return nil, err
@@ -168,6 +170,7 @@ func (pcf *processComponentsFactory) newShardBlockProcessor(
 	if err != nil {
 		return nil, err
 	}
+	vmContainer = pcf.traceVMContainer(vmContainer)
 
 	err = builtInFuncFactory.SetPayableHandler(vmFactory.BlockChainHookImpl())
 	if err != nil {
@@ -497,6 +500,7 @@ func (pcf *processComponentsFactory) newMetaBlockProcessor(
 		pcf.config.SmartContractsStorage,
 		builtInFuncFactory.NFTStorageHandler(),
 		builtInFuncFactory.DCDTGlobalSettingsHandler(),
+		pcf.executionTracer,
 	)
 	if err != nil {
 		return nil, err
@@ -506,6 +510,7 @@ func (pcf *processComponentsFactory) newMetaBlockProcessor(
 	if err != nil {
 		return nil, err
 	}
+	vmContainer = pcf.traceVMContainer(vmContainer)
 
 	argsFactory := metachain.ArgsNewIntermediateProcessorsContainerFactory{
 		ShardCoordinator:        pcf.bootstrapComponents.ShardCoordinator(),
@@ -1049,6 +1054,7 @@ func (pcf *processComponentsFactory) createVMFactoryShard(
 	configSCStorage config.StorageConfig,
 	nftStorageHandler vmcommon.SimpleDCDTNFTStorageHandler,
 	globalSettingsHandler vmcommon.DCDTGlobalSettingsHandler,
+	executionTracer process.ExecutionTracer,
 ) (process.VirtualMachinesContainerFactory, error) {
 	counter, err := counters.NewUsageCounter(dcdtTransferParser)
 	if err != nil {
@@ -1084,7 +1090,7 @@ func (pcf *processComponentsFactory) createVMFactoryShard(
 	}
 
 	argsNewVMFactory := shard.ArgVMContainerFactory{
-		BlockChainHook:      blockChainHookImpl,
+		BlockChainHook:      pcf.traceBlockChainHook(executionTracer, blockChainHookImpl),
 		BuiltInFunctions:    argsHook.BuiltInFunctions,
 		Config:              pcf.config.VirtualMachine.Execution,
 		BlockGasLimit:       pcf.coreData.EconomicsData().MaxGasLimitPerBlock(pcf.bootstrapComponents.ShardCoordinator().SelfId()),
@@ -1106,6 +1112,7 @@ func (pcf *processComponentsFactory) createVMFactoryMeta(
 	configSCStorage config.StorageConfig,
 	nftStorageHandler vmcommon.SimpleDCDTNFTStorageHandler,
 	globalSettingsHandler vmcommon.DCDTGlobalSettingsHandler,
+	executionTracer process.ExecutionTracer,
 ) (process.VirtualMachinesContainerFactory, error) {
 	argsHook := hooks.ArgBlockChainHook{
 		Accounts:                 accounts,
@@ -1136,7 +1143,7 @@ func (pcf *processComponentsFactory) createVMFactoryMeta(
 	}
 
 	argsNewVMContainer := metachain.ArgsNewVMContainerFactory{
-		BlockChainHook:      blockChainHookImpl,
+		BlockChainHook:      pcf.traceBlockChainHook(executionTracer, blockChainHookImpl),
 		PubkeyConv:          argsHook.PubkeyConv,
 		Economics:           pcf.coreData.EconomicsData(),
 		MessageSignVerifier: pcf.crypto.MessageSignVerifier(),
@@ -1155,6 +1162,25 @@ func (pcf *processComponentsFactory) createVMFactoryMeta(
 	return metachain.NewVMContainerFactory(argsNewVMContainer)
 }
 
+func (pcf *processComponentsFactory) traceBlockChainHook(
+	executionTracer process.ExecutionTracer,
+	blockChainHook process.BlockChainHookWithAccountsAdapter,
+) process.BlockChainHookWithAccountsAdapter {
+	if check.IfNil(executionTracer) {
+		return blockChainHook
+	}
+
+	return executionTracer.WrapBlockChainHook(pcf.bootstrapComponents.ShardCoordinator().SelfId(), blockChainHook)
+}
+
+func (pcf *processComponentsFactory) traceVMContainer(vmContainer process.VirtualMachinesContainer) process.VirtualMachinesContainer {
+	if check.IfNil(pcf.executionTracer) {
+		return vmContainer
+	}
+
+	return pcf.executionTracer.WrapVMContainer(pcf.bootstrapComponents.ShardCoordinator().SelfId(), vmContainer)
+}
+
 func (pcf *processComponentsFactory) createBuiltInFunctionContainer(
 	accounts state.AccountsAdapter,
 	mapDNSAddresses map[string]struct{},
@@ -167,6 +167,9 @@ type ProcessComponentsFactoryArgs struct {
 	StatusComponents        factory.StatusComponentsHolder
 	StatusCoreComponents    factory.StatusCoreComponentsHolder
 	TxExecutionOrderHandler common.TxExecutionOrderHandler
+	// ExecutionTracer is optional. When provided, the smart contracts executions performed while processing blocks
+	// are traced through it
+	ExecutionTracer process.ExecutionTracer
 
 	GenesisNonce uint64
 	GenesisRound uint64
@@ -207,6 +210,7 @@ type processComponentsFactory struct {
 	statusComponents        factory.StatusComponentsHolder
 	statusCoreComponents    factory.StatusCoreComponentsHolder
 	txExecutionOrderHandler common.TxExecutionOrderHandler
+	executionTracer         process.ExecutionTracer
 
 	genesisNonce uint64
 	genesisRound uint64
@@ -254,6 +258,7 @@ func NewProcessComponentsFactory(args ProcessComponentsFactoryArgs) (*processCom
 		statusCoreComponents:           args.StatusCoreComponents,
 		flagsConfig:                    args.FlagsConfig,
 		txExecutionOrderHandler:        args.TxExecutionOrderHandler,
+		executionTracer:                args.ExecutionTracer,
 		genesisNonce:                   args.GenesisNonce,
 		genesisRound:                   args.GenesisRound,
 		roundConfig:                    args.RoundConfig,
@@ -137,6 +137,7 @@ func (pcf *processComponentsFactory) createArgsTxSimulatorProcessorForMeta(
 		pcf.config.SmartContractsStorageSimulate,
 		builtInFuncFactory.NFTStorageHandler(),
 		builtInFuncFactory.DCDTGlobalSettingsHandler(),
+		nil,
 	)
 	if err != nil {
 		return args, nil, nil, err
@@ -299,6 +300,7 @@ func (pcf *processComponentsFactory) createArgsTxSimulatorProcessorShard(
 		smartContractStorageSimulate,
 		builtInFuncFactory.NFTStorageHandler(),
 		builtInFuncFactory.DCDTGlobalSettingsHandler(),
+		nil,
 	)
 	if err != nil {
 		return args, nil, nil, err
@@ -33,6 +33,8 @@ type StateComponentsFactoryArgs struct {
 	ProcessingMode           common.NodeProcessingMode
 	ShouldSerializeSnapshots bool
 	ChainHandler             chainData.ChainHandler
+	// AccountFactory is optional. When not provided, the user accounts are created by the default account creator
+	AccountFactory state.AccountFactory
 }
 
 type stateComponentsFactory struct {
@@ -43,6 +45,7 @@ type stateComponentsFactory struct {
 	processingMode           common.NodeProcessingMode
 	shouldSerializeSnapshots bool
 	chainHandler             chainData.ChainHandler
+	accountFactory           state.AccountFactory
 }
 
 // stateComponents struct holds the state components of the DharitrI protocol
@@ -76,6 +79,7 @@ func NewStateComponentsFactory(args StateComponentsFactoryArgs) (*stateComponent
 		processingMode:           args.ProcessingMode,
 		shouldSerializeSnapshots: args.ShouldSerializeSnapshots,
 		chainHandler:             args.ChainHandler,
+		accountFactory:           args.AccountFactory,
 	}, nil
 }
 
@@ -136,13 +140,22 @@ func (scf *stateComponentsFactory) createSnapshotManager(
 	return state.NewSnapshotsManager(argsSnapshotsManager)
 }
 
-func (scf *stateComponentsFactory) createAccountsAdapters(triesContainer common.TriesHolder) (state.AccountsAdapter, state.AccountsAdapter, state.AccountsRepository, error) {
+func (scf *stateComponentsFactory) createAccountFactory() (state.AccountFactory, error) {
+	if !check.IfNil(scf.accountFactory) {
+		return scf.accountFactory, nil
+	}
+
 	argsAccCreator := factoryState.ArgsAccountCreator{
 		Hasher:              scf.core.Hasher(),
 		Marshaller:          scf.core.InternalMarshalizer(),
 		EnableEpochsHandler: scf.core.EnableEpochsHandler(),
 	}
-	accountFactory, err := factoryState.NewAccountCreator(argsAccCreator)
+
+	return factoryState.NewAccountCreator(argsAccCreator)
+}
+
+func (scf *stateComponentsFactory) createAccountsAdapters(triesContainer common.TriesHolder) (state.AccountsAdapter, state.AccountsAdapter, state.AccountsRepository, error) {
+	accountFactory, err := scf.createAccountFactory()
 	if err != nil {
 		return nil, nil, nil, err
 	}
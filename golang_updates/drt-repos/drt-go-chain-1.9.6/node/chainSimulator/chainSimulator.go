@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"math/big"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -60,6 +62,12 @@ type ArgsChainSimulator struct {
 	ApiInterface               components.APIConfigurator
 	AlterConfigsFunction       func(cfg *config.Configs)
 	VmQueryDelayAfterStartInMs uint64
+	// DataDir is optional. When provided, all the nodes data is persisted under this directory and the chain
+	// simulator resumes from the last generated block when it is started again with the same directory
+	DataDir string
+	// Fork is optional. When the proxy URL is provided, the accounts missing from the shards are loaded on first
+	// access from the remote network, as they were at the provided metachain block nonce
+	Fork ForkConfig
 }
 
 // ArgsBaseChainSimulator holds the arguments needed to create a new instance of simulator
@@ -78,6 +86,17 @@ type simulator struct {
 	validatorsPrivateKeys  []crypto.PrivateKey
 	nodes                  map[uint32]process.NodeHandler
 	numOfShards            uint32
+	snapshots              map[string]map[uint32]*dtos.NodeSnapshot
+	lastSnapshotID         uint64
+	dataDir                string
+	genesisTimestamp       int64
+	roundsPerEpoch         int64
+	resumedState           *lastState
+	lastStateEnabled       bool
+	addedValidatorsKeys    [][]byte
+	remoteState            components.RemoteStateHandler
+	executionTracer        components.ExecutionTracerHandler
+	eventsStream           components.EventsStreamHandler
 	mutex                  sync.RWMutex
 }
 
@@ -100,6 +119,10 @@ func NewBaseChainSimulator(args ArgsBaseChainSimulator) (*simulator, error) {
 		chanStopNodeProcess:    make(chan endProcess.ArgEndProcess),
 		mutex:                  sync.RWMutex{},
 		initialStakedKeys:      make(map[string]*dtos.BLSKey),
+		snapshots:              make(map[string]map[uint32]*dtos.NodeSnapshot),
+		dataDir:                args.DataDir,
+		addedValidatorsKeys:    make([][]byte, 0),
+		eventsStream:           components.NewEventsStream(),
 	}
 
 	err := instance.createChainHandlers(args)
@@ -111,6 +134,28 @@ func NewBaseChainSimulator(args ArgsBaseChainSimulator) (*simulator, error) {
 }
 
 func (s *simulator) createChainHandlers(args ArgsBaseChainSimulator) error {
+	var err error
+	s.resumedState, err = loadLastState(args.DataDir, args.NumOfShards)
+	if err != nil {
+		return err
+	}
+
+	var initialWallets *dtos.InitialWalletKeys
+	var validatorsPrivateKeys [][]byte
+	if s.resumedState != nil {
+		args.GenesisTimestamp = s.resumedState.GenesisTimestamp
+		args.InitialEpoch = s.resumedState.Epoch
+		args.InitialRound = s.resumedState.Round
+		initialWallets = s.resumedState.InitialWallets
+		validatorsPrivateKeys = s.resumedState.ValidatorsPrivateKeys
+	}
+	s.genesisTimestamp = args.GenesisTimestamp
+
+	err = s.createRemoteState(args)
+	if err != nil {
+		return err
+	}
+
 	outputConfigs, err := configs.CreateChainSimulatorConfigs(configs.ArgsChainSimulatorConfigs{
 		NumOfShards:                 args.NumOfShards,
 		OriginalConfigsPath:         args.PathToInitialConfig,
@@ -127,11 +172,18 @@ func (s *simulator) createChainHandlers(args ArgsBaseChainSimulator) error {
 		AlterConfigsFunction:        args.AlterConfigsFunction,
 		NumNodesWaitingListShard:    args.NumNodesWaitingListShard,
 		NumNodesWaitingListMeta:     args.NumNodesWaitingListMeta,
+		InitialWallets:              initialWallets,
+		ValidatorsPrivateKeys:       validatorsPrivateKeys,
 	})
 	if err != nil {
 		return err
 	}
 
+	err = s.createExecutionTracer(outputConfigs.Configs.GeneralConfig.AddressPubkeyConverter)
+	if err != nil {
+		return err
+	}
+
 	for idx := -1; idx < int(args.NumOfShards); idx++ {
 		shardIDStr := fmt.Sprintf("%d", idx)
 		if idx == -1 {
@@ -179,18 +231,28 @@ func (s *simulator) createChainHandlers(args ArgsBaseChainSimulator) error {
 	}
 
 	s.initialWalletKeys = outputConfigs.InitialWallets
+	s.roundsPerEpoch = outputConfigs.Configs.GeneralConfig.EpochStartConfig.RoundsPerEpoch
 	s.validatorsPrivateKeys = outputConfigs.ValidatorsPrivateKeys
 
 	s.addProofs()
 	s.setBasePeerIds()
 
+	if s.resumedState != nil {
+		err = s.resumeFromLastState(s.resumedState)
+		if err != nil {
+			return err
+		}
+	}
+	s.lastStateEnabled = len(args.DataDir) > 0
+
 	log.Info("running the chain simulator with the following parameters",
 		"number of shards (including meta)", args.NumOfShards+1,
 		"round per epoch", outputConfigs.Configs.GeneralConfig.EpochStartConfig.RoundsPerEpoch,
 		"round duration", time.Millisecond*time.Duration(args.RoundDurationInMillis),
 		"genesis timestamp", args.GenesisTimestamp,
 		"original config path", args.PathToInitialConfig,
-		"temporary path", args.TempDir)
+		"temporary path", args.TempDir,
+		"data directory", args.DataDir)
 
 	return nil
 }
@@ -245,13 +307,17 @@ func (s *simulator) createTestNode(
 		APIInterface:                args.ApiInterface,
 		BypassTxSignatureCheck:      args.BypassTxSignatureCheck,
 		InitialRound:                args.InitialRound,
-		InitialNonce:                args.InitialNonce,
+		InitialNonce:                s.getInitialNonce(args, shardIDStr),
 		MinNodesPerShard:            args.MinNodesPerShard,
 		ConsensusGroupSize:          args.ConsensusGroupSize,
 		MinNodesMeta:                args.MetaChainMinNodes,
 		MetaChainConsensusGroupSize: args.MetaChainConsensusGroupSize,
 		RoundDurationInMillis:       args.RoundDurationInMillis,
 		VmQueryDelayAfterStartInMs:  args.VmQueryDelayAfterStartInMs,
+		DataDir:                     getNodeDataDir(args.DataDir, shardIDStr),
+		RemoteState:                 s.getRemoteState(shardIDStr),
+		ExecutionTracer:             s.executionTracer,
+		EventsStream:                s.eventsStream,
 	}
 
 	return components.NewTestOnlyProcessingNode(argsTestOnlyProcessorNode)
@@ -370,7 +436,7 @@ func (s *simulator) allNodesCreateBlocks() error {
 		}
 	}
 
-	return nil
+	return s.saveLastState()
 }
 
 // GetNodeHandler returns the node handler from the provided shardID
@@ -411,6 +477,8 @@ func (s *simulator) AddValidatorKeys(validatorsPrivateKeys [][]byte) error {
 		}
 	}
 
+	s.addedValidatorsKeys = append(s.addedValidatorsKeys, validatorsPrivateKeys...)
+
 	return nil
 }
 
@@ -545,6 +613,53 @@ func (s *simulator) SetStateMultiple(stateSlice []*dtos.AddressState) error {
 	return nil
 }
 
+// DumpState will return the state of all the accounts from all the shards, in the format accepted by SetStateMultiple.
+// The system account exists on every shard, so it is returned once, holding the key-value pairs from all the shards
+func (s *simulator) DumpState() ([]*dtos.AddressState, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	addressConverter := s.nodes[core.MetachainShardId].GetCoreComponents().AddressPubKeyConverter()
+	systemAccountAddress, err := addressConverter.Encode(core.SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	systemAccountState := &dtos.AddressState{
+		Address: systemAccountAddress,
+		Pairs:   make(map[string]string),
+	}
+	allStates := make([]*dtos.AddressState, 0)
+	for shardID, node := range s.nodes {
+		nodeStates, errDump := node.DumpState()
+		if errDump != nil {
+			return nil, fmt.Errorf("%w for shard %d", errDump, shardID)
+		}
+
+		for _, addressState := range nodeStates {
+			if addressState.Address != systemAccountAddress {
+				allStates = append(allStates, addressState)
+				continue
+			}
+
+			for key, value := range addressState.Pairs {
+				systemAccountState.Pairs[key] = value
+			}
+		}
+	}
+
+	if len(systemAccountState.Pairs) > 0 {
+		allStates = append(allStates, systemAccountState)
+	}
+
+	// sorted, so dumps of the same state can be compared
+	sort.Slice(allStates, func(i, j int) bool {
+		return allStates[i].Address < allStates[j].Address
+	})
+
+	return allStates, nil
+}
+
 // RemoveAccounts will try to remove all accounts data for the addresses provided
 func (s *simulator) RemoveAccounts(addresses []string) error {
 	s.mutex.Lock()
@@ -712,6 +827,67 @@ func (s *simulator) removeAllSystemAccounts() error {
 	return nil
 }
 
+// TakeSnapshot will save the state of all the nodes and will return the identifier of the snapshot
+func (s *simulator) TakeSnapshot() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	nodesSnapshots := make(map[uint32]*dtos.NodeSnapshot, len(s.nodes))
+	for shardID, node := range s.nodes {
+		snapshot, err := node.TakeSnapshot()
+		if err != nil {
+			return "", fmt.Errorf("%w for shard %d", err, shardID)
+		}
+
+		nodesSnapshots[shardID] = snapshot
+	}
+
+	s.lastSnapshotID++
+	snapshotID := strconv.FormatUint(s.lastSnapshotID, 10)
+	s.snapshots[snapshotID] = nodesSnapshots
+
+	log.Info("took snapshot of the chain state", "id", snapshotID)
+
+	return snapshotID, nil
+}
+
+// RevertToSnapshot will bring all the nodes back to the state saved in the provided snapshot.
+// The snapshot is kept, so the chain can be reverted to it again
+func (s *simulator) RevertToSnapshot(snapshotID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	nodesSnapshots, found := s.snapshots[snapshotID]
+	if !found {
+		return fmt.Errorf("%w, id: %s", chainSimulatorErrors.ErrSnapshotNotFound, snapshotID)
+	}
+
+	// the epochs are checked upfront, so no node is left reverted while the others are not
+	for shardID, node := range s.nodes {
+		currentEpoch := node.GetProcessComponents().EpochStartTrigger().Epoch()
+		if currentEpoch != nodesSnapshots[shardID].Epoch {
+			return fmt.Errorf("%w, snapshot epoch: %d, current epoch: %d, shard: %d",
+				components.ErrSnapshotFromOtherEpoch, nodesSnapshots[shardID].Epoch, currentEpoch, shardID)
+		}
+	}
+
+	for shardID, node := range s.nodes {
+		err := node.RevertToSnapshot(nodesSnapshots[shardID])
+		if err != nil {
+			return fmt.Errorf("%w for shard %d", err, shardID)
+		}
+	}
+
+	log.Info("reverted the chain state to snapshot", "id", snapshotID)
+
+	err := s.nodes[core.MetachainShardId].GetProcessComponents().ValidatorsProvider().ForceUpdate()
+	if err != nil {
+		return err
+	}
+
+	return s.saveLastState()
+}
+
 // GetAccount will fetch the account of the provided address
 func (s *simulator) GetAccount(address dtos.WalletAddress) (api.AccountResponse, error) {
 	destinationShardID := s.GetNodeHandler(0).GetShardCoordinator().ComputeId(address.Bytes)
@@ -726,13 +902,23 @@ func (s *simulator) Close() {
 	defer s.mutex.Unlock()
 
 	var errorStrings []string
+	err := s.saveLastState()
+	if err != nil {
+		errorStrings = append(errorStrings, err.Error())
+	}
+
 	for _, n := range s.nodes {
-		err := n.Close()
+		err = n.Close()
 		if err != nil {
 			errorStrings = append(errorStrings, err.Error())
 		}
 	}
 
+	err = s.eventsStream.Close()
+	if err != nil {
+		errorStrings = append(errorStrings, err.Error())
+	}
+
 	if len(errorStrings) != 0 {
 		log.Error("error closing chain simulator", "error", components.AggregateErrors(errorStrings, components.ErrClose))
 	}
@@ -1,6 +1,7 @@
 package chainSimulator
 
 import (
+	"encoding/hex"
 	"math/big"
 	"strings"
 	"testing"
@@ -9,11 +10,16 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
 	"github.com/TerraDharitri/drt-go-chain/config"
+	"github.com/TerraDharitri/drt-go-chain/dataRetriever"
 	"github.com/TerraDharitri/drt-go-chain/errors"
 	chainSimulatorCommon "github.com/TerraDharitri/drt-go-chain/integrationTests/chainSimulator"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/components"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/components/api"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/configs"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	chainSimulatorErrors "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/errors"
+	"github.com/TerraDharitri/drt-go-chain/state"
+	chainSimulatorMocks "github.com/TerraDharitri/drt-go-chain/testscommon/chainSimulator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -441,3 +447,679 @@ func TestSimulator_SentMoveBalanceNoGasForFee(t *testing.T) {
 	_, err = chainSimulator.sendTx(ftx)
 	require.True(t, strings.Contains(err.Error(), errors.ErrInsufficientFunds.Error()))
 }
+
+func TestSimulator_SnapshotAndRevert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	startTime := time.Now().Unix()
+	roundDurationInMillis := uint64(6000)
+	roundsPerEpoch := core.OptionalUint64{
+		HasValue: true,
+		Value:    20,
+	}
+	chainSimulator, err := NewChainSimulator(ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       startTime,
+		RoundDurationInMillis:  roundDurationInMillis,
+		RoundsPerEpoch:         roundsPerEpoch,
+		ApiInterface:           api.NewNoApiInterface(),
+		MinNodesPerShard:       1,
+		MetaChainMinNodes:      1,
+	})
+	require.Nil(t, err)
+	require.NotNil(t, chainSimulator)
+
+	defer chainSimulator.Close()
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	err = chainSimulator.RevertToSnapshot("missing")
+	require.True(t, strings.Contains(err.Error(), chainSimulatorErrors.ErrSnapshotNotFound.Error()))
+
+	snapshotID, err := chainSimulator.TakeSnapshot()
+	require.Nil(t, err)
+
+	nonces := make(map[uint32]uint64)
+	for shardID, node := range chainSimulator.nodes {
+		nonces[shardID] = node.GetChainHandler().GetCurrentBlockHeader().GetNonce()
+	}
+	lastTimestamp := chainSimulator.getLastBlockTimestamp()
+
+	wallet, err := chainSimulator.GenerateAndMintWalletAddress(0, big.NewInt(1000))
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(5)
+	require.Nil(t, err)
+
+	// the time travel done after the snapshot is reverted as well
+	err = chainSimulator.AdvanceTime(time.Hour)
+	require.Nil(t, err)
+
+	for i := 0; i < 2; i++ {
+		err = chainSimulator.RevertToSnapshot(snapshotID)
+		require.Nil(t, err)
+
+		for shardID, node := range chainSimulator.nodes {
+			require.Equal(t, nonces[shardID], node.GetChainHandler().GetCurrentBlockHeader().GetNonce())
+
+			// the blocks generated after the snapshot were removed from the storage
+			storer, errGet := node.GetDataComponents().StorageService().GetStorer(dataRetriever.GetHdrNonceHashDataUnit(shardID))
+			require.Nil(t, errGet)
+			nonceKey := node.GetCoreComponents().Uint64ByteSliceConverter().ToByteSlice(nonces[shardID] + 1)
+			require.NotNil(t, storer.Has(nonceKey))
+		}
+
+		_, err = chainSimulator.GetNodeHandler(0).GetStateComponents().AccountsAdapter().GetExistingAccount(wallet.Bytes)
+		require.NotNil(t, err)
+
+		// the chain keeps working after the revert
+		err = chainSimulator.GenerateBlocks(1)
+		require.Nil(t, err)
+		for _, node := range chainSimulator.nodes {
+			roundHandler, errGet := getTimeTravelRoundHandler(node)
+			require.Nil(t, errGet)
+			require.Equal(t, time.Duration(0), roundHandler.TimeOffset())
+			require.Equal(t, uint64(lastTimestamp)+roundDurationInMillis/1000, node.GetChainHandler().GetCurrentBlockHeader().GetTimeStamp())
+		}
+
+		err = chainSimulator.GenerateBlocks(2)
+		require.Nil(t, err)
+	}
+}
+
+func TestSimulator_ResumeFromDataDir(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	dataDir := t.TempDir()
+	args := ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  uint64(6000),
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+		DataDir:           dataDir,
+	}
+
+	chainSimulator, err := NewChainSimulator(args)
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(5)
+	require.Nil(t, err)
+
+	wallet, err := chainSimulator.GenerateAndMintWalletAddress(0, big.NewInt(1000))
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	nonces := make(map[uint32]uint64)
+	headHashes := make(map[uint32][]byte)
+	for shardID, node := range chainSimulator.nodes {
+		nonces[shardID] = node.GetChainHandler().GetCurrentBlockHeader().GetNonce()
+		headHashes[shardID] = node.GetChainHandler().GetCurrentBlockHeaderHash()
+	}
+	round := chainSimulator.GetNodeHandler(core.MetachainShardId).GetCoreComponents().RoundHandler().Index()
+	initialWallets := chainSimulator.GetInitialWalletKeys()
+	chainSimulator.Close()
+
+	args.TempDir = t.TempDir()
+	args.GenesisTimestamp = time.Now().Unix()
+	chainSimulator, err = NewChainSimulator(args)
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	require.Equal(t, initialWallets, chainSimulator.GetInitialWalletKeys())
+	require.Equal(t, round, chainSimulator.GetNodeHandler(core.MetachainShardId).GetCoreComponents().RoundHandler().Index())
+
+	account, err := chainSimulator.GetAccount(wallet)
+	require.Nil(t, err)
+	require.Equal(t, "1000", account.Balance)
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	for shardID, node := range chainSimulator.nodes {
+		require.Equal(t, nonces[shardID]+1, node.GetChainHandler().GetCurrentBlockHeader().GetNonce())
+		// the new block is chained to the last block generated before the restart
+		require.Equal(t, headHashes[shardID], node.GetChainHandler().GetCurrentBlockHeader().GetPrevHash())
+	}
+
+	// the last state follows the reverts, so the chain simulator is resumed from the reverted block
+	snapshotID, err := chainSimulator.TakeSnapshot()
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(2)
+	require.Nil(t, err)
+
+	err = chainSimulator.RevertToSnapshot(snapshotID)
+	require.Nil(t, err)
+
+	savedState, err := loadLastState(dataDir, args.NumOfShards)
+	require.Nil(t, err)
+	require.Equal(t, chainSimulator.GetNodeHandler(core.MetachainShardId).GetCoreComponents().RoundHandler().Index(), savedState.Round)
+	for shardID, node := range chainSimulator.nodes {
+		require.Equal(t, nonces[shardID]+1, savedState.Nodes[shardID].Nonce)
+		require.Equal(t, node.GetChainHandler().GetCurrentBlockHeaderHash(), savedState.Nodes[shardID].HeaderHash)
+	}
+
+	t.Run("different number of shards should error", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.TempDir = t.TempDir()
+		argsCopy.NumOfShards = 2
+
+		_, errCreate := NewChainSimulator(argsCopy)
+		require.True(t, strings.Contains(errCreate.Error(), chainSimulatorErrors.ErrInvalidLastState.Error()))
+	})
+}
+
+func TestSimulator_DumpState(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	args := ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  uint64(6000),
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+	}
+	chainSimulator, err := NewChainSimulator(args)
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	nonce := uint64(0)
+	contractState := &dtos.AddressState{
+		Address:          "drt1qqqqqqqqqqqqqpgqmzzm05jeav6d5qvna0q2pmcllelkz8xddz3sew8p92",
+		Nonce:            &nonce,
+		Balance:          "431271308732096033771131",
+		Code:             "0061736d010000000129086000006000017f60027f7f017f60027f7f0060017f0060037f7f7f017f60037f7f7f0060017f017f0290020b03656e7619626967496e74476574556e7369676e6564417267756d656e74000303656e760f6765744e756d417267756d656e7473000103656e760b7369676e616c4572726f72000303656e76126d42756666657253746f726167654c6f6164000203656e76176d427566666572546f426967496e74556e7369676e6564000203656e76196d42756666657246726f6d426967496e74556e7369676e6564000203656e76136d42756666657253746f7261676553746f7265000203656e760f6d4275666665725365744279746573000503656e760e636865636b4e6f5061796d656e74000003656e7614626967496e7446696e697368556e7369676e6564000403656e7609626967496e744164640006030b0a010104070301000000000503010003060f027f0041a080080b7f0041a080080b074607066d656d6f7279020004696e697400110667657453756d00120361646400130863616c6c4261636b00140a5f5f646174615f656e6403000b5f5f686561705f6261736503010aca010a0e01017f4100100c2200100020000b1901017f419c8008419c800828020041016b220036020020000b1400100120004604400f0b4180800841191002000b16002000100c220010031a2000100c220010041a20000b1401017f100c2202200110051a2000200210061a0b1301017f100c220041998008410310071a20000b1401017f10084101100d100b210010102000100f0b0e0010084100100d1010100e10090b2201037f10084101100d100b210110102202100e220020002001100a20022000100f0b0300010b0b2f0200418080080b1c77726f6e67206e756d626572206f6620617267756d656e747373756d00419c80080b049cffffff",
+		CodeHash:         "n9EviPlHS6EV+3Xp0YqP28T0IUfeAFRFBIRC1Jw6pyU=",
+		CodeMetadata:     "BQY=",
+		Owner:            "drt1ss6u80ruas2phpmr82r42xnkd6rxy40g9jl69frppl4qez9w2jpsaws9xq",
+		DeveloperRewards: "5401004999998",
+		Pairs: map[string]string{
+			"73756d": "0a",
+		},
+	}
+	err = chainSimulator.SetStateMultiple([]*dtos.AddressState{contractState})
+	require.Nil(t, err)
+
+	dump, err := chainSimulator.DumpState()
+	require.Nil(t, err)
+
+	var dumpedContractState *dtos.AddressState
+	numSystemAccounts := 0
+	systemAccountAddress := chainSimulator.GetNodeHandler(0).GetCoreComponents().AddressPubKeyConverter().SilentEncode(core.SystemAccountAddress, log)
+	for _, addressState := range dump {
+		if addressState.Address == contractState.Address {
+			dumpedContractState = addressState
+		}
+		if addressState.Address == systemAccountAddress {
+			numSystemAccounts++
+		}
+	}
+	require.Equal(t, contractState, dumpedContractState)
+	require.LessOrEqual(t, numSystemAccounts, 1)
+
+	for _, wallet := range chainSimulator.GetInitialWalletKeys().BalanceWallets {
+		found := false
+		for _, addressState := range dump {
+			found = found || addressState.Address == wallet.Address.Bech32
+		}
+		require.True(t, found)
+	}
+
+	// the dump can be loaded in another chain simulator
+	args.TempDir = t.TempDir()
+	otherChainSimulator, err := NewChainSimulator(args)
+	require.Nil(t, err)
+
+	defer otherChainSimulator.Close()
+
+	err = otherChainSimulator.SetStateMultiple([]*dtos.AddressState{dumpedContractState})
+	require.Nil(t, err)
+
+	otherDump, err := otherChainSimulator.DumpState()
+	require.Nil(t, err)
+	require.Contains(t, otherDump, contractState)
+}
+
+func TestSimulator_TimeTravel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	roundDuration := uint64(6000)
+	args := ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  roundDuration,
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+	}
+	chainSimulator, err := NewChainSimulator(args)
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	requireSameTimestampOnAllNodes := func(expectedTimestamp uint64) {
+		for _, node := range chainSimulator.nodes {
+			require.Equal(t, expectedTimestamp, node.GetChainHandler().GetCurrentBlockHeader().GetTimeStamp())
+		}
+	}
+
+	lastTimestamp := chainSimulator.getLastBlockTimestamp()
+	err = chainSimulator.SetNextBlockTimestamp(lastTimestamp)
+	require.ErrorIs(t, err, chainSimulatorErrors.ErrInvalidTimestamp)
+
+	nextTimestamp := lastTimestamp + 100000
+	err = chainSimulator.SetNextBlockTimestamp(nextTimestamp)
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(2)
+	require.Nil(t, err)
+	requireSameTimestampOnAllNodes(uint64(nextTimestamp) + roundDuration/1000)
+
+	err = chainSimulator.AdvanceTime(0)
+	require.ErrorIs(t, err, chainSimulatorErrors.ErrInvalidTimeDuration)
+
+	err = chainSimulator.AdvanceTime(time.Hour)
+	require.Nil(t, err)
+
+	lastTimestamp = chainSimulator.getLastBlockTimestamp()
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+	requireSameTimestampOnAllNodes(uint64(lastTimestamp) + uint64(time.Hour.Seconds()) + roundDuration/1000)
+
+	currentRound := chainSimulator.GetNodeHandler(core.MetachainShardId).GetCoreComponents().RoundHandler().Index()
+	err = chainSimulator.JumpToRound(currentRound)
+	require.ErrorIs(t, err, chainSimulatorErrors.ErrInvalidRound)
+
+	lastTimestamp = chainSimulator.getLastBlockTimestamp()
+	err = chainSimulator.JumpToRound(currentRound + 10)
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+	requireSameTimestampOnAllNodes(uint64(lastTimestamp) + 11*roundDuration/1000)
+	for _, node := range chainSimulator.nodes {
+		require.Equal(t, uint64(currentRound+11), node.GetChainHandler().GetCurrentBlockHeader().GetRound())
+	}
+
+	// jumping past the end of the epoch starts a new epoch with the next block
+	err = chainSimulator.JumpToRound(currentRound + 30)
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocksUntilEpochIsReached(1)
+	require.Nil(t, err)
+
+	// the next block starts a single epoch, so the rounds of more than one epoch cannot be skipped
+	epochStartTrigger := chainSimulator.GetNodeHandler(core.MetachainShardId).GetProcessComponents().EpochStartTrigger()
+	epochStartRound := int64(epochStartTrigger.EpochStartRound())
+	err = chainSimulator.JumpToRound(epochStartRound + 2*int64(args.RoundsPerEpoch.Value))
+	require.ErrorIs(t, err, chainSimulatorErrors.ErrJumpOverMultipleEpochs)
+
+	err = chainSimulator.JumpToRound(epochStartRound + 2*int64(args.RoundsPerEpoch.Value) - 1)
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocksUntilEpochIsReached(2)
+	require.Nil(t, err)
+}
+
+func TestSimulator_Fork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	server, err := chainSimulatorMocks.NewRecordedProxyServer("testdata/forkedNetwork.json")
+	require.Nil(t, err)
+
+	defer server.Close()
+
+	args := ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  uint64(6000),
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+		Fork: ForkConfig{
+			ProxyURL:   server.URL,
+			BlockNonce: 100,
+		},
+	}
+
+	t.Run("different number of shards should error", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.TempDir = t.TempDir()
+		argsCopy.NumOfShards = 2
+
+		_, errCreate := NewChainSimulator(argsCopy)
+		require.ErrorIs(t, errCreate, chainSimulatorErrors.ErrInvalidNumOfShardsForFork)
+	})
+
+	chainSimulator, err := NewChainSimulator(args)
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	wallet := dtos.WalletAddress{Bech32: "drt1qtc600lryvytxuy4h7vn7xmsy5tw6vuw3tskr75cwnmv4mnyjgsq89rptv"}
+	wallet.Bytes, err = chainSimulator.GetNodeHandler(0).GetCoreComponents().AddressPubKeyConverter().Decode(wallet.Bech32)
+	require.Nil(t, err)
+
+	// the account is loaded from the forked network on the first access, before any transaction touches it
+	account, err := chainSimulator.GetAccount(wallet)
+	require.Nil(t, err)
+	require.Equal(t, "5000000000000000000", account.Balance)
+	require.Equal(t, uint64(7), account.Nonce)
+
+	receiver := chainSimulator.GenerateAddressInShard(0)
+	tx := &transaction.Transaction{
+		Nonce:     7,
+		Value:     big.NewInt(1000),
+		SndAddr:   wallet.Bytes,
+		RcvAddr:   receiver.Bytes,
+		Data:      []byte{},
+		GasLimit:  50_000,
+		GasPrice:  1_000_000_000,
+		ChainID:   []byte(configs.ChainID),
+		Version:   1,
+		Signature: []byte("010101"),
+	}
+	_, err = chainSimulator.SendTxAndGenerateBlockTilTxIsExecuted(tx, 3)
+	require.Nil(t, err)
+
+	account, err = chainSimulator.GetAccount(wallet)
+	require.Nil(t, err)
+	require.Equal(t, uint64(8), account.Nonce)
+
+	// the contract is loaded with its code and storage
+	contractAddress := "drt1qqqqqqqqqqqqqpgqmzzm05jeav6d5qvna0q2pmcllelkz8xddz3sew8p92"
+	contractAddressBytes, err := chainSimulator.GetNodeHandler(1).GetCoreComponents().AddressPubKeyConverter().Decode(contractAddress)
+	require.Nil(t, err)
+	contract, err := chainSimulator.GetNodeHandler(1).GetStateComponents().AccountsAdapter().GetExistingAccount(contractAddressBytes)
+	require.Nil(t, err)
+
+	value, _, err := contract.(state.UserAccountHandler).RetrieveValue([]byte("sum"))
+	require.Nil(t, err)
+	require.Equal(t, []byte{10}, value)
+	require.NotEmpty(t, contract.(state.UserAccountHandler).GetCode())
+}
+
+func TestSimulator_GetTransactionTrace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	chainSimulator, err := NewChainSimulator(ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  uint64(6000),
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+	})
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	wallet, err := chainSimulator.GenerateAndMintWalletAddress(0, big.NewInt(0).Mul(big.NewInt(1000000000000000000), big.NewInt(10)))
+	require.Nil(t, err)
+
+	tx := &transaction.Transaction{
+		Nonce:     0,
+		Value:     big.NewInt(0),
+		SndAddr:   wallet.Bytes,
+		RcvAddr:   wallet.Bytes,
+		Data:      []byte(core.BuiltInFunctionSaveKeyValue + "@6b6579@76616c7565"),
+		GasLimit:  5_000_000,
+		GasPrice:  1_000_000_000,
+		ChainID:   []byte(configs.ChainID),
+		Version:   1,
+		Signature: []byte("010101"),
+	}
+	result, err := chainSimulator.SendTxAndGenerateBlockTilTxIsExecuted(tx, 3)
+	require.Nil(t, err)
+
+	trace, err := chainSimulator.GetTransactionTrace(result.Hash)
+	require.Nil(t, err)
+	require.Equal(t, result.Hash, trace.Hash)
+	require.Len(t, trace.Executions, 1)
+	require.Equal(t, "builtInFunction", trace.Executions[0].Type)
+	require.Equal(t, core.BuiltInFunctionSaveKeyValue, trace.Executions[0].Function)
+	require.Equal(t, wallet.Bech32, trace.Executions[0].Caller)
+	require.Equal(t, []string{"6b6579", "76616c7565"}, trace.Executions[0].Arguments)
+	require.Equal(t, uint32(0), trace.Executions[0].Shard)
+
+	_, err = chainSimulator.GetTransactionTrace("not a hash")
+	require.NotNil(t, err)
+
+	_, err = chainSimulator.GetTransactionTrace(hex.EncodeToString([]byte("unknown hash")))
+	require.ErrorIs(t, err, components.ErrTransactionTraceNotFound)
+}
+
+func TestSimulator_PendingTransactionsAndBlockWithTransactions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	chainSimulator, err := NewChainSimulator(ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  uint64(6000),
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+	})
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	wallet, err := chainSimulator.GenerateAndMintWalletAddress(0, big.NewInt(0).Mul(big.NewInt(1000000000000000000), big.NewInt(10)))
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	txHashes := make([]string, 0, 3)
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		txHash, errSend := chainSimulator.sendTx(&transaction.Transaction{
+			Nonce:     nonce,
+			Value:     big.NewInt(1),
+			SndAddr:   wallet.Bytes,
+			RcvAddr:   wallet.Bytes,
+			GasLimit:  50_000,
+			GasPrice:  1_000_000_000,
+			ChainID:   []byte(configs.ChainID),
+			Version:   1,
+			Signature: []byte("010101"),
+		})
+		require.Nil(t, errSend)
+
+		txHashes = append(txHashes, txHash)
+	}
+
+	pendingTransactions, err := chainSimulator.GetPendingTransactions(0)
+	require.Nil(t, err)
+	require.Len(t, pendingTransactions, 3)
+	for idx, pendingTx := range pendingTransactions {
+		require.Equal(t, txHashes[idx], pendingTx.Hash)
+		require.Equal(t, wallet.Bech32, pendingTx.Sender)
+		require.Equal(t, uint64(idx), pendingTx.Nonce)
+	}
+
+	_, err = chainSimulator.GetPendingTransactions(5)
+	require.ErrorIs(t, err, chainSimulatorErrors.ErrInvalidShardID)
+
+	err = chainSimulator.RemovePendingTransactions([]string{txHashes[2]})
+	require.Nil(t, err)
+
+	err = chainSimulator.RemovePendingTransactions([]string{txHashes[2]})
+	require.ErrorIs(t, err, components.ErrTransactionNotInPool)
+
+	err = chainSimulator.GenerateBlockWithTransactions([]string{txHashes[0]})
+	require.Nil(t, err)
+
+	pendingTransactions, err = chainSimulator.GetPendingTransactions(0)
+	require.Nil(t, err)
+	require.Len(t, pendingTransactions, 1)
+	require.Equal(t, txHashes[1], pendingTransactions[0].Hash)
+
+	account, err := chainSimulator.GetAccount(wallet)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), account.Nonce)
+
+	err = chainSimulator.GenerateBlockWithTransactions([]string{txHashes[0]})
+	require.ErrorIs(t, err, components.ErrTransactionNotInPool)
+
+	err = chainSimulator.GenerateBlockWithTransactions([]string{txHashes[1], txHashes[1]})
+	require.ErrorIs(t, err, chainSimulatorErrors.ErrDuplicatedTransaction)
+}
+
+func TestSimulator_SubscribeToEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	chainSimulator, err := NewChainSimulator(ArgsChainSimulator{
+		BypassTxSignatureCheck: true,
+		TempDir:                t.TempDir(),
+		PathToInitialConfig:    defaultPathToInitialConfig,
+		NumOfShards:            3,
+		GenesisTimestamp:       time.Now().Unix(),
+		RoundDurationInMillis:  uint64(6000),
+		RoundsPerEpoch: core.OptionalUint64{
+			HasValue: true,
+			Value:    20,
+		},
+		ApiInterface:      api.NewNoApiInterface(),
+		MinNodesPerShard:  1,
+		MetaChainMinNodes: 1,
+	})
+	require.Nil(t, err)
+
+	defer chainSimulator.Close()
+
+	_, _, err = chainSimulator.SubscribeToEvents(dtos.EventsFilter{Types: []string{"account"}})
+	require.NotNil(t, err)
+
+	blocks, unsubscribeBlocks, err := chainSimulator.SubscribeToEvents(dtos.EventsFilter{Types: []string{components.EventTypeBlock}})
+	require.Nil(t, err)
+	defer unsubscribeBlocks()
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	wallet, err := chainSimulator.GenerateAndMintWalletAddress(0, big.NewInt(0).Mul(big.NewInt(1000000000000000000), big.NewInt(10)))
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	transactions, unsubscribeTransactions, err := chainSimulator.SubscribeToEvents(dtos.EventsFilter{
+		Types:   []string{components.EventTypeTransaction},
+		Address: wallet.Bech32,
+	})
+	require.Nil(t, err)
+	defer unsubscribeTransactions()
+
+	txHash, err := chainSimulator.sendTx(&transaction.Transaction{
+		Nonce:     0,
+		Value:     big.NewInt(1),
+		SndAddr:   wallet.Bytes,
+		RcvAddr:   wallet.Bytes,
+		GasLimit:  50_000,
+		GasPrice:  1_000_000_000,
+		ChainID:   []byte(configs.ChainID),
+		Version:   1,
+		Signature: []byte("010101"),
+	})
+	require.Nil(t, err)
+
+	err = chainSimulator.GenerateBlocks(1)
+	require.Nil(t, err)
+
+	// each block generation commits one block in each of the 3 shards and in the metachain
+	numBlockEvents := 0
+	lastID := uint64(0)
+	for len(blocks) > 0 {
+		event := <-blocks
+		require.Greater(t, event.ID, lastID)
+		lastID = event.ID
+		numBlockEvents++
+	}
+	require.GreaterOrEqual(t, numBlockEvents, 4*3)
+
+	event := <-transactions
+	require.Equal(t, txHash, event.Transaction.Hash)
+	require.Equal(t, wallet.Bech32, event.Transaction.Sender)
+	require.Equal(t, "success", event.Transaction.Status)
+}
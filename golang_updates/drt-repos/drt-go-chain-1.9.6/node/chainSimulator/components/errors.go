@@ -0,0 +1,51 @@
+package components
+
+import "errors"
+
+// ErrNilNodeSnapshot signals that a nil node snapshot has been provided
+var ErrNilNodeSnapshot = errors.New("nil node snapshot")
+
+// ErrSnapshotFromOtherEpoch signals that the snapshot was taken in another epoch than the current one
+var ErrSnapshotFromOtherEpoch = errors.New("cannot revert to a snapshot taken in another epoch")
+
+// ErrRoundIndexCannotBeSet signals that the round handler does not allow setting the round index
+var ErrRoundIndexCannotBeSet = errors.New("the round index cannot be set on the round handler")
+
+// ErrNilRemoteState signals that a nil remote state provider has been provided
+var ErrNilRemoteState = errors.New("nil remote state provider")
+
+// ErrNilAccountCreator signals that a nil account creator has been provided
+var ErrNilAccountCreator = errors.New("nil account creator")
+
+// ErrNilAddressConverter signals that a nil address converter has been provided
+var ErrNilAddressConverter = errors.New("nil address converter")
+
+// ErrEmptyProxyURL signals that an empty proxy URL has been provided
+var ErrEmptyProxyURL = errors.New("empty proxy URL")
+
+// ErrRemoteRequestFailed signals that a request to the remote network failed
+var ErrRemoteRequestFailed = errors.New("request to the remote network failed")
+
+// ErrMissingForkedShardBlock signals that the forked metachain block does not notarize a block of a shard
+var ErrMissingForkedShardBlock = errors.New("no shard block notarized in the forked metachain block")
+
+// ErrTransactionTraceNotFound signals that no execution was traced for the provided transaction hash
+var ErrTransactionTraceNotFound = errors.New("no execution trace found for the transaction")
+
+// ErrTransactionNotInPool signals that the provided transaction is not pending in the transactions pool
+var ErrTransactionNotInPool = errors.New("transaction not found in the transactions pool")
+
+// ErrInvalidEventType signals that an unknown type of events has been requested
+var ErrInvalidEventType = errors.New("invalid event type")
+
+// ErrNilEventsStream signals that a nil events stream has been provided
+var ErrNilEventsStream = errors.New("nil events stream")
+
+// ErrNilOutportBlock signals that a nil outport block has been provided
+var ErrNilOutportBlock = errors.New("nil outport block")
+
+// ErrUnknownHeaderType signals that the header saved in the outport block has an unknown type
+var ErrUnknownHeaderType = errors.New("unknown header type")
+
+// ErrNilTransaction signals that a nil transaction has been provided
+var ErrNilTransaction = errors.New("nil transaction")
@@ -0,0 +1,160 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+const (
+	// EventTypeBlock is the type of the events produced for the committed blocks
+	EventTypeBlock = "block"
+	// EventTypeTransaction is the type of the events produced for the executed transactions and smart contract results
+	EventTypeTransaction = "transaction"
+	// EventTypeLog is the type of the events produced for the log events
+	EventTypeLog = "log"
+
+	// subscriptionBufferSize is the number of events kept for a subscriber which does not read them fast enough.
+	// When the buffer is full, the subscription is closed
+	subscriptionBufferSize = 10000
+)
+
+type eventsSubscription struct {
+	filter     dtos.EventsFilter
+	eventTypes map[string]struct{}
+	events     chan *dtos.StreamEvent
+}
+
+type eventsStream struct {
+	mutSubscriptions   sync.Mutex
+	subscriptions      map[uint64]*eventsSubscription
+	lastSubscriptionID uint64
+	lastEventID        uint64
+}
+
+// NewEventsStream creates a new instance of the stream distributing the events produced by all the nodes to the
+// subscribers
+func NewEventsStream() *eventsStream {
+	return &eventsStream{
+		subscriptions: make(map[uint64]*eventsSubscription),
+	}
+}
+
+// Subscribe will return a channel receiving the events which match the provided filter, together with the function
+// ending the subscription. The channel is closed when the subscription ends, including when the subscriber does not
+// read the events fast enough
+func (stream *eventsStream) Subscribe(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error) {
+	eventTypes := make(map[string]struct{}, len(filter.Types))
+	for _, eventType := range filter.Types {
+		switch eventType {
+		case EventTypeBlock, EventTypeTransaction, EventTypeLog:
+			eventTypes[eventType] = struct{}{}
+		default:
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidEventType, eventType)
+		}
+	}
+
+	subscription := &eventsSubscription{
+		filter:     filter,
+		eventTypes: eventTypes,
+		events:     make(chan *dtos.StreamEvent, subscriptionBufferSize),
+	}
+
+	stream.mutSubscriptions.Lock()
+	stream.lastSubscriptionID++
+	subscriptionID := stream.lastSubscriptionID
+	stream.subscriptions[subscriptionID] = subscription
+	stream.mutSubscriptions.Unlock()
+
+	unsubscribe := func() {
+		stream.mutSubscriptions.Lock()
+		stream.removeSubscription(subscriptionID)
+		stream.mutSubscriptions.Unlock()
+	}
+
+	return subscription.events, unsubscribe, nil
+}
+
+// Publish will send the provided events to the subscribers whose filters match them
+func (stream *eventsStream) Publish(events []*dtos.StreamEvent) {
+	stream.mutSubscriptions.Lock()
+	defer stream.mutSubscriptions.Unlock()
+
+	for _, event := range events {
+		stream.lastEventID++
+		event.ID = stream.lastEventID
+
+		for subscriptionID, subscription := range stream.subscriptions {
+			if !subscription.matches(event) {
+				continue
+			}
+
+			select {
+			case subscription.events <- event:
+			default:
+				log.Warn("events subscriber too slow, closing the subscription", "subscription", subscriptionID)
+				stream.removeSubscription(subscriptionID)
+			}
+		}
+	}
+}
+
+func (stream *eventsStream) removeSubscription(subscriptionID uint64) {
+	subscription, found := stream.subscriptions[subscriptionID]
+	if !found {
+		return
+	}
+
+	delete(stream.subscriptions, subscriptionID)
+	close(subscription.events)
+}
+
+// Close will end all the subscriptions
+func (stream *eventsStream) Close() error {
+	stream.mutSubscriptions.Lock()
+	defer stream.mutSubscriptions.Unlock()
+
+	for subscriptionID := range stream.subscriptions {
+		stream.removeSubscription(subscriptionID)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (stream *eventsStream) IsInterfaceNil() bool {
+	return stream == nil
+}
+
+func (subscription *eventsSubscription) matches(event *dtos.StreamEvent) bool {
+	if len(subscription.eventTypes) > 0 {
+		_, isTypeRequested := subscription.eventTypes[event.Type]
+		if !isTypeRequested {
+			return false
+		}
+	}
+
+	filter := subscription.filter
+	hasFilter := len(filter.Address) > 0 || len(filter.Identifier) > 0
+	if !hasFilter {
+		return true
+	}
+
+	switch event.Type {
+	case EventTypeTransaction:
+		if len(filter.Identifier) > 0 {
+			return false
+		}
+
+		return event.Transaction.Sender == filter.Address || event.Transaction.Receiver == filter.Address
+	case EventTypeLog:
+		if len(filter.Address) > 0 && event.Log.Address != filter.Address {
+			return false
+		}
+
+		return len(filter.Identifier) == 0 || event.Log.Identifier == filter.Identifier
+	default:
+		return false
+	}
+}
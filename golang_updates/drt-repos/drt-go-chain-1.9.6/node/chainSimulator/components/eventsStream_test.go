@@ -0,0 +1,144 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/stretchr/testify/require"
+)
+
+func createStreamEvents() []*dtos.StreamEvent {
+	return []*dtos.StreamEvent{
+		{
+			Type:  EventTypeBlock,
+			Block: &dtos.BlockEvent{Nonce: 1},
+		},
+		{
+			Type:        EventTypeTransaction,
+			Transaction: &dtos.TransactionEvent{Hash: "tx1", Sender: "alice", Receiver: "bob"},
+		},
+		{
+			Type:        EventTypeTransaction,
+			Transaction: &dtos.TransactionEvent{Hash: "tx2", Sender: "carol", Receiver: "dave"},
+		},
+		{
+			Type: EventTypeLog,
+			Log:  &dtos.LogEvent{TxHash: "tx1", Address: "bob", Identifier: "transfer"},
+		},
+		{
+			Type: EventTypeLog,
+			Log:  &dtos.LogEvent{TxHash: "tx2", Address: "dave", Identifier: "transfer"},
+		},
+	}
+}
+
+func readEvents(events <-chan *dtos.StreamEvent) []*dtos.StreamEvent {
+	received := make([]*dtos.StreamEvent, 0)
+	for {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+func TestEventsStream_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid event type should error", func(t *testing.T) {
+		t.Parallel()
+
+		stream := NewEventsStream()
+		events, unsubscribe, err := stream.Subscribe(dtos.EventsFilter{Types: []string{EventTypeLog, "account"}})
+		require.True(t, errors.Is(err, ErrInvalidEventType))
+		require.Nil(t, events)
+		require.Nil(t, unsubscribe)
+	})
+	t.Run("no filter should receive all the events in order", func(t *testing.T) {
+		t.Parallel()
+
+		stream := NewEventsStream()
+		events, _, err := stream.Subscribe(dtos.EventsFilter{})
+		require.Nil(t, err)
+
+		stream.Publish(createStreamEvents())
+
+		received := readEvents(events)
+		require.Len(t, received, 5)
+		for idx, event := range received {
+			require.Equal(t, uint64(idx+1), event.ID)
+		}
+	})
+	t.Run("should filter the events", func(t *testing.T) {
+		t.Parallel()
+
+		stream := NewEventsStream()
+		transactions, _, _ := stream.Subscribe(dtos.EventsFilter{Types: []string{EventTypeTransaction}})
+		byAddress, _, _ := stream.Subscribe(dtos.EventsFilter{Address: "bob"})
+		byIdentifier, _, _ := stream.Subscribe(dtos.EventsFilter{Identifier: "transfer", Address: "dave"})
+
+		stream.Publish(createStreamEvents())
+
+		received := readEvents(transactions)
+		require.Len(t, received, 2)
+		require.Equal(t, "tx1", received[0].Transaction.Hash)
+		require.Equal(t, "tx2", received[1].Transaction.Hash)
+
+		received = readEvents(byAddress)
+		require.Len(t, received, 2)
+		require.Equal(t, "tx1", received[0].Transaction.Hash)
+		require.Equal(t, "tx1", received[1].Log.TxHash)
+
+		received = readEvents(byIdentifier)
+		require.Len(t, received, 1)
+		require.Equal(t, "tx2", received[0].Log.TxHash)
+	})
+	t.Run("unsubscribe should close the channel", func(t *testing.T) {
+		t.Parallel()
+
+		stream := NewEventsStream()
+		events, unsubscribe, _ := stream.Subscribe(dtos.EventsFilter{})
+		unsubscribe()
+		unsubscribe()
+
+		stream.Publish(createStreamEvents())
+
+		_, isOpen := <-events
+		require.False(t, isOpen)
+	})
+}
+
+func TestEventsStream_PublishShouldCloseTheSlowSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	stream := NewEventsStream()
+	events, _, _ := stream.Subscribe(dtos.EventsFilter{Types: []string{EventTypeBlock}})
+
+	for i := 0; i <= subscriptionBufferSize; i++ {
+		stream.Publish([]*dtos.StreamEvent{{Type: EventTypeBlock, Block: &dtos.BlockEvent{}}})
+	}
+
+	numReceived := 0
+	for range events {
+		numReceived++
+	}
+	require.Equal(t, subscriptionBufferSize, numReceived)
+}
+
+func TestEventsStream_Close(t *testing.T) {
+	t.Parallel()
+
+	stream := NewEventsStream()
+	events, unsubscribe, _ := stream.Subscribe(dtos.EventsFilter{})
+
+	err := stream.Close()
+	require.Nil(t, err)
+
+	_, isOpen := <-events
+	require.False(t, isOpen)
+
+	require.NotPanics(t, unsubscribe)
+}
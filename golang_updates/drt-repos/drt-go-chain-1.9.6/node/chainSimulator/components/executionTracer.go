@@ -0,0 +1,416 @@
+package components
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/process"
+)
+
+const (
+	maxTracedTransactions = 10000
+
+	frameTypeSCDeploy        = "scDeploy"
+	frameTypeSCCall          = "scCall"
+	frameTypeBuiltInFunction = "builtInFunction"
+	frameTypeVMCall          = "vmCall"
+
+	transferValueOnlyIdentifier = "transferValueOnly"
+	directCallExecutionType     = "DirectCall"
+	backTransferExecutionType   = "BackTransfer"
+	executeOnDestContextType    = "ExecuteOnDestContext"
+)
+
+// executionTracer records the smart contracts executions performed while processing blocks, in all the shards.
+// The executions are grouped by the hash of the transaction that started them, so the executions of the smart
+// contract results are found together with the executions of their original transaction
+type executionTracer struct {
+	addressConverter core.PubkeyConverter
+	blockChainHooks  map[uint32]process.BlockChainHookHandler
+	activeFrames     map[uint32][]*dtos.ExecutionFrame
+	traces           map[string][]*dtos.ExecutionFrame
+	tracedHashes     []string
+	mut              sync.RWMutex
+}
+
+// NewExecutionTracer creates a new execution tracer
+func NewExecutionTracer(addressConverter core.PubkeyConverter) (*executionTracer, error) {
+	if check.IfNil(addressConverter) {
+		return nil, ErrNilAddressConverter
+	}
+
+	return &executionTracer{
+		addressConverter: addressConverter,
+		blockChainHooks:  make(map[uint32]process.BlockChainHookHandler),
+		activeFrames:     make(map[uint32][]*dtos.ExecutionFrame),
+		traces:           make(map[string][]*dtos.ExecutionFrame),
+	}, nil
+}
+
+// WrapBlockChainHook returns a blockchain hook recording the storage reads and the built-in function calls
+func (tracer *executionTracer) WrapBlockChainHook(shardID uint32, blockChainHook process.BlockChainHookWithAccountsAdapter) process.BlockChainHookWithAccountsAdapter {
+	tracer.mut.Lock()
+	tracer.blockChainHooks[shardID] = blockChainHook
+	tracer.mut.Unlock()
+
+	return &tracedBlockChainHook{
+		BlockChainHookWithAccountsAdapter: blockChainHook,
+		shardID:                           shardID,
+		tracer:                            tracer,
+	}
+}
+
+// WrapVMContainer returns a virtual machines container whose virtual machines record their executions
+func (tracer *executionTracer) WrapVMContainer(shardID uint32, vmContainer process.VirtualMachinesContainer) process.VirtualMachinesContainer {
+	return &tracedVMContainer{
+		VirtualMachinesContainer: vmContainer,
+		shardID:                  shardID,
+		tracer:                   tracer,
+	}
+}
+
+// GetTransactionTrace returns the executions recorded for the provided transaction hash
+func (tracer *executionTracer) GetTransactionTrace(txHash []byte) (*dtos.TransactionTrace, error) {
+	tracer.mut.RLock()
+	defer tracer.mut.RUnlock()
+
+	executions, found := tracer.traces[string(txHash)]
+	if !found {
+		return nil, ErrTransactionTraceNotFound
+	}
+
+	return &dtos.TransactionTrace{
+		Hash:       hex.EncodeToString(txHash),
+		Executions: append(make([]*dtos.ExecutionFrame, 0, len(executions)), executions...),
+	}, nil
+}
+
+func (tracer *executionTracer) beginFrame(shardID uint32, frameType string, input *vmcommon.VMInput, callee []byte, function string) {
+	tracer.mut.Lock()
+	defer tracer.mut.Unlock()
+
+	frame := &dtos.ExecutionFrame{
+		Type:          frameType,
+		Shard:         shardID,
+		Round:         tracer.currentRound(shardID),
+		TxHash:        hex.EncodeToString(input.CurrentTxHash),
+		CallType:      input.CallType.ToString(),
+		Caller:        tracer.encodeAddress(input.CallerAddr),
+		Callee:        tracer.encodeAddress(callee),
+		Function:      function,
+		Arguments:     encodeHexSlice(input.Arguments),
+		Value:         bigIntToString(input.CallValue),
+		DCDTTransfers: make([]*dtos.TokenTransfer, 0, len(input.DCDTTransfers)),
+		GasProvided:   input.GasProvided,
+	}
+	for _, dcdtTransfer := range input.DCDTTransfers {
+		frame.DCDTTransfers = append(frame.DCDTTransfers, &dtos.TokenTransfer{
+			Token: string(dcdtTransfer.DCDTTokenName),
+			Nonce: dcdtTransfer.DCDTTokenNonce,
+			Value: bigIntToString(dcdtTransfer.DCDTValue),
+		})
+	}
+
+	tracer.activeFrames[shardID] = append(tracer.activeFrames[shardID], frame)
+}
+
+func (tracer *executionTracer) endFrame(shardID uint32, input *vmcommon.VMInput, vmOutput *vmcommon.VMOutput, err error) {
+	tracer.mut.Lock()
+	defer tracer.mut.Unlock()
+
+	frames := tracer.activeFrames[shardID]
+	if len(frames) == 0 {
+		return
+	}
+
+	frame := frames[len(frames)-1]
+	tracer.activeFrames[shardID] = frames[:len(frames)-1]
+	tracer.setFrameOutput(frame, vmOutput, err)
+
+	if len(frames) > 1 {
+		parent := frames[len(frames)-2]
+		parent.Calls = append(parent.Calls, frame)
+		return
+	}
+
+	originalTxHash := input.OriginalTxHash
+	if len(originalTxHash) == 0 {
+		originalTxHash = input.CurrentTxHash
+	}
+	tracer.recordExecution(string(originalTxHash), frame)
+}
+
+func (tracer *executionTracer) recordStorageRead(shardID uint32, address []byte, key []byte, value []byte) {
+	tracer.mut.Lock()
+	defer tracer.mut.Unlock()
+
+	// the reads done outside an execution, like the ones of the transactions processor, are not traced
+	frames := tracer.activeFrames[shardID]
+	if len(frames) == 0 {
+		return
+	}
+
+	frame := frames[len(frames)-1]
+	frame.StorageReads = append(frame.StorageReads, &dtos.StorageAccess{
+		Address: tracer.encodeAddress(address),
+		Key:     hex.EncodeToString(key),
+		Value:   hex.EncodeToString(value),
+	})
+}
+
+func (tracer *executionTracer) recordExecution(originalTxHash string, frame *dtos.ExecutionFrame) {
+	executions, found := tracer.traces[originalTxHash]
+	if !found {
+		tracer.tracedHashes = append(tracer.tracedHashes, originalTxHash)
+	}
+
+	// a transaction not included in a block has its execution reverted and is executed again in a later round,
+	// case in which the previous execution is replaced
+	filteredExecutions := make([]*dtos.ExecutionFrame, 0, len(executions)+1)
+	for _, execution := range executions {
+		if execution.TxHash == frame.TxHash && execution.Round < frame.Round {
+			continue
+		}
+
+		filteredExecutions = append(filteredExecutions, execution)
+	}
+	tracer.traces[originalTxHash] = append(filteredExecutions, frame)
+
+	if len(tracer.tracedHashes) > maxTracedTransactions {
+		delete(tracer.traces, tracer.tracedHashes[0])
+		tracer.tracedHashes = tracer.tracedHashes[1:]
+	}
+}
+
+func (tracer *executionTracer) setFrameOutput(frame *dtos.ExecutionFrame, vmOutput *vmcommon.VMOutput, err error) {
+	if err != nil {
+		frame.Error = err.Error()
+	}
+	if vmOutput == nil {
+		return
+	}
+
+	if frame.GasProvided >= vmOutput.GasRemaining {
+		frame.GasUsed = frame.GasProvided - vmOutput.GasRemaining
+	}
+	frame.ReturnCode = vmOutput.ReturnCode.String()
+	frame.ReturnMessage = vmOutput.ReturnMessage
+	frame.ReturnData = encodeHexSlice(vmOutput.ReturnData)
+
+	// the output accounts are kept in a map, so they are sorted in order to have the same trace on each execution
+	outputAccounts := make([]*vmcommon.OutputAccount, 0, len(vmOutput.OutputAccounts))
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		outputAccounts = append(outputAccounts, outputAccount)
+	}
+	sort.Slice(outputAccounts, func(i, j int) bool {
+		return string(outputAccounts[i].Address) < string(outputAccounts[j].Address)
+	})
+
+	for _, outputAccount := range outputAccounts {
+		// the address of the deployed contract is known only after the deployment
+		isDeployedContract := frame.Type == frameTypeSCDeploy && len(frame.Callee) == 0 && len(outputAccount.Code) > 0
+		if isDeployedContract {
+			frame.Callee = tracer.encodeAddress(outputAccount.Address)
+		}
+
+		tracer.addStorageWrites(frame, outputAccount)
+		tracer.addTransfers(frame, outputAccount)
+	}
+
+	for _, logEntry := range vmOutput.Logs {
+		tracer.addEvent(frame, logEntry)
+	}
+}
+
+func (tracer *executionTracer) addStorageWrites(frame *dtos.ExecutionFrame, outputAccount *vmcommon.OutputAccount) {
+	keys := make([]string, 0, len(outputAccount.StorageUpdates))
+	for key, storageUpdate := range outputAccount.StorageUpdates {
+		// the virtual machine also keeps the read values as storage updates, which are not written
+		if storageUpdate.Written {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		frame.StorageWrites = append(frame.StorageWrites, &dtos.StorageAccess{
+			Address: tracer.encodeAddress(outputAccount.Address),
+			Key:     hex.EncodeToString([]byte(key)),
+			Value:   hex.EncodeToString(outputAccount.StorageUpdates[key].Data),
+		})
+	}
+}
+
+func (tracer *executionTracer) addTransfers(frame *dtos.ExecutionFrame, outputAccount *vmcommon.OutputAccount) {
+	for _, outputTransfer := range outputAccount.OutputTransfers {
+		frame.Transfers = append(frame.Transfers, &dtos.OutputTransfer{
+			Sender:   tracer.encodeAddress(outputTransfer.SenderAddress),
+			Receiver: tracer.encodeAddress(outputAccount.Address),
+			Value:    bigIntToString(outputTransfer.Value),
+			Data:     string(outputTransfer.Data),
+			GasLimit: outputTransfer.GasLimit,
+			CallType: outputTransfer.CallType.ToString(),
+		})
+	}
+}
+
+func (tracer *executionTracer) addEvent(frame *dtos.ExecutionFrame, logEntry *vmcommon.LogEntry) {
+	frame.Events = append(frame.Events, &dtos.Event{
+		Address:    tracer.encodeAddress(logEntry.Address),
+		Identifier: string(logEntry.Identifier),
+		Topics:     encodeHexSlice(logEntry.Topics),
+		Data:       encodeHexSlice(logEntry.Data),
+	})
+
+	// the calls executed inside the virtual machine are not visible from outside, but each of them emits an event
+	// holding the caller, the callee, the value and the called function with its arguments. The events of the whole
+	// execution are returned together, so the calls cannot be nested and their own output cannot be separated from
+	// the output of the frame
+	isCallEvent := string(logEntry.Identifier) == transferValueOnlyIdentifier && len(logEntry.Topics) >= 2 && len(logEntry.Data) >= 2
+	if !isCallEvent {
+		return
+	}
+
+	executionType := string(logEntry.Data[0])
+	isTransferOnly := executionType == directCallExecutionType || executionType == backTransferExecutionType
+	if isTransferOnly || len(logEntry.Data[1]) == 0 {
+		return
+	}
+	if len(executionType) == 0 {
+		// the virtual machine does not set the execution type for the calls executed on the destination context
+		executionType = executeOnDestContextType
+	}
+
+	frame.Calls = append(frame.Calls, &dtos.ExecutionFrame{
+		Type:      frameTypeVMCall,
+		Shard:     frame.Shard,
+		Round:     frame.Round,
+		CallType:  executionType,
+		Caller:    tracer.encodeAddress(logEntry.Address),
+		Callee:    tracer.encodeAddress(logEntry.Topics[1]),
+		Function:  string(logEntry.Data[1]),
+		Arguments: encodeHexSlice(logEntry.Data[2:]),
+		Value:     big.NewInt(0).SetBytes(logEntry.Topics[0]).String(),
+	})
+}
+
+func (tracer *executionTracer) currentRound(shardID uint32) uint64 {
+	blockChainHook, found := tracer.blockChainHooks[shardID]
+	if !found {
+		return 0
+	}
+
+	return blockChainHook.CurrentRound()
+}
+
+func (tracer *executionTracer) encodeAddress(address []byte) string {
+	if len(address) == 0 {
+		return ""
+	}
+
+	return tracer.addressConverter.SilentEncode(address, log)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tracer *executionTracer) IsInterfaceNil() bool {
+	return tracer == nil
+}
+
+func encodeHexSlice(values [][]byte) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	encodedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		encodedValues = append(encodedValues, hex.EncodeToString(value))
+	}
+
+	return encodedValues
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
+
+// tracedBlockChainHook records the storage reads and the built-in function calls of the executions
+type tracedBlockChainHook struct {
+	process.BlockChainHookWithAccountsAdapter
+	shardID uint32
+	tracer  *executionTracer
+}
+
+// GetStorageData returns the storage value of the provided key, recording the read
+func (hook *tracedBlockChainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	value, trieDepth, err := hook.BlockChainHookWithAccountsAdapter.GetStorageData(accountAddress, index)
+	if err == nil {
+		hook.tracer.recordStorageRead(hook.shardID, accountAddress, index, value)
+	}
+
+	return value, trieDepth, err
+}
+
+// ProcessBuiltInFunction processes the built-in function call, recording it
+func (hook *tracedBlockChainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	hook.tracer.beginFrame(hook.shardID, frameTypeBuiltInFunction, &input.VMInput, input.RecipientAddr, input.Function)
+	vmOutput, err := hook.BlockChainHookWithAccountsAdapter.ProcessBuiltInFunction(input)
+	hook.tracer.endFrame(hook.shardID, &input.VMInput, vmOutput, err)
+
+	return vmOutput, err
+}
+
+// tracedVMContainer returns virtual machines recording their executions
+type tracedVMContainer struct {
+	process.VirtualMachinesContainer
+	shardID uint32
+	tracer  *executionTracer
+}
+
+// Get returns the virtual machine stored under the provided key
+func (container *tracedVMContainer) Get(key []byte) (vmcommon.VMExecutionHandler, error) {
+	vm, err := container.VirtualMachinesContainer.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedVM{
+		VMExecutionHandler: vm,
+		shardID:            container.shardID,
+		tracer:             container.tracer,
+	}, nil
+}
+
+// tracedVM records the executions of the wrapped virtual machine
+type tracedVM struct {
+	vmcommon.VMExecutionHandler
+	shardID uint32
+	tracer  *executionTracer
+}
+
+// RunSmartContractCreate deploys the smart contract, recording the execution
+func (vm *tracedVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	vm.tracer.beginFrame(vm.shardID, frameTypeSCDeploy, &input.VMInput, nil, "")
+	vmOutput, err := vm.VMExecutionHandler.RunSmartContractCreate(input)
+	vm.tracer.endFrame(vm.shardID, &input.VMInput, vmOutput, err)
+
+	return vmOutput, err
+}
+
+// RunSmartContractCall executes the smart contract call, recording the execution
+func (vm *tracedVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	vm.tracer.beginFrame(vm.shardID, frameTypeSCCall, &input.VMInput, input.RecipientAddr, input.Function)
+	vmOutput, err := vm.VMExecutionHandler.RunSmartContractCall(input)
+	vm.tracer.endFrame(vm.shardID, &input.VMInput, vmOutput, err)
+
+	return vmOutput, err
+}
@@ -0,0 +1,229 @@
+package components
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/vm"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/process"
+	"github.com/TerraDharitri/drt-go-chain/process/mock"
+	"github.com/TerraDharitri/drt-go-chain/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExecutionTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewExecutionTracer(nil)
+	require.Equal(t, ErrNilAddressConverter, err)
+	require.Nil(t, tracer)
+
+	tracer, err = NewExecutionTracer(testscommon.RealWorldBech32PubkeyConverter)
+	require.NoError(t, err)
+	require.NotNil(t, tracer)
+}
+
+func createTracedComponents(
+	t *testing.T,
+	round *uint64,
+	runSmartContractCall func(hook process.BlockChainHookHandler, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error),
+) (*executionTracer, process.VirtualMachinesContainer) {
+	tracer, err := NewExecutionTracer(testscommon.RealWorldBech32PubkeyConverter)
+	require.NoError(t, err)
+
+	hook := tracer.WrapBlockChainHook(1, &testscommon.BlockChainHookStub{
+		CurrentRoundCalled: func() uint64 {
+			return *round
+		},
+		GetStorageDataCalled: func(accountsAddress []byte, index []byte) ([]byte, uint32, error) {
+			return []byte("value"), 0, nil
+		},
+		ProcessBuiltInFunctionCalled: func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{
+				ReturnCode:   vmcommon.Ok,
+				GasRemaining: input.GasProvided - 100,
+			}, nil
+		},
+	})
+	container := tracer.WrapVMContainer(1, &mock.VMContainerMock{
+		GetCalled: func(key []byte) (vmcommon.VMExecutionHandler, error) {
+			return &mock.VMExecutionHandlerStub{
+				RunSmartContractCallCalled: func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+					return runSmartContractCall(hook, input)
+				},
+			}, nil
+		},
+	})
+
+	return tracer, container
+}
+
+func TestExecutionTracer_GetTransactionTrace(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("txHash")
+	round := uint64(5)
+	tracer, container := createTracedComponents(t, &round, func(hook process.BlockChainHookHandler, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		_, _, _ = hook.GetStorageData(testscommon.TestPubKeyBob, []byte("key"))
+		_, _ = hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  testscommon.TestPubKeyBob,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1000,
+			},
+			RecipientAddr: testscommon.TestPubKeyAlice,
+			Function:      "DCDTTransfer",
+		})
+
+		return &vmcommon.VMOutput{
+			ReturnCode:   vmcommon.Ok,
+			ReturnData:   [][]byte{{1}},
+			GasRemaining: input.GasProvided - 5000,
+			OutputAccounts: map[string]*vmcommon.OutputAccount{
+				string(testscommon.TestPubKeyBob): {
+					Address: testscommon.TestPubKeyBob,
+					StorageUpdates: map[string]*vmcommon.StorageUpdate{
+						"key":     {Offset: []byte("key"), Data: []byte("value")},
+						"written": {Offset: []byte("written"), Data: []byte("new value"), Written: true},
+					},
+				},
+				string(testscommon.TestPubKeyAlice): {
+					Address: testscommon.TestPubKeyAlice,
+					OutputTransfers: []vmcommon.OutputTransfer{
+						{
+							Value:         big.NewInt(10),
+							Data:          []byte("callBack@01"),
+							GasLimit:      2000,
+							CallType:      vm.AsynchronousCall,
+							SenderAddress: testscommon.TestPubKeyBob,
+						},
+					},
+				},
+			},
+			Logs: []*vmcommon.LogEntry{
+				{
+					Identifier: []byte(transferValueOnlyIdentifier),
+					Address:    testscommon.TestPubKeyBob,
+					Topics:     [][]byte{{7}, testscommon.TestPubKeyAlice},
+					Data:       [][]byte{[]byte(""), []byte("add"), {2}},
+				},
+			},
+		}, nil
+	})
+
+	vmHandler, err := container.Get([]byte("vm"))
+	require.NoError(t, err)
+
+	_, err = vmHandler.RunSmartContractCall(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     testscommon.TestPubKeyAlice,
+			Arguments:      [][]byte{{3}},
+			CallValue:      big.NewInt(1),
+			GasProvided:    10000,
+			OriginalTxHash: txHash,
+			CurrentTxHash:  txHash,
+		},
+		RecipientAddr: testscommon.TestPubKeyBob,
+		Function:      "doSomething",
+	})
+	require.NoError(t, err)
+
+	trace, err := tracer.GetTransactionTrace(txHash)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(txHash), trace.Hash)
+	require.Len(t, trace.Executions, 1)
+
+	execution := trace.Executions[0]
+	require.Equal(t, frameTypeSCCall, execution.Type)
+	require.Equal(t, uint32(1), execution.Shard)
+	require.Equal(t, uint64(5), execution.Round)
+	require.Equal(t, testscommon.TestAddressAlice, execution.Caller)
+	require.Equal(t, testscommon.TestAddressBob, execution.Callee)
+	require.Equal(t, "doSomething", execution.Function)
+	require.Equal(t, []string{"03"}, execution.Arguments)
+	require.Equal(t, "1", execution.Value)
+	require.Equal(t, uint64(5000), execution.GasUsed)
+	require.Equal(t, vmcommon.Ok.String(), execution.ReturnCode)
+	require.Equal(t, []string{"01"}, execution.ReturnData)
+	require.Equal(t, []*dtos.StorageAccess{
+		{
+			Address: testscommon.TestAddressBob,
+			Key:     hex.EncodeToString([]byte("key")),
+			Value:   hex.EncodeToString([]byte("value")),
+		},
+	}, execution.StorageReads)
+	require.Equal(t, []*dtos.StorageAccess{
+		{
+			Address: testscommon.TestAddressBob,
+			Key:     hex.EncodeToString([]byte("written")),
+			Value:   hex.EncodeToString([]byte("new value")),
+		},
+	}, execution.StorageWrites)
+	require.Equal(t, []*dtos.OutputTransfer{
+		{
+			Sender:   testscommon.TestAddressBob,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "10",
+			Data:     "callBack@01",
+			GasLimit: 2000,
+			CallType: vm.AsynchronousCallStr,
+		},
+	}, execution.Transfers)
+	require.Len(t, execution.Events, 1)
+	require.Equal(t, transferValueOnlyIdentifier, execution.Events[0].Identifier)
+
+	require.Len(t, execution.Calls, 2)
+	require.Equal(t, frameTypeBuiltInFunction, execution.Calls[0].Type)
+	require.Equal(t, "DCDTTransfer", execution.Calls[0].Function)
+	require.Equal(t, uint64(100), execution.Calls[0].GasUsed)
+	require.Equal(t, frameTypeVMCall, execution.Calls[1].Type)
+	require.Equal(t, executeOnDestContextType, execution.Calls[1].CallType)
+	require.Equal(t, testscommon.TestAddressBob, execution.Calls[1].Caller)
+	require.Equal(t, testscommon.TestAddressAlice, execution.Calls[1].Callee)
+	require.Equal(t, "add", execution.Calls[1].Function)
+	require.Equal(t, []string{"02"}, execution.Calls[1].Arguments)
+	require.Equal(t, "7", execution.Calls[1].Value)
+}
+
+func TestExecutionTracer_ExecutionInALaterRoundShouldReplaceTheRevertedOne(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("txHash")
+	round := uint64(5)
+	tracer, container := createTracedComponents(t, &round, func(hook process.BlockChainHookHandler, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+	})
+
+	vmHandler, err := container.Get([]byte("vm"))
+	require.NoError(t, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     testscommon.TestPubKeyAlice,
+			OriginalTxHash: txHash,
+			CurrentTxHash:  txHash,
+		},
+		RecipientAddr: testscommon.TestPubKeyBob,
+	}
+	_, _ = vmHandler.RunSmartContractCall(input)
+	round = 6
+	_, _ = vmHandler.RunSmartContractCall(input)
+
+	trace, err := tracer.GetTransactionTrace(txHash)
+	require.NoError(t, err)
+	require.Len(t, trace.Executions, 1)
+	require.Equal(t, uint64(6), trace.Executions[0].Round)
+}
+
+func TestExecutionTracer_GetTransactionTraceNotFoundShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := NewExecutionTracer(testscommon.RealWorldBech32PubkeyConverter)
+
+	trace, err := tracer.GetTransactionTrace([]byte("txHash"))
+	require.Equal(t, ErrTransactionTraceNotFound, err)
+	require.Nil(t, trace)
+}
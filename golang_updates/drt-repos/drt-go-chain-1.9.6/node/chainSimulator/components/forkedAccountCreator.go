@@ -0,0 +1,241 @@
+package components
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/hashing"
+	"github.com/TerraDharitri/drt-go-chain-core/marshal"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-chain/common"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/state"
+	"github.com/TerraDharitri/drt-go-chain/state/accounts"
+	"github.com/TerraDharitri/drt-go-chain/state/parsers"
+	"github.com/TerraDharitri/drt-go-chain/state/trackableDataTrie"
+)
+
+// ArgsForkedAccountCreator holds the arguments needed to create a forked account creator
+type ArgsForkedAccountCreator struct {
+	Hasher              hashing.Hasher
+	Marshaller          marshal.Marshalizer
+	EnableEpochsHandler common.EnableEpochsHandler
+	RemoteState         RemoteStateHandler
+	ShardID             uint32
+	AddressConverter    core.PubkeyConverter
+}
+
+// forkedAccountCreator creates the user accounts whose storage keys missing locally are loaded from the remote
+// network, one by one, on first read. It also tracks the local changes which hide the remote state: the removed
+// accounts and the deleted storage keys
+type forkedAccountCreator struct {
+	hasher              hashing.Hasher
+	marshaller          marshal.Marshalizer
+	enableEpochsHandler common.EnableEpochsHandler
+	remoteState         RemoteStateHandler
+	shardID             uint32
+	addressConverter    core.PubkeyConverter
+	removedAccounts     map[string]struct{}
+	deletedKeys         map[string]map[string]struct{}
+	mutLocalChanges     sync.RWMutex
+}
+
+func newForkedAccountCreator(args ArgsForkedAccountCreator) (*forkedAccountCreator, error) {
+	if check.IfNil(args.Hasher) {
+		return nil, state.ErrNilHasher
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, state.ErrNilMarshalizer
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, state.ErrNilEnableEpochsHandler
+	}
+	if check.IfNil(args.RemoteState) {
+		return nil, ErrNilRemoteState
+	}
+	if check.IfNil(args.AddressConverter) {
+		return nil, ErrNilAddressConverter
+	}
+
+	return &forkedAccountCreator{
+		hasher:              args.Hasher,
+		marshaller:          args.Marshaller,
+		enableEpochsHandler: args.EnableEpochsHandler,
+		remoteState:         args.RemoteState,
+		shardID:             args.ShardID,
+		addressConverter:    args.AddressConverter,
+		removedAccounts:     make(map[string]struct{}),
+		deletedKeys:         make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// CreateAccount creates a user account whose data trie falls back on the remote network
+func (creator *forkedAccountCreator) CreateAccount(address []byte) (vmcommon.AccountHandler, error) {
+	tdt, err := trackableDataTrie.NewTrackableDataTrie(address, creator.hasher, creator.marshaller, creator.enableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	dataTrieLeafParser, err := parsers.NewDataTrieLeafParser(address, creator.marshaller, creator.enableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	forkedDataTrie := &forkedDataTrie{
+		DataTrieTracker: tdt,
+		address:         address,
+		accountCreator:  creator,
+		writtenKeys:     make(map[string]bool),
+	}
+
+	return accounts.NewUserAccount(address, forkedDataTrie, dataTrieLeafParser)
+}
+
+func (creator *forkedAccountCreator) getRemoteAccountState(address []byte) (*dtos.AddressState, error) {
+	encodedAddress, err := creator.addressConverter.Encode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return creator.remoteState.GetAccountState(creator.shardID, encodedAddress)
+}
+
+// getRemoteStorageValue returns an empty value if the account was removed, if the key was deleted locally or if the
+// remote account has no storage. The system account is never fetched as a whole, so its keys are always requested
+func (creator *forkedAccountCreator) getRemoteStorageValue(address []byte, key []byte) ([]byte, error) {
+	if creator.isAccountRemoved(address) || creator.isKeyDeleted(address, key) {
+		return nil, nil
+	}
+
+	encodedAddress, err := creator.addressConverter.Encode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(address, core.SystemAccountAddress) {
+		addressState, errGet := creator.remoteState.GetAccountState(creator.shardID, encodedAddress)
+		if errGet != nil {
+			return nil, errGet
+		}
+		if addressState == nil || len(addressState.RootHash) == 0 {
+			return nil, nil
+		}
+	}
+
+	return creator.remoteState.GetStorageValue(creator.shardID, encodedAddress, key)
+}
+
+func (creator *forkedAccountCreator) setAccountRemoved(address []byte) {
+	creator.mutLocalChanges.Lock()
+	creator.removedAccounts[string(address)] = struct{}{}
+	creator.mutLocalChanges.Unlock()
+}
+
+func (creator *forkedAccountCreator) isAccountRemoved(address []byte) bool {
+	creator.mutLocalChanges.RLock()
+	_, isRemoved := creator.removedAccounts[string(address)]
+	creator.mutLocalChanges.RUnlock()
+
+	return isRemoved
+}
+
+func (creator *forkedAccountCreator) setKeysDeleted(address []byte, keys []string) {
+	creator.mutLocalChanges.Lock()
+	defer creator.mutLocalChanges.Unlock()
+
+	accountDeletedKeys, found := creator.deletedKeys[string(address)]
+	if !found {
+		accountDeletedKeys = make(map[string]struct{})
+		creator.deletedKeys[string(address)] = accountDeletedKeys
+	}
+
+	for _, key := range keys {
+		accountDeletedKeys[key] = struct{}{}
+	}
+}
+
+func (creator *forkedAccountCreator) isKeyDeleted(address []byte, key []byte) bool {
+	creator.mutLocalChanges.RLock()
+	_, isDeleted := creator.deletedKeys[string(address)][string(key)]
+	creator.mutLocalChanges.RUnlock()
+
+	return isDeleted
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (creator *forkedAccountCreator) IsInterfaceNil() bool {
+	return creator == nil
+}
+
+// forkedDataTrie requests the storage keys missing locally from the remote network. The remote values are not saved
+// in the local data trie, so only the keys written locally change the root hash of the account
+type forkedDataTrie struct {
+	state.DataTrieTracker
+	address        []byte
+	accountCreator *forkedAccountCreator
+	// writtenKeys holds the keys saved since the last commit of the data trie, with true for the deleted ones
+	writtenKeys map[string]bool
+}
+
+// RetrieveValue returns the local value of the key or, if the key was never written locally, the remote one
+func (fdt *forkedDataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, depth, err := fdt.DataTrieTracker.RetrieveValue(key)
+	if err != nil && !errors.Is(err, state.ErrNilTrie) {
+		return nil, depth, err
+	}
+	if len(value) > 0 {
+		return value, depth, nil
+	}
+
+	_, isWritten := fdt.writtenKeys[string(key)]
+	if isWritten {
+		return value, depth, nil
+	}
+
+	remoteValue, errRemote := fdt.accountCreator.getRemoteStorageValue(fdt.address, key)
+	if errRemote != nil {
+		return nil, depth, errRemote
+	}
+	if len(remoteValue) == 0 {
+		return value, depth, err
+	}
+
+	return remoteValue, depth, nil
+}
+
+// SaveKeyValue saves the key locally, so it is no longer requested from the remote network
+func (fdt *forkedDataTrie) SaveKeyValue(key []byte, value []byte) error {
+	err := fdt.DataTrieTracker.SaveKeyValue(key, value)
+	if err != nil {
+		return err
+	}
+
+	fdt.writtenKeys[string(key)] = len(value) == 0
+
+	return nil
+}
+
+// SaveDirtyData saves the written keys in the data trie and records the deleted ones, as a deleted key is missing
+// from the data trie as any key never written locally
+func (fdt *forkedDataTrie) SaveDirtyData(mainTrie common.Trie) ([]core.TrieData, error) {
+	oldValues, err := fdt.DataTrieTracker.SaveDirtyData(mainTrie)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedKeys := make([]string, 0)
+	for key, isDeleted := range fdt.writtenKeys {
+		if isDeleted {
+			deletedKeys = append(deletedKeys, key)
+		}
+	}
+	if len(deletedKeys) > 0 {
+		fdt.accountCreator.setKeysDeleted(fdt.address, deletedKeys)
+	}
+	fdt.writtenKeys = make(map[string]bool)
+
+	return oldValues, nil
+}
@@ -0,0 +1,233 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain/common"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/state"
+	"github.com/TerraDharitri/drt-go-chain/testscommon"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/chainSimulator"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/enableEpochsHandlerMock"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/hashingMocks"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/marshallerMock"
+	testTrie "github.com/TerraDharitri/drt-go-chain/testscommon/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func createArgsForkedAccountCreator(remoteState RemoteStateHandler) ArgsForkedAccountCreator {
+	return ArgsForkedAccountCreator{
+		Hasher:              &hashingMocks.HasherMock{},
+		Marshaller:          &marshallerMock.MarshalizerMock{},
+		EnableEpochsHandler: &enableEpochsHandlerMock.EnableEpochsHandlerStub{},
+		RemoteState:         remoteState,
+		ShardID:             0,
+		AddressConverter:    testscommon.RealWorldBech32PubkeyConverter,
+	}
+}
+
+func createForkedUserAccountForTest(t *testing.T, creator *forkedAccountCreator, address []byte) state.UserAccountHandler {
+	account, err := creator.CreateAccount(address)
+	require.NoError(t, err)
+
+	return account.(state.UserAccountHandler)
+}
+
+func createDataTrieForTest() common.Trie {
+	return &testTrie.TrieStub{
+		GetCalled: func(_ []byte) ([]byte, uint32, error) {
+			return nil, 0, nil
+		},
+		UpdateWithVersionCalled: func(_, _ []byte, _ core.TrieNodeVersion) error {
+			return nil
+		},
+		DeleteCalled: func(_ []byte) error {
+			return nil
+		},
+	}
+}
+
+func TestNewForkedAccountCreator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{})
+		args.Hasher = nil
+		creator, err := newForkedAccountCreator(args)
+		require.Equal(t, state.ErrNilHasher, err)
+		require.Nil(t, creator)
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{})
+		args.Marshaller = nil
+		creator, err := newForkedAccountCreator(args)
+		require.Equal(t, state.ErrNilMarshalizer, err)
+		require.Nil(t, creator)
+	})
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{})
+		args.EnableEpochsHandler = nil
+		creator, err := newForkedAccountCreator(args)
+		require.Equal(t, state.ErrNilEnableEpochsHandler, err)
+		require.Nil(t, creator)
+	})
+	t.Run("nil remote state should error", func(t *testing.T) {
+		t.Parallel()
+
+		creator, err := newForkedAccountCreator(createArgsForkedAccountCreator(nil))
+		require.Equal(t, ErrNilRemoteState, err)
+		require.Nil(t, creator)
+	})
+	t.Run("nil address converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{})
+		args.AddressConverter = nil
+		creator, err := newForkedAccountCreator(args)
+		require.Equal(t, ErrNilAddressConverter, err)
+		require.Nil(t, creator)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		creator, err := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{}))
+		require.NoError(t, err)
+		require.False(t, creator.IsInterfaceNil())
+	})
+}
+
+func TestForkedDataTrie_RetrieveValue(t *testing.T) {
+	t.Parallel()
+
+	address := testscommon.TestPubKeyAlice
+	key := []byte("key")
+	remoteAccountWithStorage := func(shardID uint32, address string) (*dtos.AddressState, error) {
+		return &dtos.AddressState{Address: address, RootHash: "cm9vdEhhc2g="}, nil
+	}
+
+	t.Run("missing key should be requested from the remote network", func(t *testing.T) {
+		t.Parallel()
+
+		creator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: remoteAccountWithStorage,
+			GetStorageValueCalled: func(shardID uint32, address string, storageKey []byte) ([]byte, error) {
+				require.Equal(t, testscommon.TestAddressAlice, address)
+				require.Equal(t, key, storageKey)
+				return []byte("value"), nil
+			},
+		}))
+		account := createForkedUserAccountForTest(t, creator, address)
+
+		value, _, err := account.RetrieveValue(key)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+	t.Run("account without remote storage should not request the key", func(t *testing.T) {
+		t.Parallel()
+
+		creator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				return &dtos.AddressState{Address: address}, nil
+			},
+			GetStorageValueCalled: func(shardID uint32, address string, storageKey []byte) ([]byte, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}))
+		account := createForkedUserAccountForTest(t, creator, address)
+
+		value, _, err := account.RetrieveValue(key)
+		require.Equal(t, state.ErrNilTrie, err)
+		require.Empty(t, value)
+	})
+	t.Run("system account keys should be requested without the account", func(t *testing.T) {
+		t.Parallel()
+
+		creator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+			GetStorageValueCalled: func(shardID uint32, address string, storageKey []byte) ([]byte, error) {
+				return []byte("metadata"), nil
+			},
+		}))
+		account := createForkedUserAccountForTest(t, creator, core.SystemAccountAddress)
+
+		value, _, err := account.RetrieveValue(key)
+		require.NoError(t, err)
+		require.Equal(t, []byte("metadata"), value)
+	})
+	t.Run("written keys should not be requested", func(t *testing.T) {
+		t.Parallel()
+
+		creator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: remoteAccountWithStorage,
+			GetStorageValueCalled: func(shardID uint32, address string, storageKey []byte) ([]byte, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}))
+		account := createForkedUserAccountForTest(t, creator, address)
+
+		err := account.SaveKeyValue(key, []byte("local value"))
+		require.NoError(t, err)
+		value, _, err := account.RetrieveValue(key)
+		require.NoError(t, err)
+		require.Equal(t, []byte("local value"), value)
+
+		err = account.SaveKeyValue(key, nil)
+		require.NoError(t, err)
+		value, _, err = account.RetrieveValue(key)
+		require.NoError(t, err)
+		require.Empty(t, value)
+	})
+	t.Run("deleted keys should not be requested by the accounts created later", func(t *testing.T) {
+		t.Parallel()
+
+		creator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: remoteAccountWithStorage,
+			GetStorageValueCalled: func(shardID uint32, address string, storageKey []byte) ([]byte, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}))
+		account := createForkedUserAccountForTest(t, creator, address)
+		account.SetDataTrie(createDataTrieForTest())
+
+		err := account.SaveKeyValue(key, nil)
+		require.NoError(t, err)
+		_, err = account.AccountDataHandler().(*forkedDataTrie).SaveDirtyData(&testTrie.TrieStub{})
+		require.NoError(t, err)
+
+		account = createForkedUserAccountForTest(t, creator, address)
+		account.SetDataTrie(createDataTrieForTest())
+		value, _, err := account.RetrieveValue(key)
+		require.NoError(t, err)
+		require.Empty(t, value)
+	})
+	t.Run("removed account should not request the keys", func(t *testing.T) {
+		t.Parallel()
+
+		creator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: remoteAccountWithStorage,
+			GetStorageValueCalled: func(shardID uint32, address string, storageKey []byte) ([]byte, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		}))
+		creator.setAccountRemoved(address)
+		account := createForkedUserAccountForTest(t, creator, address)
+
+		value, _, err := account.RetrieveValue(key)
+		require.Equal(t, state.ErrNilTrie, err)
+		require.Empty(t, value)
+	})
+}
@@ -0,0 +1,184 @@
+package components
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data/api"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-chain/common"
+	"github.com/TerraDharitri/drt-go-chain/state"
+)
+
+// forkedAccountsAdapter loads the accounts missing from the local tries from the remote network, on first access.
+// The loaded accounts are saved in the tries, so they are committed with the next block as any other change. Their
+// storage is not loaded with them, the data tries of the accounts created by the forked account creator request each
+// key on first read
+type forkedAccountsAdapter struct {
+	state.AccountsAdapter
+	accountCreator *forkedAccountCreator
+	mutFork        sync.Mutex
+}
+
+func newForkedAccountsAdapter(
+	accountsAdapter state.AccountsAdapter,
+	accountCreator *forkedAccountCreator,
+) (*forkedAccountsAdapter, error) {
+	if check.IfNil(accountsAdapter) {
+		return nil, state.ErrNilAccountsAdapter
+	}
+	if check.IfNil(accountCreator) {
+		return nil, ErrNilAccountCreator
+	}
+
+	return &forkedAccountsAdapter{
+		AccountsAdapter: accountsAdapter,
+		accountCreator:  accountCreator,
+	}, nil
+}
+
+// GetExistingAccount returns the account, loading it from the remote network if it does not exist locally
+func (adapter *forkedAccountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	err := adapter.loadRemoteAccountIfMissing(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return adapter.AccountsAdapter.GetExistingAccount(address)
+}
+
+// LoadAccount returns the account, loading it from the remote network if it does not exist locally
+func (adapter *forkedAccountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	err := adapter.loadRemoteAccountIfMissing(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return adapter.AccountsAdapter.LoadAccount(address)
+}
+
+// RemoveAccount removes the account, which will not be loaded again from the remote network
+func (adapter *forkedAccountsAdapter) RemoveAccount(address []byte) error {
+	adapter.accountCreator.setAccountRemoved(address)
+
+	return adapter.AccountsAdapter.RemoveAccount(address)
+}
+
+func (adapter *forkedAccountsAdapter) loadRemoteAccountIfMissing(address []byte) error {
+	// the system account holds the metadata of all the tokens from the shard, so it is never fetched as a whole, its
+	// keys are requested one by one as the keys of any other account
+	if len(address) == 0 || bytes.Equal(address, core.SystemAccountAddress) {
+		return nil
+	}
+
+	adapter.mutFork.Lock()
+	defer adapter.mutFork.Unlock()
+
+	if adapter.accountCreator.isAccountRemoved(address) {
+		return nil
+	}
+
+	_, err := adapter.AccountsAdapter.GetExistingAccount(address)
+	if !errors.Is(err, state.ErrAccNotFound) {
+		return err
+	}
+
+	addressState, err := adapter.accountCreator.getRemoteAccountState(address)
+	if err != nil {
+		return err
+	}
+	if addressState == nil {
+		return nil
+	}
+
+	account, err := adapter.AccountsAdapter.LoadAccount(address)
+	if err != nil {
+		return err
+	}
+
+	userAccount, ok := account.(state.UserAccountHandler)
+	if !ok {
+		return errors.New("cannot cast AccountHandler to UserAccountHandler")
+	}
+
+	err = setAccountState(userAccount, addressState, adapter.accountCreator.addressConverter)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("loaded account from the forked network", "address", addressState.Address, "shard", adapter.accountCreator.shardID)
+
+	return adapter.AccountsAdapter.SaveAccount(userAccount)
+}
+
+// forkedAccountsAdapterAPI serves the accounts not yet committed from the forked accounts adapter, so the accounts
+// from the remote network are visible to the API and to the transactions interceptors before the next block
+type forkedAccountsAdapterAPI struct {
+	state.AccountsAdapter
+	forkedAccounts *forkedAccountsAdapter
+}
+
+// GetExistingAccount returns the committed account or the one loaded from the remote network
+func (adapter *forkedAccountsAdapterAPI) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := adapter.AccountsAdapter.GetExistingAccount(address)
+	errNotFound := &state.ErrAccountNotFoundAtBlock{}
+	if !errors.As(err, &errNotFound) {
+		return account, err
+	}
+
+	forkedAccount, errGet := adapter.forkedAccounts.GetExistingAccount(address)
+	if errors.Is(errGet, state.ErrAccNotFound) {
+		return nil, err
+	}
+
+	return forkedAccount, errGet
+}
+
+// GetCode returns the committed code or the one loaded from the remote network
+func (adapter *forkedAccountsAdapterAPI) GetCode(codeHash []byte) []byte {
+	code := adapter.AccountsAdapter.GetCode(codeHash)
+	if len(code) > 0 {
+		return code
+	}
+
+	return adapter.forkedAccounts.GetCode(codeHash)
+}
+
+// forkedAccountsRepository serves the accounts not yet committed from the forked accounts adapter, for the queries
+// on the current state
+type forkedAccountsRepository struct {
+	state.AccountsRepository
+	forkedAccounts *forkedAccountsAdapter
+}
+
+// GetAccountWithBlockInfo returns the committed account or the one loaded from the remote network
+func (repository *forkedAccountsRepository) GetAccountWithBlockInfo(address []byte, options api.AccountQueryOptions) (vmcommon.AccountHandler, common.BlockInfo, error) {
+	account, blockInfo, err := repository.AccountsRepository.GetAccountWithBlockInfo(address, options)
+	errNotFound := &state.ErrAccountNotFoundAtBlock{}
+	if !errors.As(err, &errNotFound) || len(options.BlockRootHash) > 0 {
+		return account, blockInfo, err
+	}
+
+	forkedAccount, errGet := repository.forkedAccounts.GetExistingAccount(address)
+	if errors.Is(errGet, state.ErrAccNotFound) {
+		return nil, nil, err
+	}
+	if errGet != nil {
+		return nil, nil, errGet
+	}
+
+	return forkedAccount, errNotFound.BlockInfo, nil
+}
+
+// GetCodeWithBlockInfo returns the committed code or the one loaded from the remote network
+func (repository *forkedAccountsRepository) GetCodeWithBlockInfo(codeHash []byte, options api.AccountQueryOptions) ([]byte, common.BlockInfo, error) {
+	code, blockInfo, err := repository.AccountsRepository.GetCodeWithBlockInfo(codeHash, options)
+	if err != nil || len(code) > 0 || len(options.BlockRootHash) > 0 {
+		return code, blockInfo, err
+	}
+
+	return repository.forkedAccounts.GetCode(codeHash), blockInfo, nil
+}
@@ -0,0 +1,218 @@
+package components
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/state"
+	"github.com/TerraDharitri/drt-go-chain/state/accounts"
+	"github.com/TerraDharitri/drt-go-chain/testscommon"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/chainSimulator"
+	stateMock "github.com/TerraDharitri/drt-go-chain/testscommon/state"
+	testTrie "github.com/TerraDharitri/drt-go-chain/testscommon/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func createForkedAccountsAdapterForTest(
+	t *testing.T,
+	accountsAdapter state.AccountsAdapter,
+	remoteState RemoteStateHandler,
+) *forkedAccountsAdapter {
+	accountCreator, err := newForkedAccountCreator(createArgsForkedAccountCreator(remoteState))
+	require.NoError(t, err)
+
+	adapter, err := newForkedAccountsAdapter(accountsAdapter, accountCreator)
+	require.NoError(t, err)
+
+	return adapter
+}
+
+func TestNewForkedAccountsAdapter(t *testing.T) {
+	t.Parallel()
+
+	accountCreator, _ := newForkedAccountCreator(createArgsForkedAccountCreator(&chainSimulator.RemoteStateHandlerStub{}))
+
+	adapter, err := newForkedAccountsAdapter(nil, accountCreator)
+	require.Equal(t, state.ErrNilAccountsAdapter, err)
+	require.Nil(t, adapter)
+
+	adapter, err = newForkedAccountsAdapter(&stateMock.AccountsStub{}, nil)
+	require.Equal(t, ErrNilAccountCreator, err)
+	require.Nil(t, adapter)
+
+	adapter, err = newForkedAccountsAdapter(&stateMock.AccountsStub{}, accountCreator)
+	require.NoError(t, err)
+	require.NotNil(t, adapter)
+}
+
+func TestForkedAccountsAdapter_LoadAccount(t *testing.T) {
+	t.Parallel()
+
+	address := testscommon.TestPubKeyAlice
+
+	t.Run("existing account should not be requested from the remote network", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{
+			GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+				return &stateMock.UserAccountStub{}, nil
+			},
+		}, &chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		})
+
+		account, err := adapter.LoadAccount(address)
+		require.NoError(t, err)
+		require.NotNil(t, account)
+	})
+	t.Run("system account should not be requested from the remote network", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{}, &chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		})
+
+		_, err := adapter.LoadAccount(core.SystemAccountAddress)
+		require.NoError(t, err)
+	})
+	t.Run("remote error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		adapter := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{
+			GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+				return nil, state.ErrAccNotFound
+			},
+		}, &chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				return nil, expectedErr
+			},
+		})
+
+		account, err := adapter.LoadAccount(address)
+		require.Equal(t, expectedErr, err)
+		require.Nil(t, account)
+	})
+	t.Run("account missing from the remote network should be created empty", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{
+			GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+				return nil, state.ErrAccNotFound
+			},
+			LoadAccountCalled: func(container []byte) (vmcommon.AccountHandler, error) {
+				return &stateMock.UserAccountStub{}, nil
+			},
+			SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}, &chainSimulator.RemoteStateHandlerStub{})
+
+		account, err := adapter.LoadAccount(address)
+		require.NoError(t, err)
+		require.NotNil(t, account)
+	})
+	t.Run("account from the remote network should be saved without its storage", func(t *testing.T) {
+		t.Parallel()
+
+		userAccount, _ := accounts.NewUserAccount(address, &testTrie.DataTrieTrackerStub{
+			SaveKeyValueCalled: func(key []byte, value []byte) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}, &testTrie.TrieLeafParserStub{})
+
+		var savedAccount vmcommon.AccountHandler
+		adapter := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{
+			GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+				return nil, state.ErrAccNotFound
+			},
+			LoadAccountCalled: func(container []byte) (vmcommon.AccountHandler, error) {
+				return userAccount, nil
+			},
+			SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+				savedAccount = account
+				return nil
+			},
+		}, &chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				require.Equal(t, testscommon.TestAddressAlice, address)
+
+				nonce := uint64(7)
+				return &dtos.AddressState{
+					Address:  address,
+					Nonce:    &nonce,
+					Balance:  "100",
+					RootHash: "cm9vdEhhc2g=",
+				}, nil
+			},
+		})
+
+		_, err := adapter.LoadAccount(address)
+		require.NoError(t, err)
+		require.Equal(t, userAccount, savedAccount)
+		require.Equal(t, uint64(7), userAccount.GetNonce())
+		require.Equal(t, big.NewInt(100), userAccount.GetBalance())
+		require.Empty(t, userAccount.GetRootHash())
+	})
+	t.Run("removed account should not be requested again", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{
+			GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+				return nil, state.ErrAccNotFound
+			},
+			RemoveAccountCalled: func(addressContainer []byte) error {
+				return nil
+			},
+		}, &chainSimulator.RemoteStateHandlerStub{
+			GetAccountStateCalled: func(shardID uint32, address string) (*dtos.AddressState, error) {
+				require.Fail(t, "should have not been called")
+				return nil, nil
+			},
+		})
+
+		err := adapter.RemoveAccount(address)
+		require.NoError(t, err)
+
+		_, err = adapter.GetExistingAccount(address)
+		require.Equal(t, state.ErrAccNotFound, err)
+	})
+}
+
+func TestForkedAccountsAdapterAPI_GetExistingAccount(t *testing.T) {
+	t.Parallel()
+
+	address := testscommon.TestPubKeyAlice
+	notFoundErr := state.NewErrAccountNotFoundAtBlock(nil)
+	forkedAccount := &stateMock.UserAccountStub{}
+	forkedAccounts := createForkedAccountsAdapterForTest(t, &stateMock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+			return forkedAccount, nil
+		},
+	}, &chainSimulator.RemoteStateHandlerStub{})
+
+	adapter := &forkedAccountsAdapterAPI{
+		AccountsAdapter: &stateMock.AccountsStub{
+			GetExistingAccountCalled: func(addressContainer []byte) (vmcommon.AccountHandler, error) {
+				return nil, notFoundErr
+			},
+		},
+		forkedAccounts: forkedAccounts,
+	}
+
+	account, err := adapter.GetExistingAccount(address)
+	require.NoError(t, err)
+	require.True(t, account == forkedAccount)
+}
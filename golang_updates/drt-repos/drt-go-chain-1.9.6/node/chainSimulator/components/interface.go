@@ -1,6 +1,10 @@
 package components
 
-import "github.com/TerraDharitri/drt-go-chain-core/core"
+import (
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/process"
+)
 
 // SyncedBroadcastNetworkHandler defines the synced network interface
 type SyncedBroadcastNetworkHandler interface {
@@ -22,3 +26,24 @@ type NetworkMessenger interface {
 	Broadcast(topic string, buff []byte)
 	IsInterfaceNil() bool
 }
+
+// RemoteStateHandler defines what a provider of the accounts from a remote network should be able to do
+type RemoteStateHandler interface {
+	GetAccountState(shardID uint32, address string) (*dtos.AddressState, error)
+	GetStorageValue(shardID uint32, address string, key []byte) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// ExecutionTracerHandler defines what a tracer of the smart contracts executions should be able to do
+type ExecutionTracerHandler interface {
+	process.ExecutionTracer
+	GetTransactionTrace(txHash []byte) (*dtos.TransactionTrace, error)
+}
+
+// EventsStreamHandler defines what the stream of the events produced by the nodes should be able to do
+type EventsStreamHandler interface {
+	Publish(events []*dtos.StreamEvent)
+	Subscribe(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error)
+	Close() error
+	IsInterfaceNil() bool
+}
@@ -10,6 +10,7 @@ type manualRoundHandler struct {
 	genesisTimeStamp int64
 	roundDuration    time.Duration
 	initialRound     int64
+	timeOffset       int64
 }
 
 // NewManualRoundHandler returns a manual round handler instance
@@ -32,11 +33,26 @@ func (handler *manualRoundHandler) RevertOneRound() {
 	atomic.AddInt64(&handler.index, -1)
 }
 
+// SetIndex will set the current round index
+func (handler *manualRoundHandler) SetIndex(index int64) {
+	atomic.StoreInt64(&handler.index, index)
+}
+
 // Index returns the current index
 func (handler *manualRoundHandler) Index() int64 {
 	return atomic.LoadInt64(&handler.index)
 }
 
+// AddTimeOffset shifts the timestamp of the current round and of all the following rounds with the provided offset
+func (handler *manualRoundHandler) AddTimeOffset(offset time.Duration) {
+	atomic.AddInt64(&handler.timeOffset, int64(offset))
+}
+
+// TimeOffset returns the total offset added to the timestamps computed from the rounds
+func (handler *manualRoundHandler) TimeOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&handler.timeOffset))
+}
+
 // BeforeGenesis returns false
 func (handler *manualRoundHandler) BeforeGenesis() bool {
 	return false
@@ -46,13 +62,13 @@ func (handler *manualRoundHandler) BeforeGenesis() bool {
 func (handler *manualRoundHandler) UpdateRound(_ time.Time, _ time.Time) {
 }
 
-// TimeStamp returns the time based of the genesis timestamp and the current round
+// TimeStamp returns the time based of the genesis timestamp, the current round and the added time offset
 func (handler *manualRoundHandler) TimeStamp() time.Time {
 	rounds := atomic.LoadInt64(&handler.index)
 	timeFromGenesis := handler.roundDuration * time.Duration(rounds)
 	timestamp := time.Unix(handler.genesisTimeStamp, 0).Add(timeFromGenesis)
 	timestamp = time.Unix(timestamp.Unix()-int64(handler.roundDuration.Seconds())*handler.initialRound, 0)
-	return timestamp
+	return timestamp.Add(handler.TimeOffset())
 }
 
 // TimeDuration returns the provided time duration for this instance
@@ -34,6 +34,9 @@ func TestManualRoundHandler_Operations(t *testing.T) {
 	require.Equal(t, providedIndex, handler.Index())
 	handler.IncrementIndex()
 	require.Equal(t, providedIndex+1, handler.Index())
+	handler.SetIndex(providedIndex + 10)
+	require.Equal(t, providedIndex+10, handler.Index())
+	handler.SetIndex(providedIndex + 1)
 	expectedTimestamp := time.Unix(handler.genesisTimeStamp, 0).Add(providedRoundDuration)
 	require.Equal(t, expectedTimestamp, handler.TimeStamp())
 	require.Equal(t, providedRoundDuration, handler.TimeDuration())
@@ -42,3 +45,24 @@ func TestManualRoundHandler_Operations(t *testing.T) {
 	require.False(t, handler.BeforeGenesis())
 	handler.UpdateRound(time.Now(), time.Now()) // for coverage only
 }
+
+func TestManualRoundHandler_AddTimeOffset(t *testing.T) {
+	t.Parallel()
+
+	genesisTime := time.Now()
+	providedRoundDuration := time.Second
+	handler := NewManualRoundHandler(genesisTime.Unix(), providedRoundDuration, 0)
+	handler.SetIndex(1)
+	timestampWithoutOffset := handler.TimeStamp()
+	require.Equal(t, time.Duration(0), handler.TimeOffset())
+
+	handler.AddTimeOffset(time.Hour)
+	require.Equal(t, time.Hour, handler.TimeOffset())
+	require.Equal(t, timestampWithoutOffset.Add(time.Hour), handler.TimeStamp())
+
+	handler.AddTimeOffset(-time.Minute)
+	require.Equal(t, time.Hour-time.Minute, handler.TimeOffset())
+
+	handler.IncrementIndex()
+	require.Equal(t, timestampWithoutOffset.Add(providedRoundDuration+time.Hour-time.Minute), handler.TimeStamp())
+}
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+type txWithHash struct {
+	hash []byte
+	tx   data.TransactionHandler
+}
+
+// GetPendingTransactions returns the transactions sent from the node's shard which are waiting in the pool to be
+// included in a block, ordered by sender and nonce
+func (node *testOnlyProcessingNode) GetPendingTransactions() ([]*dtos.PendingTransaction, error) {
+	txPool := node.DataPool.Transactions()
+	shardCoordinator := node.GetShardCoordinator()
+
+	txs := make([]*txWithHash, 0)
+	for _, txHash := range txPool.Keys() {
+		value, ok := txPool.SearchFirstData(txHash)
+		if !ok {
+			continue
+		}
+
+		tx, ok := value.(data.TransactionHandler)
+		if !ok || check.IfNil(tx) {
+			continue
+		}
+
+		if shardCoordinator.ComputeId(tx.GetSndAddr()) != shardCoordinator.SelfId() {
+			continue
+		}
+
+		txs = append(txs, &txWithHash{
+			hash: txHash,
+			tx:   tx,
+		})
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		delta := bytes.Compare(txs[i].tx.GetSndAddr(), txs[j].tx.GetSndAddr())
+		if delta == 0 {
+			return txs[i].tx.GetNonce() < txs[j].tx.GetNonce()
+		}
+
+		return delta < 0
+	})
+
+	addressConverter := node.CoreComponentsHolder.AddressPubKeyConverter()
+	pendingTransactions := make([]*dtos.PendingTransaction, 0, len(txs))
+	for _, pendingTx := range txs {
+		tx := pendingTx.tx
+		sender, err := addressConverter.Encode(tx.GetSndAddr())
+		if err != nil {
+			return nil, err
+		}
+
+		receiver, err := addressConverter.Encode(tx.GetRcvAddr())
+		if err != nil {
+			return nil, err
+		}
+
+		pendingTransactions = append(pendingTransactions, &dtos.PendingTransaction{
+			Hash:     hex.EncodeToString(pendingTx.hash),
+			Sender:   sender,
+			Receiver: receiver,
+			Nonce:    tx.GetNonce(),
+			Value:    tx.GetValue().String(),
+			GasPrice: tx.GetGasPrice(),
+			GasLimit: tx.GetGasLimit(),
+			Data:     tx.GetData(),
+		})
+	}
+
+	return pendingTransactions, nil
+}
+
+// RemovePendingTransaction will remove the provided transaction from all the node's pools. It returns true if the
+// transaction was found
+func (node *testOnlyProcessingNode) RemovePendingTransaction(txHash []byte) bool {
+	txPool := node.DataPool.Transactions()
+	_, found := txPool.SearchFirstData(txHash)
+	if !found {
+		return false
+	}
+
+	txPool.RemoveDataFromAllShards(txHash)
+
+	return true
+}
+
+// SetTransactionsSelection will replace the transactions selected from the pool when proposing the next blocks with
+// the provided ones, executed in the provided order. The transactions should be in the pool, sent from the node's shard
+func (node *testOnlyProcessingNode) SetTransactionsSelection(txHashes [][]byte) error {
+	return node.txSelectionPool.SetSelection(txHashes)
+}
+
+// ClearTransactionsSelection will restore the selection of the transactions from the pool when proposing blocks
+func (node *testOnlyProcessingNode) ClearTransactionsSelection() {
+	node.txSelectionPool.ClearSelection()
+}
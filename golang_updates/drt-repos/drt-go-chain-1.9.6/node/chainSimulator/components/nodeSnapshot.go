@@ -0,0 +1,458 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data"
+	"github.com/TerraDharitri/drt-go-chain-core/data/block"
+	"github.com/TerraDharitri/drt-go-chain/common"
+	"github.com/TerraDharitri/drt-go-chain/common/holders"
+	"github.com/TerraDharitri/drt-go-chain/dataRetriever"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/process"
+)
+
+type snapshotRoundHandler interface {
+	SetIndex(index int64)
+	AddTimeOffset(offset time.Duration)
+	TimeOffset() time.Duration
+}
+
+// txStorageUnits holds the units storing the transactions of a block and the data attached to them
+var txStorageUnits = []dataRetriever.UnitType{
+	dataRetriever.TransactionUnit,
+	dataRetriever.UnsignedTransactionUnit,
+	dataRetriever.RewardTransactionUnit,
+	dataRetriever.TxLogsUnit,
+	dataRetriever.MiniblockHashByTxHashUnit,
+	dataRetriever.ResultsHashesByTxHashUnit,
+}
+
+// TakeSnapshot will save the current state of the node: the accounts tries root hashes, the current block,
+// the round and the time offset, the block tracker, the highest stored block of each shard and the pending transactions
+func (node *testOnlyProcessingNode) TakeSnapshot() (*dtos.NodeSnapshot, error) {
+	roundHandler, ok := node.CoreComponentsHolder.RoundHandler().(snapshotRoundHandler)
+	if !ok {
+		return nil, ErrRoundIndexCannotBeSet
+	}
+
+	userAccountsRootHash, err := node.StateComponentsHolder.AccountsAdapter().RootHash()
+	if err != nil {
+		return nil, err
+	}
+
+	peerAccountsRootHash, err := node.StateComponentsHolder.PeerAccounts().RootHash()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingTransactions, err := node.getPendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &dtos.NodeSnapshot{
+		Epoch:                    node.ProcessComponentsHolder.EpochStartTrigger().Epoch(),
+		Round:                    node.CoreComponentsHolder.RoundHandler().Index(),
+		TimeOffset:               roundHandler.TimeOffset(),
+		CurrentHeader:            node.ChainHandler.GetCurrentBlockHeader(),
+		CurrentHeaderHash:        node.ChainHandler.GetCurrentBlockHeaderHash(),
+		CurrentRootHash:          node.ChainHandler.GetCurrentBlockRootHash(),
+		UserAccountsRootHash:     userAccountsRootHash,
+		PeerAccountsRootHash:     peerAccountsRootHash,
+		HighestNonceInPool:       make(map[uint32]uint64),
+		HighestStoredNonce:       make(map[uint32]uint64),
+		LastCrossNotarizedHeader: make(map[uint32]*dtos.HeaderWithHash),
+		LastSelfNotarizedHeader:  make(map[uint32]*dtos.HeaderWithHash),
+		TrackedHeaders:           make(map[uint32][]*dtos.HeaderWithHash),
+		PendingTransactions:      pendingTransactions,
+	}
+
+	blockTracker := node.ProcessComponentsHolder.BlockTracker()
+	headersPool := node.DataPool.Headers()
+	for _, shardID := range node.allShardIDs() {
+		snapshot.HighestNonceInPool[shardID] = highestNonce(headersPool.Nonces(shardID))
+
+		header, hash, errGet := blockTracker.GetLastCrossNotarizedHeader(shardID)
+		if errGet == nil {
+			snapshot.LastCrossNotarizedHeader[shardID] = &dtos.HeaderWithHash{Header: header, Hash: hash}
+		}
+
+		header, hash, errGet = blockTracker.GetLastSelfNotarizedHeader(shardID)
+		if errGet == nil {
+			snapshot.LastSelfNotarizedHeader[shardID] = &dtos.HeaderWithHash{Header: header, Hash: hash}
+		}
+
+		snapshot.HighestStoredNonce[shardID] = node.highestStoredNonce(shardID, node.lowestNonceToCheckInStorage(shardID, snapshot))
+
+		headers, hashes := blockTracker.GetTrackedHeaders(shardID)
+		for idx := range headers {
+			snapshot.TrackedHeaders[shardID] = append(snapshot.TrackedHeaders[shardID], &dtos.HeaderWithHash{
+				Header: headers[idx],
+				Hash:   hashes[idx],
+			})
+		}
+	}
+
+	return snapshot, nil
+}
+
+// RevertToSnapshot will bring the node back to the state saved in the provided snapshot. Reverting over an epoch
+// change is not supported
+func (node *testOnlyProcessingNode) RevertToSnapshot(snapshot *dtos.NodeSnapshot) error {
+	if snapshot == nil {
+		return ErrNilNodeSnapshot
+	}
+
+	currentEpoch := node.ProcessComponentsHolder.EpochStartTrigger().Epoch()
+	if currentEpoch != snapshot.Epoch {
+		return fmt.Errorf("%w, snapshot epoch: %d, current epoch: %d", ErrSnapshotFromOtherEpoch, snapshot.Epoch, currentEpoch)
+	}
+
+	err := node.revertBlockchain(snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = node.revertAccounts(snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = node.revertRound(snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = node.revertStorage(snapshot)
+	if err != nil {
+		return err
+	}
+
+	node.revertForkDetector(snapshot)
+	node.revertBlockTracker(snapshot)
+	node.revertPools(snapshot)
+
+	return nil
+}
+
+func (node *testOnlyProcessingNode) revertBlockchain(snapshot *dtos.NodeSnapshot) error {
+	err := node.ChainHandler.SetCurrentBlockHeaderAndRootHash(snapshot.CurrentHeader, snapshot.CurrentRootHash)
+	if err != nil {
+		return err
+	}
+	node.ChainHandler.SetCurrentBlockHeaderHash(snapshot.CurrentHeaderHash)
+
+	header, headerHash := node.getCurrentOrGenesisHeader(snapshot)
+	err = node.ProcessComponentsHolder.BlockProcessor().RevertStateToBlock(header, snapshot.UserAccountsRootHash)
+	if err != nil {
+		return err
+	}
+
+	scheduledTxsExecutionHandler := node.ProcessComponentsHolder.ScheduledTxsExecutionHandler()
+	err = scheduledTxsExecutionHandler.RollBackToBlock(headerHash)
+	if err != nil {
+		scheduledTxsExecutionHandler.SetScheduledInfo(&process.ScheduledInfo{
+			RootHash:        snapshot.UserAccountsRootHash,
+			IntermediateTxs: make(map[block.Type][]data.TransactionHandler),
+			GasAndFees:      process.GetZeroGasAndFees(),
+			MiniBlocks:      make(block.MiniBlockSlice, 0),
+		})
+	}
+
+	return nil
+}
+
+// revertAccounts recreates the tries from the saved root hashes, as the accounts might have been altered after the
+// current block was committed
+func (node *testOnlyProcessingNode) revertAccounts(snapshot *dtos.NodeSnapshot) error {
+	err := node.StateComponentsHolder.AccountsAdapter().RecreateTrie(holders.NewDefaultRootHashesHolder(snapshot.UserAccountsRootHash))
+	if err != nil {
+		return fmt.Errorf("%w while reverting the user accounts", err)
+	}
+
+	err = node.StateComponentsHolder.PeerAccounts().RecreateTrie(holders.NewDefaultRootHashesHolder(snapshot.PeerAccountsRootHash))
+	if err != nil {
+		return fmt.Errorf("%w while reverting the peer accounts", err)
+	}
+
+	return nil
+}
+
+// revertRound restores the round and the time offset, so the time travel done after the snapshot is undone as well
+func (node *testOnlyProcessingNode) revertRound(snapshot *dtos.NodeSnapshot) error {
+	roundHandler, ok := node.CoreComponentsHolder.RoundHandler().(snapshotRoundHandler)
+	if !ok {
+		return ErrRoundIndexCannotBeSet
+	}
+
+	roundHandler.SetIndex(snapshot.Round)
+	roundHandler.AddTimeOffset(snapshot.TimeOffset - roundHandler.TimeOffset())
+	node.StatusCoreComponents.AppStatusHandler().SetUInt64Value(common.MetricCurrentRound, uint64(snapshot.Round))
+
+	return nil
+}
+
+func (node *testOnlyProcessingNode) revertForkDetector(snapshot *dtos.NodeSnapshot) {
+	forkDetector := node.ProcessComponentsHolder.ForkDetector()
+	forkDetector.RestoreToGenesis()
+	if check.IfNil(snapshot.CurrentHeader) {
+		return
+	}
+
+	forkDetector.AddCheckpoint(snapshot.CurrentHeader.GetNonce(), snapshot.CurrentHeader.GetRound(), snapshot.CurrentHeaderHash)
+	forkDetector.SetFinalToLastCheckpoint()
+}
+
+func (node *testOnlyProcessingNode) revertBlockTracker(snapshot *dtos.NodeSnapshot) {
+	blockTracker := node.ProcessComponentsHolder.BlockTracker()
+	blockTracker.RestoreToGenesis()
+
+	for shardID, notarized := range snapshot.LastCrossNotarizedHeader {
+		_, hash, err := blockTracker.GetLastCrossNotarizedHeader(shardID)
+		if err == nil && bytes.Equal(hash, notarized.Hash) {
+			continue
+		}
+
+		blockTracker.AddCrossNotarizedHeader(shardID, notarized.Header, notarized.Hash)
+	}
+
+	for shardID, notarized := range snapshot.LastSelfNotarizedHeader {
+		_, hash, err := blockTracker.GetLastSelfNotarizedHeader(shardID)
+		if err == nil && bytes.Equal(hash, notarized.Hash) {
+			continue
+		}
+
+		blockTracker.AddSelfNotarizedHeader(shardID, notarized.Header, notarized.Hash)
+	}
+
+	for _, trackedHeaders := range snapshot.TrackedHeaders {
+		for _, tracked := range trackedHeaders {
+			blockTracker.AddTrackedHeader(tracked.Header, tracked.Hash)
+		}
+	}
+}
+
+// revertPools removes the headers received after the snapshot was taken and restores the pending transactions.
+// The other pools are only filled while a block is processed, so they are emptied
+func (node *testOnlyProcessingNode) revertPools(snapshot *dtos.NodeSnapshot) {
+	headersPool := node.DataPool.Headers()
+	for _, shardID := range node.allShardIDs() {
+		highestNonceAtSnapshot := snapshot.HighestNonceInPool[shardID]
+		for _, nonce := range headersPool.Nonces(shardID) {
+			if nonce > highestNonceAtSnapshot {
+				headersPool.RemoveHeaderByNonceAndShardId(nonce, shardID)
+			}
+		}
+	}
+
+	node.DataPool.UnsignedTransactions().Clear()
+	node.DataPool.RewardTransactions().Clear()
+	node.DataPool.MiniBlocks().Clear()
+	node.DataPool.CurrentBlockTxs().Clean()
+
+	txPool := node.DataPool.Transactions()
+	txPool.Clear()
+	for _, pendingTx := range snapshot.PendingTransactions {
+		txPool.AddData(pendingTx.Hash, pendingTx.Tx, pendingTx.Size, pendingTx.CacheID)
+	}
+}
+
+// revertStorage removes the blocks stored after the snapshot was taken, together with their miniblocks, transactions
+// and logs, so that they can no longer be fetched from the API and the new blocks can be stored at the same nonces
+func (node *testOnlyProcessingNode) revertStorage(snapshot *dtos.NodeSnapshot) error {
+	for _, shardID := range node.allShardIDs() {
+		for nonce := snapshot.HighestStoredNonce[shardID] + 1; ; nonce++ {
+			hash, err := node.getStoredHeaderHash(shardID, nonce)
+			if err != nil {
+				// no block was stored at this nonce, so no block was stored after it either
+				break
+			}
+
+			err = node.removeStoredBlock(shardID, nonce, hash)
+			if err != nil {
+				return fmt.Errorf("%w while removing the block %d of shard %d", err, nonce, shardID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (node *testOnlyProcessingNode) removeStoredBlock(shardID uint32, nonce uint64, hash []byte) error {
+	marshaller := node.CoreComponentsHolder.InternalMarshalizer()
+	header, err := process.GetHeaderFromStorage(shardID, hash, marshaller, node.StoreService)
+	if err == nil {
+		err = node.removeStoredMiniBlocks(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = node.removeFromStorage(dataRetriever.GetHeadersDataUnit(shardID), hash)
+	if err != nil {
+		return err
+	}
+
+	nonceToByteSlice := node.CoreComponentsHolder.Uint64ByteSliceConverter().ToByteSlice(nonce)
+	return node.removeFromStorage(dataRetriever.GetHdrNonceHashDataUnit(shardID), nonceToByteSlice)
+}
+
+func (node *testOnlyProcessingNode) removeStoredMiniBlocks(header data.HeaderHandler) error {
+	marshaller := node.CoreComponentsHolder.InternalMarshalizer()
+	miniBlocksStorer, err := node.StoreService.GetStorer(dataRetriever.MiniBlockUnit)
+	if err != nil {
+		return err
+	}
+
+	for _, miniBlockHash := range header.GetMiniBlockHeadersHashes() {
+		miniBlockBuff, errGet := miniBlocksStorer.Get(miniBlockHash)
+		if errGet != nil {
+			continue
+		}
+
+		miniBlock := &block.MiniBlock{}
+		err = marshaller.Unmarshal(miniBlock, miniBlockBuff)
+		if err != nil {
+			return err
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			for _, unit := range txStorageUnits {
+				err = node.removeFromStorage(unit, txHash)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err = miniBlocksStorer.Remove(miniBlockHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeFromStorage removes a key from a storage unit. The units which are not created by the node, such as the db
+// lookup extensions when they are disabled, are skipped
+func (node *testOnlyProcessingNode) removeFromStorage(unit dataRetriever.UnitType, key []byte) error {
+	storer, err := node.StoreService.GetStorer(unit)
+	if err != nil {
+		return nil
+	}
+
+	return storer.Remove(key)
+}
+
+func (node *testOnlyProcessingNode) getStoredHeaderHash(shardID uint32, nonce uint64) ([]byte, error) {
+	return process.GetHeaderHashFromStorageWithNonce(
+		nonce,
+		node.StoreService,
+		node.CoreComponentsHolder.Uint64ByteSliceConverter(),
+		node.CoreComponentsHolder.InternalMarshalizer(),
+		dataRetriever.GetHdrNonceHashDataUnit(shardID),
+	)
+}
+
+// highestStoredNonce returns the nonce of the highest block of a shard stored by the node, starting the search from
+// a nonce known to be stored
+func (node *testOnlyProcessingNode) highestStoredNonce(shardID uint32, fromNonce uint64) uint64 {
+	nonce := fromNonce
+	for {
+		_, err := node.getStoredHeaderHash(shardID, nonce+1)
+		if err != nil {
+			return nonce
+		}
+
+		nonce++
+	}
+}
+
+// lowestNonceToCheckInStorage returns the nonce the search of the highest stored block of a shard starts from: the
+// current block for the own shard and the last cross notarized block for the other ones
+func (node *testOnlyProcessingNode) lowestNonceToCheckInStorage(shardID uint32, snapshot *dtos.NodeSnapshot) uint64 {
+	if shardID == node.GetShardCoordinator().SelfId() {
+		if check.IfNil(snapshot.CurrentHeader) {
+			return 0
+		}
+
+		return snapshot.CurrentHeader.GetNonce()
+	}
+
+	notarized, ok := snapshot.LastCrossNotarizedHeader[shardID]
+	if !ok || check.IfNil(notarized.Header) {
+		return 0
+	}
+
+	return notarized.Header.GetNonce()
+}
+
+func (node *testOnlyProcessingNode) getPendingTransactions() ([]*dtos.PoolTransaction, error) {
+	txPool := node.DataPool.Transactions()
+	shardCoordinator := node.GetShardCoordinator()
+	marshaller := node.CoreComponentsHolder.InternalMarshalizer()
+
+	pendingTransactions := make([]*dtos.PoolTransaction, 0)
+	for _, txHash := range txPool.Keys() {
+		value, ok := txPool.SearchFirstData(txHash)
+		if !ok {
+			continue
+		}
+
+		tx, ok := value.(data.TransactionHandler)
+		if !ok || check.IfNil(tx) {
+			continue
+		}
+
+		txBuff, err := marshaller.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		senderShardID := shardCoordinator.ComputeId(tx.GetSndAddr())
+		receiverShardID := shardCoordinator.ComputeId(tx.GetRcvAddr())
+		pendingTransactions = append(pendingTransactions, &dtos.PoolTransaction{
+			Hash:    txHash,
+			Tx:      tx,
+			Size:    len(txBuff),
+			CacheID: process.ShardCacherIdentifier(senderShardID, receiverShardID),
+		})
+	}
+
+	return pendingTransactions, nil
+}
+
+func (node *testOnlyProcessingNode) getCurrentOrGenesisHeader(snapshot *dtos.NodeSnapshot) (data.HeaderHandler, []byte) {
+	if !check.IfNil(snapshot.CurrentHeader) {
+		return snapshot.CurrentHeader, snapshot.CurrentHeaderHash
+	}
+
+	return node.ChainHandler.GetGenesisHeader(), node.ChainHandler.GetGenesisHeaderHash()
+}
+
+func (node *testOnlyProcessingNode) allShardIDs() []uint32 {
+	numShards := node.GetShardCoordinator().NumberOfShards()
+	shardIDs := make([]uint32, 0, numShards+1)
+	for shardID := uint32(0); shardID < numShards; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+
+	return append(shardIDs, core.MetachainShardId)
+}
+
+func highestNonce(nonces []uint64) uint64 {
+	highest := uint64(0)
+	for _, nonce := range nonces {
+		if nonce > highest {
+			highest = nonce
+		}
+	}
+
+	return highest
+}
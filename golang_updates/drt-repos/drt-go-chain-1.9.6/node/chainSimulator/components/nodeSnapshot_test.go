@@ -0,0 +1,86 @@
+package components
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestOnlyProcessingNode_RevertToSnapshot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	address := "drt1qtc600lryvytxuy4h7vn7xmsy5tw6vuw3tskr75cwnmv4mnyjgsq89rptv"
+
+	t.Run("nil snapshot should error", func(t *testing.T) {
+		node, err := NewTestOnlyProcessingNode(createMockArgsTestOnlyProcessingNode(t))
+		require.NoError(t, err)
+
+		err = node.RevertToSnapshot(nil)
+		require.Equal(t, ErrNilNodeSnapshot, err)
+	})
+	t.Run("snapshot from another epoch should error", func(t *testing.T) {
+		node, err := NewTestOnlyProcessingNode(createMockArgsTestOnlyProcessingNode(t))
+		require.NoError(t, err)
+
+		snapshot, err := node.TakeSnapshot()
+		require.NoError(t, err)
+
+		snapshot.Epoch++
+		err = node.RevertToSnapshot(snapshot)
+		require.True(t, errors.Is(err, ErrSnapshotFromOtherEpoch))
+	})
+	t.Run("should restore the accounts, the round and the time offset", func(t *testing.T) {
+		node, err := NewTestOnlyProcessingNode(createMockArgsTestOnlyProcessingNode(t))
+		require.NoError(t, err)
+
+		addressBytes, _ := node.CoreComponentsHolder.AddressPubKeyConverter().Decode(address)
+		err = node.SetStateForAddress(addressBytes, &dtos.AddressState{
+			Address: address,
+			Balance: "100",
+		})
+		require.NoError(t, err)
+
+		snapshot, err := node.TakeSnapshot()
+		require.NoError(t, err)
+		roundAtSnapshot := node.CoreComponentsHolder.RoundHandler().Index()
+
+		err = node.SetStateForAddress(addressBytes, &dtos.AddressState{
+			Address: address,
+			Balance: "200",
+		})
+		require.NoError(t, err)
+		roundHandler := node.CoreComponentsHolder.RoundHandler().(*manualRoundHandler)
+		timeOffsetAtSnapshot := roundHandler.TimeOffset()
+		timestampAtSnapshot := roundHandler.TimeStamp()
+		roundHandler.IncrementIndex()
+		roundHandler.AddTimeOffset(time.Hour)
+
+		err = node.RevertToSnapshot(snapshot)
+		require.NoError(t, err)
+		require.Equal(t, timeOffsetAtSnapshot, roundHandler.TimeOffset())
+		require.Equal(t, timestampAtSnapshot, roundHandler.TimeStamp())
+
+		account, err := node.StateComponentsHolder.AccountsAdapter().GetExistingAccount(addressBytes)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), account.(state.UserAccountHandler).GetBalance())
+		require.Equal(t, roundAtSnapshot, node.CoreComponentsHolder.RoundHandler().Index())
+
+		// the same snapshot can be used again
+		err = node.RemoveAccount(addressBytes)
+		require.NoError(t, err)
+
+		err = node.RevertToSnapshot(snapshot)
+		require.NoError(t, err)
+
+		account, err = node.StateComponentsHolder.AccountsAdapter().GetExistingAccount(addressBytes)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), account.(state.UserAccountHandler).GetBalance())
+	})
+}
@@ -0,0 +1,382 @@
+package components
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data"
+	"github.com/TerraDharitri/drt-go-chain-core/data/block"
+	outportcore "github.com/TerraDharitri/drt-go-chain-core/data/outport"
+	"github.com/TerraDharitri/drt-go-chain-core/marshal"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+const (
+	transactionTypeNormal = "normal"
+	transactionTypeSCR    = "unsigned"
+
+	transactionStatusSuccess = "success"
+	transactionStatusFail    = "fail"
+	transactionStatusInvalid = "invalid"
+)
+
+// ArgsOutportEventsDriver holds the arguments needed to create a new outport events driver
+type ArgsOutportEventsDriver struct {
+	ShardID          uint32
+	Marshaller       marshal.Marshalizer
+	AddressConverter core.PubkeyConverter
+	EventsStream     EventsStreamHandler
+}
+
+// outportEventsDriver is an outport driver converting the data saved for each committed block into events published
+// on the events stream
+type outportEventsDriver struct {
+	shardID          uint32
+	marshaller       marshal.Marshalizer
+	addressConverter core.PubkeyConverter
+	eventsStream     EventsStreamHandler
+}
+
+type executedTransaction struct {
+	event          *dtos.TransactionEvent
+	executionOrder uint32
+}
+
+// NewOutportEventsDriver creates a new outport driver publishing the blocks, the transactions and the logs on the
+// events stream
+func NewOutportEventsDriver(args ArgsOutportEventsDriver) (*outportEventsDriver, error) {
+	if check.IfNil(args.Marshaller) {
+		return nil, core.ErrNilMarshalizer
+	}
+	if check.IfNil(args.AddressConverter) {
+		return nil, ErrNilAddressConverter
+	}
+	if check.IfNil(args.EventsStream) {
+		return nil, ErrNilEventsStream
+	}
+
+	return &outportEventsDriver{
+		shardID:          args.ShardID,
+		marshaller:       args.Marshaller,
+		addressConverter: args.AddressConverter,
+		eventsStream:     args.EventsStream,
+	}, nil
+}
+
+// SaveBlock will publish the events of the committed block. The errors are only logged, as the outport handler
+// retries the blocks that could not be saved
+func (driver *outportEventsDriver) SaveBlock(outportBlock *outportcore.OutportBlock) error {
+	events, err := driver.createEvents(outportBlock)
+	if err != nil {
+		log.Warn("outportEventsDriver.SaveBlock: cannot create the events of the block", "shard", driver.shardID, "error", err)
+		return nil
+	}
+
+	driver.eventsStream.Publish(events)
+
+	return nil
+}
+
+func (driver *outportEventsDriver) createEvents(outportBlock *outportcore.OutportBlock) ([]*dtos.StreamEvent, error) {
+	if outportBlock == nil || outportBlock.BlockData == nil {
+		return nil, ErrNilOutportBlock
+	}
+
+	header, err := driver.getHeader(outportBlock.BlockData)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash := hex.EncodeToString(outportBlock.BlockData.HeaderHash)
+	events := []*dtos.StreamEvent{
+		{
+			Type:  EventTypeBlock,
+			Shard: driver.shardID,
+			Block: &dtos.BlockEvent{
+				Hash:      blockHash,
+				Nonce:     header.GetNonce(),
+				Round:     header.GetRound(),
+				Epoch:     header.GetEpoch(),
+				Timestamp: header.GetTimeStamp(),
+				NumTxs:    header.GetTxCount(),
+			},
+		},
+	}
+
+	pool := outportBlock.TransactionPool
+	if pool == nil {
+		return events, nil
+	}
+
+	transactions, err := driver.createTransactionEvents(pool, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range transactions {
+		events = append(events, &dtos.StreamEvent{
+			Type:        EventTypeTransaction,
+			Shard:       driver.shardID,
+			Transaction: tx,
+		})
+	}
+
+	logs, err := driver.createLogEvents(pool.Logs, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, logEvent := range logs {
+		events = append(events, &dtos.StreamEvent{
+			Type:  EventTypeLog,
+			Shard: driver.shardID,
+			Log:   logEvent,
+		})
+	}
+
+	return events, nil
+}
+
+func (driver *outportEventsDriver) getHeader(blockData *outportcore.BlockData) (data.HeaderHandler, error) {
+	var header data.HeaderHandler
+	switch core.HeaderType(blockData.HeaderType) {
+	case core.MetaHeader:
+		header = &block.MetaBlock{}
+	case core.ShardHeaderV1:
+		header = &block.Header{}
+	case core.ShardHeaderV2:
+		header = &block.HeaderV2{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHeaderType, blockData.HeaderType)
+	}
+
+	err := driver.marshaller.Unmarshal(header, blockData.HeaderBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (driver *outportEventsDriver) createTransactionEvents(pool *outportcore.TransactionPool, blockHash string) ([]*dtos.TransactionEvent, error) {
+	failedTxs := getFailedTransactions(pool.Logs)
+
+	executed := make([]*executedTransaction, 0, len(pool.Transactions)+len(pool.SmartContractResults)+len(pool.InvalidTxs))
+	for txHash, txInfo := range pool.Transactions {
+		status := transactionStatusSuccess
+		_, failed := failedTxs[txHash]
+		if failed {
+			status = transactionStatusFail
+		}
+
+		event, err := driver.createTransactionEvent(txHash, txInfo.Transaction, txInfo.FeeInfo, transactionTypeNormal, status)
+		if err != nil {
+			return nil, err
+		}
+
+		executed = append(executed, &executedTransaction{event: event, executionOrder: txInfo.ExecutionOrder})
+	}
+
+	for txHash, txInfo := range pool.InvalidTxs {
+		event, err := driver.createTransactionEvent(txHash, txInfo.Transaction, txInfo.FeeInfo, transactionTypeNormal, transactionStatusInvalid)
+		if err != nil {
+			return nil, err
+		}
+
+		executed = append(executed, &executedTransaction{event: event, executionOrder: txInfo.ExecutionOrder})
+	}
+
+	for scrHash, scrInfo := range pool.SmartContractResults {
+		scr := scrInfo.SmartContractResult
+		event, err := driver.createTransactionEvent(scrHash, scr, scrInfo.FeeInfo, transactionTypeSCR, transactionStatusSuccess)
+		if err != nil {
+			return nil, err
+		}
+
+		event.OriginalTxHash = hex.EncodeToString(scr.GetOriginalTxHash())
+		event.ReturnMessage = string(scr.GetReturnMessage())
+		executed = append(executed, &executedTransaction{event: event, executionOrder: scrInfo.ExecutionOrder})
+	}
+
+	sort.SliceStable(executed, func(i, j int) bool {
+		if executed[i].executionOrder == executed[j].executionOrder {
+			return executed[i].event.Hash < executed[j].event.Hash
+		}
+
+		return executed[i].executionOrder < executed[j].executionOrder
+	})
+
+	events := make([]*dtos.TransactionEvent, 0, len(executed))
+	for _, tx := range executed {
+		tx.event.BlockHash = blockHash
+		events = append(events, tx.event)
+	}
+
+	return events, nil
+}
+
+func (driver *outportEventsDriver) createTransactionEvent(
+	txHash string,
+	tx data.TransactionHandler,
+	feeInfo *outportcore.FeeInfo,
+	txType string,
+	status string,
+) (*dtos.TransactionEvent, error) {
+	if check.IfNil(tx) {
+		return nil, fmt.Errorf("%w, hash: %s", ErrNilTransaction, txHash)
+	}
+
+	sender, err := driver.encodeAddress(tx.GetSndAddr())
+	if err != nil {
+		return nil, err
+	}
+
+	receiver, err := driver.encodeAddress(tx.GetRcvAddr())
+	if err != nil {
+		return nil, err
+	}
+
+	event := &dtos.TransactionEvent{
+		Hash:     txHash,
+		Type:     txType,
+		Status:   status,
+		Nonce:    tx.GetNonce(),
+		Sender:   sender,
+		Receiver: receiver,
+		Value:    bigIntToString(tx.GetValue()),
+		Data:     tx.GetData(),
+		GasLimit: tx.GetGasLimit(),
+	}
+	if feeInfo != nil {
+		event.GasUsed = feeInfo.GasUsed
+		event.Fee = bigIntToString(feeInfo.Fee)
+	}
+
+	return event, nil
+}
+
+func (driver *outportEventsDriver) createLogEvents(logs []*outportcore.LogData, blockHash string) ([]*dtos.LogEvent, error) {
+	events := make([]*dtos.LogEvent, 0)
+	for _, logData := range logs {
+		if logData == nil || logData.Log == nil {
+			continue
+		}
+
+		for _, event := range logData.Log.Events {
+			if event == nil {
+				continue
+			}
+
+			address, err := driver.encodeAddress(event.Address)
+			if err != nil {
+				return nil, err
+			}
+
+			topics := make([]string, 0, len(event.Topics))
+			for _, topic := range event.Topics {
+				topics = append(topics, hex.EncodeToString(topic))
+			}
+
+			events = append(events, &dtos.LogEvent{
+				TxHash:     logData.TxHash,
+				BlockHash:  blockHash,
+				Address:    address,
+				Identifier: string(event.Identifier),
+				Topics:     topics,
+				Data:       hex.EncodeToString(event.Data),
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func (driver *outportEventsDriver) encodeAddress(address []byte) (string, error) {
+	if len(address) == 0 {
+		return "", nil
+	}
+
+	return driver.addressConverter.Encode(address)
+}
+
+func getFailedTransactions(logs []*outportcore.LogData) map[string]struct{} {
+	failedTxs := make(map[string]struct{})
+	for _, logData := range logs {
+		if logData == nil || logData.Log == nil {
+			continue
+		}
+
+		for _, event := range logData.Log.Events {
+			if event != nil && string(event.Identifier) == core.SignalErrorOperation {
+				failedTxs[logData.TxHash] = struct{}{}
+			}
+		}
+	}
+
+	return failedTxs
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
+
+// RevertIndexedBlock does nothing, the events of the reverted blocks were already published
+func (driver *outportEventsDriver) RevertIndexedBlock(_ *outportcore.BlockData) error {
+	return nil
+}
+
+// SaveRoundsInfo does nothing
+func (driver *outportEventsDriver) SaveRoundsInfo(_ *outportcore.RoundsInfo) error {
+	return nil
+}
+
+// SaveValidatorsPubKeys does nothing
+func (driver *outportEventsDriver) SaveValidatorsPubKeys(_ *outportcore.ValidatorsPubKeys) error {
+	return nil
+}
+
+// SaveValidatorsRating does nothing
+func (driver *outportEventsDriver) SaveValidatorsRating(_ *outportcore.ValidatorsRating) error {
+	return nil
+}
+
+// SaveAccounts does nothing
+func (driver *outportEventsDriver) SaveAccounts(_ *outportcore.Accounts) error {
+	return nil
+}
+
+// FinalizedBlock does nothing
+func (driver *outportEventsDriver) FinalizedBlock(_ *outportcore.FinalizedBlock) error {
+	return nil
+}
+
+// GetMarshaller returns the marshaller used to encode the headers sent to the driver
+func (driver *outportEventsDriver) GetMarshaller() marshal.Marshalizer {
+	return driver.marshaller
+}
+
+// SetCurrentSettings does nothing
+func (driver *outportEventsDriver) SetCurrentSettings(_ outportcore.OutportConfig) error {
+	return nil
+}
+
+// RegisterHandler does nothing
+func (driver *outportEventsDriver) RegisterHandler(_ func() error, _ string) error {
+	return nil
+}
+
+// Close does nothing, the events stream is shared by all the nodes
+func (driver *outportEventsDriver) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (driver *outportEventsDriver) IsInterfaceNil() bool {
+	return driver == nil
+}
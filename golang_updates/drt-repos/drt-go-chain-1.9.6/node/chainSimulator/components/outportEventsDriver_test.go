@@ -0,0 +1,208 @@
+package components
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/data/block"
+	outportcore "github.com/TerraDharitri/drt-go-chain-core/data/outport"
+	"github.com/TerraDharitri/drt-go-chain-core/data/smartContractResult"
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/testscommon"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/marshallerMock"
+	"github.com/stretchr/testify/require"
+)
+
+func createArgsOutportEventsDriver() ArgsOutportEventsDriver {
+	return ArgsOutportEventsDriver{
+		ShardID:          1,
+		Marshaller:       &marshallerMock.MarshalizerMock{},
+		AddressConverter: testscommon.RealWorldBech32PubkeyConverter,
+		EventsStream:     NewEventsStream(),
+	}
+}
+
+func TestNewOutportEventsDriver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsOutportEventsDriver()
+		args.Marshaller = nil
+		driver, err := NewOutportEventsDriver(args)
+		require.Equal(t, core.ErrNilMarshalizer, err)
+		require.Nil(t, driver)
+	})
+	t.Run("nil address converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsOutportEventsDriver()
+		args.AddressConverter = nil
+		driver, err := NewOutportEventsDriver(args)
+		require.Equal(t, ErrNilAddressConverter, err)
+		require.Nil(t, driver)
+	})
+	t.Run("nil events stream should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsOutportEventsDriver()
+		args.EventsStream = nil
+		driver, err := NewOutportEventsDriver(args)
+		require.Equal(t, ErrNilEventsStream, err)
+		require.Nil(t, driver)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		driver, err := NewOutportEventsDriver(createArgsOutportEventsDriver())
+		require.Nil(t, err)
+		require.False(t, driver.IsInterfaceNil())
+	})
+}
+
+func TestOutportEventsDriver_SaveBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown header type should not publish events", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsOutportEventsDriver()
+		events, _, _ := args.EventsStream.Subscribe(dtos.EventsFilter{})
+		driver, _ := NewOutportEventsDriver(args)
+
+		_, err := driver.createEvents(&outportcore.OutportBlock{
+			BlockData: &outportcore.BlockData{HeaderType: "unknown"},
+		})
+		require.True(t, errors.Is(err, ErrUnknownHeaderType))
+
+		err = driver.SaveBlock(&outportcore.OutportBlock{
+			BlockData: &outportcore.BlockData{HeaderType: "unknown"},
+		})
+		require.Nil(t, err)
+		require.Empty(t, readEvents(events))
+	})
+	t.Run("should publish the block, the transactions and the logs", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsOutportEventsDriver()
+		events, _, _ := args.EventsStream.Subscribe(dtos.EventsFilter{})
+		driver, _ := NewOutportEventsDriver(args)
+
+		headerBytes, _ := args.Marshaller.Marshal(&block.Header{Nonce: 10, Round: 11, Epoch: 2, TimeStamp: 1000, TxCount: 3})
+		alice := testscommon.TestPubKeyAlice
+		bob := testscommon.TestPubKeyBob
+		err := driver.SaveBlock(&outportcore.OutportBlock{
+			BlockData: &outportcore.BlockData{
+				HeaderBytes: headerBytes,
+				HeaderType:  string(core.ShardHeaderV1),
+				HeaderHash:  []byte("block hash"),
+			},
+			TransactionPool: &outportcore.TransactionPool{
+				Transactions: map[string]*outportcore.TxInfo{
+					"02": {
+						Transaction:    &transaction.Transaction{Nonce: 2, SndAddr: alice, RcvAddr: bob, Value: big.NewInt(5)},
+						FeeInfo:        &outportcore.FeeInfo{GasUsed: 50, Fee: big.NewInt(500)},
+						ExecutionOrder: 2,
+					},
+					"01": {
+						Transaction:    &transaction.Transaction{Nonce: 1, SndAddr: alice, RcvAddr: bob},
+						FeeInfo:        &outportcore.FeeInfo{GasUsed: 50, Fee: big.NewInt(500)},
+						ExecutionOrder: 0,
+					},
+				},
+				SmartContractResults: map[string]*outportcore.SCRInfo{
+					"03": {
+						SmartContractResult: &smartContractResult.SmartContractResult{
+							SndAddr:        bob,
+							RcvAddr:        alice,
+							Value:          big.NewInt(1),
+							OriginalTxHash: []byte{1},
+							ReturnMessage:  []byte("refund"),
+						},
+						ExecutionOrder: 1,
+					},
+				},
+				InvalidTxs: map[string]*outportcore.TxInfo{
+					"04": {
+						Transaction:    &transaction.Transaction{Nonce: 5, SndAddr: bob, RcvAddr: alice},
+						ExecutionOrder: 3,
+					},
+				},
+				Logs: []*outportcore.LogData{
+					{
+						TxHash: "02",
+						Log: &transaction.Log{
+							Address: bob,
+							Events: []*transaction.Event{
+								{
+									Address:    bob,
+									Identifier: []byte(core.SignalErrorOperation),
+									Topics:     [][]byte{[]byte("topic")},
+									Data:       []byte("data"),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+		require.Nil(t, err)
+
+		received := readEvents(events)
+		require.Len(t, received, 6)
+
+		blockHash := hex.EncodeToString([]byte("block hash"))
+		require.Equal(t, &dtos.StreamEvent{
+			ID:    1,
+			Type:  EventTypeBlock,
+			Shard: 1,
+			Block: &dtos.BlockEvent{
+				Hash:      blockHash,
+				Nonce:     10,
+				Round:     11,
+				Epoch:     2,
+				Timestamp: 1000,
+				NumTxs:    3,
+			},
+		}, received[0])
+
+		expectedTransactions := []struct {
+			hash   string
+			txType string
+			status string
+		}{
+			{"01", transactionTypeNormal, transactionStatusSuccess},
+			{"03", transactionTypeSCR, transactionStatusSuccess},
+			{"02", transactionTypeNormal, transactionStatusFail},
+			{"04", transactionTypeNormal, transactionStatusInvalid},
+		}
+		for idx, expected := range expectedTransactions {
+			event := received[idx+1]
+			require.Equal(t, EventTypeTransaction, event.Type)
+			require.Equal(t, expected.hash, event.Transaction.Hash)
+			require.Equal(t, expected.txType, event.Transaction.Type)
+			require.Equal(t, expected.status, event.Transaction.Status)
+			require.Equal(t, blockHash, event.Transaction.BlockHash)
+		}
+
+		require.Equal(t, testscommon.TestAddressAlice, received[3].Transaction.Sender)
+		require.Equal(t, "5", received[3].Transaction.Value)
+		require.Equal(t, "500", received[3].Transaction.Fee)
+		require.Equal(t, "01", received[2].Transaction.OriginalTxHash)
+		require.Equal(t, "refund", received[2].Transaction.ReturnMessage)
+
+		require.Equal(t, &dtos.LogEvent{
+			TxHash:     "02",
+			BlockHash:  blockHash,
+			Address:    testscommon.TestAddressBob,
+			Identifier: core.SignalErrorOperation,
+			Topics:     []string{hex.EncodeToString([]byte("topic"))},
+			Data:       hex.EncodeToString([]byte("data")),
+		}, received[5].Log)
+	})
+}
@@ -0,0 +1,42 @@
+package components
+
+import (
+	"github.com/TerraDharitri/drt-go-chain/storage"
+	"github.com/TerraDharitri/drt-go-chain/storage/database"
+	"github.com/TerraDharitri/drt-go-chain/storage/storageunit"
+)
+
+const (
+	persistentUnitCacheCapacity = 100_000
+	persisterBatchDelaySeconds  = 2
+	persisterMaxBatchSize       = 100
+	persisterMaxOpenFiles       = 10
+)
+
+// CreatePersistentUnit creates a new storage unit backed by a leveldb database located in the provided path
+func CreatePersistentUnit(path string) (storage.Storer, error) {
+	cache, err := storageunit.NewCache(storageunit.CacheConfig{Type: storageunit.LRUCache, Capacity: persistentUnitCacheCapacity, Shards: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	persist, err := database.NewSerialDB(path, persisterBatchDelaySeconds, persisterMaxBatchSize, persisterMaxOpenFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return storageunit.NewStorageUnit(cache, persist)
+}
+
+// CreatePersistentUnitForTries returns a special type of storer used on tries instances, backed by a leveldb database
+// located in the provided path
+func CreatePersistentUnitForTries(path string) (storage.Storer, error) {
+	unit, err := CreatePersistentUnit(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trieStorage{
+		Storer: unit,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package components
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePersistentUnitForTries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "UserAccountsUnit")
+	key := []byte("key")
+	data := []byte("data")
+
+	unit, err := CreatePersistentUnitForTries(path)
+	require.NoError(t, err)
+
+	_, ok := unit.(*trieStorage)
+	require.True(t, ok)
+	require.NoError(t, unit.Put(key, data))
+	require.NoError(t, unit.Close())
+
+	unit, err = CreatePersistentUnitForTries(path)
+	require.NoError(t, err)
+	defer func() {
+		_ = unit.Close()
+	}()
+
+	value, err := unit.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, data, value)
+}
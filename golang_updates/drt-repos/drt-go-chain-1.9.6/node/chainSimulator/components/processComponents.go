@@ -53,6 +53,8 @@ type ArgsProcessComponentsHolder struct {
 	EconomicsConfig          config.EconomicsConfig
 	SystemSCConfig           config.SystemSmartContractsConfig
 
+	ExecutionTracer process.ExecutionTracer
+
 	GenesisNonce uint64
 	GenesisRound uint64
 }
@@ -222,6 +224,7 @@ func CreateProcessComponents(args ArgsProcessComponentsHolder) (*processComponen
 		StatusComponents:        args.StatusComponents,
 		StatusCoreComponents:    args.StatusCoreComponents,
 		TxExecutionOrderHandler: txExecutionOrderHandler,
+		ExecutionTracer:         args.ExecutionTracer,
 		GenesisNonce:            args.GenesisNonce,
 		GenesisRound:            args.GenesisRound,
 	}
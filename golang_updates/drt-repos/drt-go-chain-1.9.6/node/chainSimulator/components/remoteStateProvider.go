@@ -0,0 +1,271 @@
+package components
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain/common"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+const (
+	remoteRequestTimeout = time.Minute
+	remoteSuccessCode    = "successful"
+)
+
+// ArgsRemoteStateProvider holds the arguments needed to create a remote state provider
+type ArgsRemoteStateProvider struct {
+	ProxyURL string
+	// BlockNonce is the nonce of the metachain block the state is pinned at. The state of each shard is the one
+	// from the shard block notarized in this metachain block
+	BlockNonce uint64
+}
+
+type remoteStateProvider struct {
+	proxyURL         string
+	httpClient       *http.Client
+	numOfShards      uint32
+	shardBlockNonces map[uint32]uint64
+	accountsStates   map[string]*dtos.AddressState
+	storageValues    map[string][]byte
+	mutAccounts      sync.Mutex
+	mutStorage       sync.Mutex
+}
+
+type remoteResponse struct {
+	Data  interface{} `json:"data"`
+	Error string      `json:"error"`
+	Code  string      `json:"code"`
+}
+
+type remoteNetworkConfig struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+type remoteHyperblock struct {
+	Hyperblock struct {
+		ShardBlocks []struct {
+			Shard uint32 `json:"shard"`
+			Nonce uint64 `json:"nonce"`
+		} `json:"shardBlocks"`
+	} `json:"hyperblock"`
+}
+
+type remoteAccount struct {
+	Account struct {
+		Nonce           uint64 `json:"nonce"`
+		Balance         string `json:"balance"`
+		Code            string `json:"code"`
+		CodeHash        []byte `json:"codeHash"`
+		RootHash        []byte `json:"rootHash"`
+		CodeMetadata    []byte `json:"codeMetadata"`
+		DeveloperReward string `json:"developerReward"`
+		OwnerAddress    string `json:"ownerAddress"`
+	} `json:"account"`
+}
+
+type remoteKeyValue struct {
+	Value string `json:"value"`
+}
+
+// NewRemoteStateProvider creates a provider that fetches the accounts from the proxy of a remote network. The number
+// of shards of the remote network and the shard blocks notarized in the provided metachain block are fetched at once
+func NewRemoteStateProvider(args ArgsRemoteStateProvider) (*remoteStateProvider, error) {
+	if len(args.ProxyURL) == 0 {
+		return nil, ErrEmptyProxyURL
+	}
+
+	provider := &remoteStateProvider{
+		proxyURL:         strings.TrimSuffix(args.ProxyURL, "/"),
+		httpClient:       &http.Client{Timeout: remoteRequestTimeout},
+		shardBlockNonces: make(map[uint32]uint64),
+		accountsStates:   make(map[string]*dtos.AddressState),
+		storageValues:    make(map[string][]byte),
+	}
+
+	err := provider.fetchNumOfShards()
+	if err != nil {
+		return nil, err
+	}
+
+	err = provider.fetchShardBlockNonces(args.BlockNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+func (provider *remoteStateProvider) fetchNumOfShards() error {
+	networkConfig := &remoteNetworkConfig{}
+	err := provider.get("/network/config", networkConfig)
+	if err != nil {
+		return err
+	}
+
+	numOfShards, ok := networkConfig.Config[common.MetricNumShardsWithoutMetachain].(float64)
+	if !ok {
+		return fmt.Errorf("%w, missing %s from the network config", ErrRemoteRequestFailed, common.MetricNumShardsWithoutMetachain)
+	}
+
+	provider.numOfShards = uint32(numOfShards)
+
+	return nil
+}
+
+func (provider *remoteStateProvider) fetchShardBlockNonces(metachainBlockNonce uint64) error {
+	hyperblock := &remoteHyperblock{}
+	err := provider.get(fmt.Sprintf("/hyperblock/by-nonce/%d", metachainBlockNonce), hyperblock)
+	if err != nil {
+		return err
+	}
+
+	for _, shardBlock := range hyperblock.Hyperblock.ShardBlocks {
+		if shardBlock.Nonce > provider.shardBlockNonces[shardBlock.Shard] {
+			provider.shardBlockNonces[shardBlock.Shard] = shardBlock.Nonce
+		}
+	}
+
+	for shardID := uint32(0); shardID < provider.numOfShards; shardID++ {
+		_, found := provider.shardBlockNonces[shardID]
+		if !found {
+			return fmt.Errorf("%w, shard: %d, metachain block nonce: %d", ErrMissingForkedShardBlock, shardID, metachainBlockNonce)
+		}
+	}
+
+	return nil
+}
+
+// NumOfShards returns the number of shards of the remote network, without the metachain
+func (provider *remoteStateProvider) NumOfShards() uint32 {
+	return provider.numOfShards
+}
+
+// GetAccountState returns the state of the provided address as it was in the pinned block of the provided shard,
+// without the storage key-value pairs, which are requested one by one with GetStorageValue. The root hash of the
+// remote data trie is kept, so it is known whether the account has any storage. It returns nil if the account does
+// not exist on the remote network. The results are cached, so each account is requested only once
+func (provider *remoteStateProvider) GetAccountState(shardID uint32, address string) (*dtos.AddressState, error) {
+	provider.mutAccounts.Lock()
+	defer provider.mutAccounts.Unlock()
+
+	addressState, found := provider.accountsStates[address]
+	if found {
+		return addressState, nil
+	}
+
+	blockNonce, found := provider.shardBlockNonces[shardID]
+	if !found {
+		return nil, fmt.Errorf("%w, shard: %d", ErrMissingForkedShardBlock, shardID)
+	}
+
+	addressState, err := provider.fetchAccountState(address, blockNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.accountsStates[address] = addressState
+
+	return addressState, nil
+}
+
+func (provider *remoteStateProvider) fetchAccountState(address string, blockNonce uint64) (*dtos.AddressState, error) {
+	account := &remoteAccount{}
+	err := provider.get(fmt.Sprintf("/address/%s?blockNonce=%d", address, blockNonce), account)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := account.Account
+	isEmptyAccount := remote.Nonce == 0 && (remote.Balance == "" || remote.Balance == "0") &&
+		len(remote.Code) == 0 && len(remote.RootHash) == 0
+	if isEmptyAccount {
+		return nil, nil
+	}
+
+	nonce := remote.Nonce
+	addressState := &dtos.AddressState{
+		Address:          address,
+		Nonce:            &nonce,
+		Balance:          remote.Balance,
+		Code:             remote.Code,
+		CodeHash:         base64.StdEncoding.EncodeToString(remote.CodeHash),
+		CodeMetadata:     base64.StdEncoding.EncodeToString(remote.CodeMetadata),
+		Owner:            remote.OwnerAddress,
+		DeveloperRewards: remote.DeveloperReward,
+	}
+	if len(remote.RootHash) > 0 {
+		addressState.RootHash = base64.StdEncoding.EncodeToString(remote.RootHash)
+	}
+
+	return addressState, nil
+}
+
+// GetStorageValue returns the value of the provided storage key of the address as it was in the pinned block of the
+// provided shard. It returns an empty value if the key does not exist on the remote network. The shard is forced on
+// the request, as the system account exists in every shard. The results are cached, so each key is requested only once
+func (provider *remoteStateProvider) GetStorageValue(shardID uint32, address string, key []byte) ([]byte, error) {
+	provider.mutStorage.Lock()
+	defer provider.mutStorage.Unlock()
+
+	storageKey := fmt.Sprintf("%d_%s_%s", shardID, address, hex.EncodeToString(key))
+	value, found := provider.storageValues[storageKey]
+	if found {
+		return value, nil
+	}
+
+	blockNonce, found := provider.shardBlockNonces[shardID]
+	if !found {
+		return nil, fmt.Errorf("%w, shard: %d", ErrMissingForkedShardBlock, shardID)
+	}
+
+	keyValue := &remoteKeyValue{}
+	path := fmt.Sprintf("/address/%s/key/%s?blockNonce=%d&forced-shard-id=%d", address, hex.EncodeToString(key), blockNonce, shardID)
+	err := provider.get(path, keyValue)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = hex.DecodeString(keyValue.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w, path: %s, invalid value: %s", ErrRemoteRequestFailed, path, err.Error())
+	}
+
+	provider.storageValues[storageKey] = value
+
+	return value, nil
+}
+
+func (provider *remoteStateProvider) get(path string, data interface{}) error {
+	resp, err := provider.httpClient.Get(provider.proxyURL + path)
+	if err != nil {
+		return fmt.Errorf("%w, path: %s, error: %s", ErrRemoteRequestFailed, path, err.Error())
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	response := &remoteResponse{
+		Data: data,
+	}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return fmt.Errorf("%w, path: %s, status: %d, error: %s", ErrRemoteRequestFailed, path, resp.StatusCode, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK || response.Code != remoteSuccessCode {
+		return fmt.Errorf("%w, path: %s, status: %d, error: %s", ErrRemoteRequestFailed, path, resp.StatusCode, response.Error)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (provider *remoteStateProvider) IsInterfaceNil() bool {
+	return provider == nil
+}
@@ -0,0 +1,130 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/chainSimulator"
+	"github.com/stretchr/testify/require"
+)
+
+const recordedForkedNetwork = "../testdata/forkedNetwork.json"
+
+func TestNewRemoteStateProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty proxy URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		provider, err := NewRemoteStateProvider(ArgsRemoteStateProvider{})
+		require.Equal(t, ErrEmptyProxyURL, err)
+		require.Nil(t, provider)
+	})
+	t.Run("unknown metachain block should error", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := chainSimulator.NewRecordedProxyServer(recordedForkedNetwork)
+		require.NoError(t, err)
+		defer server.Close()
+
+		provider, err := NewRemoteStateProvider(ArgsRemoteStateProvider{
+			ProxyURL:   server.URL,
+			BlockNonce: 101,
+		})
+		require.True(t, errors.Is(err, ErrRemoteRequestFailed))
+		require.Nil(t, provider)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := chainSimulator.NewRecordedProxyServer(recordedForkedNetwork)
+		require.NoError(t, err)
+		defer server.Close()
+
+		provider, err := NewRemoteStateProvider(ArgsRemoteStateProvider{
+			ProxyURL:   server.URL + "/",
+			BlockNonce: 100,
+		})
+		require.NoError(t, err)
+		require.False(t, provider.IsInterfaceNil())
+		require.Equal(t, uint32(3), provider.NumOfShards())
+		require.Equal(t, map[uint32]uint64{0: 98, 1: 97, 2: 99}, provider.shardBlockNonces)
+	})
+}
+
+func TestRemoteStateProvider_GetAccountState(t *testing.T) {
+	t.Parallel()
+
+	server, err := chainSimulator.NewRecordedProxyServer(recordedForkedNetwork)
+	require.NoError(t, err)
+
+	provider, err := NewRemoteStateProvider(ArgsRemoteStateProvider{
+		ProxyURL:   server.URL,
+		BlockNonce: 100,
+	})
+	require.NoError(t, err)
+
+	address := "drt1qtc600lryvytxuy4h7vn7xmsy5tw6vuw3tskr75cwnmv4mnyjgsq89rptv"
+	nonce := uint64(7)
+	expectedState := &dtos.AddressState{
+		Address:          address,
+		Nonce:            &nonce,
+		Balance:          "5000000000000000000",
+		RootHash:         "Sf6q2rHUlVZdPbfoSrBudl7fMNkp0MFLSm1Q7AyUcgI=",
+		DeveloperRewards: "0",
+	}
+	addressState, err := provider.GetAccountState(0, address)
+	require.NoError(t, err)
+	require.Equal(t, expectedState, addressState)
+
+	addressState, err = provider.GetAccountState(0, "drt1ss6u80ruas2phpmr82r42xnkd6rxy40g9jl69frppl4qez9w2jpsaws9xq")
+	require.NoError(t, err)
+	require.Nil(t, addressState)
+
+	_, err = provider.GetAccountState(3, "drt1qqqqqqqqqqqqqpgqmzzm05jeav6d5qvna0q2pmcllelkz8xddz3sew8p92")
+	require.True(t, errors.Is(err, ErrMissingForkedShardBlock))
+
+	// the accounts are cached, so they are returned even if the remote network is no longer reachable
+	server.Close()
+	addressState, err = provider.GetAccountState(0, address)
+	require.NoError(t, err)
+	require.Equal(t, expectedState, addressState)
+
+	_, err = provider.GetAccountState(1, "drt1qqqqqqqqqqqqqpgqmzzm05jeav6d5qvna0q2pmcllelkz8xddz3sew8p92")
+	require.True(t, errors.Is(err, ErrRemoteRequestFailed))
+}
+
+func TestRemoteStateProvider_GetStorageValue(t *testing.T) {
+	t.Parallel()
+
+	server, err := chainSimulator.NewRecordedProxyServer(recordedForkedNetwork)
+	require.NoError(t, err)
+
+	provider, err := NewRemoteStateProvider(ArgsRemoteStateProvider{
+		ProxyURL:   server.URL,
+		BlockNonce: 100,
+	})
+	require.NoError(t, err)
+
+	address := "drt1qtc600lryvytxuy4h7vn7xmsy5tw6vuw3tskr75cwnmv4mnyjgsq89rptv"
+	value, err := provider.GetStorageValue(0, address, []byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 4}, value)
+
+	value, err = provider.GetStorageValue(0, address, []byte{5})
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	_, err = provider.GetStorageValue(3, address, []byte{1, 2})
+	require.True(t, errors.Is(err, ErrMissingForkedShardBlock))
+
+	// the values are cached, so they are returned even if the remote network is no longer reachable
+	server.Close()
+	value, err = provider.GetStorageValue(0, address, []byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 4}, value)
+
+	_, err = provider.GetStorageValue(1, address, []byte{1, 2})
+	require.True(t, errors.Is(err, ErrRemoteRequestFailed))
+}
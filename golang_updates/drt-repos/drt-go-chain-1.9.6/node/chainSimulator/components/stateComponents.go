@@ -3,6 +3,7 @@ package components
 import (
 	"io"
 
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	chainData "github.com/TerraDharitri/drt-go-chain-core/data"
 	"github.com/TerraDharitri/drt-go-chain/common"
 	"github.com/TerraDharitri/drt-go-chain/config"
@@ -19,6 +20,9 @@ type ArgsStateComponents struct {
 	StatusCore     factory.StatusCoreComponentsHolder
 	StoreService   dataRetriever.StorageService
 	ChainHandler   chainData.ChainHandler
+	// RemoteState is optional. When provided, the accounts missing locally are loaded from the remote network
+	RemoteState RemoteStateHandler
+	ShardID     uint32
 }
 
 type stateComponentsHolder struct {
@@ -34,7 +38,12 @@ type stateComponentsHolder struct {
 
 // CreateStateComponents will create the state components holder
 func CreateStateComponents(args ArgsStateComponents) (*stateComponentsHolder, error) {
-	stateComponentsFactory, err := factoryState.NewStateComponentsFactory(factoryState.StateComponentsFactoryArgs{
+	accountCreator, err := createForkedAccountCreator(args)
+	if err != nil {
+		return nil, err
+	}
+
+	stateComponentsFactoryArgs := factoryState.StateComponentsFactoryArgs{
 		Config:                   args.Config,
 		Core:                     args.CoreComponents,
 		StatusCore:               args.StatusCore,
@@ -42,7 +51,12 @@ func CreateStateComponents(args ArgsStateComponents) (*stateComponentsHolder, er
 		ProcessingMode:           common.Normal,
 		ShouldSerializeSnapshots: false,
 		ChainHandler:             args.ChainHandler,
-	})
+	}
+	if accountCreator != nil {
+		stateComponentsFactoryArgs.AccountFactory = accountCreator
+	}
+
+	stateComponentsFactory, err := factoryState.NewStateComponentsFactory(stateComponentsFactoryArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +76,7 @@ func CreateStateComponents(args ArgsStateComponents) (*stateComponentsHolder, er
 		return nil, err
 	}
 
-	return &stateComponentsHolder{
+	holder := &stateComponentsHolder{
 		peerAccount:              stateComp.PeerAccounts(),
 		accountsAdapter:          stateComp.AccountsAdapter(),
 		accountsAdapterAPI:       stateComp.AccountsAdapterAPI(),
@@ -71,7 +85,52 @@ func CreateStateComponents(args ArgsStateComponents) (*stateComponentsHolder, er
 		triesStorageManager:      stateComp.TrieStorageManagers(),
 		missingTrieNodesNotifier: stateComp.MissingTrieNodesNotifier(),
 		stateComponentsCloser:    stateComp,
-	}, nil
+	}
+	if accountCreator == nil {
+		return holder, nil
+	}
+
+	err = holder.setForkedAccounts(accountCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	return holder, nil
+}
+
+// createForkedAccountCreator returns nil when no remote state is provided, so the default account creator is used
+func createForkedAccountCreator(args ArgsStateComponents) (*forkedAccountCreator, error) {
+	if check.IfNil(args.RemoteState) {
+		return nil, nil
+	}
+
+	return newForkedAccountCreator(ArgsForkedAccountCreator{
+		Hasher:              args.CoreComponents.Hasher(),
+		Marshaller:          args.CoreComponents.InternalMarshalizer(),
+		EnableEpochsHandler: args.CoreComponents.EnableEpochsHandler(),
+		RemoteState:         args.RemoteState,
+		ShardID:             args.ShardID,
+		AddressConverter:    args.CoreComponents.AddressPubKeyConverter(),
+	})
+}
+
+func (s *stateComponentsHolder) setForkedAccounts(accountCreator *forkedAccountCreator) error {
+	forkedAccounts, err := newForkedAccountsAdapter(s.accountsAdapter, accountCreator)
+	if err != nil {
+		return err
+	}
+
+	s.accountsAdapter = forkedAccounts
+	s.accountsAdapterAPI = &forkedAccountsAdapterAPI{
+		AccountsAdapter: s.accountsAdapterAPI,
+		forkedAccounts:  forkedAccounts,
+	}
+	s.accountsRepository = &forkedAccountsRepository{
+		AccountsRepository: s.accountsRepository,
+		forkedAccounts:     forkedAccounts,
+	}
+
+	return nil
 }
 
 // PeerAccounts will return peer accounts
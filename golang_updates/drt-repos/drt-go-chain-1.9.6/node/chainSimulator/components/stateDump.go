@@ -0,0 +1,151 @@
+package components
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain/common"
+	"github.com/TerraDharitri/drt-go-chain/common/errChan"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/TerraDharitri/drt-go-chain/state"
+	"github.com/TerraDharitri/drt-go-chain/state/parsers"
+)
+
+// DumpState will return the state of all the accounts from the node's shard, including the system account.
+// The accounts are read from the current state, so the changes not yet included in a block are also returned
+func (node *testOnlyProcessingNode) DumpState() ([]*dtos.AddressState, error) {
+	accountsAdapter := node.StateComponentsHolder.AccountsAdapter()
+	rootHash, err := accountsAdapter.RootHash()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := getAllLeavesKeys(func(leavesChannels *common.TrieIteratorChannels) error {
+		return accountsAdapter.GetAllLeaves(leavesChannels, context.Background(), rootHash, parsers.NewMainTrieLeafParser())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addressesStates := make([]*dtos.AddressState, 0, len(addresses))
+	for _, address := range addresses {
+		account, errGet := accountsAdapter.GetExistingAccount(address)
+		if errGet != nil {
+			return nil, errGet
+		}
+
+		userAccount, ok := account.(state.UserAccountHandler)
+		if !ok {
+			return nil, errors.New("cannot cast AccountHandler to UserAccountHandler")
+		}
+
+		addressState, errGet := node.getAddressState(userAccount)
+		if errGet != nil {
+			return nil, errGet
+		}
+
+		addressesStates = append(addressesStates, addressState)
+	}
+
+	return addressesStates, nil
+}
+
+func (node *testOnlyProcessingNode) getAddressState(userAccount state.UserAccountHandler) (*dtos.AddressState, error) {
+	addressConverter := node.CoreComponentsHolder.AddressPubKeyConverter()
+	address, err := addressConverter.Encode(userAccount.AddressBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := userAccount.GetNonce()
+	addressState := &dtos.AddressState{
+		Address: address,
+		Nonce:   &nonce,
+		Balance: userAccount.GetBalance().String(),
+	}
+
+	if core.IsSmartContractAddress(userAccount.AddressBytes()) {
+		err = node.setScDataInAddressState(addressState, userAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if check.IfNil(userAccount.DataTrie()) {
+		return addressState, nil
+	}
+
+	pairs, err := getAllLeavesKeysAndValues(func(leavesChannels *common.TrieIteratorChannels) error {
+		return userAccount.GetAllLeaves(leavesChannels, context.Background())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs) > 0 {
+		addressState.Pairs = pairs
+	}
+
+	return addressState, nil
+}
+
+// setScDataInAddressState uses the same encoding expected by setScDataIfNeeded, so the dumped state can be set back
+func (node *testOnlyProcessingNode) setScDataInAddressState(addressState *dtos.AddressState, userAccount state.UserAccountHandler) error {
+	addressState.Code = hex.EncodeToString(userAccount.GetCode())
+	addressState.CodeHash = base64.StdEncoding.EncodeToString(userAccount.GetCodeHash())
+	addressState.CodeMetadata = base64.StdEncoding.EncodeToString(userAccount.GetCodeMetadata())
+	addressState.DeveloperRewards = userAccount.GetDeveloperReward().String()
+
+	if len(userAccount.GetOwnerAddress()) == 0 {
+		return nil
+	}
+
+	owner, err := node.CoreComponentsHolder.AddressPubKeyConverter().Encode(userAccount.GetOwnerAddress())
+	if err != nil {
+		return err
+	}
+	addressState.Owner = owner
+
+	return nil
+}
+
+func getAllLeavesKeys(getAllLeaves func(leavesChannels *common.TrieIteratorChannels) error) ([][]byte, error) {
+	keys := make([][]byte, 0)
+	err := iterateLeaves(getAllLeaves, func(leaf core.KeyValueHolder) {
+		keys = append(keys, leaf.Key())
+	})
+
+	return keys, err
+}
+
+func getAllLeavesKeysAndValues(getAllLeaves func(leavesChannels *common.TrieIteratorChannels) error) (map[string]string, error) {
+	pairs := make(map[string]string)
+	err := iterateLeaves(getAllLeaves, func(leaf core.KeyValueHolder) {
+		pairs[hex.EncodeToString(leaf.Key())] = hex.EncodeToString(leaf.Value())
+	})
+
+	return pairs, err
+}
+
+func iterateLeaves(
+	getAllLeaves func(leavesChannels *common.TrieIteratorChannels) error,
+	handleLeaf func(leaf core.KeyValueHolder),
+) error {
+	leavesChannels := &common.TrieIteratorChannels{
+		LeavesChan: make(chan core.KeyValueHolder, common.TrieLeavesChannelDefaultCapacity),
+		ErrChan:    errChan.NewErrChanWrapper(),
+	}
+	err := getAllLeaves(leavesChannels)
+	if err != nil {
+		return err
+	}
+
+	for leaf := range leavesChannels.LeavesChan {
+		handleLeaf(leaf)
+	}
+
+	return leavesChannels.ErrChan.ReadFromChanNonBlocking()
+}
@@ -0,0 +1,42 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestOnlyProcessingNode_DumpState(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	node, err := NewTestOnlyProcessingNode(createMockArgsTestOnlyProcessingNode(t))
+	require.NoError(t, err)
+
+	address := "drt1qtc600lryvytxuy4h7vn7xmsy5tw6vuw3tskr75cwnmv4mnyjgsq89rptv"
+	addressBytes, _ := node.CoreComponentsHolder.AddressPubKeyConverter().Decode(address)
+	nonce := uint64(5)
+	addressState := &dtos.AddressState{
+		Address: address,
+		Nonce:   &nonce,
+		Balance: "100",
+		Pairs: map[string]string{
+			"01": "02",
+		},
+	}
+	err = node.SetStateForAddress(addressBytes, addressState)
+	require.NoError(t, err)
+
+	dump, err := node.DumpState()
+	require.NoError(t, err)
+	require.Contains(t, dump, addressState)
+
+	err = node.RemoveAccount(addressBytes)
+	require.NoError(t, err)
+
+	dump, err = node.DumpState()
+	require.NoError(t, err)
+	require.NotContains(t, dump, addressState)
+}
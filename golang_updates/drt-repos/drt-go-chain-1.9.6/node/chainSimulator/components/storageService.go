@@ -1,40 +1,87 @@
 package components
 
 import (
+	"fmt"
+	"path/filepath"
+
 	"github.com/TerraDharitri/drt-go-chain/dataRetriever"
+	"github.com/TerraDharitri/drt-go-chain/storage"
 )
 
 // CreateStore creates a storage service for shard nodes
 func CreateStore(numOfShards uint32) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
-	store.AddStorer(dataRetriever.TransactionUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.MiniBlockUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.MetaBlockUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.PeerChangesUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.BlockHeaderUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.UnsignedTransactionUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.RewardTransactionUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.BootstrapUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.StatusMetricsUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.ReceiptsUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.ScheduledSCRsUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.TxLogsUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.UserAccountsUnit, CreateMemUnitForTries())
-	store.AddStorer(dataRetriever.PeerAccountsUnit, CreateMemUnitForTries())
-	store.AddStorer(dataRetriever.DCDTSuppliesUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.RoundHdrHashDataUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.MiniblocksMetadataUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.MiniblockHashByTxHashUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.EpochByHashUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.ResultsHashesByTxHashUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.TrieEpochRootHashUnit, CreateMemUnit())
-	store.AddStorer(dataRetriever.ProofsUnit, CreateMemUnit())
+	for _, unitType := range getUnitTypes(numOfShards) {
+		if isTrieUnit(unitType) {
+			store.AddStorer(unitType, CreateMemUnitForTries())
+			continue
+		}
 
-	for i := uint32(0); i < numOfShards; i++ {
-		hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnit + dataRetriever.UnitType(i)
-		store.AddStorer(hdrNonceHashDataUnit, CreateMemUnit())
+		store.AddStorer(unitType, CreateMemUnit())
 	}
 
 	return store
 }
+
+// CreatePersistentStore creates a storage service for shard nodes that keeps every unit in its own leveldb
+// database, under the provided directory
+func CreatePersistentStore(numOfShards uint32, dataDir string) (dataRetriever.StorageService, error) {
+	store := dataRetriever.NewChainStorer()
+	for _, unitType := range getUnitTypes(numOfShards) {
+		unit, err := createPersistentUnit(unitType, filepath.Join(dataDir, unitType.String()))
+		if err != nil {
+			_ = store.CloseAll()
+			return nil, fmt.Errorf("%w while creating the %s storer", err, unitType.String())
+		}
+
+		store.AddStorer(unitType, unit)
+	}
+
+	return store, nil
+}
+
+func createPersistentUnit(unitType dataRetriever.UnitType, path string) (storage.Storer, error) {
+	if isTrieUnit(unitType) {
+		return CreatePersistentUnitForTries(path)
+	}
+
+	return CreatePersistentUnit(path)
+}
+
+func isTrieUnit(unitType dataRetriever.UnitType) bool {
+	return unitType == dataRetriever.UserAccountsUnit || unitType == dataRetriever.PeerAccountsUnit
+}
+
+func getUnitTypes(numOfShards uint32) []dataRetriever.UnitType {
+	unitTypes := []dataRetriever.UnitType{
+		dataRetriever.TransactionUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.MetaBlockUnit,
+		dataRetriever.PeerChangesUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.UnsignedTransactionUnit,
+		dataRetriever.RewardTransactionUnit,
+		dataRetriever.MetaHdrNonceHashDataUnit,
+		dataRetriever.BootstrapUnit,
+		dataRetriever.StatusMetricsUnit,
+		dataRetriever.ReceiptsUnit,
+		dataRetriever.ScheduledSCRsUnit,
+		dataRetriever.TxLogsUnit,
+		dataRetriever.UserAccountsUnit,
+		dataRetriever.PeerAccountsUnit,
+		dataRetriever.DCDTSuppliesUnit,
+		dataRetriever.RoundHdrHashDataUnit,
+		dataRetriever.MiniblocksMetadataUnit,
+		dataRetriever.MiniblockHashByTxHashUnit,
+		dataRetriever.EpochByHashUnit,
+		dataRetriever.ResultsHashesByTxHashUnit,
+		dataRetriever.TrieEpochRootHashUnit,
+		dataRetriever.ProofsUnit,
+	}
+
+	for i := uint32(0); i < numOfShards; i++ {
+		unitTypes = append(unitTypes, dataRetriever.ShardHdrNonceHashDataUnit+dataRetriever.UnitType(i))
+	}
+
+	return unitTypes
+}
@@ -50,3 +50,34 @@ func TestCreateStore(t *testing.T) {
 		require.NotNil(t, unit)
 	}
 }
+
+func TestCreatePersistentStore(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	key := []byte("key")
+	value := []byte("value")
+
+	store, err := CreatePersistentStore(2, dataDir)
+	require.NoError(t, err)
+	require.Equal(t, len(CreateStore(2).GetAllStorers()), len(store.GetAllStorers()))
+
+	err = store.Put(dataRetriever.TransactionUnit, key, value)
+	require.NoError(t, err)
+	require.NoError(t, store.CloseAll())
+
+	store, err = CreatePersistentStore(2, dataDir)
+	require.NoError(t, err)
+	defer func() {
+		_ = store.CloseAll()
+	}()
+
+	recovered, err := store.Get(dataRetriever.TransactionUnit, key)
+	require.NoError(t, err)
+	require.Equal(t, value, recovered)
+
+	trieUnit, err := store.GetStorer(dataRetriever.UserAccountsUnit)
+	require.NoError(t, err)
+	_, ok := trieUnit.(*trieStorage)
+	require.True(t, ok)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/TerraDharitri/drt-go-chain/state"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	chainData "github.com/TerraDharitri/drt-go-chain-core/data"
 	"github.com/TerraDharitri/drt-go-chain-core/data/endProcess"
 )
@@ -50,6 +51,10 @@ type ArgsTestOnlyProcessingNode struct {
 	MetaChainConsensusGroupSize uint32
 	RoundDurationInMillis       uint64
 	VmQueryDelayAfterStartInMs  uint64
+	DataDir                     string
+	RemoteState                 RemoteStateHandler
+	ExecutionTracer             ExecutionTracerHandler
+	EventsStream                EventsStreamHandler
 }
 
 type testOnlyProcessingNode struct {
@@ -70,6 +75,7 @@ type testOnlyProcessingNode struct {
 	TransactionFeeHandler process.TransactionFeeHandler
 	StoreService          dataRetriever.StorageService
 	DataPool              dataRetriever.PoolsHolder
+	txSelectionPool       *txSelectionPool
 	broadcastMessenger    consensus.BroadcastMessenger
 
 	httpServer    shared.UpgradeableHttpServerHandler
@@ -82,11 +88,15 @@ type testOnlyProcessingNode struct {
 func NewTestOnlyProcessingNode(args ArgsTestOnlyProcessingNode) (*testOnlyProcessingNode, error) {
 	instance := &testOnlyProcessingNode{
 		ArgumentsParser: smartContract.NewArgumentParser(),
-		StoreService:    CreateStore(args.NumShards),
 		closeHandler:    NewCloseHandler(),
 	}
 
 	var err error
+	instance.StoreService, err = createStorageService(args)
+	if err != nil {
+		return nil, err
+	}
+
 	instance.TransactionFeeHandler = postprocess.NewFeeAccumulator()
 
 	instance.CoreComponentsHolder, err = CreateCoreComponents(ArgsCoreComponentsHolder{
@@ -161,6 +171,11 @@ func NewTestOnlyProcessingNode(args ArgsTestOnlyProcessingNode) (*testOnlyProces
 		return nil, err
 	}
 
+	err = instance.subscribeEventsStream(selfShardID, args.EventsStream)
+	if err != nil {
+		return nil, err
+	}
+
 	err = instance.createBlockChain(selfShardID)
 	if err != nil {
 		return nil, err
@@ -172,12 +187,14 @@ func NewTestOnlyProcessingNode(args ArgsTestOnlyProcessingNode) (*testOnlyProces
 		StatusCore:     instance.StatusCoreComponents,
 		StoreService:   instance.StoreService,
 		ChainHandler:   instance.ChainHandler,
+		RemoteState:    args.RemoteState,
+		ShardID:        selfShardID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	instance.DataPool, err = dataRetrieverFactory.NewDataPoolFromConfig(dataRetrieverFactory.ArgsDataPool{
+	dataPool, err := dataRetrieverFactory.NewDataPoolFromConfig(dataRetrieverFactory.ArgsDataPool{
 		Config:           args.Configs.GeneralConfig,
 		EconomicsData:    instance.CoreComponentsHolder.EconomicsData(),
 		ShardCoordinator: instance.BootstrapComponentsHolder.ShardCoordinator(),
@@ -188,6 +205,12 @@ func NewTestOnlyProcessingNode(args ArgsTestOnlyProcessingNode) (*testOnlyProces
 		return nil, err
 	}
 
+	instance.txSelectionPool = newTxSelectionPool(dataPool.Transactions(), selfShardID)
+	instance.DataPool = &txSelectionPoolsHolder{
+		PoolsHolder:  dataPool,
+		transactions: instance.txSelectionPool,
+	}
+
 	err = instance.createNodesCoordinator(args.Configs.PreferencesConfig.Preferences, *args.Configs.GeneralConfig)
 	if err != nil {
 		return nil, err
@@ -222,6 +245,7 @@ func NewTestOnlyProcessingNode(args ArgsTestOnlyProcessingNode) (*testOnlyProces
 		ConfigurationPathsHolder: *args.Configs.ConfigurationPathsHolder,
 		NodesCoordinator:         instance.NodesCoordinator,
 		DataComponents:           instance.DataComponentsHolder,
+		ExecutionTracer:          args.ExecutionTracer,
 		GenesisNonce:             args.InitialNonce,
 		GenesisRound:             uint64(args.InitialRound),
 	})
@@ -259,6 +283,24 @@ func NewTestOnlyProcessingNode(args ArgsTestOnlyProcessingNode) (*testOnlyProces
 	return instance, nil
 }
 
+func (node *testOnlyProcessingNode) subscribeEventsStream(selfShardID uint32, eventsStream EventsStreamHandler) error {
+	if check.IfNil(eventsStream) {
+		return nil
+	}
+
+	driver, err := NewOutportEventsDriver(ArgsOutportEventsDriver{
+		ShardID:          selfShardID,
+		Marshaller:       node.CoreComponentsHolder.InternalMarshalizer(),
+		AddressConverter: node.CoreComponentsHolder.AddressPubKeyConverter(),
+		EventsStream:     eventsStream,
+	})
+	if err != nil {
+		return err
+	}
+
+	return node.StatusComponentsHolder.OutportHandler().SubscribeDriver(driver)
+}
+
 func (node *testOnlyProcessingNode) createBlockChain(selfShardID uint32) error {
 	var err error
 	if selfShardID == core.MetachainShardId {
@@ -465,17 +507,7 @@ func (node *testOnlyProcessingNode) SetStateForAddress(address []byte, addressSt
 		return err
 	}
 
-	err = setNonceAndBalanceForAccount(userAccount, addressState.Nonce, addressState.Balance)
-	if err != nil {
-		return err
-	}
-
-	err = setKeyValueMap(userAccount, addressState.Pairs)
-	if err != nil {
-		return err
-	}
-
-	err = node.setScDataIfNeeded(address, userAccount, addressState)
+	err = setAccountState(userAccount, addressState, node.CoreComponentsHolder.AddressPubKeyConverter())
 	if err != nil {
 		return err
 	}
@@ -549,8 +581,22 @@ func setNonceAndBalanceForAccount(userAccount state.UserAccountHandler, nonce *u
 	return userAccount.AddToBalance(providedBalance)
 }
 
-func (node *testOnlyProcessingNode) setScDataIfNeeded(address []byte, userAccount state.UserAccountHandler, addressState *dtos.AddressState) error {
-	if !core.IsSmartContractAddress(address) {
+func setAccountState(userAccount state.UserAccountHandler, addressState *dtos.AddressState, addressConverter core.PubkeyConverter) error {
+	err := setNonceAndBalanceForAccount(userAccount, addressState.Nonce, addressState.Balance)
+	if err != nil {
+		return err
+	}
+
+	err = setKeyValueMap(userAccount, addressState.Pairs)
+	if err != nil {
+		return err
+	}
+
+	return setScDataIfNeeded(userAccount, addressState, addressConverter)
+}
+
+func setScDataIfNeeded(userAccount state.UserAccountHandler, addressState *dtos.AddressState, addressConverter core.PubkeyConverter) error {
+	if !core.IsSmartContractAddress(userAccount.AddressBytes()) {
 		return nil
 	}
 
@@ -579,7 +625,7 @@ func (node *testOnlyProcessingNode) setScDataIfNeeded(address []byte, userAccoun
 	}
 
 	if addressState.Owner != "" {
-		ownerAddress, errD := node.CoreComponentsHolder.AddressPubKeyConverter().Decode(addressState.Owner)
+		ownerAddress, errD := addressConverter.Decode(addressState.Owner)
 		if errD != nil {
 			return errD
 		}
@@ -622,6 +668,15 @@ func (node *testOnlyProcessingNode) SetBasePeers(basePeers map[uint32]core.PeerI
 	node.basePeers = basePeers
 }
 
+// createStorageService will keep all the data in memory, unless a data directory was provided
+func createStorageService(args ArgsTestOnlyProcessingNode) (dataRetriever.StorageService, error) {
+	if len(args.DataDir) == 0 {
+		return CreateStore(args.NumShards), nil
+	}
+
+	return CreatePersistentStore(args.NumShards, args.DataDir)
+}
+
 // Close will call the Close methods on all inner components
 func (node *testOnlyProcessingNode) Close() error {
 	return node.closeHandler.Close()
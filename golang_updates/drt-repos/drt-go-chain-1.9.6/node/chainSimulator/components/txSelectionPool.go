@@ -0,0 +1,135 @@
+package components
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain/dataRetriever"
+	"github.com/TerraDharitri/drt-go-chain/process"
+	"github.com/TerraDharitri/drt-go-chain/storage"
+	"github.com/TerraDharitri/drt-go-chain/storage/txcache"
+)
+
+type txCacheWithLookup interface {
+	GetByTxHash(txHash []byte) (*txcache.WrappedTransaction, bool)
+}
+
+// txSelectionPool wraps the transactions pool of a node, allowing the transactions selected from the pool when
+// proposing a block to be replaced by an explicit, ordered, list of transactions
+type txSelectionPool struct {
+	dataRetriever.ShardedDataCacherNotifier
+	selfShardID uint32
+
+	mutSelection   sync.RWMutex
+	selection      []*txcache.WrappedTransaction
+	isSelectionSet bool
+}
+
+func newTxSelectionPool(txPool dataRetriever.ShardedDataCacherNotifier, selfShardID uint32) *txSelectionPool {
+	return &txSelectionPool{
+		ShardedDataCacherNotifier: txPool,
+		selfShardID:               selfShardID,
+	}
+}
+
+// ShardDataStore returns the requested cache. While a selection is set, the cache holding the transactions sent from
+// the node's shard selects only the transactions from the selection, in the provided order
+func (pool *txSelectionPool) ShardDataStore(cacheID string) storage.Cacher {
+	cache := pool.ShardedDataCacherNotifier.ShardDataStore(cacheID)
+
+	pool.mutSelection.RLock()
+	defer pool.mutSelection.RUnlock()
+
+	if !pool.isSelectionSet || !process.IsShardCacherIdentifierForSourceMe(cacheID, pool.selfShardID) {
+		return cache
+	}
+
+	return &orderedSelectionCache{
+		Cacher:    cache,
+		selection: pool.selection,
+	}
+}
+
+// SetSelection will set the transactions selected when proposing the next blocks. All the transactions should be in
+// the pool, sent from the node's shard
+func (pool *txSelectionPool) SetSelection(txHashes [][]byte) error {
+	cacheID := process.ShardCacherIdentifier(pool.selfShardID, pool.selfShardID)
+	cache, ok := pool.ShardedDataCacherNotifier.ShardDataStore(cacheID).(txCacheWithLookup)
+	if !ok {
+		return fmt.Errorf("%w for cache %s", process.ErrWrongTypeAssertion, cacheID)
+	}
+
+	selection := make([]*txcache.WrappedTransaction, 0, len(txHashes))
+	for _, txHash := range txHashes {
+		wrappedTx, found := cache.GetByTxHash(txHash)
+		if !found {
+			return fmt.Errorf("%w, hash: %s", ErrTransactionNotInPool, hex.EncodeToString(txHash))
+		}
+
+		selection = append(selection, wrappedTx)
+	}
+
+	pool.mutSelection.Lock()
+	pool.selection = selection
+	pool.isSelectionSet = true
+	pool.mutSelection.Unlock()
+
+	return nil
+}
+
+// ClearSelection will restore the selection of the transactions from the pool
+func (pool *txSelectionPool) ClearSelection() {
+	pool.mutSelection.Lock()
+	pool.selection = nil
+	pool.isSelectionSet = false
+	pool.mutSelection.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pool *txSelectionPool) IsInterfaceNil() bool {
+	return pool == nil
+}
+
+// orderedSelectionCache is the cache provided to the transactions preprocessor while a selection is set
+type orderedSelectionCache struct {
+	storage.Cacher
+	selection []*txcache.WrappedTransaction
+}
+
+// SelectTransactions returns the selected transactions, in the provided order
+func (cache *orderedSelectionCache) SelectTransactions(_ txcache.SelectionSession, _ uint64, _ int, _ time.Duration) ([]*txcache.WrappedTransaction, uint64) {
+	accumulatedGas := uint64(0)
+	for _, wrappedTx := range cache.selection {
+		accumulatedGas += wrappedTx.Tx.GetGasLimit()
+	}
+
+	return cache.selection, accumulatedGas
+}
+
+// KeepSelectionOrder returns true as the transactions should be executed in the provided order
+func (cache *orderedSelectionCache) KeepSelectionOrder() bool {
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cache *orderedSelectionCache) IsInterfaceNil() bool {
+	return cache == nil
+}
+
+// txSelectionPoolsHolder is the data pool of a node, having the transactions pool replaced by a txSelectionPool
+type txSelectionPoolsHolder struct {
+	dataRetriever.PoolsHolder
+	transactions *txSelectionPool
+}
+
+// Transactions returns the transactions pool
+func (holder *txSelectionPoolsHolder) Transactions() dataRetriever.ShardedDataCacherNotifier {
+	return holder.transactions
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (holder *txSelectionPoolsHolder) IsInterfaceNil() bool {
+	return holder == nil
+}
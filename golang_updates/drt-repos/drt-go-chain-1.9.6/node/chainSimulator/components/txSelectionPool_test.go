@@ -0,0 +1,72 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	"github.com/TerraDharitri/drt-go-chain/process"
+	"github.com/TerraDharitri/drt-go-chain/process/block/preprocess"
+	"github.com/TerraDharitri/drt-go-chain/storage"
+	"github.com/TerraDharitri/drt-go-chain/storage/txcache"
+	"github.com/TerraDharitri/drt-go-chain/testscommon"
+	"github.com/TerraDharitri/drt-go-chain/testscommon/txcachemocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createTxSelectionPool(pendingTxs map[string]*txcache.WrappedTransaction) *txSelectionPool {
+	cache := &txcachemocks.TxCacheMock{
+		GetByTxHashCalled: func(txHash []byte) (*txcache.WrappedTransaction, bool) {
+			wrappedTx, found := pendingTxs[string(txHash)]
+			return wrappedTx, found
+		},
+	}
+
+	return newTxSelectionPool(&testscommon.ShardedDataStub{
+		ShardDataStoreCalled: func(cacheID string) storage.Cacher {
+			return cache
+		},
+	}, 1)
+}
+
+func TestTxSelectionPool_SetSelection(t *testing.T) {
+	t.Parallel()
+
+	pendingTxs := map[string]*txcache.WrappedTransaction{
+		"hash1": {Tx: &transaction.Transaction{Nonce: 1, GasLimit: 10}, TxHash: []byte("hash1")},
+		"hash2": {Tx: &transaction.Transaction{Nonce: 2, GasLimit: 20}, TxHash: []byte("hash2")},
+	}
+
+	t.Run("transaction not in pool should error", func(t *testing.T) {
+		t.Parallel()
+
+		pool := createTxSelectionPool(pendingTxs)
+		err := pool.SetSelection([][]byte{[]byte("hash1"), []byte("missing")})
+		require.True(t, errors.Is(err, ErrTransactionNotInPool))
+
+		_, isOrdered := pool.ShardDataStore(process.ShardCacherIdentifier(1, 1)).(preprocess.TxCacheWithSelectionOrder)
+		require.False(t, isOrdered)
+	})
+	t.Run("should select the transactions in the provided order", func(t *testing.T) {
+		t.Parallel()
+
+		pool := createTxSelectionPool(pendingTxs)
+		err := pool.SetSelection([][]byte{[]byte("hash2"), []byte("hash1")})
+		require.Nil(t, err)
+
+		_, isOrdered := pool.ShardDataStore(process.ShardCacherIdentifier(0, 1)).(preprocess.TxCacheWithSelectionOrder)
+		require.False(t, isOrdered)
+
+		cache, isOrdered := pool.ShardDataStore(process.ShardCacherIdentifier(1, 1)).(preprocess.TxCacheWithSelectionOrder)
+		require.True(t, isOrdered)
+		require.True(t, cache.KeepSelectionOrder())
+
+		selectedTxs, accumulatedGas := cache.SelectTransactions(nil, 0, 0, 0)
+		require.Equal(t, []*txcache.WrappedTransaction{pendingTxs["hash2"], pendingTxs["hash1"]}, selectedTxs)
+		require.Equal(t, uint64(30), accumulatedGas)
+
+		pool.ClearSelection()
+		_, isOrdered = pool.ShardDataStore(process.ShardCacherIdentifier(1, 1)).(preprocess.TxCacheWithSelectionOrder)
+		require.False(t, isOrdered)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"math/big"
 	"os"
 	"path"
@@ -32,6 +34,8 @@ import (
 var oneRewa = big.NewInt(1000000000000000000)
 var initialStakedRewaPerNode = big.NewInt(0).Mul(oneRewa, big.NewInt(2500))
 var initialSupply = big.NewInt(0).Mul(oneRewa, big.NewInt(20000000)) // 20 million REWA
+var errInvalidNumOfProvidedKeys = errors.New("invalid number of provided keys")
+
 const (
 	// ChainID contains the chain id
 	ChainID = "chain"
@@ -58,6 +62,11 @@ type ArgsChainSimulatorConfigs struct {
 	NumNodesWaitingListShard    uint32
 	NumNodesWaitingListMeta     uint32
 	AlterConfigsFunction        func(cfg *config.Configs)
+
+	// InitialWallets and ValidatorsPrivateKeys are optional. When provided, they are used instead of generating
+	// new keys, so a chain can be restarted with the same genesis wallets and validators
+	InitialWallets        *dtos.InitialWalletKeys
+	ValidatorsPrivateKeys [][]byte
 }
 
 // ArgsConfigsSimulator holds the configs for the chain simulator
@@ -116,6 +125,9 @@ func CreateChainSimulatorConfigs(args ArgsChainSimulatorConfigs) (*ArgsConfigsSi
 
 	// set compatible trie configs
 	configs.GeneralConfig.StateTriesConfig.SnapshotsEnabled = false
+	// keep the old accounts and peer tries, so the chain simulator can revert to a snapshot
+	configs.GeneralConfig.StateTriesConfig.AccountsStatePruningEnabled = false
+	configs.GeneralConfig.StateTriesConfig.PeerStatePruningEnabled = false
 	// enable db lookup extension
 	configs.GeneralConfig.DbLookupExtensions.Enabled = true
 
@@ -221,8 +233,13 @@ func generateGenesisFile(args ArgsChainSimulatorConfigs, configs *config.Configs
 
 	addresses := make([]data.InitialAccount, 0)
 	numOfNodes := int((args.NumNodesWaitingListShard+args.MinNodesPerShard)*args.NumOfShards + args.NumNodesWaitingListMeta + args.MetaChainMinNodes)
+	err = checkProvidedKeys(args, numOfNodes)
+	if err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < numOfNodes; i++ {
-		wallet, errGenerate := generateWalletKey(addressConverter)
+		wallet, errGenerate := getOrGenerateStakeWallet(args.InitialWallets, i, addressConverter)
 		if errGenerate != nil {
 			return nil, errGenerate
 		}
@@ -251,7 +268,7 @@ func generateGenesisFile(args ArgsChainSimulatorConfigs, configs *config.Configs
 	remainder.Mod(remainder, big.NewInt(int64(args.NumOfShards)))
 
 	for shardID := uint32(0); shardID < args.NumOfShards; shardID++ {
-		walletKey, errG := generateWalletKeyForShard(shardID, args.NumOfShards, addressConverter)
+		walletKey, errG := getOrGenerateBalanceWallet(args.InitialWallets, shardID, args.NumOfShards, addressConverter)
 		if errG != nil {
 			return nil, errG
 		}
@@ -307,7 +324,10 @@ func generateValidatorsKeyAndUpdateFiles(
 	walletIndex := 0
 	// generate meta keys
 	for idx := uint32(0); idx < args.NumNodesWaitingListMeta+args.MetaChainMinNodes; idx++ {
-		sk, pk := blockSigningGenerator.GeneratePair()
+		sk, pk, errG := getOrGenerateValidatorKey(blockSigningGenerator, args.ValidatorsPrivateKeys, walletIndex)
+		if errG != nil {
+			return nil, nil, errG
+		}
 		privateKeys = append(privateKeys, sk)
 		publicKeys = append(publicKeys, pk)
 
@@ -333,7 +353,10 @@ func generateValidatorsKeyAndUpdateFiles(
 	// generate shard keys
 	for idx1 := uint32(0); idx1 < args.NumOfShards; idx1++ {
 		for idx2 := uint32(0); idx2 < args.NumNodesWaitingListShard+args.MinNodesPerShard; idx2++ {
-			sk, pk := blockSigningGenerator.GeneratePair()
+			sk, pk, errG := getOrGenerateValidatorKey(blockSigningGenerator, args.ValidatorsPrivateKeys, walletIndex)
+			if errG != nil {
+				return nil, nil, errG
+			}
 			privateKeys = append(privateKeys, sk)
 			publicKeys = append(publicKeys, pk)
 
@@ -370,6 +393,58 @@ func generateValidatorsKeyAndUpdateFiles(
 	return privateKeys, publicKeys, nil
 }
 
+func checkProvidedKeys(args ArgsChainSimulatorConfigs, numOfNodes int) error {
+	if len(args.ValidatorsPrivateKeys) > 0 && len(args.ValidatorsPrivateKeys) != numOfNodes {
+		return fmt.Errorf("%w, validators keys: %d, number of nodes: %d", errInvalidNumOfProvidedKeys, len(args.ValidatorsPrivateKeys), numOfNodes)
+	}
+	if args.InitialWallets == nil {
+		return nil
+	}
+	if len(args.InitialWallets.StakeWallets) != numOfNodes {
+		return fmt.Errorf("%w, stake wallets: %d, number of nodes: %d", errInvalidNumOfProvidedKeys, len(args.InitialWallets.StakeWallets), numOfNodes)
+	}
+	if len(args.InitialWallets.BalanceWallets) != int(args.NumOfShards) {
+		return fmt.Errorf("%w, balance wallets: %d, number of shards: %d", errInvalidNumOfProvidedKeys, len(args.InitialWallets.BalanceWallets), args.NumOfShards)
+	}
+
+	return nil
+}
+
+func getOrGenerateStakeWallet(initialWallets *dtos.InitialWalletKeys, index int, converter core.PubkeyConverter) (*dtos.WalletKey, error) {
+	if initialWallets != nil {
+		return initialWallets.StakeWallets[index], nil
+	}
+
+	return generateWalletKey(converter)
+}
+
+func getOrGenerateBalanceWallet(initialWallets *dtos.InitialWalletKeys, shardID, numOfShards uint32, converter core.PubkeyConverter) (*dtos.WalletKey, error) {
+	if initialWallets != nil {
+		walletKey, found := initialWallets.BalanceWallets[shardID]
+		if !found {
+			return nil, fmt.Errorf("%w, missing balance wallet for shard %d", errInvalidNumOfProvidedKeys, shardID)
+		}
+
+		return walletKey, nil
+	}
+
+	return generateWalletKeyForShard(shardID, numOfShards, converter)
+}
+
+func getOrGenerateValidatorKey(keyGenerator crypto.KeyGenerator, privateKeys [][]byte, index int) (crypto.PrivateKey, crypto.PublicKey, error) {
+	if len(privateKeys) == 0 {
+		sk, pk := keyGenerator.GeneratePair()
+		return sk, pk, nil
+	}
+
+	sk, err := keyGenerator.PrivateKeyFromByteArray(privateKeys[index])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sk, sk.GeneratePublic(), nil
+}
+
 func generateValidatorsPem(validatorsFile string, publicKeys []crypto.PublicKey, privateKey []crypto.PrivateKey) error {
 	validatorPubKeyConverter, err := pubkeyConverter.NewHexPubkeyConverter(96)
 	if err != nil {
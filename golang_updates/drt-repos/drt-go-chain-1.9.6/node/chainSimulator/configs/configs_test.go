@@ -1,6 +1,7 @@
 package configs
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/TerraDharitri/drt-go-chain/integrationTests/realcomponents"
@@ -29,3 +30,48 @@ func TestNewProcessorRunnerChainArguments(t *testing.T) {
 	pr := realcomponents.NewProcessorRunner(t, outputConfig.Configs)
 	pr.Close(t)
 }
+
+func TestCreateChainSimulatorConfigs_WithProvidedKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	args := ArgsChainSimulatorConfigs{
+		NumOfShards:                 3,
+		OriginalConfigsPath:         "../../../cmd/node/config",
+		RoundDurationInMillis:       6000,
+		GenesisTimeStamp:            0,
+		TempDir:                     t.TempDir(),
+		MetaChainMinNodes:           1,
+		MinNodesPerShard:            1,
+		ConsensusGroupSize:          1,
+		MetaChainConsensusGroupSize: 1,
+	}
+
+	firstConfig, err := CreateChainSimulatorConfigs(args)
+	require.Nil(t, err)
+
+	t.Run("same keys should work", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.TempDir = t.TempDir()
+		argsCopy.InitialWallets = firstConfig.InitialWallets
+		for _, sk := range firstConfig.ValidatorsPrivateKeys {
+			skBytes, errB := sk.ToByteArray()
+			require.Nil(t, errB)
+			argsCopy.ValidatorsPrivateKeys = append(argsCopy.ValidatorsPrivateKeys, skBytes)
+		}
+
+		secondConfig, errCreate := CreateChainSimulatorConfigs(argsCopy)
+		require.Nil(t, errCreate)
+		require.Equal(t, firstConfig.InitialWallets, secondConfig.InitialWallets)
+		require.Equal(t, firstConfig.Configs.NodesConfig.InitialNodes, secondConfig.Configs.NodesConfig.InitialNodes)
+	})
+	t.Run("invalid number of keys should error", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.TempDir = t.TempDir()
+		argsCopy.ValidatorsPrivateKeys = [][]byte{[]byte("key")}
+
+		_, errCreate := CreateChainSimulatorConfigs(argsCopy)
+		require.True(t, errors.Is(errCreate, errInvalidNumOfProvidedKeys))
+	})
+}
@@ -0,0 +1,58 @@
+package dtos
+
+// StreamEvent holds an event produced while the chain simulator generates blocks: a committed block, an executed
+// transaction or smart contract result, or a log event. The identifiers increase in the order the events were produced
+type StreamEvent struct {
+	ID          uint64            `json:"id"`
+	Type        string            `json:"type"`
+	Shard       uint32            `json:"shard"`
+	Block       *BlockEvent       `json:"block,omitempty"`
+	Transaction *TransactionEvent `json:"transaction,omitempty"`
+	Log         *LogEvent         `json:"log,omitempty"`
+}
+
+// BlockEvent holds a block committed by a shard
+type BlockEvent struct {
+	Hash      string `json:"hash"`
+	Nonce     uint64 `json:"nonce"`
+	Round     uint64 `json:"round"`
+	Epoch     uint32 `json:"epoch"`
+	Timestamp uint64 `json:"timestamp"`
+	NumTxs    uint32 `json:"numTxs"`
+}
+
+// TransactionEvent holds a transaction or a smart contract result executed in a block
+type TransactionEvent struct {
+	Hash           string `json:"hash"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	BlockHash      string `json:"blockHash"`
+	Nonce          uint64 `json:"nonce"`
+	Sender         string `json:"sender"`
+	Receiver       string `json:"receiver"`
+	Value          string `json:"value"`
+	Data           []byte `json:"data,omitempty"`
+	GasLimit       uint64 `json:"gasLimit"`
+	GasUsed        uint64 `json:"gasUsed"`
+	Fee            string `json:"fee,omitempty"`
+	OriginalTxHash string `json:"originalTxHash,omitempty"`
+	ReturnMessage  string `json:"returnMessage,omitempty"`
+}
+
+// LogEvent holds an event from the logs generated by a transaction, with the topics and the data hex encoded
+type LogEvent struct {
+	TxHash     string   `json:"txHash"`
+	BlockHash  string   `json:"blockHash"`
+	Address    string   `json:"address"`
+	Identifier string   `json:"identifier"`
+	Topics     []string `json:"topics,omitempty"`
+	Data       string   `json:"data,omitempty"`
+}
+
+// EventsFilter holds the criteria of the events streamed to a subscriber. Empty criteria match all the events. The
+// blocks are not related to an address or an identifier, so they are skipped when filtering by these criteria
+type EventsFilter struct {
+	Types      []string
+	Address    string
+	Identifier string
+}
@@ -0,0 +1,13 @@
+package dtos
+
+// PendingTransaction holds a transaction waiting in the pool of a shard to be included in a block
+type PendingTransaction struct {
+	Hash     string `json:"hash"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Nonce    uint64 `json:"nonce"`
+	Value    string `json:"value"`
+	GasPrice uint64 `json:"gasPrice"`
+	GasLimit uint64 `json:"gasLimit"`
+	Data     []byte `json:"data,omitempty"`
+}
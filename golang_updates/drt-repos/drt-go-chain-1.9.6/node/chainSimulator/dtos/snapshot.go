@@ -0,0 +1,39 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data"
+)
+
+// HeaderWithHash holds a header together with its hash
+type HeaderWithHash struct {
+	Header data.HeaderHandler
+	Hash   []byte
+}
+
+// PoolTransaction holds a transaction from the transactions pool together with the cache it was stored in
+type PoolTransaction struct {
+	Hash    []byte
+	Tx      data.TransactionHandler
+	Size    int
+	CacheID string
+}
+
+// NodeSnapshot holds everything needed to bring a node back to a previous state
+type NodeSnapshot struct {
+	Epoch                    uint32
+	Round                    int64
+	TimeOffset               time.Duration
+	CurrentHeader            data.HeaderHandler
+	CurrentHeaderHash        []byte
+	CurrentRootHash          []byte
+	UserAccountsRootHash     []byte
+	PeerAccountsRootHash     []byte
+	HighestNonceInPool       map[uint32]uint64
+	HighestStoredNonce       map[uint32]uint64
+	LastCrossNotarizedHeader map[uint32]*HeaderWithHash
+	LastSelfNotarizedHeader  map[uint32]*HeaderWithHash
+	TrackedHeaders           map[uint32][]*HeaderWithHash
+	PendingTransactions      []*PoolTransaction
+}
@@ -0,0 +1,70 @@
+package dtos
+
+// TransactionTrace holds the executions performed for a transaction and for the smart contract results it generated,
+// in all the shards, in the order they happened
+type TransactionTrace struct {
+	Hash       string            `json:"hash"`
+	Executions []*ExecutionFrame `json:"executions"`
+}
+
+// ExecutionFrame holds an execution performed by the virtual machine, by a built-in function or a call made from
+// inside the virtual machine, together with the calls it made. The calls made from inside the virtual machine (the
+// vmCall type) are rebuilt from the events of the execution containing them, so they only hold the caller, the callee,
+// the call type, the value, the function and its arguments: their gas, output, storage accesses and events are reported
+// on the containing execution, and the calls they made are listed next to them, flattened, instead of in their Calls
+type ExecutionFrame struct {
+	Type          string            `json:"type"`
+	Shard         uint32            `json:"shard"`
+	Round         uint64            `json:"round"`
+	TxHash        string            `json:"txHash,omitempty"`
+	CallType      string            `json:"callType"`
+	Caller        string            `json:"caller"`
+	Callee        string            `json:"callee"`
+	Function      string            `json:"function,omitempty"`
+	Arguments     []string          `json:"arguments,omitempty"`
+	Value         string            `json:"value"`
+	DCDTTransfers []*TokenTransfer  `json:"dcdtTransfers,omitempty"`
+	GasProvided   uint64            `json:"gasProvided,omitempty"`
+	GasUsed       uint64            `json:"gasUsed,omitempty"`
+	ReturnCode    string            `json:"returnCode,omitempty"`
+	ReturnMessage string            `json:"returnMessage,omitempty"`
+	ReturnData    []string          `json:"returnData,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	StorageReads  []*StorageAccess  `json:"storageReads,omitempty"`
+	StorageWrites []*StorageAccess  `json:"storageWrites,omitempty"`
+	Transfers     []*OutputTransfer `json:"transfers,omitempty"`
+	Events        []*Event          `json:"events,omitempty"`
+	Calls         []*ExecutionFrame `json:"calls,omitempty"`
+}
+
+// TokenTransfer holds a DCDT transfer received by an execution
+type TokenTransfer struct {
+	Token string `json:"token"`
+	Nonce uint64 `json:"nonce"`
+	Value string `json:"value"`
+}
+
+// StorageAccess holds a storage key, hex encoded, together with the value read or written
+type StorageAccess struct {
+	Address string `json:"address"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+// OutputTransfer holds a transfer generated by an execution
+type OutputTransfer struct {
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Value    string `json:"value"`
+	Data     string `json:"data,omitempty"`
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+	CallType string `json:"callType"`
+}
+
+// Event holds an event emitted by an execution
+type Event struct {
+	Address    string   `json:"address"`
+	Identifier string   `json:"identifier"`
+	Topics     []string `json:"topics,omitempty"`
+	Data       []string `json:"data,omitempty"`
+}
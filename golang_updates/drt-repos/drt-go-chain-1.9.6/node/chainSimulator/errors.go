@@ -6,4 +6,5 @@ var (
 	errNilChainSimulator = errors.New("nil chain simulator")
 	errNilMetachainNode  = errors.New("nil metachain node")
 	errShardSetupError   = errors.New("shard setup error")
+	errTimeCannotBeSet   = errors.New("the round handler does not allow changing the time")
 )
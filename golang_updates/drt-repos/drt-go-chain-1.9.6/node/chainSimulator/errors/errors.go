@@ -10,3 +10,30 @@ var ErrNilTransaction = errors.New("nil transaction")
 
 // ErrInvalidMaxNumOfBlocks signals that an invalid max numerof blocks has been provided
 var ErrInvalidMaxNumOfBlocks = errors.New("invalid max number of blocks to generate")
+
+// ErrSnapshotNotFound signals that the provided snapshot identifier is unknown
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// ErrInvalidLastState signals that the last state saved in the data directory cannot be used
+var ErrInvalidLastState = errors.New("invalid last state in the data directory")
+
+// ErrInvalidTimestamp signals that the provided timestamp is not after the timestamp of the last generated block
+var ErrInvalidTimestamp = errors.New("invalid timestamp")
+
+// ErrInvalidTimeDuration signals that an invalid time duration has been provided
+var ErrInvalidTimeDuration = errors.New("invalid time duration")
+
+// ErrInvalidRound signals that the provided round is not after the current round
+var ErrInvalidRound = errors.New("invalid round")
+
+// ErrJumpOverMultipleEpochs signals that the provided round would skip the rounds of more than one epoch
+var ErrJumpOverMultipleEpochs = errors.New("cannot jump over more than one epoch")
+
+// ErrInvalidNumOfShardsForFork signals that the number of shards differs from the one of the forked network
+var ErrInvalidNumOfShardsForFork = errors.New("invalid number of shards for the forked network")
+
+// ErrInvalidShardID signals that the provided shard ID does not belong to the simulated network
+var ErrInvalidShardID = errors.New("invalid shard ID")
+
+// ErrDuplicatedTransaction signals that a transaction hash was provided more than once
+var ErrDuplicatedTransaction = errors.New("duplicated transaction")
@@ -0,0 +1,12 @@
+package chainSimulator
+
+import (
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+// SubscribeToEvents returns a channel receiving the blocks, the transactions and the log events produced by all the
+// nodes while generating blocks, which match the provided filter. The returned function ends the subscription and
+// closes the channel. A subscriber which does not read the events fast enough is unsubscribed
+func (s *simulator) SubscribeToEvents(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error) {
+	return s.eventsStream.Subscribe(filter)
+}
@@ -0,0 +1,58 @@
+package chainSimulator
+
+import (
+	"fmt"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/components"
+	chainSimulatorErrors "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/errors"
+)
+
+// ForkConfig holds the remote network the chain simulator is forked from
+type ForkConfig struct {
+	// ProxyURL is the proxy of the remote network. The fork mode is disabled when it is empty
+	ProxyURL string
+	// BlockNonce is the nonce of the metachain block the remote state is pinned at
+	BlockNonce uint64
+}
+
+func (s *simulator) createRemoteState(args ArgsBaseChainSimulator) error {
+	if len(args.Fork.ProxyURL) == 0 {
+		return nil
+	}
+
+	remoteState, err := components.NewRemoteStateProvider(components.ArgsRemoteStateProvider{
+		ProxyURL:   args.Fork.ProxyURL,
+		BlockNonce: args.Fork.BlockNonce,
+	})
+	if err != nil {
+		return fmt.Errorf("%w while connecting to the forked network", err)
+	}
+
+	if remoteState.NumOfShards() != args.NumOfShards {
+		return fmt.Errorf("%w, the forked network has %d shards, requested %d shards",
+			chainSimulatorErrors.ErrInvalidNumOfShardsForFork, remoteState.NumOfShards(), args.NumOfShards)
+	}
+
+	s.remoteState = remoteState
+
+	log.Info("forking the remote network",
+		"proxy", args.Fork.ProxyURL,
+		"metachain block nonce", args.Fork.BlockNonce)
+
+	return nil
+}
+
+// getRemoteState returns nil for metachain, as its accounts hold the validators of the local chain
+func (s *simulator) getRemoteState(shardIDStr string) components.RemoteStateHandler {
+	if s.remoteState == nil {
+		return nil
+	}
+
+	shardID, err := core.ConvertShardIDToUint32(shardIDStr)
+	if err != nil || shardID == core.MetachainShardId {
+		return nil
+	}
+
+	return s.remoteState
+}
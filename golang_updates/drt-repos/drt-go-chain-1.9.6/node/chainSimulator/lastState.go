@@ -0,0 +1,319 @@
+package chainSimulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	chainSimulatorErrors "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/errors"
+	chainSimulatorProcess "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/process"
+	"github.com/TerraDharitri/drt-go-chain/process"
+)
+
+const lastStateFileName = "lastState.json"
+
+// lastState holds everything needed to resume the chain simulator from the last generated block
+type lastState struct {
+	NumOfShards           uint32                    `json:"numOfShards"`
+	GenesisTimestamp      int64                     `json:"genesisTimestamp"`
+	Epoch                 uint32                    `json:"epoch"`
+	Round                 int64                     `json:"round"`
+	TimeOffset            time.Duration             `json:"timeOffset"`
+	InitialWallets        *dtos.InitialWalletKeys   `json:"initialWallets"`
+	ValidatorsPrivateKeys [][]byte                  `json:"validatorsPrivateKeys"`
+	AddedValidatorsKeys   [][]byte                  `json:"addedValidatorsKeys"`
+	Nodes                 map[uint32]*nodeLastState `json:"nodes"`
+}
+
+// nodeLastState holds the last block of a node, by hash, so it can be loaded back from the storage of the node,
+// together with the blocks notarized by it and the key of the saved nodes coordinator registry
+type nodeLastState struct {
+	Nonce                     uint64            `json:"nonce"`
+	HeaderHash                []byte            `json:"headerHash"`
+	RootHash                  []byte            `json:"rootHash"`
+	UserAccountsRootHash      []byte            `json:"userAccountsRootHash"`
+	PeerAccountsRootHash      []byte            `json:"peerAccountsRootHash"`
+	LastCrossNotarizedHeaders map[uint32][]byte `json:"lastCrossNotarizedHeaders"`
+	LastSelfNotarizedHeaders  map[uint32][]byte `json:"lastSelfNotarizedHeaders"`
+	NodesCoordinatorStateKey  []byte            `json:"nodesCoordinatorStateKey"`
+}
+
+// loadLastState returns nil if the chain simulator was not started before in the provided data directory
+func loadLastState(dataDir string, numOfShards uint32) (*lastState, error) {
+	if len(dataDir) == 0 {
+		return nil, nil
+	}
+
+	buff, err := os.ReadFile(filepath.Join(dataDir, lastStateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &lastState{}
+	err = json.Unmarshal(buff, state)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", chainSimulatorErrors.ErrInvalidLastState, err.Error())
+	}
+	if state.NumOfShards != numOfShards {
+		return nil, fmt.Errorf("%w, the data directory holds %d shards, requested %d shards",
+			chainSimulatorErrors.ErrInvalidLastState, state.NumOfShards, numOfShards)
+	}
+
+	return state, nil
+}
+
+// saveLastState does nothing until all the nodes were created and resumed, so a failed start will not overwrite
+// the previously saved state
+func (s *simulator) saveLastState() error {
+	if !s.lastStateEnabled {
+		return nil
+	}
+
+	metachainNode := s.nodes[core.MetachainShardId]
+	roundHandler, err := getTimeTravelRoundHandler(metachainNode)
+	if err != nil {
+		return err
+	}
+
+	state := &lastState{
+		NumOfShards:           s.numOfShards,
+		GenesisTimestamp:      s.genesisTimestamp,
+		Epoch:                 metachainNode.GetProcessComponents().EpochStartTrigger().Epoch(),
+		Round:                 metachainNode.GetCoreComponents().RoundHandler().Index(),
+		TimeOffset:            roundHandler.TimeOffset(),
+		InitialWallets:        s.initialWalletKeys,
+		ValidatorsPrivateKeys: make([][]byte, 0, len(s.validatorsPrivateKeys)),
+		AddedValidatorsKeys:   s.addedValidatorsKeys,
+		Nodes:                 make(map[uint32]*nodeLastState, len(s.nodes)),
+	}
+
+	for _, privateKey := range s.validatorsPrivateKeys {
+		privateKeyBytes, err := privateKey.ToByteArray()
+		if err != nil {
+			return err
+		}
+
+		state.ValidatorsPrivateKeys = append(state.ValidatorsPrivateKeys, privateKeyBytes)
+	}
+
+	for shardID, node := range s.nodes {
+		nodeState, err := getNodeLastState(node)
+		if err != nil {
+			return fmt.Errorf("%w for shard %d", err, shardID)
+		}
+
+		state.Nodes[shardID] = nodeState
+	}
+
+	buff, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// the file is replaced at once, so a crash while writing will not leave a corrupted state behind
+	lastStatePath := filepath.Join(s.dataDir, lastStateFileName)
+	err = os.WriteFile(lastStatePath+".tmp", buff, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(lastStatePath+".tmp", lastStatePath)
+}
+
+func getNodeLastState(node chainSimulatorProcess.NodeHandler) (*nodeLastState, error) {
+	userAccountsRootHash, err := node.GetStateComponents().AccountsAdapter().RootHash()
+	if err != nil {
+		return nil, err
+	}
+
+	peerAccountsRootHash, err := node.GetStateComponents().PeerAccounts().RootHash()
+	if err != nil {
+		return nil, err
+	}
+
+	chainHandler := node.GetChainHandler()
+	nonce := chainHandler.GetGenesisHeader().GetNonce()
+	currentHeader := chainHandler.GetCurrentBlockHeader()
+	if !check.IfNil(currentHeader) {
+		nonce = currentHeader.GetNonce()
+	}
+
+	nodeState := &nodeLastState{
+		Nonce:                     nonce,
+		HeaderHash:                chainHandler.GetCurrentBlockHeaderHash(),
+		RootHash:                  chainHandler.GetCurrentBlockRootHash(),
+		UserAccountsRootHash:      userAccountsRootHash,
+		PeerAccountsRootHash:      peerAccountsRootHash,
+		LastCrossNotarizedHeaders: make(map[uint32][]byte),
+		LastSelfNotarizedHeaders:  make(map[uint32][]byte),
+		NodesCoordinatorStateKey:  node.GetProcessComponents().NodesCoordinator().GetSavedStateKey(),
+	}
+
+	blockTracker := node.GetProcessComponents().BlockTracker()
+	for _, shardID := range getAllShardIDs(node) {
+		_, hash, errGet := blockTracker.GetLastCrossNotarizedHeader(shardID)
+		if errGet == nil {
+			nodeState.LastCrossNotarizedHeaders[shardID] = hash
+		}
+
+		_, hash, errGet = blockTracker.GetLastSelfNotarizedHeader(shardID)
+		if errGet == nil {
+			nodeState.LastSelfNotarizedHeaders[shardID] = hash
+		}
+	}
+
+	return nodeState, nil
+}
+
+// resumeFromLastState brings all the nodes back to the last block saved in the last state: the block is loaded from
+// the storage of each node and set in its chain handler, fork detector and block tracker, as a revert to a snapshot
+// does, the accounts are brought to the saved root hashes and the saved nodes coordinator registry is loaded. The
+// nodes were already started in the saved epoch, round and nonce, so the next generated block continues the
+// previous chain
+func (s *simulator) resumeFromLastState(state *lastState) error {
+	for shardID, node := range s.nodes {
+		nodeState, found := state.Nodes[shardID]
+		if !found {
+			return fmt.Errorf("%w, missing state for shard %d", chainSimulatorErrors.ErrInvalidLastState, shardID)
+		}
+
+		snapshot, err := node.TakeSnapshot()
+		if err != nil {
+			return err
+		}
+
+		err = setLastBlockInSnapshot(node, nodeState, snapshot)
+		if err != nil {
+			return fmt.Errorf("%w while resuming shard %d", err, shardID)
+		}
+
+		snapshot.UserAccountsRootHash = nodeState.UserAccountsRootHash
+		snapshot.PeerAccountsRootHash = nodeState.PeerAccountsRootHash
+		err = node.RevertToSnapshot(snapshot)
+		if err != nil {
+			return fmt.Errorf("%w while resuming shard %d", err, shardID)
+		}
+
+		if len(nodeState.NodesCoordinatorStateKey) > 0 {
+			err = node.GetProcessComponents().NodesCoordinator().LoadState(nodeState.NodesCoordinatorStateKey)
+			if err != nil {
+				return fmt.Errorf("%w while loading the nodes coordinator registry of shard %d", err, shardID)
+			}
+		}
+
+		err = s.setValidatorKeysForNode(node, state.AddedValidatorsKeys)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.addTimeOffsetOnAllNodes(state.TimeOffset)
+	if err != nil {
+		return err
+	}
+
+	s.addedValidatorsKeys = state.AddedValidatorsKeys
+
+	log.Info("resumed the chain simulator from the data directory",
+		"data directory", s.dataDir,
+		"epoch", state.Epoch,
+		"round", state.Round)
+
+	return s.nodes[core.MetachainShardId].GetProcessComponents().ValidatorsProvider().ForceUpdate()
+}
+
+// setLastBlockInSnapshot replaces the blocks of a snapshot taken right after the node was started with the last
+// block saved before the restart and the blocks notarized by it, all of them loaded from the storage of the node
+func setLastBlockInSnapshot(node chainSimulatorProcess.NodeHandler, nodeState *nodeLastState, snapshot *dtos.NodeSnapshot) error {
+	if len(nodeState.HeaderHash) == 0 {
+		// no block was generated before the restart, the node is already at the genesis block
+		return nil
+	}
+
+	shardID := node.GetShardCoordinator().SelfId()
+	header, err := getHeaderFromStorage(node, shardID, nodeState.HeaderHash)
+	if err != nil {
+		return fmt.Errorf("%w, cannot load the last block: %s", chainSimulatorErrors.ErrInvalidLastState, err.Error())
+	}
+
+	snapshot.CurrentHeader = header
+	snapshot.CurrentHeaderHash = nodeState.HeaderHash
+	snapshot.CurrentRootHash = nodeState.RootHash
+	snapshot.LastCrossNotarizedHeader = getNotarizedHeadersFromStorage(node, nodeState.LastCrossNotarizedHeaders)
+	snapshot.LastSelfNotarizedHeader = getNotarizedHeadersFromStorage(node, nodeState.LastSelfNotarizedHeaders)
+	snapshot.TrackedHeaders = make(map[uint32][]*dtos.HeaderWithHash)
+
+	return nil
+}
+
+// getNotarizedHeadersFromStorage skips the headers which are not stored, such as the genesis blocks, which are
+// already notarized when the block tracker is restored to genesis
+func getNotarizedHeadersFromStorage(node chainSimulatorProcess.NodeHandler, hashes map[uint32][]byte) map[uint32]*dtos.HeaderWithHash {
+	headers := make(map[uint32]*dtos.HeaderWithHash, len(hashes))
+	for shardID, hash := range hashes {
+		header, err := getHeaderFromStorage(node, shardID, hash)
+		if err != nil {
+			log.Debug("notarized header not found in storage", "shard", shardID, "hash", hash)
+			continue
+		}
+
+		headers[shardID] = &dtos.HeaderWithHash{Header: header, Hash: hash}
+	}
+
+	return headers
+}
+
+func getHeaderFromStorage(node chainSimulatorProcess.NodeHandler, shardID uint32, hash []byte) (data.HeaderHandler, error) {
+	return process.GetHeaderFromStorage(
+		shardID,
+		hash,
+		node.GetCoreComponents().InternalMarshalizer(),
+		node.GetDataComponents().StorageService(),
+	)
+}
+
+func getAllShardIDs(node chainSimulatorProcess.NodeHandler) []uint32 {
+	numShards := node.GetShardCoordinator().NumberOfShards()
+	shardIDs := make([]uint32, 0, numShards+1)
+	for shardID := uint32(0); shardID < numShards; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+
+	return append(shardIDs, core.MetachainShardId)
+}
+
+func (s *simulator) getInitialNonce(args ArgsBaseChainSimulator, shardIDStr string) uint64 {
+	if s.resumedState == nil {
+		return args.InitialNonce
+	}
+
+	shardID, err := core.ConvertShardIDToUint32(shardIDStr)
+	if err != nil {
+		return args.InitialNonce
+	}
+
+	nodeState, found := s.resumedState.Nodes[shardID]
+	if !found {
+		return args.InitialNonce
+	}
+
+	return nodeState.Nonce
+}
+
+func getNodeDataDir(dataDir string, shardIDStr string) string {
+	if len(dataDir) == 0 {
+		return ""
+	}
+
+	return filepath.Join(dataDir, shardIDStr)
+}
@@ -0,0 +1,142 @@
+package chainSimulator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/components"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	chainSimulatorErrors "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/errors"
+)
+
+// GetPendingTransactions returns the transactions sent from the provided shard which are waiting in the pool to be
+// included in a block, ordered by sender and nonce
+func (s *simulator) GetPendingTransactions(shardID uint32) ([]*dtos.PendingTransaction, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	node, found := s.nodes[shardID]
+	if !found {
+		return nil, fmt.Errorf("%w: %d", chainSimulatorErrors.ErrInvalidShardID, shardID)
+	}
+
+	return node.GetPendingTransactions()
+}
+
+// RemovePendingTransactions will remove the provided transactions from the pools of all the nodes, so they will not
+// be included in the next blocks. The transactions that are not found in any pool are reported in the returned error
+func (s *simulator) RemovePendingTransactions(txHashes []string) error {
+	hashes, err := decodeTransactionsHashes(txHashes)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	notFound := make([]string, 0)
+	for idx, txHash := range hashes {
+		removed := false
+		for _, node := range s.nodes {
+			removed = node.RemovePendingTransaction(txHash) || removed
+		}
+
+		if !removed {
+			notFound = append(notFound, txHashes[idx])
+		}
+	}
+
+	if len(notFound) > 0 {
+		return fmt.Errorf("%w, hashes: %s", components.ErrTransactionNotInPool, strings.Join(notFound, ", "))
+	}
+
+	log.Info("removed pending transactions", "num transactions", len(hashes))
+
+	return nil
+}
+
+// GenerateBlockWithTransactions will generate one block in all the shards, each shard including only the provided
+// transactions sent from it, executed in the provided order. The other pending transactions are kept in the pools.
+// All the transactions should be pending in the pools
+func (s *simulator) GenerateBlockWithTransactions(txHashes []string) error {
+	hashes, err := decodeTransactionsHashes(txHashes)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	selections, err := s.groupPendingTransactionsByShard(hashes)
+	if err != nil {
+		return err
+	}
+
+	defer s.clearTransactionsSelectionOnAllNodes()
+
+	for shardID, node := range s.nodes {
+		err = node.SetTransactionsSelection(selections[shardID])
+		if err != nil {
+			return fmt.Errorf("%w for shard %d", err, shardID)
+		}
+	}
+
+	s.incrementRoundOnAllValidators()
+
+	return s.allNodesCreateBlocks()
+}
+
+func (s *simulator) groupPendingTransactionsByShard(txHashes [][]byte) (map[uint32][][]byte, error) {
+	shardOfTransaction := make(map[string]uint32)
+	for shardID, node := range s.nodes {
+		pendingTransactions, err := node.GetPendingTransactions()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pendingTx := range pendingTransactions {
+			shardOfTransaction[pendingTx.Hash] = shardID
+		}
+	}
+
+	selections := make(map[uint32][][]byte)
+	for _, txHash := range txHashes {
+		hexTxHash := hex.EncodeToString(txHash)
+		shardID, found := shardOfTransaction[hexTxHash]
+		if !found {
+			return nil, fmt.Errorf("%w, hash: %s", components.ErrTransactionNotInPool, hexTxHash)
+		}
+
+		selections[shardID] = append(selections[shardID], txHash)
+	}
+
+	return selections, nil
+}
+
+func (s *simulator) clearTransactionsSelectionOnAllNodes() {
+	for _, node := range s.nodes {
+		node.ClearTransactionsSelection()
+	}
+}
+
+func decodeTransactionsHashes(txHashes []string) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(txHashes))
+	providedHashes := make(map[string]struct{}, len(txHashes))
+	for _, txHash := range txHashes {
+		hash, err := hex.DecodeString(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction hash %s: %w", txHash, err)
+		}
+
+		_, isDuplicated := providedHashes[string(hash)]
+		if isDuplicated {
+			return nil, fmt.Errorf("%w, hash: %s", chainSimulatorErrors.ErrDuplicatedTransaction, txHash)
+		}
+
+		providedHashes[string(hash)] = struct{}{}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
@@ -27,6 +27,13 @@ type NodeHandler interface {
 	SetStateForAddress(address []byte, state *dtos.AddressState) error
 	RemoveAccount(address []byte) error
 	ForceChangeOfEpoch() error
+	TakeSnapshot() (*dtos.NodeSnapshot, error)
+	RevertToSnapshot(snapshot *dtos.NodeSnapshot) error
+	DumpState() ([]*dtos.AddressState, error)
+	GetPendingTransactions() ([]*dtos.PendingTransaction, error)
+	RemovePendingTransaction(txHash []byte) bool
+	SetTransactionsSelection(txHashes [][]byte) error
+	ClearTransactionsSelection()
 	GetBasePeers() map[uint32]core.PeerID
 	SetBasePeers(basePeers map[uint32]core.PeerID)
 	Close() error
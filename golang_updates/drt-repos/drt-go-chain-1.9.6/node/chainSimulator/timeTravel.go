@@ -0,0 +1,158 @@
+package chainSimulator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain/common"
+	chainSimulatorErrors "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/errors"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/process"
+)
+
+type timeTravelRoundHandler interface {
+	SetIndex(index int64)
+	AddTimeOffset(offset time.Duration)
+	TimeOffset() time.Duration
+}
+
+// SetNextBlockTimestamp will set the timestamp of the next generated block, in seconds. The following blocks keep
+// the round duration between them, starting from the provided timestamp
+func (s *simulator) SetNextBlockTimestamp(timestamp int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lastTimestamp := s.getLastBlockTimestamp()
+	if timestamp <= lastTimestamp {
+		return fmt.Errorf("%w, provided timestamp: %d, last block timestamp: %d",
+			chainSimulatorErrors.ErrInvalidTimestamp, timestamp, lastTimestamp)
+	}
+
+	// all the round handlers are in sync, so the metachain one is used to compute the offset for all the nodes
+	roundHandler := s.nodes[core.MetachainShardId].GetCoreComponents().RoundHandler()
+	nextRoundTimestamp := roundHandler.TimeStamp().Add(roundHandler.TimeDuration())
+	offset := time.Unix(timestamp, 0).Sub(nextRoundTimestamp)
+
+	err := s.addTimeOffsetOnAllNodes(offset)
+	if err != nil {
+		return err
+	}
+
+	log.Info("set the timestamp of the next block", "timestamp", timestamp)
+
+	return s.saveLastState()
+}
+
+// AdvanceTime will move the timestamps of the next generated blocks forward with the provided duration,
+// without generating any block
+func (s *simulator) AdvanceTime(duration time.Duration) error {
+	if duration < time.Second {
+		return fmt.Errorf("%w, the duration should be at least one second, provided: %s",
+			chainSimulatorErrors.ErrInvalidTimeDuration, duration)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.addTimeOffsetOnAllNodes(duration.Truncate(time.Second))
+	if err != nil {
+		return err
+	}
+
+	log.Info("advanced the time", "duration", duration)
+
+	return s.saveLastState()
+}
+
+// JumpToRound will set the current round on all the nodes without generating the blocks in between. The timestamps
+// of the next blocks are computed from the new round. If the round passes the end of the current epoch, the next
+// generated block will start a new epoch. The epoch start trigger starts a single epoch with the next block, so the
+// rounds of more than one epoch cannot be skipped: the round can be at most the last round of the next epoch, and
+// the following epochs have to be reached by generating blocks
+func (s *simulator) JumpToRound(round int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	currentRound := s.nodes[core.MetachainShardId].GetCoreComponents().RoundHandler().Index()
+	if round <= currentRound {
+		return fmt.Errorf("%w, provided round: %d, current round: %d",
+			chainSimulatorErrors.ErrInvalidRound, round, currentRound)
+	}
+
+	maxRound := s.getLastRoundOfNextEpoch()
+	if round > maxRound {
+		return fmt.Errorf("%w, provided round: %d, last round of the next epoch: %d",
+			chainSimulatorErrors.ErrJumpOverMultipleEpochs, round, maxRound)
+	}
+
+	for shardID, node := range s.nodes {
+		roundHandler, err := getTimeTravelRoundHandler(node)
+		if err != nil {
+			return fmt.Errorf("%w for shard %d", err, shardID)
+		}
+
+		roundHandler.SetIndex(round)
+		node.GetStatusCoreComponents().AppStatusHandler().SetUInt64Value(common.MetricCurrentRound, uint64(round))
+	}
+
+	log.Info("jumped to round", "previous round", currentRound, "round", round)
+
+	return s.saveLastState()
+}
+
+// getLastRoundOfNextEpoch returns the last round of the epoch following the current one, when the next block is
+// generated in the round after it. The metachain starts a new epoch with the first block whose round is past the
+// rounds of the current epoch
+func (s *simulator) getLastRoundOfNextEpoch() int64 {
+	epochStartRound := int64(s.nodes[core.MetachainShardId].GetProcessComponents().EpochStartTrigger().EpochStartRound())
+
+	return epochStartRound + 2*s.roundsPerEpoch - 1
+}
+
+func (s *simulator) addTimeOffsetOnAllNodes(offset time.Duration) error {
+	// the round handlers are checked upfront, so no node is left with a different time than the others
+	roundHandlers := make([]timeTravelRoundHandler, 0, len(s.nodes))
+	for shardID, node := range s.nodes {
+		roundHandler, err := getTimeTravelRoundHandler(node)
+		if err != nil {
+			return fmt.Errorf("%w for shard %d", err, shardID)
+		}
+
+		roundHandlers = append(roundHandlers, roundHandler)
+	}
+
+	for _, roundHandler := range roundHandlers {
+		roundHandler.AddTimeOffset(offset)
+	}
+
+	return nil
+}
+
+func (s *simulator) getLastBlockTimestamp() int64 {
+	lastTimestamp := int64(0)
+	for _, node := range s.nodes {
+		currentHeader := node.GetChainHandler().GetCurrentBlockHeader()
+		if check.IfNil(currentHeader) {
+			currentHeader = node.GetChainHandler().GetGenesisHeader()
+		}
+		if check.IfNil(currentHeader) {
+			continue
+		}
+
+		if int64(currentHeader.GetTimeStamp()) > lastTimestamp {
+			lastTimestamp = int64(currentHeader.GetTimeStamp())
+		}
+	}
+
+	return lastTimestamp
+}
+
+func getTimeTravelRoundHandler(node process.NodeHandler) (timeTravelRoundHandler, error) {
+	roundHandler, ok := node.GetCoreComponents().RoundHandler().(timeTravelRoundHandler)
+	if !ok {
+		return nil, errTimeCannotBeSet
+	}
+
+	return roundHandler, nil
+}
@@ -0,0 +1,35 @@
+package chainSimulator
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	factoryPubKey "github.com/TerraDharitri/drt-go-chain/common/factory"
+	"github.com/TerraDharitri/drt-go-chain/config"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/components"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+func (s *simulator) createExecutionTracer(addressPubkeyConverterConfig config.PubkeyConfig) error {
+	// the tracer is shared by all the nodes, so the address converter is created from the config before the nodes
+	addressConverter, err := factoryPubKey.NewPubkeyConverter(addressPubkeyConverterConfig)
+	if err != nil {
+		return err
+	}
+
+	s.executionTracer, err = components.NewExecutionTracer(addressConverter)
+
+	return err
+}
+
+// GetTransactionTrace returns the smart contracts executions performed for the provided transaction and for the
+// smart contract results it generated, in all the shards. Only the transactions that reached a smart contract or a
+// built-in function are traced
+func (s *simulator) GetTransactionTrace(txHash string) (*dtos.TransactionTrace, error) {
+	txHashBytes, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash %s: %w", txHash, err)
+	}
+
+	return s.executionTracer.GetTransactionTrace(txHashBytes)
+}
@@ -19,6 +19,13 @@ type TxCache interface {
 	IsInterfaceNil() bool
 }
 
+// TxCacheWithSelectionOrder defines a transactions cache which selects the transactions in the order they should be
+// executed, so the selection is not filtered nor sorted again
+type TxCacheWithSelectionOrder interface {
+	TxCache
+	KeepSelectionOrder() bool
+}
+
 // BlockTracker defines the functionality for node to track the blocks which are received from network
 type BlockTracker interface {
 	IsShardStuck(shardID uint32) bool
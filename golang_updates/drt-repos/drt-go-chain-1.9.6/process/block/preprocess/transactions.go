@@ -1420,6 +1420,10 @@ func (txs *transactions) computeSortedTxs(
 	}
 
 	sortedTxs := sortedTransactionsProvider.GetSortedTransactions(session)
+	if shouldKeepSelectionOrder(txShardPool) {
+		// the cache already provides the transactions in the order they have to be executed
+		return sortedTxs, make([]*txcache.WrappedTransaction, 0), nil
+	}
 
 	// TODO: this could be moved to SortedTransactionsProvider
 	selectedTxs, remainingTxs := txs.preFilterTransactionsWithMoveBalancePriority(sortedTxs, gasBandwidth)
@@ -1428,6 +1432,12 @@ func (txs *transactions) computeSortedTxs(
 	return selectedTxs, remainingTxs, nil
 }
 
+func shouldKeepSelectionOrder(cache storage.Cacher) bool {
+	orderedCache, ok := cache.(TxCacheWithSelectionOrder)
+
+	return ok && orderedCache.KeepSelectionOrder()
+}
+
 // ProcessMiniBlock processes all the transactions from the given miniblock and saves the processed ones in a local cache
 func (txs *transactions) ProcessMiniBlock(
 	miniBlock *block.MiniBlock,
@@ -710,6 +710,45 @@ func TestTransactions_CreateAndProcessMiniBlockCrossShardGasLimitAddAll(t *testi
 	assert.Equal(t, len(addedTxs), txHashes)
 }
 
+type txCacheWithSelectionOrderStub struct {
+	*cache.CacherStub
+	selectedTxs []*txcache.WrappedTransaction
+}
+
+func (stub *txCacheWithSelectionOrderStub) SelectTransactions(_ txcache.SelectionSession, _ uint64, _ int, _ time.Duration) ([]*txcache.WrappedTransaction, uint64) {
+	return stub.selectedTxs, 0
+}
+
+func (stub *txCacheWithSelectionOrderStub) KeepSelectionOrder() bool {
+	return true
+}
+
+func TestTransactions_ComputeSortedTxsShouldKeepTheSelectionOrderOfTheCache(t *testing.T) {
+	t.Parallel()
+
+	selectedTxs := []*txcache.WrappedTransaction{
+		{Tx: &transaction.Transaction{SndAddr: []byte("sender c"), Nonce: 1}, TxHash: []byte("hash1")},
+		{Tx: &transaction.Transaction{SndAddr: []byte("sender a"), Nonce: 7}, TxHash: []byte("hash2")},
+		{Tx: &transaction.Transaction{SndAddr: []byte("sender b"), Nonce: 3}, TxHash: []byte("hash3")},
+	}
+
+	args := createDefaultTransactionsProcessorArgs()
+	args.TxDataPool = &testscommon.ShardedDataStub{
+		ShardDataStoreCalled: func(cacheID string) storage.Cacher {
+			return &txCacheWithSelectionOrderStub{
+				CacherStub:  &cache.CacherStub{},
+				selectedTxs: selectedTxs,
+			}
+		},
+	}
+	txs, _ := NewTransactionPreprocessor(args)
+
+	sortedTxs, remainingTxs, err := txs.computeSortedTxs(0, 0, MaxGasLimitPerBlock, []byte("randomness"))
+	require.Nil(t, err)
+	require.Equal(t, selectedTxs, sortedTxs)
+	require.Empty(t, remainingTxs)
+}
+
 func TestTransactions_CreateAndProcessMiniBlockCrossShardGasLimitAddAllAsNoSCCalls(t *testing.T) {
 	t.Parallel()
 
@@ -553,6 +553,14 @@ type BlockChainHookWithAccountsAdapter interface {
 	GetAccountsAdapter() state.AccountsAdapter
 }
 
+// ExecutionTracer defines the component able to trace the smart contracts executions performed while processing blocks,
+// by wrapping the blockchain hook and the virtual machines container
+type ExecutionTracer interface {
+	WrapBlockChainHook(shardID uint32, blockChainHook BlockChainHookWithAccountsAdapter) BlockChainHookWithAccountsAdapter
+	WrapVMContainer(shardID uint32, vmContainer VirtualMachinesContainer) VirtualMachinesContainer
+	IsInterfaceNil() bool
+}
+
 // Interceptor defines what a data interceptor should do
 // It should also adhere to the p2p.MessageProcessor interface so it can wire to a p2p.Messenger
 type Interceptor interface {
@@ -12,23 +12,30 @@ import (
 
 // NodeHandlerMock -
 type NodeHandlerMock struct {
-	GetProcessComponentsCalled    func() factory.ProcessComponentsHolder
-	GetChainHandlerCalled         func() chainData.ChainHandler
-	GetBroadcastMessengerCalled   func() consensus.BroadcastMessenger
-	GetShardCoordinatorCalled     func() sharding.Coordinator
-	GetCryptoComponentsCalled     func() factory.CryptoComponentsHolder
-	GetCoreComponentsCalled       func() factory.CoreComponentsHolder
-	GetDataComponentsCalled       func() factory.DataComponentsHandler
-	GetStateComponentsCalled      func() factory.StateComponentsHolder
-	GetFacadeHandlerCalled        func() shared.FacadeHandler
-	GetStatusCoreComponentsCalled func() factory.StatusCoreComponentsHolder
-	GetNetworkComponentsCalled    func() factory.NetworkComponentsHolder
-	SetKeyValueForAddressCalled   func(addressBytes []byte, state map[string]string) error
-	SetStateForAddressCalled      func(address []byte, state *dtos.AddressState) error
-	RemoveAccountCalled           func(address []byte) error
-	GetBasePeersCalled            func() map[uint32]core.PeerID
-	SetBasePeersCalled            func(basePeers map[uint32]core.PeerID)
-	CloseCalled                   func() error
+	GetProcessComponentsCalled       func() factory.ProcessComponentsHolder
+	GetChainHandlerCalled            func() chainData.ChainHandler
+	GetBroadcastMessengerCalled      func() consensus.BroadcastMessenger
+	GetShardCoordinatorCalled        func() sharding.Coordinator
+	GetCryptoComponentsCalled        func() factory.CryptoComponentsHolder
+	GetCoreComponentsCalled          func() factory.CoreComponentsHolder
+	GetDataComponentsCalled          func() factory.DataComponentsHandler
+	GetStateComponentsCalled         func() factory.StateComponentsHolder
+	GetFacadeHandlerCalled           func() shared.FacadeHandler
+	GetStatusCoreComponentsCalled    func() factory.StatusCoreComponentsHolder
+	GetNetworkComponentsCalled       func() factory.NetworkComponentsHolder
+	SetKeyValueForAddressCalled      func(addressBytes []byte, state map[string]string) error
+	SetStateForAddressCalled         func(address []byte, state *dtos.AddressState) error
+	RemoveAccountCalled              func(address []byte) error
+	GetBasePeersCalled               func() map[uint32]core.PeerID
+	SetBasePeersCalled               func(basePeers map[uint32]core.PeerID)
+	TakeSnapshotCalled               func() (*dtos.NodeSnapshot, error)
+	RevertToSnapshotCalled           func(snapshot *dtos.NodeSnapshot) error
+	DumpStateCalled                  func() ([]*dtos.AddressState, error)
+	GetPendingTransactionsCalled     func() ([]*dtos.PendingTransaction, error)
+	RemovePendingTransactionCalled   func(txHash []byte) bool
+	SetTransactionsSelectionCalled   func(txHashes [][]byte) error
+	ClearTransactionsSelectionCalled func()
+	CloseCalled                      func() error
 }
 
 // ForceChangeOfEpoch -
@@ -149,6 +156,67 @@ func (mock *NodeHandlerMock) RemoveAccount(address []byte) error {
 	return nil
 }
 
+// TakeSnapshot -
+func (mock *NodeHandlerMock) TakeSnapshot() (*dtos.NodeSnapshot, error) {
+	if mock.TakeSnapshotCalled != nil {
+		return mock.TakeSnapshotCalled()
+	}
+
+	return &dtos.NodeSnapshot{}, nil
+}
+
+// RevertToSnapshot -
+func (mock *NodeHandlerMock) RevertToSnapshot(snapshot *dtos.NodeSnapshot) error {
+	if mock.RevertToSnapshotCalled != nil {
+		return mock.RevertToSnapshotCalled(snapshot)
+	}
+
+	return nil
+}
+
+// DumpState -
+func (mock *NodeHandlerMock) DumpState() ([]*dtos.AddressState, error) {
+	if mock.DumpStateCalled != nil {
+		return mock.DumpStateCalled()
+	}
+
+	return make([]*dtos.AddressState, 0), nil
+}
+
+// GetPendingTransactions -
+func (mock *NodeHandlerMock) GetPendingTransactions() ([]*dtos.PendingTransaction, error) {
+	if mock.GetPendingTransactionsCalled != nil {
+		return mock.GetPendingTransactionsCalled()
+	}
+
+	return make([]*dtos.PendingTransaction, 0), nil
+}
+
+// RemovePendingTransaction -
+func (mock *NodeHandlerMock) RemovePendingTransaction(txHash []byte) bool {
+	if mock.RemovePendingTransactionCalled != nil {
+		return mock.RemovePendingTransactionCalled(txHash)
+	}
+
+	return false
+}
+
+// SetTransactionsSelection -
+func (mock *NodeHandlerMock) SetTransactionsSelection(txHashes [][]byte) error {
+	if mock.SetTransactionsSelectionCalled != nil {
+		return mock.SetTransactionsSelectionCalled(txHashes)
+	}
+
+	return nil
+}
+
+// ClearTransactionsSelection -
+func (mock *NodeHandlerMock) ClearTransactionsSelection() {
+	if mock.ClearTransactionsSelectionCalled != nil {
+		mock.ClearTransactionsSelectionCalled()
+	}
+}
+
 // GetBasePeers -
 func (mock *NodeHandlerMock) GetBasePeers() map[uint32]core.PeerID {
 	if mock.GetBasePeersCalled != nil {
@@ -0,0 +1,52 @@
+package chainSimulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+// NewRecordedProxyServer starts an http server that replays the proxy responses recorded in the provided fixture file.
+// The fixture holds the responses indexed by the request path, including the query. The accounts missing from the
+// fixture are returned as empty accounts and the storage keys missing from the fixture as empty values, as the proxy does
+func NewRecordedProxyServer(fixtureFile string) (*httptest.Server, error) {
+	buff, err := os.ReadFile(fixtureFile)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string]json.RawMessage)
+	err = json.Unmarshal(buff, &responses)
+	if err != nil {
+		return nil, err
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		response, found := responses[r.URL.RequestURI()]
+		if found {
+			_, _ = w.Write(response)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/address/") && strings.Count(r.URL.Path, "/") == 2 {
+			address := strings.TrimPrefix(r.URL.Path, "/address/")
+			_, _ = fmt.Fprintf(w, `{"data":{"account":{"address":"%s","nonce":0,"balance":"0"}},"error":"","code":"successful"}`, address)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/address/") && strings.Contains(r.URL.Path, "/key/") {
+			_, _ = fmt.Fprint(w, `{"data":{"value":""},"error":"","code":"successful"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintf(w, `{"data":null,"error":"no recorded response for %s","code":"bad_request"}`, r.URL.RequestURI())
+	}))
+
+	return server, nil
+}
@@ -0,0 +1,32 @@
+package chainSimulator
+
+import "github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+
+// RemoteStateHandlerStub -
+type RemoteStateHandlerStub struct {
+	GetAccountStateCalled func(shardID uint32, address string) (*dtos.AddressState, error)
+	GetStorageValueCalled func(shardID uint32, address string, key []byte) ([]byte, error)
+}
+
+// GetAccountState -
+func (stub *RemoteStateHandlerStub) GetAccountState(shardID uint32, address string) (*dtos.AddressState, error) {
+	if stub.GetAccountStateCalled != nil {
+		return stub.GetAccountStateCalled(shardID, address)
+	}
+
+	return nil, nil
+}
+
+// GetStorageValue -
+func (stub *RemoteStateHandlerStub) GetStorageValue(shardID uint32, address string, key []byte) ([]byte, error) {
+	if stub.GetStorageValueCalled != nil {
+		return stub.GetStorageValueCalled(shardID, address, key)
+	}
+
+	return make([]byte, 0), nil
+}
+
+// IsInterfaceNil -
+func (stub *RemoteStateHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -120,6 +120,22 @@ var (
 		Usage: "This flag is used to specify the initial epoch when chain simulator will start",
 		Value: 0,
 	}
+	dataDir = cli.StringFlag{
+		Name:  "data-dir",
+		Usage: "This flag is used to specify the directory where the chain data is persisted. If the directory holds the data of a previous run, the chain simulator will resume from the last generated block",
+	}
+	loadStateFile = cli.StringFlag{
+		Name:  "load-state",
+		Usage: "This flag is used to specify a JSON file with the accounts state to be set when the chain simulator starts. The file can be obtained from the /simulator/dump-state endpoint",
+	}
+	forkProxyURL = cli.StringFlag{
+		Name:  "fork-proxy-url",
+		Usage: "This flag is used to specify the proxy URL of a remote network to fork. The accounts are loaded from the remote network on first access",
+	}
+	forkBlockNonce = cli.Uint64Flag{
+		Name:  "fork-block-nonce",
+		Usage: "This flag is used to specify the metachain block nonce the forked state is pinned at, when fork-proxy-url is set",
+	}
 	autoGenerateBlocks = cli.BoolFlag{
 		Name:  "auto-generate-blocks",
 		Usage: "Boolean option to specify that blocks should be generated automatically, after a given period of time",
@@ -137,6 +153,11 @@ var (
 		Name:  "fetch-configs-and-close",
 		Usage: "This flag is used to specify to fetch all configs and close the chain simulator after",
 	}
+	exportEmbeddedConfigs = cli.BoolFlag{
+		Name: "export-embedded-configs",
+		Usage: "This flag is used to write the node and proxy configs embedded in the binary in the node-configs and " +
+			"proxy-configs folders and close the chain simulator after. The folders should not exist",
+	}
 )
 
 func applyFlags(ctx *cli.Context, cfg *config.Config) {
@@ -168,6 +189,18 @@ func applyFlags(ctx *cli.Context, cfg *config.Config) {
 		cfg.Config.Simulator.InitialEpoch = uint32(ctx.GlobalUint(initialEpoch.Name))
 	}
 
+	if ctx.IsSet(dataDir.Name) {
+		cfg.Config.Simulator.DataDir = ctx.GlobalString(dataDir.Name)
+	}
+
+	if ctx.IsSet(forkProxyURL.Name) {
+		cfg.Config.Fork.ProxyURL = ctx.GlobalString(forkProxyURL.Name)
+	}
+
+	if ctx.IsSet(forkBlockNonce.Name) {
+		cfg.Config.Fork.BlockNonce = ctx.GlobalUint64(forkBlockNonce.Name)
+	}
+
 	if ctx.IsSet(autoGenerateBlocks.Name) {
 		cfg.Config.BlocksGenerator.AutoGenerateBlocks = ctx.GlobalBool(autoGenerateBlocks.Name)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -27,6 +28,7 @@ import (
 	"github.com/TerraDharitri/drt-go-chain/config/overridableConfig"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/components/api"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
 	"github.com/urfave/cli"
 )
 
@@ -78,10 +80,15 @@ func main() {
 		initialRound,
 		initialNonce,
 		initialEpoch,
+		dataDir,
+		loadStateFile,
+		forkProxyURL,
+		forkBlockNonce,
 		autoGenerateBlocks,
 		blockTimeInMs,
 		skipConfigsDownload,
 		fetchConfigsAndClose,
+		exportEmbeddedConfigs,
 		pathWhereToSaveLogs,
 	}
 
@@ -126,6 +133,10 @@ func startChainSimulator(ctx *cli.Context) error {
 	nodeConfigs := ctx.GlobalString(pathToNodeConfigs.Name)
 	proxyConfigs := ctx.GlobalString(pathToProxyConfigs.Name)
 	fetchConfigsAndCloseBool := ctx.GlobalBool(fetchConfigsAndClose.Name)
+	if ctx.GlobalBool(exportEmbeddedConfigs.Name) {
+		return exportConfigs(nodeConfigs, proxyConfigs)
+	}
+
 	err = fetchConfigs(skipDownload, cfg, nodeConfigs, proxyConfigs)
 	if err != nil {
 		return fmt.Errorf("%w while fetching configs", err)
@@ -190,6 +201,11 @@ func startChainSimulator(ctx *cli.Context) error {
 			alterConfigsError = overridableConfig.OverrideConfigValues(overrideCfg.OverridableConfigTomlValues, cfg)
 		},
 		VmQueryDelayAfterStartInMs: 0,
+		DataDir:                    cfg.Config.Simulator.DataDir,
+		Fork: chainSimulator.ForkConfig{
+			ProxyURL:   cfg.Config.Fork.ProxyURL,
+			BlockNonce: cfg.Config.Fork.BlockNonce,
+		},
 	}
 	simulator, err := chainSimulator.NewChainSimulator(argsChainSimulator)
 	if err != nil {
@@ -202,6 +218,14 @@ func startChainSimulator(ctx *cli.Context) error {
 
 	log.Info("simulators were initialized")
 
+	stateFile := ctx.GlobalString(loadStateFile.Name)
+	if len(stateFile) > 0 {
+		err = loadState(simulator, stateFile)
+		if err != nil {
+			return fmt.Errorf("%w while loading the state from %s", err, stateFile)
+		}
+	}
+
 	err = simulator.GenerateBlocks(1)
 	if err != nil {
 		return err
@@ -339,7 +363,7 @@ func fetchConfigs(skipDownload bool, cfg config.Config, nodeConfigs, proxyConfig
 	}
 
 	gitFetcher := git.NewGitFetcher()
-	configsFetcher, err := configs.NewConfigsFetcher(cfg.Config.Simulator.MxChainRepo, cfg.Config.Simulator.MxProxyRepo, gitFetcher)
+	configsFetcher, err := configs.NewConfigsFetcher(cfg.Config.Simulator.MxChainRepo, cfg.Config.Simulator.MxProxyRepo, gitFetcher, createEmbeddedConfigs())
 	if err != nil {
 		return err
 	}
@@ -352,6 +376,44 @@ func fetchConfigs(skipDownload bool, cfg config.Config, nodeConfigs, proxyConfig
 	return configsFetcher.FetchProxyConfigs(buildInfo, proxyConfigs)
 }
 
+// createEmbeddedConfigs falls back on a disabled handler when the binary was built without the embedded configs, so
+// the configs can still be fetched with git
+func createEmbeddedConfigs() configs.EmbeddedConfigsHandler {
+	embeddedConfigs, err := configs.NewEmbeddedConfigs()
+	if err != nil {
+		log.Warn("the configs can only be fetched with git", "error", err)
+		return configs.NewDisabledEmbeddedConfigs()
+	}
+
+	return embeddedConfigs
+}
+
+func exportConfigs(nodeConfigs, proxyConfigs string) error {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return errors.New("cannot read build info")
+	}
+
+	embeddedConfigs, err := configs.NewEmbeddedConfigs()
+	if err != nil {
+		return err
+	}
+
+	err = embeddedConfigs.ExtractNodeConfigs(buildInfo, nodeConfigs)
+	if err != nil {
+		return fmt.Errorf("%w while exporting the node configs", err)
+	}
+
+	err = embeddedConfigs.ExtractProxyConfigs(buildInfo, proxyConfigs)
+	if err != nil {
+		return fmt.Errorf("%w while exporting the proxy configs", err)
+	}
+
+	log.Info("the embedded configs were exported", "node configs", nodeConfigs, "proxy configs", proxyConfigs)
+
+	return nil
+}
+
 func loadMainConfig(filepath string) (config.Config, error) {
 	cfg := config.Config{}
 	err := core.LoadTomlFile(&cfg, filepath)
@@ -359,6 +421,32 @@ func loadMainConfig(filepath string) (config.Config, error) {
 	return cfg, err
 }
 
+// loadState accepts both a plain list of addresses states and the response of the dump-state endpoint
+func loadState(simulator facade.SimulatorHandler, stateFile string) error {
+	buff, err := os.ReadFile(stateFile)
+	if err != nil {
+		return err
+	}
+
+	addressesState := make([]*dtos.AddressState, 0)
+	err = json.Unmarshal(buff, &addressesState)
+	if err != nil {
+		response := struct {
+			Data []*dtos.AddressState `json:"data"`
+		}{}
+		errUnmarshal := json.Unmarshal(buff, &response)
+		if errUnmarshal != nil {
+			return err
+		}
+
+		addressesState = response.Data
+	}
+
+	log.Info("loading the state", "file", stateFile, "num accounts", len(addressesState))
+
+	return simulator.SetStateMultiple(addressesState)
+}
+
 func determineOverrideConfigFiles(ctx *cli.Context) []string {
 	overrideFiles := strings.Split(ctx.GlobalString(nodeOverrideConfigurationFile.Name), overrideConfigFilesSeparator)
 
@@ -13,6 +13,7 @@ type Config struct {
 			InitialRound      int64  `toml:"initial-round"`
 			InitialNonce      uint64 `toml:"initial-nonce"`
 			InitialEpoch      uint32 `toml:"initial-epoch"`
+			DataDir           string `toml:"data-dir"`
 			MxChainRepo       string `toml:"drt-go-chain-repo"`
 			MxProxyRepo       string `toml:"drt-go-chain-proxy-repo"`
 		} `toml:"simulator"`
@@ -23,6 +24,7 @@ type Config struct {
 			LogsPath             string `toml:"logs-path"`
 		} `toml:"logs"`
 		BlocksGenerator BlocksGeneratorConfig `toml:"blocks-generator"`
+		Fork            ForkConfig            `toml:"fork"`
 	} `toml:"config"`
 }
 
@@ -32,6 +34,12 @@ type BlocksGeneratorConfig struct {
 	BlockTimeInMs      uint64 `toml:"block-time-in-milliseconds"`
 }
 
+// ForkConfig defines the configuration for starting the chain simulator from the state of a remote network
+type ForkConfig struct {
+	ProxyURL   string `toml:"proxy-url"`
+	BlockNonce uint64 `toml:"block-nonce"`
+}
+
 // OverrideConfigs defines the struct used for the overridable configs
 type OverrideConfigs struct {
 	OverridableConfigTomlValues []config.OverridableConfig
@@ -0,0 +1,6 @@
+package dtos
+
+// TransactionsHashes is the dto holding a list of hex encoded transactions hashes
+type TransactionsHashes struct {
+	TxHashes []string `json:"txHashes"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+// SnapshotInfo is the dto that identifies a snapshot of the chain state
+type SnapshotInfo struct {
+	ID string `json:"id"`
+}
@@ -6,4 +6,7 @@ var (
 	errNilSimulatorHandler         = errors.New("nil simulator handler ")
 	errInvalidNumOfBlocks          = errors.New("num of blocks must be greater than zero")
 	errNilProxyTransactionsHandler = errors.New("nil proxy transactions handler ")
+	errEmptySnapshotID             = errors.New("empty snapshot id")
+	errInvalidNumOfSeconds         = errors.New("num of seconds must be greater than zero")
+	errEmptyListOfTransactions     = errors.New("empty list of transactions hashes")
 )
@@ -1,6 +1,8 @@
 package facade
 
 import (
+	"time"
+
 	"github.com/TerraDharitri/drt-go-chain-proxy/data"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/process"
@@ -19,6 +21,17 @@ type SimulatorHandler interface {
 	GetRestAPIInterfaces() map[uint32]string
 	ForceChangeOfEpoch() error
 	GetNodeHandler(shardID uint32) process.NodeHandler
+	TakeSnapshot() (string, error)
+	RevertToSnapshot(snapshotID string) error
+	DumpState() ([]*dtos.AddressState, error)
+	SetNextBlockTimestamp(timestamp int64) error
+	AdvanceTime(duration time.Duration) error
+	JumpToRound(round int64) error
+	GetTransactionTrace(txHash string) (*dtos.TransactionTrace, error)
+	GetPendingTransactions(shardID uint32) ([]*dtos.PendingTransaction, error)
+	RemovePendingTransactions(txHashes []string) error
+	GenerateBlockWithTransactions(txHashes []string) error
+	SubscribeToEvents(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error)
 	IsInterfaceNil() bool
 }
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-core/core/check"
@@ -199,6 +200,81 @@ func (sf *simulatorFacade) GenerateBlocksUntilTransactionIsProcessed(txHash stri
 	return errors.New("something went wrong, transaction is still in pending")
 }
 
+// TakeSnapshot will save the state of the chain and will return the snapshot identifier
+func (sf *simulatorFacade) TakeSnapshot() (*dtoc.SnapshotInfo, error) {
+	snapshotID, err := sf.simulator.TakeSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtoc.SnapshotInfo{
+		ID: snapshotID,
+	}, nil
+}
+
+// RevertToSnapshot will bring the chain back to the state saved in the provided snapshot
+func (sf *simulatorFacade) RevertToSnapshot(snapshotID string) error {
+	if snapshotID == "" {
+		return errEmptySnapshotID
+	}
+
+	return sf.simulator.RevertToSnapshot(snapshotID)
+}
+
+// DumpState will return the state of all the accounts from all the shards
+func (sf *simulatorFacade) DumpState() ([]*dtos.AddressState, error) {
+	return sf.simulator.DumpState()
+}
+
+// SetNextBlockTimestamp will set the timestamp of the next generated block on all the shards
+func (sf *simulatorFacade) SetNextBlockTimestamp(timestamp int64) error {
+	return sf.simulator.SetNextBlockTimestamp(timestamp)
+}
+
+// AdvanceTime will move the timestamps of the next generated blocks forward with the provided number of seconds
+func (sf *simulatorFacade) AdvanceTime(seconds uint64) error {
+	if seconds == 0 {
+		return errInvalidNumOfSeconds
+	}
+
+	return sf.simulator.AdvanceTime(time.Duration(seconds) * time.Second)
+}
+
+// JumpToRound will set the current round on all the shards, without generating the blocks in between
+func (sf *simulatorFacade) JumpToRound(round int64) error {
+	return sf.simulator.JumpToRound(round)
+}
+
+// GetTransactionTrace will return the smart contracts executions performed for the provided transaction hash
+func (sf *simulatorFacade) GetTransactionTrace(txHash string) (*dtos.TransactionTrace, error) {
+	return sf.simulator.GetTransactionTrace(txHash)
+}
+
+// GetPendingTransactions will return the transactions sent from the provided shard which are waiting in the pool
+func (sf *simulatorFacade) GetPendingTransactions(shardID uint32) ([]*dtos.PendingTransaction, error) {
+	return sf.simulator.GetPendingTransactions(shardID)
+}
+
+// RemovePendingTransactions will remove the provided transactions from the pools of all the shards
+func (sf *simulatorFacade) RemovePendingTransactions(txHashes []string) error {
+	if len(txHashes) == 0 {
+		return errEmptyListOfTransactions
+	}
+
+	return sf.simulator.RemovePendingTransactions(txHashes)
+}
+
+// GenerateBlockWithTransactions will generate one block including only the provided transactions, in the provided order
+func (sf *simulatorFacade) GenerateBlockWithTransactions(txHashes []string) error {
+	return sf.simulator.GenerateBlockWithTransactions(txHashes)
+}
+
+// SubscribeToEvents will return a channel receiving the blocks, the transactions and the log events which match the
+// provided filter, together with the function ending the subscription
+func (sf *simulatorFacade) SubscribeToEvents(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error) {
+	return sf.simulator.SubscribeToEvents(filter)
+}
+
 func (sf *simulatorFacade) getCurrentEpoch() uint32 {
 	return sf.simulator.GetNodeHandler(core.MetachainShardId).GetProcessComponents().EpochStartTrigger().Epoch()
 }
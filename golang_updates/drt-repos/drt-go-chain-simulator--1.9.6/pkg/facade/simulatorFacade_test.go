@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
 	"github.com/TerraDharitri/drt-go-chain-proxy/data"
@@ -413,3 +414,270 @@ func getNodeHandlerWithCurrentEpoch(epoch uint32) process.NodeHandler {
 		},
 	}
 }
+
+func TestSimulatorFacade_TakeSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("simulator error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			TakeSnapshotCalled: func() (string, error) {
+				return "", expectedErr
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		snapshotInfo, err := facade.TakeSnapshot()
+		require.Equal(t, expectedErr, err)
+		require.Nil(t, snapshotInfo)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			TakeSnapshotCalled: func() (string, error) {
+				return "1", nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		snapshotInfo, err := facade.TakeSnapshot()
+		require.NoError(t, err)
+		require.Equal(t, &dtoc.SnapshotInfo{ID: "1"}, snapshotInfo)
+	})
+}
+
+func TestSimulatorFacade_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty snapshot id should error", func(t *testing.T) {
+		t.Parallel()
+
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			RevertToSnapshotCalled: func(snapshotID string) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		err := facade.RevertToSnapshot("")
+		require.Equal(t, errEmptySnapshotID, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		revertedSnapshotID := ""
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			RevertToSnapshotCalled: func(snapshotID string) error {
+				revertedSnapshotID = snapshotID
+				return nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		err := facade.RevertToSnapshot("2")
+		require.NoError(t, err)
+		require.Equal(t, "2", revertedSnapshotID)
+	})
+}
+
+func TestSimulatorFacade_DumpState(t *testing.T) {
+	t.Parallel()
+
+	expectedState := []*dtos.AddressState{
+		{
+			Address: "drt1qtc600lryvytxuy4h7vn7xmsy5tw6vuw3tskr75cwnmv4mnyjgsq89rptv",
+			Balance: "100",
+		},
+	}
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		DumpStateCalled: func() ([]*dtos.AddressState, error) {
+			return expectedState, nil
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	addressesState, err := facade.DumpState()
+	require.NoError(t, err)
+	require.Equal(t, expectedState, addressesState)
+}
+
+func TestSimulatorFacade_SetNextBlockTimestamp(t *testing.T) {
+	t.Parallel()
+
+	providedTimestamp := int64(0)
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		SetNextBlockTimestampCalled: func(timestamp int64) error {
+			providedTimestamp = timestamp
+			return nil
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	err := facade.SetNextBlockTimestamp(1700000000)
+	require.NoError(t, err)
+	require.Equal(t, int64(1700000000), providedTimestamp)
+}
+
+func TestSimulatorFacade_AdvanceTime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero seconds should error", func(t *testing.T) {
+		t.Parallel()
+
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			AdvanceTimeCalled: func(duration time.Duration) error {
+				require.Fail(t, "should have not been called")
+				return nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		err := facade.AdvanceTime(0)
+		require.Equal(t, errInvalidNumOfSeconds, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		providedDuration := time.Duration(0)
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			AdvanceTimeCalled: func(duration time.Duration) error {
+				providedDuration = duration
+				return nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		err := facade.AdvanceTime(3600)
+		require.NoError(t, err)
+		require.Equal(t, time.Hour, providedDuration)
+	})
+}
+
+func TestSimulatorFacade_JumpToRound(t *testing.T) {
+	t.Parallel()
+
+	providedRound := int64(0)
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		JumpToRoundCalled: func(round int64) error {
+			providedRound = round
+			return nil
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	err := facade.JumpToRound(1000)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), providedRound)
+}
+
+func TestSimulatorFacade_GetTransactionTrace(t *testing.T) {
+	t.Parallel()
+
+	expectedTrace := &dtos.TransactionTrace{
+		Hash: "aabb",
+		Executions: []*dtos.ExecutionFrame{
+			{
+				Type:     "scCall",
+				Function: "add",
+			},
+		},
+	}
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		GetTransactionTraceCalled: func(txHash string) (*dtos.TransactionTrace, error) {
+			require.Equal(t, "aabb", txHash)
+			return expectedTrace, nil
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	trace, err := facade.GetTransactionTrace("aabb")
+	require.NoError(t, err)
+	require.Equal(t, expectedTrace, trace)
+}
+
+func TestSimulatorFacade_GetPendingTransactions(t *testing.T) {
+	t.Parallel()
+
+	expectedTransactions := []*dtos.PendingTransaction{
+		{
+			Hash:  "aabb",
+			Nonce: 7,
+		},
+	}
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		GetPendingTransactionsCalled: func(shardID uint32) ([]*dtos.PendingTransaction, error) {
+			require.Equal(t, uint32(1), shardID)
+			return expectedTransactions, nil
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	pendingTransactions, err := facade.GetPendingTransactions(1)
+	require.NoError(t, err)
+	require.Equal(t, expectedTransactions, pendingTransactions)
+}
+
+func TestSimulatorFacade_RemovePendingTransactions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty list of transactions should error", func(t *testing.T) {
+		t.Parallel()
+
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			RemovePendingTransactionsCalled: func(txHashes []string) error {
+				require.Fail(t, "should not have been called")
+				return nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		err := facade.RemovePendingTransactions(nil)
+		require.Equal(t, errEmptyListOfTransactions, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		wasCalled := false
+		facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+			RemovePendingTransactionsCalled: func(txHashes []string) error {
+				require.Equal(t, []string{"aa", "bb"}, txHashes)
+				wasCalled = true
+				return nil
+			},
+		}, &testscommon.TransactionHandlerMock{})
+
+		err := facade.RemovePendingTransactions([]string{"aa", "bb"})
+		require.NoError(t, err)
+		require.True(t, wasCalled)
+	})
+}
+
+func TestSimulatorFacade_GenerateBlockWithTransactions(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		GenerateBlockWithTransactionsCalled: func(txHashes []string) error {
+			require.Equal(t, []string{"bb", "aa"}, txHashes)
+			return expectedErr
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	err := facade.GenerateBlockWithTransactions([]string{"bb", "aa"})
+	require.Equal(t, expectedErr, err)
+}
+
+func TestSimulatorFacade_SubscribeToEvents(t *testing.T) {
+	t.Parallel()
+
+	expectedFilter := dtos.EventsFilter{Types: []string{"log"}, Identifier: "transfer"}
+	expectedEvents := make(chan *dtos.StreamEvent)
+	unsubscribeCalled := false
+	facade, _ := NewSimulatorFacade(&testscommon.SimulatorHandlerMock{
+		SubscribeToEventsCalled: func(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error) {
+			require.Equal(t, expectedFilter, filter)
+			return expectedEvents, func() {
+				unsubscribeCalled = true
+			}, nil
+		},
+	}, &testscommon.TransactionHandlerMock{})
+
+	events, unsubscribe, err := facade.SubscribeToEvents(expectedFilter)
+	require.Nil(t, err)
+	require.Equal(t, (<-chan *dtos.StreamEvent)(expectedEvents), events)
+
+	unsubscribe()
+	require.True(t, unsubscribeCalled)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/TerraDharitri/drt-go-chain-core/marshal"
 	logger "github.com/TerraDharitri/drt-go-chain-logger"
@@ -31,10 +32,24 @@ const (
 	forceUpdateValidatorStatistics          = "/simulator/force-reset-validator-statistics"
 	observersInfo                           = "/simulator/observers"
 	epochChange                             = "/simulator/force-epoch-change"
+	snapshotEndpoint                        = "/simulator/snapshot"
+	revertEndpoint                          = "/simulator/revert/:id"
+	dumpStateEndpoint                       = "/simulator/dump-state"
+	setNextBlockTimestampEndpoint           = "/simulator/time/set-next-block-timestamp/:timestamp"
+	advanceTimeEndpoint                     = "/simulator/time/advance/:seconds"
+	jumpToRoundEndpoint                     = "/simulator/time/jump-to-round/:round"
+	transactionTraceEndpoint                = "/simulator/transaction/:txHash/trace"
+	pendingTransactionsEndpoint             = "/simulator/mempool/:shard"
+	removePendingTransactionsEndpoint       = "/simulator/mempool/remove"
+	generateBlockWithTransactionsEndpoint   = "/simulator/generate-block-with-transactions"
+	eventsEndpoint                          = "/simulator/events"
 
 	queryParamNoGenerate   = "noGenerate"
 	queryParamTargetEpoch  = "targetEpoch"
 	queryParamMaxNumBlocks = "maxNumBlocks"
+	queryParamTypes        = "types"
+	queryParamAddress      = "address"
+	queryParamIdentifier   = "identifier"
 
 	maxNumOfBlockToGenerateUntilTxProcessed = 20
 )
@@ -68,6 +83,17 @@ func (ep *endpointsProcessor) ExtendProxyServer(httpServer *http.Server) error {
 	ws.POST(forceUpdateValidatorStatistics, ep.forceUpdateValidatorStatistics)
 	ws.GET(observersInfo, ep.getObserversInfo)
 	ws.POST(epochChange, ep.forceEpochChange)
+	ws.POST(snapshotEndpoint, ep.takeSnapshot)
+	ws.POST(revertEndpoint, ep.revertToSnapshot)
+	ws.GET(dumpStateEndpoint, ep.dumpState)
+	ws.POST(setNextBlockTimestampEndpoint, ep.setNextBlockTimestamp)
+	ws.POST(advanceTimeEndpoint, ep.advanceTime)
+	ws.POST(jumpToRoundEndpoint, ep.jumpToRound)
+	ws.GET(transactionTraceEndpoint, ep.getTransactionTrace)
+	ws.GET(pendingTransactionsEndpoint, ep.getPendingTransactions)
+	ws.POST(removePendingTransactionsEndpoint, ep.removePendingTransactions)
+	ws.POST(generateBlockWithTransactionsEndpoint, ep.generateBlockWithTransactions)
+	ws.GET(eventsEndpoint, ep.streamEvents)
 
 	serializerForLogs := &marshal.GogoProtoMarshalizer{}
 	registerLoggerWsRoute(ws, serializerForLogs)
@@ -323,3 +349,221 @@ func (ep *endpointsProcessor) forceUpdateValidatorStatistics(c *gin.Context) {
 
 	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
 }
+
+func (ep *endpointsProcessor) takeSnapshot(c *gin.Context) {
+	snapshotInfo, err := ep.facade.TakeSnapshot()
+	if err != nil {
+		shared.RespondWithInternalError(c, errors.New("cannot take snapshot"), err)
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, snapshotInfo, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) revertToSnapshot(c *gin.Context) {
+	snapshotID := c.Param("id")
+	if snapshotID == "" {
+		shared.RespondWithBadRequest(c, "invalid snapshot id")
+		return
+	}
+
+	err := ep.facade.RevertToSnapshot(snapshotID)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot revert to snapshot, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) dumpState(c *gin.Context) {
+	addressesState, err := ep.facade.DumpState()
+	if err != nil {
+		shared.RespondWithInternalError(c, errors.New("cannot dump state"), err)
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, addressesState, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) setNextBlockTimestamp(c *gin.Context) {
+	timestamp, err := strconv.ParseInt(c.Param("timestamp"), 10, 64)
+	if err != nil {
+		shared.RespondWithBadRequest(c, "cannot convert string to number")
+		return
+	}
+
+	err = ep.facade.SetNextBlockTimestamp(timestamp)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot set the next block timestamp, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) advanceTime(c *gin.Context) {
+	seconds, err := strconv.ParseUint(c.Param("seconds"), 10, 64)
+	if err != nil {
+		shared.RespondWithBadRequest(c, "cannot convert string to number")
+		return
+	}
+
+	err = ep.facade.AdvanceTime(seconds)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot advance time, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) jumpToRound(c *gin.Context) {
+	round, err := strconv.ParseInt(c.Param("round"), 10, 64)
+	if err != nil {
+		shared.RespondWithBadRequest(c, "cannot convert string to number")
+		return
+	}
+
+	err = ep.facade.JumpToRound(round)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot jump to round, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) getTransactionTrace(c *gin.Context) {
+	txHash := c.Param("txHash")
+	if txHash == "" {
+		shared.RespondWithBadRequest(c, "invalid transaction hash")
+		return
+	}
+
+	trace, err := ep.facade.GetTransactionTrace(txHash)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot get the transaction trace, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, trace, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) getPendingTransactions(c *gin.Context) {
+	shardID, err := strconv.ParseUint(c.Param("shard"), 10, 32)
+	if err != nil {
+		shared.RespondWithBadRequest(c, "cannot convert string to number")
+		return
+	}
+
+	pendingTransactions, err := ep.facade.GetPendingTransactions(uint32(shardID))
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot get the pending transactions, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, pendingTransactions, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) removePendingTransactions(c *gin.Context) {
+	transactionsHashes := &dtosc.TransactionsHashes{}
+	err := c.ShouldBindJSON(transactionsHashes)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("invalid transactions hashes structure, error: %s", err.Error()))
+		return
+	}
+
+	err = ep.facade.RemovePendingTransactions(transactionsHashes.TxHashes)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot remove the pending transactions, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) generateBlockWithTransactions(c *gin.Context) {
+	transactionsHashes := &dtosc.TransactionsHashes{}
+	err := c.ShouldBindJSON(transactionsHashes)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("invalid transactions hashes structure, error: %s", err.Error()))
+		return
+	}
+
+	err = ep.facade.GenerateBlockWithTransactions(transactionsHashes.TxHashes)
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot generate the block, error: %s", err.Error()))
+		return
+	}
+
+	shared.RespondWith(c, http.StatusOK, gin.H{}, "", data.ReturnCodeSuccess)
+}
+
+func (ep *endpointsProcessor) streamEvents(c *gin.Context) {
+	events, unsubscribe, err := ep.facade.SubscribeToEvents(getEventsFilterQueryParams(c))
+	if err != nil {
+		shared.RespondWithBadRequest(c, fmt.Sprintf("cannot subscribe to the events, error: %s", err.Error()))
+		return
+	}
+	defer unsubscribe()
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// the messages sent by the client are ignored, the reads only detect when the connection is closed
+	chanConnectionClosed := make(chan struct{})
+	go func() {
+		defer close(chanConnectionClosed)
+		for {
+			_, _, errRead := conn.ReadMessage()
+			if errRead != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "subscription ended"))
+				return
+			}
+
+			err = conn.WriteJSON(event)
+			if err != nil {
+				log.Debug("cannot send the event on the websocket connection", "error", err)
+				return
+			}
+		case <-chanConnectionClosed:
+			return
+		}
+	}
+}
+
+func getEventsFilterQueryParams(c *gin.Context) dtos.EventsFilter {
+	query := c.Request.URL.Query()
+	filter := dtos.EventsFilter{
+		Address:    query.Get(queryParamAddress),
+		Identifier: query.Get(queryParamIdentifier),
+	}
+
+	typesStr := query.Get(queryParamTypes)
+	if len(typesStr) > 0 {
+		filter.Types = strings.Split(typesStr, ",")
+	}
+
+	return filter
+}
@@ -18,5 +18,16 @@ type SimulatorFacade interface {
 	ForceUpdateValidatorStatistics() error
 	GetObserversInfo() (map[uint32]*dtosc.ObserverInfo, error)
 	ForceChangeOfEpoch(targetEpoch uint32) error
+	TakeSnapshot() (*dtosc.SnapshotInfo, error)
+	RevertToSnapshot(snapshotID string) error
+	DumpState() ([]*dtos.AddressState, error)
+	SetNextBlockTimestamp(timestamp int64) error
+	AdvanceTime(seconds uint64) error
+	JumpToRound(round int64) error
+	GetTransactionTrace(txHash string) (*dtos.TransactionTrace, error)
+	GetPendingTransactions(shardID uint32) ([]*dtos.PendingTransaction, error)
+	RemovePendingTransactions(txHashes []string) error
+	GenerateBlockWithTransactions(txHashes []string) error
+	SubscribeToEvents(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error)
 	IsInterfaceNil() bool
 }
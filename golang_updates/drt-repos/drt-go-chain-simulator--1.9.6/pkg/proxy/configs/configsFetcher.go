@@ -1,11 +1,13 @@
 package configs
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"runtime/debug"
 	"strings"
 
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	logger "github.com/TerraDharitri/drt-go-chain-logger"
 )
 
@@ -18,16 +20,23 @@ const (
 
 type fetcher struct {
 	gitFetcher      GitHandler
+	embeddedConfigs EmbeddedConfigsHandler
 	mxChainNodeRepo string
 	mxChainProxy    string
 }
 
-// NewConfigsFetcher will create a new instance of fetcher
-func NewConfigsFetcher(mxChainNodeRepo, mxChainProxy string, git GitHandler) (*fetcher, error) {
+// NewConfigsFetcher will create a new instance of fetcher. When the configs cannot be fetched with git, the configs
+// embedded in the binary are used
+func NewConfigsFetcher(mxChainNodeRepo, mxChainProxy string, git GitHandler, embeddedConfigs EmbeddedConfigsHandler) (*fetcher, error) {
+	if check.IfNil(embeddedConfigs) {
+		return nil, errNilEmbeddedConfigs
+	}
+
 	return &fetcher{
 		mxChainNodeRepo: mxChainNodeRepo,
 		mxChainProxy:    mxChainProxy,
 		gitFetcher:      git,
+		embeddedConfigs: embeddedConfigs,
 	}, nil
 }
 
@@ -44,7 +53,14 @@ func (f *fetcher) FetchProxyConfigs(info *debug.BuildInfo, pathWhereToPutConfigs
 	mxProxyTag := extractTag(info, f.mxChainProxy)
 	log.Info("fetching proxy configs...", "repo", f.mxChainProxy, "version", mxProxyTag)
 
-	return f.fetchConfigFolder(f.mxChainProxy, mxProxyTag, pathWhereToPutConfigs, appProxy)
+	err = f.fetchConfigFolder(f.mxChainProxy, mxProxyTag, pathWhereToPutConfigs, appProxy)
+	if err == nil {
+		return nil
+	}
+
+	return f.fallbackToEmbeddedConfigs(err, pathWhereToPutConfigs, func() error {
+		return f.embeddedConfigs.ExtractProxyConfigs(info, pathWhereToPutConfigs)
+	})
 }
 
 // FetchNodeConfigs will try to fetch the node configs
@@ -60,7 +76,31 @@ func (f *fetcher) FetchNodeConfigs(info *debug.BuildInfo, pathWhereToPutConfigs
 	mxNodeTag := extractTag(info, f.mxChainNodeRepo)
 	log.Info("fetching node configs...", "repo", f.mxChainNodeRepo, "version", mxNodeTag)
 
-	return f.fetchConfigFolder(f.mxChainNodeRepo, mxNodeTag, pathWhereToPutConfigs, appNode)
+	err = f.fetchConfigFolder(f.mxChainNodeRepo, mxNodeTag, pathWhereToPutConfigs, appNode)
+	if err == nil {
+		return nil
+	}
+
+	return f.fallbackToEmbeddedConfigs(err, pathWhereToPutConfigs, func() error {
+		return f.embeddedConfigs.ExtractNodeConfigs(info, pathWhereToPutConfigs)
+	})
+}
+
+func (f *fetcher) fallbackToEmbeddedConfigs(fetchErr error, pathWhereToPutConfigs string, extractEmbeddedConfigs func() error) error {
+	log.Warn("cannot fetch the configs, using the configs embedded in the binary", "error", fetchErr)
+
+	// the folder did not exist before fetching, so the partially copied configs are removed
+	err := os.RemoveAll(pathWhereToPutConfigs)
+	if err != nil {
+		return err
+	}
+
+	err = extractEmbeddedConfigs()
+	if err != nil {
+		return fmt.Errorf("%w, the embedded configs cannot be used either: %s", fetchErr, err.Error())
+	}
+
+	return nil
 }
 
 func (f *fetcher) fetchConfigFolder(repo string, version string, pathWhereToSaveConfig string, app string) error {
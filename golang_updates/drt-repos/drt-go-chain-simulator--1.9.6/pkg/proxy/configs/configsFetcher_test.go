@@ -18,6 +18,29 @@ const (
 
 var expectedErr = errors.New("expected error")
 
+func createEmbeddedConfigsNotAvailable() *testscommon.EmbeddedConfigsStub {
+	return &testscommon.EmbeddedConfigsStub{
+		ExtractNodeConfigsCalled: func(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+			return errEmbeddedConfigsNotAvailable
+		},
+		ExtractProxyConfigsCalled: func(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+			return errEmbeddedConfigsNotAvailable
+		},
+	}
+}
+
+func TestNewConfigsFetcher(t *testing.T) {
+	t.Parallel()
+
+	cf, err := NewConfigsFetcher(mxNodeRepo, mxProxyRepo, &testscommon.GitFetcherStub{}, nil)
+	require.Equal(t, errNilEmbeddedConfigs, err)
+	require.Nil(t, cf)
+
+	cf, err = NewConfigsFetcher(mxNodeRepo, mxProxyRepo, &testscommon.GitFetcherStub{}, &testscommon.EmbeddedConfigsStub{})
+	require.Nil(t, err)
+	require.NotNil(t, cf)
+}
+
 func TestConfigsFetcher(t *testing.T) {
 	t.Run("FetchProxyConfigs dir already exists should early exit", func(t *testing.T) {
 		cf, _ := NewConfigsFetcher(mxNodeRepo, mxProxyRepo, &testscommon.GitFetcherStub{
@@ -25,7 +48,7 @@ func TestConfigsFetcher(t *testing.T) {
 				require.Fail(t, "should have not been called")
 				return nil
 			},
-		})
+		}, &testscommon.EmbeddedConfigsStub{})
 
 		err := cf.FetchProxyConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -43,7 +66,7 @@ func TestConfigsFetcher(t *testing.T) {
 			CloneCalled: func(r, d string) error {
 				return expectedErr
 			},
-		})
+		}, createEmbeddedConfigsNotAvailable())
 
 		err := cf.FetchProxyConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -53,7 +76,7 @@ func TestConfigsFetcher(t *testing.T) {
 				},
 			},
 		}, dir)
-		require.Equal(t, expectedErr, err)
+		require.True(t, errors.Is(err, expectedErr))
 	})
 	t.Run("FetchProxyConfigs Checkout error should error", func(t *testing.T) {
 		dir := path.Join(t.TempDir(), "shouldWorkTest")
@@ -61,7 +84,7 @@ func TestConfigsFetcher(t *testing.T) {
 			CheckoutCalled: func(repoDir string, commitHashOrBranch string) error {
 				return expectedErr
 			},
-		})
+		}, createEmbeddedConfigsNotAvailable())
 
 		err := cf.FetchProxyConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -71,7 +94,7 @@ func TestConfigsFetcher(t *testing.T) {
 				},
 			},
 		}, dir)
-		require.Equal(t, expectedErr, err)
+		require.True(t, errors.Is(err, expectedErr))
 	})
 	t.Run("FetchProxyConfigs errors while copying should error", func(t *testing.T) {
 		dir := path.Join(t.TempDir(), "shouldWorkTest")
@@ -79,7 +102,7 @@ func TestConfigsFetcher(t *testing.T) {
 			CloneCalled: func(r, d string) error {
 				return nil
 			},
-		})
+		}, createEmbeddedConfigsNotAvailable())
 
 		err := cf.FetchProxyConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -104,7 +127,7 @@ func TestConfigsFetcher(t *testing.T) {
 
 				return nil
 			},
-		})
+		}, &testscommon.EmbeddedConfigsStub{})
 
 		err := cf.FetchProxyConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -126,7 +149,7 @@ func TestConfigsFetcher(t *testing.T) {
 				require.Fail(t, "should have not been called")
 				return nil
 			},
-		})
+		}, &testscommon.EmbeddedConfigsStub{})
 
 		err := cf.FetchNodeConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -147,7 +170,7 @@ func TestConfigsFetcher(t *testing.T) {
 
 				return nil
 			},
-		})
+		}, &testscommon.EmbeddedConfigsStub{})
 
 		err := cf.FetchNodeConfigs(&debug.BuildInfo{
 			Deps: []*debug.Module{
@@ -163,4 +186,55 @@ func TestConfigsFetcher(t *testing.T) {
 		}, dir)
 		require.Nil(t, err)
 	})
+	t.Run("FetchNodeConfigs fetch error should use the embedded configs", func(t *testing.T) {
+		dir := path.Join(t.TempDir(), "shouldWorkTest")
+		buildInfo := &debug.BuildInfo{}
+		extractCalled := false
+		cf, _ := NewConfigsFetcher(mxNodeRepo, mxProxyRepo, &testscommon.GitFetcherStub{
+			CloneCalled: func(r, d string) error {
+				return expectedErr
+			},
+		}, &testscommon.EmbeddedConfigsStub{
+			ExtractNodeConfigsCalled: func(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+				require.True(t, info == buildInfo)
+				require.Equal(t, dir, pathWhereToPutConfigs)
+				extractCalled = true
+				return nil
+			},
+		})
+
+		err := cf.FetchNodeConfigs(buildInfo, dir)
+		require.Nil(t, err)
+		require.True(t, extractCalled)
+	})
+	t.Run("FetchProxyConfigs fetch error should use the embedded configs", func(t *testing.T) {
+		dir := path.Join(t.TempDir(), "shouldWorkTest")
+		extractCalled := false
+		cf, _ := NewConfigsFetcher(mxNodeRepo, mxProxyRepo, &testscommon.GitFetcherStub{
+			CheckoutCalled: func(repoDir string, commitHashOrBranch string) error {
+				return expectedErr
+			},
+		}, &testscommon.EmbeddedConfigsStub{
+			ExtractProxyConfigsCalled: func(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+				extractCalled = true
+				return nil
+			},
+		})
+
+		err := cf.FetchProxyConfigs(&debug.BuildInfo{}, dir)
+		require.Nil(t, err)
+		require.True(t, extractCalled)
+	})
+	t.Run("FetchNodeConfigs embedded configs not available should return both errors", func(t *testing.T) {
+		dir := path.Join(t.TempDir(), "shouldWorkTest")
+		cf, _ := NewConfigsFetcher(mxNodeRepo, mxProxyRepo, &testscommon.GitFetcherStub{
+			CloneCalled: func(r, d string) error {
+				return expectedErr
+			},
+		}, createEmbeddedConfigsNotAvailable())
+
+		err := cf.FetchNodeConfigs(&debug.BuildInfo{}, dir)
+		require.True(t, errors.Is(err, expectedErr))
+		require.Contains(t, err.Error(), errEmbeddedConfigsNotAvailable.Error())
+	})
 }
@@ -0,0 +1,27 @@
+package configs
+
+import "runtime/debug"
+
+type disabledEmbeddedConfigs struct {
+}
+
+// NewDisabledEmbeddedConfigs will create a handler for a binary built without the embedded configs, so that the
+// configs can still be fetched with git
+func NewDisabledEmbeddedConfigs() *disabledEmbeddedConfigs {
+	return &disabledEmbeddedConfigs{}
+}
+
+// ExtractNodeConfigs returns an error, as there are no embedded configs
+func (dec *disabledEmbeddedConfigs) ExtractNodeConfigs(_ *debug.BuildInfo, _ string) error {
+	return errEmbeddedConfigsNotAvailable
+}
+
+// ExtractProxyConfigs returns an error, as there are no embedded configs
+func (dec *disabledEmbeddedConfigs) ExtractProxyConfigs(_ *debug.BuildInfo, _ string) error {
+	return errEmbeddedConfigsNotAvailable
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dec *disabledEmbeddedConfigs) IsInterfaceNil() bool {
+	return dec == nil
+}
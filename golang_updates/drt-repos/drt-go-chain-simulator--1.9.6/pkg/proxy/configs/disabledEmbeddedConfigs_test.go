@@ -0,0 +1,16 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisabledEmbeddedConfigs(t *testing.T) {
+	t.Parallel()
+
+	dec := NewDisabledEmbeddedConfigs()
+	require.False(t, dec.IsInterfaceNil())
+	require.Equal(t, errEmbeddedConfigsNotAvailable, dec.ExtractNodeConfigs(createBuildInfo("v1.9.6", "v1.1.57"), t.TempDir()))
+	require.Equal(t, errEmbeddedConfigsNotAvailable, dec.ExtractProxyConfigs(createBuildInfo("v1.9.6", "v1.1.57"), t.TempDir()))
+}
@@ -0,0 +1,179 @@
+package configs
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/TerraDharitri/drt-go-chain-simulator/pkg/proxy/configs/manifest"
+)
+
+//go:generate go run ./generate
+
+const bundleRoot = "bundle"
+
+// embeddedBundle holds the node and proxy configs copied by go generate from the module cache, for the versions
+// required by go.mod. When go generate was not run, it only holds the bundle's readme
+//
+//go:embed all:bundle
+var embeddedBundle embed.FS
+
+type embeddedConfigs struct {
+	bundle fs.FS
+}
+
+// NewEmbeddedConfigs will create a new instance able to extract the configs embedded in the binary. It errors when the
+// binary was built without running go generate, so the bundle holds no manifest
+func NewEmbeddedConfigs() (*embeddedConfigs, error) {
+	bundle, err := fs.Sub(embeddedBundle, bundleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return createEmbeddedConfigs(bundle)
+}
+
+func createEmbeddedConfigs(bundle fs.FS) (*embeddedConfigs, error) {
+	_, err := fs.Stat(bundle, manifest.FileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errEmbeddedConfigsNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newEmbeddedConfigs(bundle), nil
+}
+
+func newEmbeddedConfigs(bundle fs.FS) *embeddedConfigs {
+	return &embeddedConfigs{
+		bundle: bundle,
+	}
+}
+
+// ExtractNodeConfigs will write the embedded node configs in the provided folder, if they match the compiled node version
+func (ec *embeddedConfigs) ExtractNodeConfigs(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+	bundleManifest, err := ec.readManifest()
+	if err != nil {
+		return err
+	}
+
+	return ec.extract(info, manifest.NodeModulePath, bundleManifest.NodeVersion, appNode, pathWhereToPutConfigs)
+}
+
+// ExtractProxyConfigs will write the embedded proxy configs in the provided folder, if they match the compiled proxy version
+func (ec *embeddedConfigs) ExtractProxyConfigs(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+	bundleManifest, err := ec.readManifest()
+	if err != nil {
+		return err
+	}
+
+	return ec.extract(info, manifest.ProxyModulePath, bundleManifest.ProxyVersion, appProxy, pathWhereToPutConfigs)
+}
+
+func (ec *embeddedConfigs) readManifest() (*manifest.BundleManifest, error) {
+	buff, err := fs.ReadFile(ec.bundle, manifest.FileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errEmbeddedConfigsNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bundleManifest := &manifest.BundleManifest{}
+	err = json.Unmarshal(buff, bundleManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return bundleManifest, nil
+}
+
+func (ec *embeddedConfigs) extract(info *debug.BuildInfo, modulePath string, bundledVersion string, app string, pathWhereToPutConfigs string) error {
+	err := checkCompiledVersion(info, modulePath, bundledVersion)
+	if err != nil {
+		return err
+	}
+
+	exists, err := folderExists(pathWhereToPutConfigs)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", errConfigsFolderAlreadyExists, pathWhereToPutConfigs)
+	}
+
+	log.Info("extracting the embedded configs...", "module", modulePath, "version", bundledVersion)
+
+	return fs.WalkDir(ec.bundle, app, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(app, filePath)
+		if err != nil {
+			return err
+		}
+
+		destination := path.Join(pathWhereToPutConfigs, filepath.ToSlash(relPath))
+		if entry.IsDir() {
+			return os.MkdirAll(destination, os.ModePerm)
+		}
+
+		buff, err := fs.ReadFile(ec.bundle, filePath)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(destination, buff, 0644)
+	})
+}
+
+// checkCompiledVersion returns an error if the embedded configs were copied from another version than the one compiled
+// in the binary. The configs are accepted when the module is replaced by a local folder, as it has no version
+func checkCompiledVersion(info *debug.BuildInfo, modulePath string, bundledVersion string) error {
+	compiledVersion, found := getCompiledVersion(info, modulePath)
+	if !found {
+		return fmt.Errorf("%w: %s", errModuleNotCompiled, modulePath)
+	}
+
+	if len(compiledVersion) == 0 {
+		log.Warn("the module is replaced by a local folder, the embedded configs version cannot be validated",
+			"module", modulePath, "embedded configs version", bundledVersion)
+		return nil
+	}
+
+	if compiledVersion != bundledVersion {
+		return fmt.Errorf("%w for module %s: embedded configs version %s, compiled version %s",
+			errEmbeddedConfigsVersionMismatch, modulePath, bundledVersion, compiledVersion)
+	}
+
+	return nil
+}
+
+func getCompiledVersion(info *debug.BuildInfo, modulePath string) (string, bool) {
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+
+		if dep.Replace != nil {
+			return dep.Replace.Version, true
+		}
+
+		return dep.Version, true
+	}
+
+	return "", false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ec *embeddedConfigs) IsInterfaceNil() bool {
+	return ec == nil
+}
@@ -0,0 +1,178 @@
+package configs
+
+import (
+	"errors"
+	"os"
+	"path"
+	"runtime/debug"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TerraDharitri/drt-go-chain-simulator/pkg/proxy/configs/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func createBundle() fstest.MapFS {
+	return fstest.MapFS{
+		manifest.FileName: &fstest.MapFile{
+			Data: []byte(`{"nodeVersion": "v1.9.6", "proxyVersion": "v1.1.57"}`),
+		},
+		"node/config.toml": &fstest.MapFile{
+			Data: []byte("node config"),
+		},
+		"node/gasSchedules/gasScheduleV1.toml": &fstest.MapFile{
+			Data: []byte("gas schedule"),
+		},
+		"proxy/config.toml": &fstest.MapFile{
+			Data: []byte("proxy config"),
+		},
+	}
+}
+
+func createBuildInfo(nodeVersion string, proxyVersion string) *debug.BuildInfo {
+	return &debug.BuildInfo{
+		Deps: []*debug.Module{
+			{
+				Path:    manifest.NodeModulePath,
+				Version: nodeVersion,
+			},
+			{
+				Path:    manifest.ProxyModulePath,
+				Version: proxyVersion,
+			},
+		},
+	}
+}
+
+func TestNewEmbeddedConfigs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("embedded bundle", func(t *testing.T) {
+		t.Parallel()
+
+		// the readme of the bundle folder is always embedded, the manifest only after running go generate
+		_, err := embeddedBundle.Open(path.Join(bundleRoot, "README.md"))
+		require.Nil(t, err)
+
+		_, errManifest := embeddedBundle.Open(path.Join(bundleRoot, manifest.FileName))
+		ec, err := NewEmbeddedConfigs()
+		if errManifest != nil {
+			require.Equal(t, errEmbeddedConfigsNotAvailable, err)
+			require.Nil(t, ec)
+			return
+		}
+
+		require.Nil(t, err)
+		require.False(t, ec.IsInterfaceNil())
+	})
+	t.Run("bundle without manifest should error", func(t *testing.T) {
+		t.Parallel()
+
+		ec, err := createEmbeddedConfigs(fstest.MapFS{
+			"README.md": &fstest.MapFile{
+				Data: []byte("readme"),
+			},
+		})
+		require.Equal(t, errEmbeddedConfigsNotAvailable, err)
+		require.Nil(t, ec)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		ec, err := createEmbeddedConfigs(createBundle())
+		require.Nil(t, err)
+		require.False(t, ec.IsInterfaceNil())
+	})
+}
+
+func TestEmbeddedConfigs_ExtractNodeConfigs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("configs not embedded should error", func(t *testing.T) {
+		t.Parallel()
+
+		ec := newEmbeddedConfigs(fstest.MapFS{})
+		err := ec.ExtractNodeConfigs(createBuildInfo("v1.9.6", "v1.1.57"), path.Join(t.TempDir(), "node"))
+		require.Equal(t, errEmbeddedConfigsNotAvailable, err)
+	})
+	t.Run("module not compiled should error", func(t *testing.T) {
+		t.Parallel()
+
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractNodeConfigs(&debug.BuildInfo{}, path.Join(t.TempDir(), "node"))
+		require.True(t, errors.Is(err, errModuleNotCompiled))
+	})
+	t.Run("other compiled version should error", func(t *testing.T) {
+		t.Parallel()
+
+		dir := path.Join(t.TempDir(), "node")
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractNodeConfigs(createBuildInfo("v1.9.7", "v1.1.57"), dir)
+		require.True(t, errors.Is(err, errEmbeddedConfigsVersionMismatch))
+
+		exists, _ := folderExists(dir)
+		require.False(t, exists)
+	})
+	t.Run("existing folder should error", func(t *testing.T) {
+		t.Parallel()
+
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractNodeConfigs(createBuildInfo("v1.9.6", "v1.1.57"), t.TempDir())
+		require.True(t, errors.Is(err, errConfigsFolderAlreadyExists))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		dir := path.Join(t.TempDir(), "node")
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractNodeConfigs(createBuildInfo("v1.9.6", "v1.1.57"), dir)
+		require.Nil(t, err)
+
+		buff, err := os.ReadFile(path.Join(dir, "config.toml"))
+		require.Nil(t, err)
+		require.Equal(t, "node config", string(buff))
+
+		buff, err = os.ReadFile(path.Join(dir, "gasSchedules", "gasScheduleV1.toml"))
+		require.Nil(t, err)
+		require.Equal(t, "gas schedule", string(buff))
+
+		_, err = os.Stat(path.Join(dir, "proxy"))
+		require.True(t, os.IsNotExist(err))
+	})
+	t.Run("module replaced by a local folder should work", func(t *testing.T) {
+		t.Parallel()
+
+		buildInfo := createBuildInfo("v1.9.7", "v1.1.57")
+		buildInfo.Deps[0].Replace = &debug.Module{
+			Path: "../drt-go-chain",
+		}
+
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractNodeConfigs(buildInfo, path.Join(t.TempDir(), "node"))
+		require.Nil(t, err)
+	})
+}
+
+func TestEmbeddedConfigs_ExtractProxyConfigs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("other compiled version should error", func(t *testing.T) {
+		t.Parallel()
+
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractProxyConfigs(createBuildInfo("v1.9.6", "v1.1.58"), path.Join(t.TempDir(), "proxy"))
+		require.True(t, errors.Is(err, errEmbeddedConfigsVersionMismatch))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		dir := path.Join(t.TempDir(), "proxy")
+		ec := newEmbeddedConfigs(createBundle())
+		err := ec.ExtractProxyConfigs(createBuildInfo("v1.9.6", "v1.1.57"), dir)
+		require.Nil(t, err)
+
+		buff, err := os.ReadFile(path.Join(dir, "config.toml"))
+		require.Nil(t, err)
+		require.Equal(t, "proxy config", string(buff))
+	})
+}
@@ -0,0 +1,11 @@
+package configs
+
+import "errors"
+
+var (
+	errEmbeddedConfigsNotAvailable    = errors.New("the configs were not embedded in the binary, run go generate ./pkg/proxy/configs before building")
+	errEmbeddedConfigsVersionMismatch = errors.New("the embedded configs do not match the compiled version")
+	errModuleNotCompiled              = errors.New("module not found in the build info")
+	errConfigsFolderAlreadyExists     = errors.New("the configs folder already exists")
+	errNilEmbeddedConfigs             = errors.New("nil embedded configs handler")
+)
@@ -0,0 +1,153 @@
+// The generate tool copies the node and proxy configs of the versions required by go.mod from the module cache in the
+// bundle embedded in the chain simulator binary. It is run by go generate from the configs package folder:
+//
+//	go generate ./pkg/proxy/configs
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/TerraDharitri/drt-go-chain-simulator/pkg/proxy/configs/manifest"
+)
+
+const bundlePath = "bundle"
+
+type module struct {
+	Path    string
+	Version string
+	Dir     string
+	Replace *module
+}
+
+func main() {
+	err := generateBundle()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "cannot generate the configs bundle:", err)
+		os.Exit(1)
+	}
+}
+
+func generateBundle() error {
+	// the manifest is written last, so a bundle partially generated is not used
+	manifestPath := filepath.Join(bundlePath, manifest.FileName)
+	err := os.RemoveAll(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	nodeVersion, err := copyModuleConfigs(manifest.NodeModulePath, filepath.Join("cmd", "node", "config"), "node")
+	if err != nil {
+		return err
+	}
+
+	proxyVersion, err := copyModuleConfigs(manifest.ProxyModulePath, filepath.Join("cmd", "proxy", "config"), "proxy")
+	if err != nil {
+		return err
+	}
+
+	buff, err := json.MarshalIndent(&manifest.BundleManifest{
+		NodeVersion:  nodeVersion,
+		ProxyVersion: proxyVersion,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("configs bundle generated, node version %s, proxy version %s\n", nodeVersion, proxyVersion)
+
+	return os.WriteFile(manifestPath, buff, 0644)
+}
+
+func copyModuleConfigs(modulePath string, configsPath string, app string) (string, error) {
+	mod, err := getModule(modulePath)
+	if err != nil {
+		return "", err
+	}
+
+	version := mod.Version
+	if mod.Replace != nil {
+		version = mod.Replace.Version
+	}
+
+	destination := filepath.Join(bundlePath, app)
+	err = os.RemoveAll(destination)
+	if err != nil {
+		return "", err
+	}
+
+	err = copyFolder(filepath.Join(mod.Dir, configsPath), destination)
+	if err != nil {
+		return "", fmt.Errorf("%w while copying the configs of %s", err, modulePath)
+	}
+
+	return version, nil
+}
+
+func getModule(modulePath string) (*module, error) {
+	// the module is downloaded in the module cache, as go list only returns the folder of the downloaded modules
+	output, err := exec.Command("go", "mod", "download", modulePath).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%w while downloading %s: %s", err, modulePath, string(output))
+	}
+
+	output, err = exec.Command("go", "list", "-m", "-json", modulePath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w while listing %s", err, modulePath)
+	}
+
+	mod := &module{}
+	err = json.Unmarshal(output, mod)
+	if err != nil {
+		return nil, err
+	}
+	if len(mod.Dir) == 0 {
+		return nil, fmt.Errorf("the folder of %s was not found", modulePath)
+	}
+
+	return mod, nil
+}
+
+// copyFolder copies all the files of the provided folder. The files from the module cache are read only, so the copies
+// are created with the default permissions
+func copyFolder(src string, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, relPath), os.ModePerm)
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = in.Close()
+		}()
+
+		out, err := os.Create(filepath.Join(dst, relPath))
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(out, in)
+		if err != nil {
+			_ = out.Close()
+			return err
+		}
+
+		return out.Close()
+	})
+}
@@ -1,7 +1,16 @@
 package configs
 
+import "runtime/debug"
+
 // GitHandler defines what a git handler should be able to do
 type GitHandler interface {
 	Clone(repoURL, destDir string) error
 	Checkout(repoDir string, commitHashOrBranch string) error
 }
+
+// EmbeddedConfigsHandler defines what a handler of the configs embedded in the binary should be able to do
+type EmbeddedConfigsHandler interface {
+	ExtractNodeConfigs(info *debug.BuildInfo, pathWhereToPutConfigs string) error
+	ExtractProxyConfigs(info *debug.BuildInfo, pathWhereToPutConfigs string) error
+	IsInterfaceNil() bool
+}
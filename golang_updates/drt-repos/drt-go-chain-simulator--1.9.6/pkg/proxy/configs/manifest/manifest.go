@@ -0,0 +1,17 @@
+package manifest
+
+const (
+	// NodeModulePath is the path of the node module the chain simulator is compiled with
+	NodeModulePath = "github.com/TerraDharitri/drt-go-chain"
+	// ProxyModulePath is the path of the proxy module the chain simulator is compiled with
+	ProxyModulePath = "github.com/TerraDharitri/drt-go-chain-proxy"
+	// FileName is the file of the bundle holding the versions of the embedded configs
+	FileName = "manifest.json"
+)
+
+// BundleManifest holds the versions of the modules the embedded configs were copied from. It is kept apart from the
+// configs package, which does not compile before the bundle is generated, so the generate tool can use it
+type BundleManifest struct {
+	NodeVersion  string `json:"nodeVersion"`
+	ProxyVersion string `json:"proxyVersion"`
+}
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	"github.com/TerraDharitri/drt-go-chain-proxy/data"
+	dtosc "github.com/TerraDharitri/drt-go-chain-simulator/pkg/dtos"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+const (
+	queryParamNoGenerate   = "noGenerate"
+	queryParamTargetEpoch  = "targetEpoch"
+	queryParamMaxNumBlocks = "maxNumBlocks"
+	queryParamWithResults  = "withResults"
+)
+
+// apiResponse is the envelope of all the responses returned by the chain simulator
+type apiResponse struct {
+	Data  json.RawMessage `json:"data"`
+	Error string          `json:"error"`
+	Code  string          `json:"code"`
+}
+
+// Client is a typed client for the chain simulator REST API. It covers the /simulator endpoints and the proxy
+// endpoints needed to send transactions and to read the accounts
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient will create a new client for the chain simulator listening on the provided URL, e.g. http://localhost:8085
+func NewClient(simulatorURL string) (*Client, error) {
+	parsedURL, err := url.Parse(simulatorURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidURL, err.Error())
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, fmt.Errorf("%w: unsupported scheme %q", errInvalidURL, parsedURL.Scheme)
+	}
+
+	return &Client{
+		url: strings.TrimSuffix(simulatorURL, "/"),
+		// no timeout is set, as generating blocks until an epoch is reached can take a while. The requests are
+		// canceled through the provided contexts
+		httpClient: &http.Client{},
+	}, nil
+}
+
+// GenerateBlocks will generate the provided number of blocks
+func (c *Client) GenerateBlocks(ctx context.Context, numOfBlocks int) error {
+	return c.post(ctx, fmt.Sprintf("/simulator/generate-blocks/%d", numOfBlocks), nil, nil, nil)
+}
+
+// GenerateBlocksUntilEpochIsReached will generate blocks until the provided epoch is reached
+func (c *Client) GenerateBlocksUntilEpochIsReached(ctx context.Context, targetEpoch int32) error {
+	return c.post(ctx, fmt.Sprintf("/simulator/generate-blocks-until-epoch-reached/%d", targetEpoch), nil, nil, nil)
+}
+
+// GenerateBlocksUntilTransactionIsProcessed will generate blocks until the provided transaction is no longer pending,
+// at most maxNumOfBlocks blocks. A zero maxNumOfBlocks uses the limit of the chain simulator
+func (c *Client) GenerateBlocksUntilTransactionIsProcessed(ctx context.Context, txHash string, maxNumOfBlocks int) error {
+	query := url.Values{}
+	if maxNumOfBlocks > 0 {
+		query.Set(queryParamMaxNumBlocks, strconv.Itoa(maxNumOfBlocks))
+	}
+
+	return c.post(ctx, "/simulator/generate-blocks-until-transaction-processed/"+url.PathEscape(txHash), query, nil, nil)
+}
+
+// GetInitialWalletKeys returns the wallets funded at genesis
+func (c *Client) GetInitialWalletKeys(ctx context.Context) (*dtos.InitialWalletKeys, error) {
+	initialWallets := &dtos.InitialWalletKeys{}
+	err := c.get(ctx, "/simulator/initial-wallets", nil, initialWallets)
+	if err != nil {
+		return nil, err
+	}
+
+	return initialWallets, nil
+}
+
+// SetKeyValueForAddress will set the provided hex encoded key-value pairs in the storage of the provided address
+func (c *Client) SetKeyValueForAddress(ctx context.Context, address string, keyValueMap map[string]string) error {
+	return c.post(ctx, "/simulator/address/"+url.PathEscape(address)+"/set-state", nil, keyValueMap, nil)
+}
+
+// SetStateMultiple will set the provided state of the accounts. When noGenerate is false, a block is generated afterwards
+func (c *Client) SetStateMultiple(ctx context.Context, stateSlice []*dtos.AddressState, noGenerate bool) error {
+	return c.post(ctx, "/simulator/set-state", createNoGenerateQuery(noGenerate), stateSlice, nil)
+}
+
+// SetStateMultipleOverwrite will overwrite the state of the accounts, removing the storage keys not provided. When
+// noGenerate is false, a block is generated afterwards
+func (c *Client) SetStateMultipleOverwrite(ctx context.Context, stateSlice []*dtos.AddressState, noGenerate bool) error {
+	return c.post(ctx, "/simulator/set-state-overwrite", createNoGenerateQuery(noGenerate), stateSlice, nil)
+}
+
+func createNoGenerateQuery(noGenerate bool) url.Values {
+	query := url.Values{}
+	if noGenerate {
+		query.Set(queryParamNoGenerate, strconv.FormatBool(noGenerate))
+	}
+
+	return query
+}
+
+// AddValidatorKeys will add the provided validators keys to the nodes of the chain simulator
+func (c *Client) AddValidatorKeys(ctx context.Context, validatorKeys *dtosc.ValidatorKeys) error {
+	return c.post(ctx, "/simulator/add-keys", nil, validatorKeys, nil)
+}
+
+// ForceUpdateValidatorStatistics will force the reset of the validator statistics
+func (c *Client) ForceUpdateValidatorStatistics(ctx context.Context) error {
+	return c.post(ctx, "/simulator/force-reset-validator-statistics", nil, nil, nil)
+}
+
+// GetObserversInfo returns the API ports of the observers, for each shard
+func (c *Client) GetObserversInfo(ctx context.Context) (map[uint32]*dtosc.ObserverInfo, error) {
+	observersInfo := make(map[uint32]*dtosc.ObserverInfo)
+	err := c.get(ctx, "/simulator/observers", nil, &observersInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return observersInfo, nil
+}
+
+// ForceChangeOfEpoch will force the change of the epoch. A zero targetEpoch changes to the next epoch
+func (c *Client) ForceChangeOfEpoch(ctx context.Context, targetEpoch uint32) error {
+	query := url.Values{}
+	if targetEpoch > 0 {
+		query.Set(queryParamTargetEpoch, strconv.FormatUint(uint64(targetEpoch), 10))
+	}
+
+	return c.post(ctx, "/simulator/force-epoch-change", query, nil, nil)
+}
+
+// TakeSnapshot will save the state of the chain and will return the snapshot identifier
+func (c *Client) TakeSnapshot(ctx context.Context) (*dtosc.SnapshotInfo, error) {
+	snapshotInfo := &dtosc.SnapshotInfo{}
+	err := c.post(ctx, "/simulator/snapshot", nil, nil, snapshotInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshotInfo, nil
+}
+
+// RevertToSnapshot will restore the state of the chain saved by the provided snapshot
+func (c *Client) RevertToSnapshot(ctx context.Context, snapshotID string) error {
+	return c.post(ctx, "/simulator/revert/"+url.PathEscape(snapshotID), nil, nil, nil)
+}
+
+// DumpState returns the state of all the accounts
+func (c *Client) DumpState(ctx context.Context) ([]*dtos.AddressState, error) {
+	addressesState := make([]*dtos.AddressState, 0)
+	err := c.get(ctx, "/simulator/dump-state", nil, &addressesState)
+	if err != nil {
+		return nil, err
+	}
+
+	return addressesState, nil
+}
+
+// SetNextBlockTimestamp will set the timestamp of the next generated block
+func (c *Client) SetNextBlockTimestamp(ctx context.Context, timestamp int64) error {
+	return c.post(ctx, fmt.Sprintf("/simulator/time/set-next-block-timestamp/%d", timestamp), nil, nil, nil)
+}
+
+// AdvanceTime will move the time of the next generated blocks forward by the provided number of seconds
+func (c *Client) AdvanceTime(ctx context.Context, seconds uint64) error {
+	return c.post(ctx, fmt.Sprintf("/simulator/time/advance/%d", seconds), nil, nil, nil)
+}
+
+// JumpToRound will make the next generated block use the provided round
+func (c *Client) JumpToRound(ctx context.Context, round int64) error {
+	return c.post(ctx, fmt.Sprintf("/simulator/time/jump-to-round/%d", round), nil, nil, nil)
+}
+
+// GetTransactionTrace returns the execution trace of the provided transaction
+func (c *Client) GetTransactionTrace(ctx context.Context, txHash string) (*dtos.TransactionTrace, error) {
+	trace := &dtos.TransactionTrace{}
+	err := c.get(ctx, "/simulator/transaction/"+url.PathEscape(txHash)+"/trace", nil, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	return trace, nil
+}
+
+// GetPendingTransactions returns the transactions waiting in the mempool of the provided shard
+func (c *Client) GetPendingTransactions(ctx context.Context, shardID uint32) ([]*dtos.PendingTransaction, error) {
+	pendingTransactions := make([]*dtos.PendingTransaction, 0)
+	err := c.get(ctx, fmt.Sprintf("/simulator/mempool/%d", shardID), nil, &pendingTransactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingTransactions, nil
+}
+
+// RemovePendingTransactions will remove the provided transactions from the mempools
+func (c *Client) RemovePendingTransactions(ctx context.Context, txHashes []string) error {
+	return c.post(ctx, "/simulator/mempool/remove", nil, &dtosc.TransactionsHashes{TxHashes: txHashes}, nil)
+}
+
+// GenerateBlockWithTransactions will generate a block holding exactly the provided transactions, in the provided order
+func (c *Client) GenerateBlockWithTransactions(ctx context.Context, txHashes []string) error {
+	return c.post(ctx, "/simulator/generate-block-with-transactions", nil, &dtosc.TransactionsHashes{TxHashes: txHashes}, nil)
+}
+
+// GetNetworkConfig returns the network config exposed by the proxy
+func (c *Client) GetNetworkConfig(ctx context.Context) (*data.NetworkConfig, error) {
+	networkConfig := &data.NetworkConfig{}
+	err := c.get(ctx, "/network/config", nil, networkConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkConfig, nil
+}
+
+// SendUserFunds will send funds to the provided address from the proxy faucet
+func (c *Client) SendUserFunds(ctx context.Context, receiver string) error {
+	return c.post(ctx, "/transaction/send-user-funds", nil, map[string]string{"receiver": receiver}, nil)
+}
+
+// SendTransaction will send the provided transaction and will return its hash. The signature is not checked by the
+// chain simulator when the signatures bypass is enabled, so any non-empty value can be used
+func (c *Client) SendTransaction(ctx context.Context, tx *data.Transaction) (string, error) {
+	response := &data.TransactionResponseData{}
+	err := c.post(ctx, "/transaction/send", nil, tx, response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.TxHash, nil
+}
+
+// GetTransaction returns the provided transaction. When withResults is set, the smart contract results and the logs
+// are also returned
+func (c *Client) GetTransaction(ctx context.Context, txHash string, withResults bool) (*transaction.ApiTransactionResult, error) {
+	query := url.Values{}
+	if withResults {
+		query.Set(queryParamWithResults, strconv.FormatBool(withResults))
+	}
+
+	response := &data.GetTransactionResponseData{}
+	err := c.get(ctx, "/transaction/"+url.PathEscape(txHash), query, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Transaction, nil
+}
+
+// GetAccount returns the account of the provided address
+func (c *Client) GetAccount(ctx context.Context, address string) (*data.Account, error) {
+	response := &data.AccountModel{}
+	err := c.get(ctx, "/address/"+url.PathEscape(address), nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Account, nil
+}
+
+// GetStorageValue returns the hex encoded value stored under the provided hex encoded key of the provided address
+func (c *Client) GetStorageValue(ctx context.Context, address string, key string) (string, error) {
+	response := &data.AccountKeyValueResponseData{}
+	err := c.get(ctx, "/address/"+url.PathEscape(address)+"/key/"+url.PathEscape(key), nil, response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.Value, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, response interface{}) error {
+	return c.doRequest(ctx, http.MethodGet, path, query, nil, response)
+}
+
+func (c *Client) post(ctx context.Context, path string, query url.Values, body interface{}, response interface{}) error {
+	return c.doRequest(ctx, http.MethodPost, path, query, body, response)
+}
+
+func (c *Client) doRequest(ctx context.Context, method string, path string, query url.Values, body interface{}, response interface{}) error {
+	requestURL := c.url + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		buff, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(buff)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResponse, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = httpResponse.Body.Close()
+	}()
+
+	buff, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	apiResp := &apiResponse{}
+	err = json.Unmarshal(buff, apiResp)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s, status %d, cannot decode the response: %s",
+			errRequestFailed, method, path, httpResponse.StatusCode, err.Error())
+	}
+
+	isSuccessful := httpResponse.StatusCode == http.StatusOK && apiResp.Code == string(data.ReturnCodeSuccess)
+	if !isSuccessful {
+		return fmt.Errorf("%w: %s %s, status %d, code %s, error %s",
+			errRequestFailed, method, path, httpResponse.StatusCode, apiResp.Code, apiResp.Error)
+	}
+
+	if response == nil || len(apiResp.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(apiResp.Data, response)
+}
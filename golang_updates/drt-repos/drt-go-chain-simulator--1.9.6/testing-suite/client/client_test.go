@@ -0,0 +1,370 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-proxy/data"
+	dtosc "github.com/TerraDharitri/drt-go-chain-simulator/pkg/dtos"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/btcsuite/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	method string
+	uri    string
+	body   string
+}
+
+type requestsRecorder struct {
+	mut      sync.Mutex
+	requests []receivedRequest
+}
+
+func (rr *requestsRecorder) add(request receivedRequest) {
+	rr.mut.Lock()
+	rr.requests = append(rr.requests, request)
+	rr.mut.Unlock()
+}
+
+func (rr *requestsRecorder) get() []receivedRequest {
+	rr.mut.Lock()
+	defer rr.mut.Unlock()
+
+	return append([]receivedRequest{}, rr.requests...)
+}
+
+func respond(w http.ResponseWriter, status int, responseData interface{}, errMessage string) {
+	code := data.ReturnCodeSuccess
+	if status != http.StatusOK {
+		code = data.ReturnCodeRequestError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&data.GenericAPIResponse{
+		Data:  responseData,
+		Error: errMessage,
+		Code:  code,
+	})
+}
+
+// createServer returns a server answering all the requests with the provided data and recording the requests
+func createServer(t *testing.T, responseData interface{}) (*Client, *requestsRecorder) {
+	recorder := &requestsRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		recorder.add(receivedRequest{
+			method: r.Method,
+			uri:    r.URL.RequestURI(),
+			body:   string(body),
+		})
+
+		respond(w, http.StatusOK, responseData, "")
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL + "/")
+	require.Nil(t, err)
+
+	return c, recorder
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewClient("localhost:8085")
+		require.True(t, errors.Is(err, errInvalidURL))
+		require.Nil(t, c)
+
+		c, err = NewClient("http://local host")
+		require.True(t, errors.Is(err, errInvalidURL))
+		require.Nil(t, c)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewClient("http://localhost:8085/")
+		require.Nil(t, err)
+		require.Equal(t, "http://localhost:8085", c.url)
+	})
+}
+
+func TestClient_Requests(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, requests := createServer(t, struct{}{})
+
+	require.Nil(t, c.GenerateBlocks(ctx, 5))
+	require.Nil(t, c.GenerateBlocksUntilEpochIsReached(ctx, 3))
+	require.Nil(t, c.GenerateBlocksUntilTransactionIsProcessed(ctx, "0a0b", 0))
+	require.Nil(t, c.GenerateBlocksUntilTransactionIsProcessed(ctx, "0a0b", 7))
+	require.Nil(t, c.SetKeyValueForAddress(ctx, "drt1alice", map[string]string{"01": "02"}))
+	require.Nil(t, c.SetStateMultiple(ctx, []*dtos.AddressState{{Address: "drt1alice", Balance: "10"}}, false))
+	require.Nil(t, c.SetStateMultipleOverwrite(ctx, []*dtos.AddressState{{Address: "drt1alice"}}, true))
+	require.Nil(t, c.AddValidatorKeys(ctx, &dtosc.ValidatorKeys{PrivateKeysBase64: []string{"key"}}))
+	require.Nil(t, c.ForceUpdateValidatorStatistics(ctx))
+	require.Nil(t, c.ForceChangeOfEpoch(ctx, 0))
+	require.Nil(t, c.ForceChangeOfEpoch(ctx, 4))
+	require.Nil(t, c.RevertToSnapshot(ctx, "snapshot-1"))
+	require.Nil(t, c.SetNextBlockTimestamp(ctx, 1700000000))
+	require.Nil(t, c.AdvanceTime(ctx, 60))
+	require.Nil(t, c.JumpToRound(ctx, 100))
+	require.Nil(t, c.RemovePendingTransactions(ctx, []string{"0a"}))
+	require.Nil(t, c.GenerateBlockWithTransactions(ctx, []string{"0a", "0b"}))
+	require.Nil(t, c.SendUserFunds(ctx, "drt1alice"))
+
+	expectedRequests := []receivedRequest{
+		{http.MethodPost, "/simulator/generate-blocks/5", ""},
+		{http.MethodPost, "/simulator/generate-blocks-until-epoch-reached/3", ""},
+		{http.MethodPost, "/simulator/generate-blocks-until-transaction-processed/0a0b", ""},
+		{http.MethodPost, "/simulator/generate-blocks-until-transaction-processed/0a0b?maxNumBlocks=7", ""},
+		{http.MethodPost, "/simulator/address/drt1alice/set-state", `{"01":"02"}`},
+		{http.MethodPost, "/simulator/set-state", `[{"address":"drt1alice","balance":"10"}]`},
+		{http.MethodPost, "/simulator/set-state-overwrite?noGenerate=true", `[{"address":"drt1alice"}]`},
+		{http.MethodPost, "/simulator/add-keys", `{"privateKeysBase64":["key"]}`},
+		{http.MethodPost, "/simulator/force-reset-validator-statistics", ""},
+		{http.MethodPost, "/simulator/force-epoch-change", ""},
+		{http.MethodPost, "/simulator/force-epoch-change?targetEpoch=4", ""},
+		{http.MethodPost, "/simulator/revert/snapshot-1", ""},
+		{http.MethodPost, "/simulator/time/set-next-block-timestamp/1700000000", ""},
+		{http.MethodPost, "/simulator/time/advance/60", ""},
+		{http.MethodPost, "/simulator/time/jump-to-round/100", ""},
+		{http.MethodPost, "/simulator/mempool/remove", `{"txHashes":["0a"]}`},
+		{http.MethodPost, "/simulator/generate-block-with-transactions", `{"txHashes":["0a","0b"]}`},
+		{http.MethodPost, "/transaction/send-user-funds", `{"receiver":"drt1alice"}`},
+	}
+	require.Equal(t, expectedRequests, requests.get())
+}
+
+func TestClient_Responses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("initial wallets", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, &dtos.InitialWalletKeys{
+			BalanceWallets: map[uint32]*dtos.WalletKey{
+				0: {Address: dtos.WalletAddress{Bech32: "drt1alice"}},
+			},
+		})
+
+		initialWallets, err := c.GetInitialWalletKeys(ctx)
+		require.Nil(t, err)
+		require.Equal(t, "drt1alice", initialWallets.BalanceWallets[0].Address.Bech32)
+		require.Equal(t, "/simulator/initial-wallets", requests.get()[0].uri)
+	})
+	t.Run("observers", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := createServer(t, map[uint32]*dtosc.ObserverInfo{
+			0: {APIPort: 55800},
+		})
+
+		observers, err := c.GetObserversInfo(ctx)
+		require.Nil(t, err)
+		require.Equal(t, 55800, observers[0].APIPort)
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, &dtosc.SnapshotInfo{ID: "snapshot-1"})
+
+		snapshot, err := c.TakeSnapshot(ctx)
+		require.Nil(t, err)
+		require.Equal(t, "snapshot-1", snapshot.ID)
+		require.Equal(t, http.MethodPost, requests.get()[0].method)
+	})
+	t.Run("dump state", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := createServer(t, []*dtos.AddressState{{Address: "drt1alice", Balance: "10"}})
+
+		state, err := c.DumpState(ctx)
+		require.Nil(t, err)
+		require.Equal(t, []*dtos.AddressState{{Address: "drt1alice", Balance: "10"}}, state)
+	})
+	t.Run("transaction trace", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, &dtos.TransactionTrace{})
+
+		trace, err := c.GetTransactionTrace(ctx, "0a")
+		require.Nil(t, err)
+		require.NotNil(t, trace)
+		require.Equal(t, "/simulator/transaction/0a/trace", requests.get()[0].uri)
+	})
+	t.Run("pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, []*dtos.PendingTransaction{{}})
+
+		pendingTransactions, err := c.GetPendingTransactions(ctx, 1)
+		require.Nil(t, err)
+		require.Len(t, pendingTransactions, 1)
+		require.Equal(t, "/simulator/mempool/1", requests.get()[0].uri)
+	})
+	t.Run("network config", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := createServer(t, map[string]interface{}{
+			"config": map[string]interface{}{
+				"drt_chain_id":      "chain",
+				"drt_min_gas_limit": 50000,
+			},
+		})
+
+		networkConfig, err := c.GetNetworkConfig(ctx)
+		require.Nil(t, err)
+		require.Equal(t, "chain", networkConfig.Config.ChainID)
+		require.Equal(t, uint64(50000), networkConfig.Config.MinGasLimit)
+	})
+	t.Run("send transaction", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, &data.TransactionResponseData{TxHash: "0a"})
+
+		txHash, err := c.SendTransaction(ctx, &data.Transaction{Nonce: 1, Value: "10", Sender: "drt1alice"})
+		require.Nil(t, err)
+		require.Equal(t, "0a", txHash)
+		require.Equal(t, "/transaction/send", requests.get()[0].uri)
+
+		tx := &data.Transaction{}
+		require.Nil(t, json.Unmarshal([]byte(requests.get()[0].body), tx))
+		require.Equal(t, uint64(1), tx.Nonce)
+		require.Equal(t, "drt1alice", tx.Sender)
+	})
+	t.Run("get transaction", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, map[string]interface{}{
+			"transaction": map[string]interface{}{
+				"hash":   "0a",
+				"status": "success",
+			},
+		})
+
+		tx, err := c.GetTransaction(ctx, "0a", true)
+		require.Nil(t, err)
+		require.Equal(t, "0a", tx.Hash)
+		require.Equal(t, "success", tx.Status.String())
+		require.Equal(t, "/transaction/0a?withResults=true", requests.get()[0].uri)
+	})
+	t.Run("get account", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, &data.AccountModel{
+			Account: data.Account{Address: "drt1alice", Nonce: 3, Balance: "10"},
+		})
+
+		account, err := c.GetAccount(ctx, "drt1alice")
+		require.Nil(t, err)
+		require.Equal(t, uint64(3), account.Nonce)
+		require.Equal(t, "10", account.Balance)
+		require.Equal(t, "/address/drt1alice", requests.get()[0].uri)
+	})
+	t.Run("get storage value", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, &data.AccountKeyValueResponseData{Value: "02"})
+
+		value, err := c.GetStorageValue(ctx, "drt1alice", "01")
+		require.Nil(t, err)
+		require.Equal(t, "02", value)
+		require.Equal(t, "/address/drt1alice/key/01", requests.get()[0].uri)
+	})
+}
+
+func TestClient_RequestFailedShouldError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respond(w, http.StatusBadRequest, nil, "cannot generate blocks")
+		}))
+		defer server.Close()
+
+		c, _ := NewClient(server.URL)
+		err := c.GenerateBlocks(context.Background(), 1)
+		require.True(t, errors.Is(err, errRequestFailed))
+		require.Contains(t, err.Error(), "cannot generate blocks")
+	})
+	t.Run("not a json response", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		c, _ := NewClient(server.URL)
+		_, err := c.GetAccount(context.Background(), "drt1alice")
+		require.True(t, errors.Is(err, errRequestFailed))
+		require.Contains(t, err.Error(), "status 404")
+	})
+	t.Run("canceled context", func(t *testing.T) {
+		t.Parallel()
+
+		c, requests := createServer(t, struct{}{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := c.GenerateBlocks(ctx, 1)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Empty(t, requests.get())
+	})
+}
+
+func TestClient_SubscribeToEvents(t *testing.T) {
+	t.Parallel()
+
+	receivedQuery := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedQuery <- r.URL.RawQuery
+
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		_ = conn.WriteJSON(&dtos.StreamEvent{ID: 1, Type: "block", Block: &dtos.BlockEvent{Nonce: 5}})
+		_ = conn.WriteJSON(&dtos.StreamEvent{ID: 2, Type: "log", Log: &dtos.LogEvent{Identifier: "transfer"}})
+
+		// waits for the client to close the connection
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(server.URL)
+	subscription, err := c.SubscribeToEvents(dtos.EventsFilter{Types: []string{"block", "log"}, Address: "drt1alice"})
+	require.Nil(t, err)
+	require.Equal(t, "address=drt1alice&types=block%2Clog", <-receivedQuery)
+
+	event := <-subscription.Events()
+	require.Equal(t, uint64(5), event.Block.Nonce)
+	event = <-subscription.Events()
+	require.Equal(t, "transfer", event.Log.Identifier)
+
+	require.Nil(t, subscription.Close())
+	require.Nil(t, subscription.Close())
+
+	_, isOpen := <-subscription.Events()
+	require.False(t, isOpen)
+}
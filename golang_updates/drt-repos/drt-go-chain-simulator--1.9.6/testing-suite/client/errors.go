@@ -0,0 +1,8 @@
+package client
+
+import "errors"
+
+var (
+	errInvalidURL    = errors.New("invalid chain simulator URL")
+	errRequestFailed = errors.New("chain simulator request failed")
+)
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/btcsuite/websocket"
+)
+
+const (
+	queryParamTypes      = "types"
+	queryParamAddress    = "address"
+	queryParamIdentifier = "identifier"
+
+	eventsBufferSize = 1000
+)
+
+// EventsSubscription receives the events streamed by the chain simulator on a websocket connection
+type EventsSubscription struct {
+	conn      *websocket.Conn
+	events    chan *dtos.StreamEvent
+	chanClose chan struct{}
+	closeOnce sync.Once
+}
+
+// SubscribeToEvents will open a websocket connection receiving the blocks, transactions and logs generated by the
+// chain simulator that match the provided filter
+func (c *Client) SubscribeToEvents(filter dtos.EventsFilter) (*EventsSubscription, error) {
+	eventsURL, err := c.createEventsURL(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, response, err := websocket.DefaultDialer.Dial(eventsURL, nil)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf("%w: cannot subscribe to the events, status %d, error %s",
+				errRequestFailed, response.StatusCode, err.Error())
+		}
+
+		return nil, err
+	}
+
+	subscription := &EventsSubscription{
+		conn:      conn,
+		events:    make(chan *dtos.StreamEvent, eventsBufferSize),
+		chanClose: make(chan struct{}),
+	}
+	go subscription.readEvents()
+
+	return subscription, nil
+}
+
+func (c *Client) createEventsURL(filter dtos.EventsFilter) (string, error) {
+	eventsURL, err := url.Parse(c.url + "/simulator/events")
+	if err != nil {
+		return "", err
+	}
+
+	eventsURL.Scheme = "ws"
+	if strings.HasPrefix(c.url, "https") {
+		eventsURL.Scheme = "wss"
+	}
+
+	query := url.Values{}
+	if len(filter.Types) > 0 {
+		query.Set(queryParamTypes, strings.Join(filter.Types, ","))
+	}
+	if len(filter.Address) > 0 {
+		query.Set(queryParamAddress, filter.Address)
+	}
+	if len(filter.Identifier) > 0 {
+		query.Set(queryParamIdentifier, filter.Identifier)
+	}
+	eventsURL.RawQuery = query.Encode()
+
+	return eventsURL.String(), nil
+}
+
+func (es *EventsSubscription) readEvents() {
+	defer close(es.events)
+
+	for {
+		event := &dtos.StreamEvent{}
+		err := es.conn.ReadJSON(event)
+		if err != nil {
+			return
+		}
+
+		select {
+		case es.events <- event:
+		case <-es.chanClose:
+			return
+		}
+	}
+}
+
+// Events returns the channel receiving the events. The channel is closed when the connection ends
+func (es *EventsSubscription) Events() <-chan *dtos.StreamEvent {
+	return es.events
+}
+
+// Close will close the websocket connection
+func (es *EventsSubscription) Close() error {
+	var err error
+	es.closeOnce.Do(func() {
+		close(es.chanClose)
+		_ = es.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		err = es.conn.Close()
+	})
+
+	return err
+}
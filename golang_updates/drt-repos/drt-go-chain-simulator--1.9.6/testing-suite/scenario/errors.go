@@ -0,0 +1,12 @@
+package scenario
+
+import "errors"
+
+var (
+	errNilSimulatorClient = errors.New("nil simulator client")
+	errNilScenario        = errors.New("nil scenario")
+	errInvalidScenario    = errors.New("invalid scenario")
+	errUnknownTransaction = errors.New("unknown transaction")
+	errTransactionNotSeen = errors.New("transaction not received by the chain simulator")
+	errAssertionFailed    = errors.New("assertion failed")
+)
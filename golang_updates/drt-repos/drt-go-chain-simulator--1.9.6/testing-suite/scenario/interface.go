@@ -0,0 +1,21 @@
+package scenario
+
+import (
+	"context"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	"github.com/TerraDharitri/drt-go-chain-proxy/data"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+// SimulatorClient defines what a chain simulator client should be able to do for running the scenarios
+type SimulatorClient interface {
+	GenerateBlocks(ctx context.Context, numOfBlocks int) error
+	GenerateBlocksUntilTransactionIsProcessed(ctx context.Context, txHash string, maxNumOfBlocks int) error
+	SetStateMultiple(ctx context.Context, stateSlice []*dtos.AddressState, noGenerate bool) error
+	GetNetworkConfig(ctx context.Context) (*data.NetworkConfig, error)
+	SendTransaction(ctx context.Context, tx *data.Transaction) (string, error)
+	GetTransaction(ctx context.Context, txHash string, withResults bool) (*transaction.ApiTransactionResult, error)
+	GetAccount(ctx context.Context, address string) (*data.Account, error)
+	GetStorageValue(ctx context.Context, address string, key string) (string, error)
+}
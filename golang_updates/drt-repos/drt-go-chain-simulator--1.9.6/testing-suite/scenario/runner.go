@@ -0,0 +1,399 @@
+package scenario
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+	"github.com/TerraDharitri/drt-go-chain-proxy/data"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+var log = logger.GetOrCreate("testing-suite/scenario")
+
+const (
+	// gasPerDataByte is the gas added to the minimum gas limit for each byte of the data field
+	gasPerDataByte = 1500
+	// the signatures are not checked when the chain simulator runs with the signatures bypass, which is the default
+	dummySignature = "64756d6d79"
+
+	maxPollAttempts = 50
+	pollInterval    = 100 * time.Millisecond
+)
+
+type runner struct {
+	client SimulatorClient
+}
+
+// scenarioRun holds the state built while running the steps of a scenario
+type scenarioRun struct {
+	client        SimulatorClient
+	scenario      *Scenario
+	networkConfig *data.NetworkConfig
+	nonces        map[string]uint64
+	txHashes      map[string]string
+}
+
+// NewRunner will create a new scenarios runner using the provided chain simulator client
+func NewRunner(client SimulatorClient) (*runner, error) {
+	if check.IfNilReflect(client) {
+		return nil, errNilSimulatorClient
+	}
+
+	return &runner{
+		client: client,
+	}, nil
+}
+
+// Run will execute the steps of the provided scenario, stopping at the first failed step
+func (r *runner) Run(ctx context.Context, scenario *Scenario) error {
+	if scenario == nil {
+		return errNilScenario
+	}
+
+	run := &scenarioRun{
+		client:   r.client,
+		scenario: scenario,
+		nonces:   make(map[string]uint64),
+		txHashes: make(map[string]string),
+	}
+
+	for idx, step := range scenario.Steps {
+		log.Debug("running scenario step", "scenario", scenario.Name, "step", idx, "name", step.Name)
+
+		err := run.executeStep(ctx, step)
+		if err != nil {
+			return fmt.Errorf("scenario %s, step %d (%s): %w", scenario.Name, idx, step.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (run *scenarioRun) executeStep(ctx context.Context, step *Step) error {
+	switch {
+	case step.SetState != nil:
+		return run.setState(ctx, step.SetState)
+	case step.SendTx != nil:
+		return run.sendTransaction(ctx, step.SendTx)
+	case step.GenerateBlocks != nil:
+		return run.client.GenerateBlocks(ctx, *step.GenerateBlocks)
+	case len(step.ProcessTx) > 0:
+		return run.processTransaction(ctx, step.ProcessTx)
+	case step.AssertAccount != nil:
+		return run.assertAccount(ctx, step.AssertAccount)
+	case step.AssertStorage != nil:
+		return run.assertStorage(ctx, step.AssertStorage)
+	case step.AssertTx != nil:
+		return run.assertTransaction(ctx, step.AssertTx)
+	default:
+		return fmt.Errorf("%w: the step holds no action", errInvalidScenario)
+	}
+}
+
+// resolveAddress returns the address of the provided account name, or the provided value if it is not an account name
+func (run *scenarioRun) resolveAddress(nameOrAddress string) string {
+	address, found := run.scenario.Accounts[nameOrAddress]
+	if found {
+		return address
+	}
+
+	return nameOrAddress
+}
+
+// resolveTxHash returns the hash of the transaction sent with the provided identifier, or the provided value if no
+// transaction was sent with this identifier
+func (run *scenarioRun) resolveTxHash(idOrHash string) string {
+	txHash, found := run.txHashes[idOrHash]
+	if found {
+		return txHash
+	}
+
+	return idOrHash
+}
+
+func (run *scenarioRun) setState(ctx context.Context, stateSlice []*dtos.AddressState) error {
+	resolvedStateSlice := make([]*dtos.AddressState, 0, len(stateSlice))
+	for _, state := range stateSlice {
+		resolvedState := *state
+		resolvedState.Address = run.resolveAddress(state.Address)
+		if len(state.Owner) > 0 {
+			resolvedState.Owner = run.resolveAddress(state.Owner)
+		}
+
+		resolvedStateSlice = append(resolvedStateSlice, &resolvedState)
+
+		// the nonce might have been changed, it will be fetched again on the next transaction
+		delete(run.nonces, resolvedState.Address)
+	}
+
+	return run.client.SetStateMultiple(ctx, resolvedStateSlice, false)
+}
+
+func (run *scenarioRun) sendTransaction(ctx context.Context, txStep *TransactionStep) error {
+	if len(txStep.ID) > 0 {
+		_, found := run.txHashes[txStep.ID]
+		if found {
+			return fmt.Errorf("%w: transaction identifier %s is already used", errInvalidScenario, txStep.ID)
+		}
+	}
+
+	tx, err := run.createTransaction(ctx, txStep)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := run.client.SendTransaction(ctx, tx)
+	if err != nil {
+		return err
+	}
+	run.nonces[tx.Sender] = tx.Nonce + 1
+
+	if len(txStep.ID) > 0 {
+		run.txHashes[txStep.ID] = txHash
+	}
+	log.Debug("scenario transaction sent", "id", txStep.ID, "hash", txHash)
+
+	return run.waitTransactionReceived(ctx, txHash)
+}
+
+func (run *scenarioRun) createTransaction(ctx context.Context, txStep *TransactionStep) (*data.Transaction, error) {
+	networkConfig, err := run.getNetworkConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := run.resolveAddress(txStep.Sender)
+	nonce, err := run.getNonce(ctx, sender, txStep.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &data.Transaction{
+		Nonce:     nonce,
+		Value:     txStep.Value,
+		Receiver:  run.resolveAddress(txStep.Receiver),
+		Sender:    sender,
+		GasPrice:  txStep.GasPrice,
+		GasLimit:  txStep.GasLimit,
+		Signature: dummySignature,
+		ChainID:   networkConfig.Config.ChainID,
+		Version:   networkConfig.Config.MinTransactionVersion,
+	}
+	if len(txStep.Data) > 0 {
+		tx.Data = []byte(txStep.Data)
+	}
+	if len(tx.Value) == 0 {
+		tx.Value = "0"
+	}
+	if tx.GasPrice == 0 {
+		tx.GasPrice = networkConfig.Config.MinGasPrice
+	}
+	if tx.GasLimit == 0 {
+		tx.GasLimit = networkConfig.Config.MinGasLimit + uint64(len(tx.Data))*gasPerDataByte
+	}
+
+	return tx, nil
+}
+
+func (run *scenarioRun) getNetworkConfig(ctx context.Context) (*data.NetworkConfig, error) {
+	if run.networkConfig != nil {
+		return run.networkConfig, nil
+	}
+
+	networkConfig, err := run.client.GetNetworkConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	run.networkConfig = networkConfig
+
+	return networkConfig, nil
+}
+
+// getNonce returns the provided nonce if set. Otherwise, it returns the nonce following the transactions already sent
+// by the scenario, or the account nonce for the first transaction of the sender
+func (run *scenarioRun) getNonce(ctx context.Context, sender string, providedNonce *uint64) (uint64, error) {
+	if providedNonce != nil {
+		return *providedNonce, nil
+	}
+
+	nonce, found := run.nonces[sender]
+	if found {
+		return nonce, nil
+	}
+
+	account, err := run.client.GetAccount(ctx, sender)
+	if err != nil {
+		return 0, err
+	}
+
+	return account.Nonce, nil
+}
+
+// waitTransactionReceived waits until the sent transaction reaches the mempool, as the blocks generated before would
+// not include it
+func (run *scenarioRun) waitTransactionReceived(ctx context.Context, txHash string) error {
+	for i := 0; i < maxPollAttempts; i++ {
+		_, err := run.client.GetTransaction(ctx, txHash, false)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("%w: %s", errTransactionNotSeen, txHash)
+}
+
+func (run *scenarioRun) processTransaction(ctx context.Context, txID string) error {
+	txHash, found := run.txHashes[txID]
+	if !found {
+		return fmt.Errorf("%w: %s", errUnknownTransaction, txID)
+	}
+
+	return run.client.GenerateBlocksUntilTransactionIsProcessed(ctx, txHash, 0)
+}
+
+func (run *scenarioRun) assertAccount(ctx context.Context, assertion *AccountAssertion) error {
+	address := run.resolveAddress(assertion.Address)
+	account, err := run.client.GetAccount(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	if len(assertion.Balance) > 0 {
+		err = checkBalance(assertion.Balance, account.Balance)
+		if err != nil {
+			return fmt.Errorf("%w for %s", err, assertion.Address)
+		}
+	}
+
+	if assertion.Nonce != nil && *assertion.Nonce != account.Nonce {
+		return fmt.Errorf("%w: nonce of %s, expected %d, actual %d",
+			errAssertionFailed, assertion.Address, *assertion.Nonce, account.Nonce)
+	}
+
+	return nil
+}
+
+func checkBalance(expectedBalance string, actualBalance string) error {
+	expected, ok := big.NewInt(0).SetString(expectedBalance, 10)
+	if !ok {
+		return fmt.Errorf("%w: invalid expected balance %s", errInvalidScenario, expectedBalance)
+	}
+
+	actual, ok := big.NewInt(0).SetString(actualBalance, 10)
+	if !ok || expected.Cmp(actual) != 0 {
+		return fmt.Errorf("%w: balance, expected %s, actual %s", errAssertionFailed, expectedBalance, actualBalance)
+	}
+
+	return nil
+}
+
+func (run *scenarioRun) assertStorage(ctx context.Context, assertion *StorageAssertion) error {
+	address := run.resolveAddress(assertion.Address)
+	value, err := run.client.GetStorageValue(ctx, address, strings.ToLower(assertion.Key))
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(assertion.Value, value) {
+		return fmt.Errorf("%w: storage of %s under key %s, expected %s, actual %s",
+			errAssertionFailed, assertion.Address, assertion.Key, assertion.Value, value)
+	}
+
+	return nil
+}
+
+func (run *scenarioRun) assertTransaction(ctx context.Context, assertion *TransactionAssertion) error {
+	txHash := run.resolveTxHash(assertion.Tx)
+	tx, err := run.client.GetTransaction(ctx, txHash, true)
+	if err != nil {
+		return err
+	}
+
+	if len(assertion.Status) > 0 && assertion.Status != tx.Status.String() {
+		return fmt.Errorf("%w: status of transaction %s, expected %s, actual %s",
+			errAssertionFailed, assertion.Tx, assertion.Status, tx.Status.String())
+	}
+
+	events := getTransactionEvents(tx)
+	for _, expectedEvent := range assertion.Events {
+		if !run.containsEvent(events, expectedEvent) {
+			return fmt.Errorf("%w: transaction %s did not generate the event %s",
+				errAssertionFailed, assertion.Tx, describeEvent(expectedEvent))
+		}
+	}
+
+	return nil
+}
+
+// getTransactionEvents returns the events generated by the transaction and by its smart contract results
+func getTransactionEvents(tx *transaction.ApiTransactionResult) []*transaction.Events {
+	events := make([]*transaction.Events, 0)
+	if tx.Logs != nil {
+		events = append(events, tx.Logs.Events...)
+	}
+
+	for _, scr := range tx.SmartContractResults {
+		if scr.Logs != nil {
+			events = append(events, scr.Logs.Events...)
+		}
+	}
+
+	return events
+}
+
+func (run *scenarioRun) containsEvent(events []*transaction.Events, expectedEvent *ExpectedEvent) bool {
+	for _, event := range events {
+		if run.eventMatches(event, expectedEvent) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (run *scenarioRun) eventMatches(event *transaction.Events, expectedEvent *ExpectedEvent) bool {
+	if event == nil {
+		return false
+	}
+	if len(expectedEvent.Address) > 0 && run.resolveAddress(expectedEvent.Address) != event.Address {
+		return false
+	}
+	if len(expectedEvent.Identifier) > 0 && expectedEvent.Identifier != event.Identifier {
+		return false
+	}
+	if len(expectedEvent.Data) > 0 && !strings.EqualFold(expectedEvent.Data, hex.EncodeToString(event.Data)) {
+		return false
+	}
+	if len(expectedEvent.Topics) == 0 {
+		return true
+	}
+	if len(expectedEvent.Topics) != len(event.Topics) {
+		return false
+	}
+
+	for idx, topic := range event.Topics {
+		if !strings.EqualFold(expectedEvent.Topics[idx], hex.EncodeToString(topic)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeEvent(event *ExpectedEvent) string {
+	return fmt.Sprintf("{address: %s, identifier: %s, topics: [%s], data: %s}",
+		event.Address, event.Identifier, strings.Join(event.Topics, ", "), event.Data)
+}
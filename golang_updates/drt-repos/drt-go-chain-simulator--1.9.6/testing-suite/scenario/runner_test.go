@@ -0,0 +1,270 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	"github.com/TerraDharitri/drt-go-chain-proxy/data"
+	"github.com/TerraDharitri/drt-go-chain-simulator/testscommon"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"github.com/stretchr/testify/require"
+)
+
+func createNetworkConfig() *data.NetworkConfig {
+	networkConfig := &data.NetworkConfig{}
+	networkConfig.Config.ChainID = "chain"
+	networkConfig.Config.MinGasLimit = 50000
+	networkConfig.Config.MinGasPrice = 1000000000
+	networkConfig.Config.MinTransactionVersion = 2
+
+	return networkConfig
+}
+
+func runScenario(t *testing.T, client SimulatorClient, scenarioJSON string) error {
+	scenario, err := ParseJSON([]byte(scenarioJSON))
+	require.Nil(t, err)
+
+	scenarioRunner, err := NewRunner(client)
+	require.Nil(t, err)
+
+	return scenarioRunner.Run(context.Background(), scenario)
+}
+
+func TestNewRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil client should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenarioRunner, err := NewRunner(nil)
+		require.Equal(t, errNilSimulatorClient, err)
+		require.Nil(t, scenarioRunner)
+
+		var client *testscommon.SimulatorClientStub
+		scenarioRunner, err = NewRunner(client)
+		require.Equal(t, errNilSimulatorClient, err)
+		require.Nil(t, scenarioRunner)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		scenarioRunner, err := NewRunner(&testscommon.SimulatorClientStub{})
+		require.Nil(t, err)
+		require.NotNil(t, scenarioRunner)
+	})
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil scenario should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenarioRunner, _ := NewRunner(&testscommon.SimulatorClientStub{})
+		err := scenarioRunner.Run(context.Background(), nil)
+		require.Equal(t, errNilScenario, err)
+	})
+	t.Run("should resolve the accounts when setting the state", func(t *testing.T) {
+		t.Parallel()
+
+		var receivedState []*dtos.AddressState
+		client := &testscommon.SimulatorClientStub{
+			SetStateMultipleCalled: func(stateSlice []*dtos.AddressState, noGenerate bool) error {
+				require.False(t, noGenerate)
+				receivedState = stateSlice
+				return nil
+			},
+		}
+
+		err := runScenario(t, client, `{
+			"accounts": {"alice": "drt1alice", "bob": "drt1bob"},
+			"steps": [{"setState": [{"address": "alice", "balance": "10", "ownerAddress": "bob"}, {"address": "drt1carol"}]}]
+		}`)
+		require.Nil(t, err)
+		require.Equal(t, []*dtos.AddressState{
+			{Address: "drt1alice", Balance: "10", Owner: "drt1bob"},
+			{Address: "drt1carol"},
+		}, receivedState)
+	})
+	t.Run("should send the transactions with the computed fields and process them", func(t *testing.T) {
+		t.Parallel()
+
+		numGetAccountCalls := 0
+		numGetTransactionCalls := 0
+		sentTransactions := make([]*data.Transaction, 0)
+		processedTransactions := make([]string, 0)
+		client := &testscommon.SimulatorClientStub{
+			GetNetworkConfigCalled: func() (*data.NetworkConfig, error) {
+				return createNetworkConfig(), nil
+			},
+			GetAccountCalled: func(address string) (*data.Account, error) {
+				numGetAccountCalls++
+				require.Equal(t, "drt1alice", address)
+				return &data.Account{Nonce: 7}, nil
+			},
+			SendTransactionCalled: func(tx *data.Transaction) (string, error) {
+				sentTransactions = append(sentTransactions, tx)
+				return fmt.Sprintf("hash%d", len(sentTransactions)), nil
+			},
+			GetTransactionCalled: func(txHash string, withResults bool) (*transaction.ApiTransactionResult, error) {
+				numGetTransactionCalls++
+				if numGetTransactionCalls == 1 {
+					return nil, errors.New("transaction not found")
+				}
+				return &transaction.ApiTransactionResult{}, nil
+			},
+			GenerateBlocksUntilTransactionIsProcessedCalled: func(txHash string, maxNumOfBlocks int) error {
+				processedTransactions = append(processedTransactions, txHash)
+				return nil
+			},
+		}
+
+		err := runScenario(t, client, `{
+			"accounts": {"alice": "drt1alice", "bob": "drt1bob"},
+			"steps": [
+				{"sendTx": {"id": "first", "sender": "alice", "receiver": "bob", "value": "10"}},
+				{"sendTx": {"id": "second", "sender": "alice", "receiver": "drt1carol", "data": "test", "gasLimit": 0}},
+				{"sendTx": {"sender": "alice", "receiver": "bob", "nonce": 20, "gasPrice": 5, "gasLimit": 600000}},
+				{"processTx": "second"},
+				{"processTx": "first"}
+			]
+		}`)
+		require.Nil(t, err)
+		require.Equal(t, 1, numGetAccountCalls)
+		require.Equal(t, []string{"hash2", "hash1"}, processedTransactions)
+
+		require.Equal(t, &data.Transaction{
+			Nonce:     7,
+			Value:     "10",
+			Receiver:  "drt1bob",
+			Sender:    "drt1alice",
+			GasPrice:  1000000000,
+			GasLimit:  50000,
+			Signature: dummySignature,
+			ChainID:   "chain",
+			Version:   2,
+		}, sentTransactions[0])
+
+		require.Equal(t, uint64(8), sentTransactions[1].Nonce)
+		require.Equal(t, "0", sentTransactions[1].Value)
+		require.Equal(t, "drt1carol", sentTransactions[1].Receiver)
+		require.Equal(t, []byte("test"), sentTransactions[1].Data)
+		require.Equal(t, uint64(50000+4*gasPerDataByte), sentTransactions[1].GasLimit)
+
+		require.Equal(t, uint64(20), sentTransactions[2].Nonce)
+		require.Equal(t, uint64(5), sentTransactions[2].GasPrice)
+		require.Equal(t, uint64(600000), sentTransactions[2].GasLimit)
+	})
+	t.Run("duplicated transaction identifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := runScenario(t, &testscommon.SimulatorClientStub{}, `{"steps": [
+			{"sendTx": {"id": "transfer", "sender": "drt1alice", "receiver": "drt1bob"}},
+			{"sendTx": {"id": "transfer", "sender": "drt1alice", "receiver": "drt1bob"}}
+		]}`)
+		require.True(t, errors.Is(err, errInvalidScenario))
+	})
+	t.Run("processing an unknown transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := runScenario(t, &testscommon.SimulatorClientStub{}, `{"steps": [{"processTx": "transfer"}]}`)
+		require.True(t, errors.Is(err, errUnknownTransaction))
+	})
+	t.Run("should stop at the first failed step", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		numGenerateBlocksCalls := 0
+		client := &testscommon.SimulatorClientStub{
+			GenerateBlocksCalled: func(numOfBlocks int) error {
+				numGenerateBlocksCalls++
+				require.Equal(t, 3, numOfBlocks)
+				return expectedErr
+			},
+		}
+
+		err := runScenario(t, client, `{"name": "blocks", "steps": [
+			{"name": "first", "generateBlocks": 3},
+			{"name": "second", "generateBlocks": 3}
+		]}`)
+		require.True(t, errors.Is(err, expectedErr))
+		require.Contains(t, err.Error(), "scenario blocks, step 0 (first)")
+		require.Equal(t, 1, numGenerateBlocksCalls)
+	})
+}
+
+func TestRunner_Assertions(t *testing.T) {
+	t.Parallel()
+
+	client := &testscommon.SimulatorClientStub{
+		GetAccountCalled: func(address string) (*data.Account, error) {
+			require.Equal(t, "drt1alice", address)
+			return &data.Account{Balance: "1000", Nonce: 3}, nil
+		},
+		GetStorageValueCalled: func(address string, key string) (string, error) {
+			require.Equal(t, "drt1alice", address)
+			require.Equal(t, "0a0b", key)
+			return "0c0d", nil
+		},
+		GetTransactionCalled: func(txHash string, withResults bool) (*transaction.ApiTransactionResult, error) {
+			require.Equal(t, "0a", txHash)
+			require.True(t, withResults)
+			return &transaction.ApiTransactionResult{
+				Status: transaction.TxStatusSuccess,
+				Logs: &transaction.ApiLogs{
+					Events: []*transaction.Events{
+						{Address: "drt1alice", Identifier: "transferValueOnly", Topics: [][]byte{{10}, {11}}},
+					},
+				},
+				SmartContractResults: []*transaction.ApiSmartContractResult{
+					{},
+					{
+						Logs: &transaction.ApiLogs{
+							Events: []*transaction.Events{
+								{Address: "drt1bob", Identifier: "completedTxEvent", Data: []byte{12}},
+							},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		step        string
+		expectedErr error
+	}{
+		{"balance", `{"assertAccount": {"address": "alice", "balance": "1000", "nonce": 3}}`, nil},
+		{"other balance", `{"assertAccount": {"address": "alice", "balance": "999"}}`, errAssertionFailed},
+		{"invalid balance", `{"assertAccount": {"address": "alice", "balance": "1e3"}}`, errInvalidScenario},
+		{"other nonce", `{"assertAccount": {"address": "alice", "nonce": 4}}`, errAssertionFailed},
+		{"storage", `{"assertStorage": {"address": "alice", "key": "0A0B", "value": "0C0D"}}`, nil},
+		{"other storage", `{"assertStorage": {"address": "alice", "key": "0a0b", "value": "0c"}}`, errAssertionFailed},
+		{"status", `{"assertTx": {"tx": "0a", "status": "success"}}`, nil},
+		{"other status", `{"assertTx": {"tx": "0a", "status": "fail"}}`, errAssertionFailed},
+		{"transaction event", `{"assertTx": {"tx": "0a", "events": [{"address": "alice", "topics": ["0a", "0b"]}]}}`, nil},
+		{"smart contract result event", `{"assertTx": {"tx": "0a", "events": [{"identifier": "completedTxEvent", "data": "0c"}]}}`, nil},
+		{"other topics", `{"assertTx": {"tx": "0a", "events": [{"identifier": "transferValueOnly", "topics": ["0a"]}]}}`, errAssertionFailed},
+		{"other address", `{"assertTx": {"tx": "0a", "events": [{"address": "drt1bob", "identifier": "transferValueOnly"}]}}`, errAssertionFailed},
+		{"missing event", `{"assertTx": {"tx": "0a", "events": [{"identifier": "signalError"}]}}`, errAssertionFailed},
+	}
+
+	for _, tc := range testCases {
+		testCase := tc
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := runScenario(t, client, `{"accounts": {"alice": "drt1alice"}, "steps": [`+testCase.step+`]}`)
+			if testCase.expectedErr == nil {
+				require.Nil(t, err)
+				return
+			}
+
+			require.True(t, errors.Is(err, testCase.expectedErr))
+		})
+	}
+}
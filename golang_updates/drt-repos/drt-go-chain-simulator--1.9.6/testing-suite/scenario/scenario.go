@@ -0,0 +1,176 @@
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is a declarative test run against the chain simulator. The steps are executed in order and the run stops
+// at the first failed step
+type Scenario struct {
+	Name string `json:"name"`
+	// Accounts maps names to bech32 addresses. The names can be used instead of the addresses in all the steps
+	Accounts map[string]string `json:"accounts,omitempty"`
+	Steps    []*Step           `json:"steps"`
+}
+
+// Step holds a single action of a scenario, so exactly one of its actions must be set
+type Step struct {
+	// Name is an optional description of the step, used in the errors
+	Name string `json:"name,omitempty"`
+
+	SetState       []*dtos.AddressState `json:"setState,omitempty"`
+	SendTx         *TransactionStep     `json:"sendTx,omitempty"`
+	GenerateBlocks *int                 `json:"generateBlocks,omitempty"`
+	// ProcessTx holds the identifier of a sent transaction. Blocks are generated until the transaction is processed
+	ProcessTx     string                `json:"processTx,omitempty"`
+	AssertAccount *AccountAssertion     `json:"assertAccount,omitempty"`
+	AssertStorage *StorageAssertion     `json:"assertStorage,omitempty"`
+	AssertTx      *TransactionAssertion `json:"assertTx,omitempty"`
+}
+
+// TransactionStep defines a transaction to be sent. The nonce, the gas price and the gas limit are computed when not set
+type TransactionStep struct {
+	// ID is the identifier used by the next steps to refer to the transaction
+	ID       string  `json:"id,omitempty"`
+	Sender   string  `json:"sender"`
+	Receiver string  `json:"receiver"`
+	Value    string  `json:"value,omitempty"`
+	Data     string  `json:"data,omitempty"`
+	Nonce    *uint64 `json:"nonce,omitempty"`
+	GasPrice uint64  `json:"gasPrice,omitempty"`
+	GasLimit uint64  `json:"gasLimit,omitempty"`
+}
+
+// AccountAssertion checks the balance and the nonce of an account. The empty fields are not checked
+type AccountAssertion struct {
+	Address string  `json:"address"`
+	Balance string  `json:"balance,omitempty"`
+	Nonce   *uint64 `json:"nonce,omitempty"`
+}
+
+// StorageAssertion checks the value stored under a key of an account, both hex encoded. An empty value checks that
+// the key is not set
+type StorageAssertion struct {
+	Address string `json:"address"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+// TransactionAssertion checks the status of a transaction and the events generated by it and by its smart contract
+// results. The empty fields are not checked
+type TransactionAssertion struct {
+	// Tx holds the identifier of a sent transaction or a transaction hash
+	Tx     string           `json:"tx"`
+	Status string           `json:"status,omitempty"`
+	Events []*ExpectedEvent `json:"events,omitempty"`
+}
+
+// ExpectedEvent defines an event that must be generated by a transaction. The topics and the data are hex encoded.
+// The empty fields match any value
+type ExpectedEvent struct {
+	Address    string   `json:"address,omitempty"`
+	Identifier string   `json:"identifier,omitempty"`
+	Topics     []string `json:"topics,omitempty"`
+	Data       string   `json:"data,omitempty"`
+}
+
+// LoadFile will load the scenario from the provided file. The files with the .yaml or .yml extensions are decoded as
+// YAML, all the others as JSON
+func LoadFile(path string) (*Scenario, error) {
+	buff, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := strings.ToLower(filepath.Ext(path))
+	if extension == ".yaml" || extension == ".yml" {
+		return ParseYAML(buff)
+	}
+
+	return ParseJSON(buff)
+}
+
+// ParseYAML will decode and validate a scenario written in YAML. The YAML keys are the same as the JSON ones
+func ParseYAML(buff []byte) (*Scenario, error) {
+	var content interface{}
+	err := yaml.Unmarshal(buff, &content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidScenario, err.Error())
+	}
+
+	// the YAML content is converted to JSON, so the JSON tags of the reused dtos are also applied for YAML
+	jsonBuff, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidScenario, err.Error())
+	}
+
+	return ParseJSON(jsonBuff)
+}
+
+// ParseJSON will decode and validate a scenario written in JSON
+func ParseJSON(buff []byte) (*Scenario, error) {
+	decoder := json.NewDecoder(bytes.NewReader(buff))
+	decoder.DisallowUnknownFields()
+
+	scenario := &Scenario{}
+	err := decoder.Decode(scenario)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidScenario, err.Error())
+	}
+
+	err = scenario.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return scenario, nil
+}
+
+func (s *Scenario) validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("%w: no steps", errInvalidScenario)
+	}
+
+	for idx, step := range s.Steps {
+		if step == nil {
+			return fmt.Errorf("%w: step %d is empty", errInvalidScenario, idx)
+		}
+
+		numActions := step.numActions()
+		if numActions != 1 {
+			return fmt.Errorf("%w: step %d (%s) must hold exactly one action, found %d",
+				errInvalidScenario, idx, step.Name, numActions)
+		}
+	}
+
+	return nil
+}
+
+func (step *Step) numActions() int {
+	isSet := []bool{
+		step.SetState != nil,
+		step.SendTx != nil,
+		step.GenerateBlocks != nil,
+		len(step.ProcessTx) > 0,
+		step.AssertAccount != nil,
+		step.AssertStorage != nil,
+		step.AssertTx != nil,
+	}
+
+	numActions := 0
+	for _, set := range isSet {
+		if set {
+			numActions++
+		}
+	}
+
+	return numActions
+}
@@ -0,0 +1,161 @@
+package scenario
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const yamlScenario = `
+name: move balance
+accounts:
+  alice: drt1alice
+  bob: drt1bob
+steps:
+  - name: fund alice
+    setState:
+      - address: alice
+        balance: "1000000"
+        nonce: 5
+        pairs:
+          "01": "02"
+  - sendTx:
+      id: transfer
+      sender: alice
+      receiver: bob
+      value: "10"
+  - processTx: transfer
+  - generateBlocks: 2
+  - assertAccount:
+      address: bob
+      balance: "10"
+  - assertStorage:
+      address: alice
+      key: "01"
+      value: "02"
+  - assertTx:
+      tx: transfer
+      status: success
+      events:
+        - identifier: transferValueOnly
+          topics: ["0a"]
+`
+
+const jsonScenario = `{
+  "name": "move balance",
+  "accounts": {"alice": "drt1alice", "bob": "drt1bob"},
+  "steps": [
+    {"name": "fund alice", "setState": [{"address": "alice", "balance": "1000000", "nonce": 5, "pairs": {"01": "02"}}]},
+    {"sendTx": {"id": "transfer", "sender": "alice", "receiver": "bob", "value": "10"}},
+    {"processTx": "transfer"},
+    {"generateBlocks": 2},
+    {"assertAccount": {"address": "bob", "balance": "10"}},
+    {"assertStorage": {"address": "alice", "key": "01", "value": "02"}},
+    {"assertTx": {"tx": "transfer", "status": "success", "events": [{"identifier": "transferValueOnly", "topics": ["0a"]}]}}
+  ]
+}`
+
+func requireExpectedScenario(t *testing.T, scenario *Scenario) {
+	require.Equal(t, "move balance", scenario.Name)
+	require.Equal(t, map[string]string{"alice": "drt1alice", "bob": "drt1bob"}, scenario.Accounts)
+	require.Len(t, scenario.Steps, 7)
+
+	require.Equal(t, "fund alice", scenario.Steps[0].Name)
+	require.Equal(t, "alice", scenario.Steps[0].SetState[0].Address)
+	require.Equal(t, "1000000", scenario.Steps[0].SetState[0].Balance)
+	require.Equal(t, uint64(5), *scenario.Steps[0].SetState[0].Nonce)
+	require.Equal(t, map[string]string{"01": "02"}, scenario.Steps[0].SetState[0].Pairs)
+	require.Equal(t, &TransactionStep{ID: "transfer", Sender: "alice", Receiver: "bob", Value: "10"}, scenario.Steps[1].SendTx)
+	require.Equal(t, "transfer", scenario.Steps[2].ProcessTx)
+	require.Equal(t, 2, *scenario.Steps[3].GenerateBlocks)
+	require.Equal(t, &AccountAssertion{Address: "bob", Balance: "10"}, scenario.Steps[4].AssertAccount)
+	require.Equal(t, &StorageAssertion{Address: "alice", Key: "01", Value: "02"}, scenario.Steps[5].AssertStorage)
+	require.Equal(t, &TransactionAssertion{
+		Tx:     "transfer",
+		Status: "success",
+		Events: []*ExpectedEvent{{Identifier: "transferValueOnly", Topics: []string{"0a"}}},
+	}, scenario.Steps[6].AssertTx)
+}
+
+func TestParseYAML(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid YAML should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseYAML([]byte("steps: [generateBlocks: 1"))
+		require.True(t, errors.Is(err, errInvalidScenario))
+		require.Nil(t, scenario)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseYAML([]byte(yamlScenario))
+		require.Nil(t, err)
+		requireExpectedScenario(t, scenario)
+	})
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown field should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseJSON([]byte(`{"steps": [{"generateBlock": 1}]}`))
+		require.True(t, errors.Is(err, errInvalidScenario))
+		require.Nil(t, scenario)
+	})
+	t.Run("no steps should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseJSON([]byte(`{"name": "empty"}`))
+		require.True(t, errors.Is(err, errInvalidScenario))
+		require.Nil(t, scenario)
+	})
+	t.Run("step without action should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseJSON([]byte(`{"steps": [{"name": "nothing"}]}`))
+		require.True(t, errors.Is(err, errInvalidScenario))
+		require.Nil(t, scenario)
+	})
+	t.Run("step with more actions should error", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseJSON([]byte(`{"steps": [{"generateBlocks": 1, "processTx": "transfer"}]}`))
+		require.True(t, errors.Is(err, errInvalidScenario))
+		require.Nil(t, scenario)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		scenario, err := ParseJSON([]byte(jsonScenario))
+		require.Nil(t, err)
+		requireExpectedScenario(t, scenario)
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	yamlPath := path.Join(dir, "scenario.yml")
+	jsonPath := path.Join(dir, "scenario.json")
+	require.Nil(t, os.WriteFile(yamlPath, []byte(yamlScenario), 0644))
+	require.Nil(t, os.WriteFile(jsonPath, []byte(jsonScenario), 0644))
+
+	scenario, err := LoadFile(yamlPath)
+	require.Nil(t, err)
+	requireExpectedScenario(t, scenario)
+
+	scenario, err = LoadFile(jsonPath)
+	require.Nil(t, err)
+	requireExpectedScenario(t, scenario)
+
+	_, err = LoadFile(path.Join(dir, "missing.json"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,27 @@
+package scenario
+
+import (
+	"context"
+	"testing"
+)
+
+// RunFile will load the scenario from the provided file and will run it against the chain simulator, failing the
+// test if the scenario cannot be loaded or if any of its steps fails
+func RunFile(tb testing.TB, client SimulatorClient, path string) {
+	tb.Helper()
+
+	scenario, err := LoadFile(path)
+	if err != nil {
+		tb.Fatalf("cannot load scenario %s: %s", path, err.Error())
+	}
+
+	scenarioRunner, err := NewRunner(client)
+	if err != nil {
+		tb.Fatalf("cannot create the scenario runner: %s", err.Error())
+	}
+
+	err = scenarioRunner.Run(context.Background(), scenario)
+	if err != nil {
+		tb.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,40 @@
+package scenarios
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-simulator/testing-suite/client"
+	"github.com/TerraDharitri/drt-go-chain-simulator/testing-suite/scenario"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatorURLEnvVariable holds the URL of a running chain simulator. The scenarios are skipped when it is not set
+const simulatorURLEnvVariable = "CHAIN_SIMULATOR_URL"
+
+func TestScenarios(t *testing.T) {
+	simulatorURL := os.Getenv(simulatorURLEnvVariable)
+	if len(simulatorURL) == 0 {
+		t.Skipf("%s is not set, the scenarios need a running chain simulator", simulatorURLEnvVariable)
+	}
+
+	simulatorClient, err := client.NewClient(simulatorURL)
+	require.Nil(t, err)
+
+	files := make([]string, 0)
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, errGlob := filepath.Glob(pattern)
+		require.Nil(t, errGlob)
+		files = append(files, matches...)
+	}
+	require.NotEmpty(t, files)
+
+	// the scenarios share the chain simulator, so they are not run in parallel
+	for _, file := range files {
+		scenarioFile := file
+		t.Run(scenarioFile, func(t *testing.T) {
+			scenario.RunFile(t, simulatorClient, scenarioFile)
+		})
+	}
+}
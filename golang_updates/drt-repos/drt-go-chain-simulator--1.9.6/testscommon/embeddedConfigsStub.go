@@ -0,0 +1,32 @@
+package testscommon
+
+import "runtime/debug"
+
+// EmbeddedConfigsStub -
+type EmbeddedConfigsStub struct {
+	ExtractNodeConfigsCalled  func(info *debug.BuildInfo, pathWhereToPutConfigs string) error
+	ExtractProxyConfigsCalled func(info *debug.BuildInfo, pathWhereToPutConfigs string) error
+}
+
+// ExtractNodeConfigs -
+func (stub *EmbeddedConfigsStub) ExtractNodeConfigs(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+	if stub.ExtractNodeConfigsCalled != nil {
+		return stub.ExtractNodeConfigsCalled(info, pathWhereToPutConfigs)
+	}
+
+	return nil
+}
+
+// ExtractProxyConfigs -
+func (stub *EmbeddedConfigsStub) ExtractProxyConfigs(info *debug.BuildInfo, pathWhereToPutConfigs string) error {
+	if stub.ExtractProxyConfigsCalled != nil {
+		return stub.ExtractProxyConfigsCalled(info, pathWhereToPutConfigs)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *EmbeddedConfigsStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -103,6 +103,40 @@ func (n *NodeHandlerStub) ForceChangeOfEpoch() error {
 	return nil
 }
 
+// TakeSnapshot -
+func (n *NodeHandlerStub) TakeSnapshot() (*dtos.NodeSnapshot, error) {
+	return &dtos.NodeSnapshot{}, nil
+}
+
+// RevertToSnapshot -
+func (n *NodeHandlerStub) RevertToSnapshot(_ *dtos.NodeSnapshot) error {
+	return nil
+}
+
+// DumpState -
+func (n *NodeHandlerStub) DumpState() ([]*dtos.AddressState, error) {
+	return make([]*dtos.AddressState, 0), nil
+}
+
+// GetPendingTransactions -
+func (n *NodeHandlerStub) GetPendingTransactions() ([]*dtos.PendingTransaction, error) {
+	return make([]*dtos.PendingTransaction, 0), nil
+}
+
+// RemovePendingTransaction -
+func (n *NodeHandlerStub) RemovePendingTransaction(_ []byte) bool {
+	return false
+}
+
+// SetTransactionsSelection -
+func (n *NodeHandlerStub) SetTransactionsSelection(_ [][]byte) error {
+	return nil
+}
+
+// ClearTransactionsSelection -
+func (n *NodeHandlerStub) ClearTransactionsSelection() {
+}
+
 // Close -
 func (n *NodeHandlerStub) Close() error {
 	return nil
@@ -0,0 +1,93 @@
+package testscommon
+
+import (
+	"context"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+	"github.com/TerraDharitri/drt-go-chain-proxy/data"
+	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
+)
+
+// SimulatorClientStub -
+type SimulatorClientStub struct {
+	GenerateBlocksCalled                            func(numOfBlocks int) error
+	GenerateBlocksUntilTransactionIsProcessedCalled func(txHash string, maxNumOfBlocks int) error
+	SetStateMultipleCalled                          func(stateSlice []*dtos.AddressState, noGenerate bool) error
+	GetNetworkConfigCalled                          func() (*data.NetworkConfig, error)
+	SendTransactionCalled                           func(tx *data.Transaction) (string, error)
+	GetTransactionCalled                            func(txHash string, withResults bool) (*transaction.ApiTransactionResult, error)
+	GetAccountCalled                                func(address string) (*data.Account, error)
+	GetStorageValueCalled                           func(address string, key string) (string, error)
+}
+
+// GenerateBlocks -
+func (stub *SimulatorClientStub) GenerateBlocks(_ context.Context, numOfBlocks int) error {
+	if stub.GenerateBlocksCalled != nil {
+		return stub.GenerateBlocksCalled(numOfBlocks)
+	}
+
+	return nil
+}
+
+// GenerateBlocksUntilTransactionIsProcessed -
+func (stub *SimulatorClientStub) GenerateBlocksUntilTransactionIsProcessed(_ context.Context, txHash string, maxNumOfBlocks int) error {
+	if stub.GenerateBlocksUntilTransactionIsProcessedCalled != nil {
+		return stub.GenerateBlocksUntilTransactionIsProcessedCalled(txHash, maxNumOfBlocks)
+	}
+
+	return nil
+}
+
+// SetStateMultiple -
+func (stub *SimulatorClientStub) SetStateMultiple(_ context.Context, stateSlice []*dtos.AddressState, noGenerate bool) error {
+	if stub.SetStateMultipleCalled != nil {
+		return stub.SetStateMultipleCalled(stateSlice, noGenerate)
+	}
+
+	return nil
+}
+
+// GetNetworkConfig -
+func (stub *SimulatorClientStub) GetNetworkConfig(_ context.Context) (*data.NetworkConfig, error) {
+	if stub.GetNetworkConfigCalled != nil {
+		return stub.GetNetworkConfigCalled()
+	}
+
+	return &data.NetworkConfig{}, nil
+}
+
+// SendTransaction -
+func (stub *SimulatorClientStub) SendTransaction(_ context.Context, tx *data.Transaction) (string, error) {
+	if stub.SendTransactionCalled != nil {
+		return stub.SendTransactionCalled(tx)
+	}
+
+	return "", nil
+}
+
+// GetTransaction -
+func (stub *SimulatorClientStub) GetTransaction(_ context.Context, txHash string, withResults bool) (*transaction.ApiTransactionResult, error) {
+	if stub.GetTransactionCalled != nil {
+		return stub.GetTransactionCalled(txHash, withResults)
+	}
+
+	return &transaction.ApiTransactionResult{}, nil
+}
+
+// GetAccount -
+func (stub *SimulatorClientStub) GetAccount(_ context.Context, address string) (*data.Account, error) {
+	if stub.GetAccountCalled != nil {
+		return stub.GetAccountCalled(address)
+	}
+
+	return &data.Account{}, nil
+}
+
+// GetStorageValue -
+func (stub *SimulatorClientStub) GetStorageValue(_ context.Context, address string, key string) (string, error) {
+	if stub.GetStorageValueCalled != nil {
+		return stub.GetStorageValueCalled(address, key)
+	}
+
+	return "", nil
+}
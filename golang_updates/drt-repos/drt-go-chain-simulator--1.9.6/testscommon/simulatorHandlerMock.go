@@ -1,6 +1,8 @@
 package testscommon
 
 import (
+	"time"
+
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/dtos"
 	"github.com/TerraDharitri/drt-go-chain/node/chainSimulator/process"
 )
@@ -18,6 +20,17 @@ type SimulatorHandlerMock struct {
 	GetRestAPIInterfacesCalled               func() map[uint32]string
 	ForceChangeOfEpochCalled                 func() error
 	GetNodeHandlerCalled                     func(shardID uint32) process.NodeHandler
+	TakeSnapshotCalled                       func() (string, error)
+	RevertToSnapshotCalled                   func(snapshotID string) error
+	DumpStateCalled                          func() ([]*dtos.AddressState, error)
+	SetNextBlockTimestampCalled              func(timestamp int64) error
+	AdvanceTimeCalled                        func(duration time.Duration) error
+	JumpToRoundCalled                        func(round int64) error
+	GetTransactionTraceCalled                func(txHash string) (*dtos.TransactionTrace, error)
+	GetPendingTransactionsCalled             func(shardID uint32) ([]*dtos.PendingTransaction, error)
+	RemovePendingTransactionsCalled          func(txHashes []string) error
+	GenerateBlockWithTransactionsCalled      func(txHashes []string) error
+	SubscribeToEventsCalled                  func(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error)
 }
 
 // GetNodeHandler -
@@ -114,6 +127,105 @@ func (mock *SimulatorHandlerMock) ForceResetValidatorStatisticsCache() error {
 	return nil
 }
 
+// TakeSnapshot -
+func (mock *SimulatorHandlerMock) TakeSnapshot() (string, error) {
+	if mock.TakeSnapshotCalled != nil {
+		return mock.TakeSnapshotCalled()
+	}
+
+	return "", nil
+}
+
+// RevertToSnapshot -
+func (mock *SimulatorHandlerMock) RevertToSnapshot(snapshotID string) error {
+	if mock.RevertToSnapshotCalled != nil {
+		return mock.RevertToSnapshotCalled(snapshotID)
+	}
+
+	return nil
+}
+
+// DumpState -
+func (mock *SimulatorHandlerMock) DumpState() ([]*dtos.AddressState, error) {
+	if mock.DumpStateCalled != nil {
+		return mock.DumpStateCalled()
+	}
+
+	return make([]*dtos.AddressState, 0), nil
+}
+
+// SetNextBlockTimestamp -
+func (mock *SimulatorHandlerMock) SetNextBlockTimestamp(timestamp int64) error {
+	if mock.SetNextBlockTimestampCalled != nil {
+		return mock.SetNextBlockTimestampCalled(timestamp)
+	}
+
+	return nil
+}
+
+// AdvanceTime -
+func (mock *SimulatorHandlerMock) AdvanceTime(duration time.Duration) error {
+	if mock.AdvanceTimeCalled != nil {
+		return mock.AdvanceTimeCalled(duration)
+	}
+
+	return nil
+}
+
+// JumpToRound -
+func (mock *SimulatorHandlerMock) JumpToRound(round int64) error {
+	if mock.JumpToRoundCalled != nil {
+		return mock.JumpToRoundCalled(round)
+	}
+
+	return nil
+}
+
+// GetTransactionTrace -
+func (mock *SimulatorHandlerMock) GetTransactionTrace(txHash string) (*dtos.TransactionTrace, error) {
+	if mock.GetTransactionTraceCalled != nil {
+		return mock.GetTransactionTraceCalled(txHash)
+	}
+
+	return &dtos.TransactionTrace{}, nil
+}
+
+// GetPendingTransactions -
+func (mock *SimulatorHandlerMock) GetPendingTransactions(shardID uint32) ([]*dtos.PendingTransaction, error) {
+	if mock.GetPendingTransactionsCalled != nil {
+		return mock.GetPendingTransactionsCalled(shardID)
+	}
+
+	return make([]*dtos.PendingTransaction, 0), nil
+}
+
+// RemovePendingTransactions -
+func (mock *SimulatorHandlerMock) RemovePendingTransactions(txHashes []string) error {
+	if mock.RemovePendingTransactionsCalled != nil {
+		return mock.RemovePendingTransactionsCalled(txHashes)
+	}
+
+	return nil
+}
+
+// GenerateBlockWithTransactions -
+func (mock *SimulatorHandlerMock) GenerateBlockWithTransactions(txHashes []string) error {
+	if mock.GenerateBlockWithTransactionsCalled != nil {
+		return mock.GenerateBlockWithTransactionsCalled(txHashes)
+	}
+
+	return nil
+}
+
+// SubscribeToEvents -
+func (mock *SimulatorHandlerMock) SubscribeToEvents(filter dtos.EventsFilter) (<-chan *dtos.StreamEvent, func(), error) {
+	if mock.SubscribeToEventsCalled != nil {
+		return mock.SubscribeToEventsCalled(filter)
+	}
+
+	return make(chan *dtos.StreamEvent), func() {}, nil
+}
+
 // IsInterfaceNil -
 func (mock *SimulatorHandlerMock) IsInterfaceNil() bool {
 	return mock == nil
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/TerraDharitri/drt-go-chain-core/core"
 	logger "github.com/TerraDharitri/drt-go-chain-logger"
@@ -39,6 +43,39 @@ var (
 		Name:  "log-save",
 		Usage: "Boolean option for enabling log saving. If set, it will automatically save all the logs into a file.",
 	}
+	// fromEpoch is the first epoch of the stake diff report
+	fromEpoch = cli.UintFlag{
+		Name:  "from-epoch",
+		Usage: "The epoch the stake is compared from",
+	}
+	// toEpoch is the second epoch of the stake diff report
+	toEpoch = cli.UintFlag{
+		Name:  "to-epoch",
+		Usage: "The epoch the stake is compared to",
+	}
+	// reportFormat is the format of the stake diff report
+	reportFormat = cli.StringFlag{
+		Name:  "format",
+		Usage: "The format of the report: json or csv",
+		Value: "json",
+	}
+	// reportOutput is the file the stake diff report is written to
+	reportOutput = cli.StringFlag{
+		Name:  "output",
+		Usage: "The file the report is written to. If not set, it is stake-diff-<from-epoch>-<to-epoch>.<format>",
+	}
+	// reportTop is the number of accounts in the stake diff report
+	reportTop = cli.IntFlag{
+		Name:  "top",
+		Usage: "The number of accounts in the report, the biggest movers first. If set to 0, all the changed accounts are reported",
+		Value: 100,
+	}
+	// daemonMode is used when the manager should keep running, processing each new epoch
+	daemonMode = cli.BoolFlag{
+		Name: "daemon",
+		Usage: "Boolean option for running as a daemon. If set, the manager polls the current epoch and, on each new " +
+			"epoch, only reindexes the accounts changed since the last processed epoch.",
+	}
 )
 
 func main() {
@@ -52,6 +89,7 @@ func main() {
 		logLevel,
 		logSaveFile,
 		indicesConfigPath,
+		daemonMode,
 	}
 	app.Authors = []cli.Author{
 		{
@@ -61,6 +99,14 @@ func main() {
 	}
 
 	app.Action = startAccountsManager
+	app.Commands = []cli.Command{
+		{
+			Name:   "stake-diff",
+			Usage:  "Writes how the stake of the accounts changed between two epochs, from the stake history index",
+			Flags:  []cli.Flag{fromEpoch, toEpoch, reportFormat, reportOutput, reportTop},
+			Action: writeStakeDiff,
+		},
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
@@ -83,6 +129,10 @@ func startAccountsManager(ctx *cli.Context) error {
 		return err
 	}
 
+	if ctx.GlobalBool(daemonMode.Name) {
+		return runDaemon(generalConfig, ctx.GlobalString(indicesConfigPath.Name))
+	}
+
 	dataProc, err := process.CreateDataProcessor(generalConfig, ctx.GlobalString(indicesConfigPath.Name))
 	if err != nil {
 		return err
@@ -98,6 +148,83 @@ func startAccountsManager(ctx *cli.Context) error {
 	return nil
 }
 
+func runDaemon(cfg *config.Config, indicesPath string) error {
+	dataProc, err := process.CreateDaemonDataProcessor(cfg, indicesPath)
+	if err != nil {
+		return err
+	}
+
+	pollingInterval := time.Duration(cfg.Daemon.PollingIntervalInSeconds) * time.Second
+	if pollingInterval == 0 {
+		pollingInterval = time.Minute
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	log.Info("Running as a daemon", "polling interval", pollingInterval)
+
+	for {
+		err = dataProc.ProcessAccountsData()
+		if err != nil {
+			log.Error("cannot process accounts data, will retry", "error", err.Error(), "retry in", pollingInterval)
+		}
+
+		select {
+		case <-sigs:
+			log.Info("Terminating at user's signal...")
+			return nil
+		case <-time.After(pollingInterval):
+		}
+	}
+}
+
+func writeStakeDiff(ctx *cli.Context) error {
+	err := initializeLogger(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet(fromEpoch.Name) || !ctx.IsSet(toEpoch.Name) {
+		return fmt.Errorf("both --%s and --%s must be set", fromEpoch.Name, toEpoch.Name)
+	}
+
+	generalConfig, err := loadMainConfig(ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		return err
+	}
+
+	reporter, err := process.CreateStakeDiffReporter(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	from, to, format := ctx.Uint(fromEpoch.Name), ctx.Uint(toEpoch.Name), ctx.String(reportFormat.Name)
+	outputPath := ctx.String(reportOutput.Name)
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("stake-diff-%d-%d.%s", from, to, format)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = reporter.WriteStakeDiff(uint32(from), uint32(to), ctx.Int(reportTop.Name), format, file)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(outputPath)
+		return err
+	}
+
+	log.Info("Stake diff written", "file", outputPath)
+
+	return nil
+}
+
 func loadMainConfig(filepath string) (*config.Config, error) {
 	cfg := &config.Config{}
 	err := core.LoadTomlFile(cfg, filepath)
@@ -18,6 +18,8 @@ type Config struct {
 		DestinationElasticSearchClients []data.EsClientConfig `toml:"DestinationElasticSearchClients"`
 	}
 	APIConfig APIConfig
+	Daemon    DaemonConfig
+	Output    OutputConfig
 }
 
 // GeneralConfig will hold the general settings for an accounts manager
@@ -26,6 +28,22 @@ type GeneralConfig struct {
 	LKMOAStakingContractAddress     string
 	EnergyContractAddress           string
 	ValidatorsContract              string
+	StakeSources                    []StakeSourceConfig
+	AllowUnverifiedSources          bool
+}
+
+// StakeSourceConfig holds the settings of a generic stake source: a contract whose storage, or view function, holds
+// an amount for each account
+type StakeSourceConfig struct {
+	Name              string
+	ContractAddress   string
+	StorageKeyPrefix  string
+	ViewFunction      string
+	KeyTypes          []string
+	ValueTypes        []string
+	AddressFieldIndex int
+	AmountFieldIndex  int
+	TargetField       string
 }
 
 // APIConfig holds the configuration for the API
@@ -34,3 +52,15 @@ type APIConfig struct {
 	Username string
 	Password string
 }
+
+// DaemonConfig holds the configuration for the daemon mode
+type DaemonConfig struct {
+	PollingIntervalInSeconds uint32
+	CursorPath               string
+}
+
+// OutputConfig holds the configuration of where the accounts data is written
+type OutputConfig struct {
+	Type string
+	Path string
+}
@@ -11,6 +11,8 @@ type ElasticClientHandler interface {
 	PutPolicy(policyName string, policy *bytes.Buffer) error
 	PutMapping(targetIndex string, body *bytes.Buffer) error
 	CreateIndexWithMapping(index string, mapping *bytes.Buffer) error
+	CloneIndex(index, newIndex string, body *bytes.Buffer) error
+	DeleteIndex(index string) error
 	CheckIfIndexExists(index string) (bool, error)
 	DoRequest(index, documentID string, buff *bytes.Buffer) error
 	DoBulkRequest(buff *bytes.Buffer, index string) error
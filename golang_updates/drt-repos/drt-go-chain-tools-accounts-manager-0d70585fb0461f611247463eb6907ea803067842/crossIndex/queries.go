@@ -17,6 +17,23 @@ func EncodeQuery(query object) (bytes.Buffer, error) {
 	return buff, nil
 }
 
+// GetAccountsUpdatedSince returns the query of the accounts updated at or after the given timestamp
+func GetAccountsUpdatedSince(timestamp uint64) *bytes.Buffer {
+	obj := object{
+		"query": object{
+			"range": object{
+				"timestamp": object{
+					"gte": timestamp,
+				},
+			},
+		},
+	}
+
+	encoded, _ := EncodeQuery(obj)
+
+	return &encoded
+}
+
 func GetAll() *bytes.Buffer {
 	obj := object{
 		"query": object{
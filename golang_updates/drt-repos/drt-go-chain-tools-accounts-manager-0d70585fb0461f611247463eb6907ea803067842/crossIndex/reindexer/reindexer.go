@@ -12,6 +12,7 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/crossIndex"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/process/accountsIndexer"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/process/stakeHistory"
 )
 
 type reindexer struct {
@@ -64,8 +65,92 @@ func (r *reindexer) ReindexAccounts(sourceIndex string, destinationIndex string,
 		if err != nil {
 			return err
 		}
+
+		err = putSnapshotBlockInfoInMapping(restAccounts, destinationIndex, dstClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.reindexSourceAccounts(sourceIndex, crossIndex.GetAll(), destinationIndex, restAccounts)
+	if err != nil {
+		return err
+	}
+
+	err = r.checkAndCreateExtraIndices()
+	if err != nil {
+		return err
+	}
+
+	return r.indexExtraInformation(restAccounts)
+}
+
+// ReindexChangedAccounts will clone the previous index into the destination index and will reindex only the accounts
+// whose stake changed since the previous index, and the accounts updated in the source index since the previous pinned
+// block, so that their balance is refreshed. The accounts which are not found in the source index are removed
+func (r *reindexer) ReindexChangedAccounts(
+	sourceIndex string,
+	previousIndex string,
+	destinationIndex string,
+	restAccounts *data.AccountsData,
+	diff *data.AccountsDiff,
+) error {
+	log.Info("Clone the previous index", "previous index", previousIndex, "new index", destinationIndex)
+
+	for _, dstClient := range r.destinationClients {
+		err := dstClient.CloneIndex(previousIndex, destinationIndex, bytes.NewBufferString(cloneIndexBody))
+		if err != nil {
+			return err
+		}
+
+		err = putSnapshotBlockInfoInMapping(restAccounts, destinationIndex, dstClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	addresses := make([]string, 0, len(diff.Changed)+len(diff.Removed))
+	addresses = append(addresses, diff.Changed...)
+	addresses = append(addresses, diff.Removed...)
+
+	sourceAccountsIndexer, err := accountsIndexer.NewAccountsIndexer(r.sourceIndexer)
+	if err != nil {
+		return err
 	}
 
+	esAccounts, err := sourceAccountsIndexer.GetAccounts(addresses, sourceIndex)
+	if err != nil {
+		return err
+	}
+
+	mergedAccounts := core.MergeElasticAndRestAccounts(esAccounts, restAccounts.AccountsWithStake)
+	err = r.indexAllAccounts(mergedAccounts, destinationIndex)
+	if err != nil {
+		return err
+	}
+
+	err = r.removeAccountsNotFound(addresses, esAccounts, destinationIndex)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Refresh the accounts updated since the previous pinned block", "timestamp", diff.BalancesUpdatedSince)
+
+	err = r.reindexSourceAccounts(sourceIndex, crossIndex.GetAccountsUpdatedSince(diff.BalancesUpdatedSince), destinationIndex, restAccounts)
+	if err != nil {
+		return err
+	}
+
+	err = r.checkAndCreateExtraIndices()
+	if err != nil {
+		return err
+	}
+
+	return r.indexExtraInformation(restAccounts)
+}
+
+// reindexSourceAccounts reindexes the accounts of the source index matched by the query, merged with their stake
+func (r *reindexer) reindexSourceAccounts(sourceIndex string, query *bytes.Buffer, destinationIndex string, restAccounts *data.AccountsData) error {
 	saverFunc := func(responseBytes []byte) error {
 		r.count++
 		log.Info("indexing accounts", "bulk", r.count)
@@ -80,17 +165,48 @@ func (r *reindexer) ReindexAccounts(sourceIndex string, destinationIndex string,
 		return r.indexAllAccounts(mergedAccounts, destinationIndex)
 	}
 
-	err = r.sourceIndexer.DoScrollRequestAllDocuments(sourceIndex, crossIndex.GetAll().Bytes(), saverFunc)
-	if err != nil {
-		return err
+	return r.sourceIndexer.DoScrollRequestAllDocuments(sourceIndex, query.Bytes(), saverFunc)
+}
+
+func (r *reindexer) removeAccountsNotFound(addresses []string, esAccounts map[string]*data.AccountInfoWithStakeValues, destinationIndex string) error {
+	notFound := make([]string, 0)
+	for _, address := range addresses {
+		_, found := esAccounts[address]
+		if !found {
+			notFound = append(notFound, address)
+		}
+	}
+	if len(notFound) == 0 {
+		return nil
 	}
 
-	err = r.checkAndCreateValuesIndex()
-	if err != nil {
-		return err
+	log.Info("removing accounts not found in the source index", "num", len(notFound))
+
+	for _, dstClient := range r.destinationClients {
+		acIndexer, err := accountsIndexer.NewAccountsIndexer(dstClient)
+		if err != nil {
+			return err
+		}
+
+		err = acIndexer.RemoveAccounts(notFound, destinationIndex)
+		if err != nil {
+			return err
+		}
 	}
 
-	return r.indexExtraInformation(restAccounts)
+	return nil
+}
+
+// RemoveIndex will remove an index from all the destination clients, if it exists
+func (r *reindexer) RemoveIndex(index string) error {
+	for _, dstClient := range r.destinationClients {
+		err := dstClient.DeleteIndex(index)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (r *reindexer) indexAllAccounts(mapAllAccounts map[string]*data.AccountInfoWithStakeValues, destinationIndex string) error {
@@ -110,7 +226,22 @@ func (r *reindexer) indexAllAccounts(mapAllAccounts map[string]*data.AccountInfo
 
 func (r *reindexer) indexExtraInformation(accountsData *data.AccountsData) error {
 	for _, dstClient := range r.destinationClients {
-		err := indexEnergyBlockInfo(accountsData.EnergyBlockInfo, accountsData.Epoch, dstClient)
+		err := indexSnapshotBlockInfo(accountsData.BlockInfo, accountsData.Epoch, dstClient)
+		if err != nil {
+			return err
+		}
+
+		err = indexEnergyBlockInfo(accountsData.BlockInfo, accountsData.Epoch, dstClient)
+		if err != nil {
+			return err
+		}
+
+		history, err := stakeHistory.NewStakeHistory(dstClient)
+		if err != nil {
+			return err
+		}
+
+		err = history.IndexStakeHistory(accountsData)
 		if err != nil {
 			return err
 		}
@@ -119,13 +250,61 @@ func (r *reindexer) indexExtraInformation(accountsData *data.AccountsData) error
 	return nil
 }
 
-func indexEnergyBlockInfo(energyBlockInfo *data.BlockInfo, epoch uint32, esClient crossIndex.ElasticClientHandler) error {
+// putSnapshotBlockInfoInMapping records, in the metadata of the accounts index, the block the accounts were fetched at,
+// the shard blocks notarized by it and the sources which could not be checked against it
+func putSnapshotBlockInfoInMapping(accountsData *data.AccountsData, index string, esClient crossIndex.ElasticClientHandler) error {
+	meta := map[string]interface{}{
+		"_meta": map[string]interface{}{
+			"epoch":             accountsData.Epoch,
+			"blockNonce":        accountsData.BlockInfo.Nonce,
+			"blockHash":         accountsData.BlockInfo.Hash,
+			"blockRootHash":     accountsData.BlockInfo.RootHash,
+			"shardBlocks":       accountsData.BlockInfo.ShardBlocks,
+			"unverifiedSources": accountsData.BlockInfo.UnverifiedSources,
+		},
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	return esClient.PutMapping(index, bytes.NewBuffer(metaBytes))
+}
+
+func indexSnapshotBlockInfo(blockInfo *data.BlockInfo, epoch uint32, esClient crossIndex.ElasticClientHandler) error {
+	log.Info(fmt.Sprintf("Indexing snapshot block in `%s` index...", valuesIndex), "epoch", epoch, "block nonce", blockInfo.Nonce)
+
+	id := fmt.Sprintf("accounts-snapshot-%d", epoch)
+	snapshotInfo := &data.SnapshotInfo{
+		KeyValueObj: data.KeyValueObj{
+			Key:   "blockHash",
+			Value: blockInfo.Hash,
+		},
+		Epoch:             epoch,
+		Nonce:             blockInfo.Nonce,
+		Hash:              blockInfo.Hash,
+		RootHash:          blockInfo.RootHash,
+		ShardBlocks:       blockInfo.ShardBlocks,
+		UnverifiedSources: blockInfo.UnverifiedSources,
+	}
+
+	snapshotInfoBytes, err := json.Marshal(snapshotInfo)
+	if err != nil {
+		return err
+	}
+
+	return esClient.DoRequest(valuesIndex, id, bytes.NewBuffer(snapshotInfoBytes))
+}
+
+// indexEnergyBlockInfo keeps the energy snapshot entry, which points to the same block as the whole accounts snapshot
+func indexEnergyBlockInfo(blockInfo *data.BlockInfo, epoch uint32, esClient crossIndex.ElasticClientHandler) error {
 	log.Info(fmt.Sprintf("Indexing extra information in `%s` index...", valuesIndex))
 
 	id := fmt.Sprintf("energy-snapshot-%d", epoch)
 	keyValueObj := &data.KeyValueObj{
 		Key:   "blockHash",
-		Value: energyBlockInfo.Hash,
+		Value: blockInfo.Hash,
 	}
 
 	keyValueObjBytes, err := json.Marshal(keyValueObj)
@@ -136,12 +315,24 @@ func indexEnergyBlockInfo(energyBlockInfo *data.BlockInfo, epoch uint32, esClien
 	return esClient.DoRequest(valuesIndex, id, bytes.NewBuffer(keyValueObjBytes))
 }
 
-func (r *reindexer) checkAndCreateValuesIndex() error {
-	template, err := readTemplateForIndex(r.pathToIndicesConfig, valuesIndex)
+func (r *reindexer) checkAndCreateExtraIndices() error {
+	err := r.checkAndCreateIndex(valuesIndex)
+	if err != nil {
+		return err
+	}
+
+	return r.checkAndCreateIndex(stakeHistory.StakeHistoryIndex)
+}
+
+func (r *reindexer) checkAndCreateIndex(index string) error {
+	template, err := readTemplateForIndex(r.pathToIndicesConfig, index)
+	if err != nil {
+		return err
+	}
 	templateBytes := template.Bytes()
 
 	for _, dstClient := range r.destinationClients {
-		exists, errC := dstClient.CheckIfIndexExists(valuesIndex)
+		exists, errC := dstClient.CheckIfIndexExists(index)
 		if errC != nil {
 			return errC
 		}
@@ -149,7 +340,7 @@ func (r *reindexer) checkAndCreateValuesIndex() error {
 			continue
 		}
 
-		err = dstClient.CreateIndexWithMapping(valuesIndex, bytes.NewBuffer(templateBytes))
+		err = dstClient.CreateIndexWithMapping(index, bytes.NewBuffer(templateBytes))
 		if err != nil {
 			return err
 		}
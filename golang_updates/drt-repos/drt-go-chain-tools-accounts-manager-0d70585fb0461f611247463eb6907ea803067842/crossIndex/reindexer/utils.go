@@ -11,6 +11,9 @@ const (
 	accountsTemplateFileName = "accounts.json"
 	accountsPolicyFileName   = "accounts-policy.json"
 	valuesIndex              = "values"
+
+	// the clone of a read-only index is read-only as well, unless the setting is reset
+	cloneIndexBody = `{"settings": {"index.blocks.write": null}}`
 )
 
 func readTemplateAndPolicyForAccountsIndex(pathToIndicesConfig string) (*bytes.Buffer, *bytes.Buffer, error) {
@@ -14,11 +14,15 @@ type GenericAPIResponse struct {
 	Code  string          `json:"code"`
 }
 
-// BlockInfo defines the structure of block info
+// BlockInfo defines the structure of block info. It identifies the block whose state was queried. For the pinned
+// metablock, it also holds the shard blocks notarized by it and the sources which could not be checked against it
 type BlockInfo struct {
-	Hash     string `json:"hash"`
-	Nonce    uint64 `json:"nonce"`
-	RootHash string `json:"rootHash"`
+	Hash              string                `json:"hash"`
+	Nonce             uint64                `json:"nonce"`
+	RootHash          string                `json:"rootHash"`
+	Timestamp         uint64                `json:"timestamp,omitempty"`
+	ShardBlocks       map[uint32]*BlockInfo `json:"shardBlocks,omitempty"`
+	UnverifiedSources []string              `json:"unverifiedSources,omitempty"`
 }
 
 // StakedInfo defines the structure of a response staked info response
@@ -40,7 +44,8 @@ type DelegatorStake struct {
 
 // VmValuesResponseData follows the format of the data field in an API response for a VM values query
 type VmValuesResponseData struct {
-	Data *vm.VMOutputApi `json:"data"`
+	Data      *vm.VMOutputApi `json:"data"`
+	BlockInfo *BlockInfo      `json:"blockInfo"`
 }
 
 // ResponseVmValue defines a wrapper over string containing returned data in hex format
@@ -95,13 +100,30 @@ type AccountInfoWithStakeValues struct {
 	StakeInfo
 }
 
+// AccountsData holds all the accounts with stake, as fetched at the same block
 type AccountsData struct {
 	AccountsWithStake map[string]*AccountInfoWithStakeValues
 	Addresses         []string
-	EnergyBlockInfo   *BlockInfo
+	BlockInfo         *BlockInfo
 	Epoch             uint32
 }
 
+// EpochCursor holds the last epoch whose accounts were indexed, together with the accounts with stake of that epoch
+type EpochCursor struct {
+	Epoch             uint32                `json:"epoch"`
+	Index             string                `json:"index"`
+	BlockInfo         *BlockInfo            `json:"blockInfo"`
+	AccountsWithStake map[string]*StakeInfo `json:"accountsWithStake"`
+}
+
+// AccountsDiff holds the addresses whose stake changed between two epochs. The balances of the accounts updated in
+// the source index since BalancesUpdatedSince (the timestamp of the previous pinned block) have to be refreshed
+type AccountsDiff struct {
+	Changed              []string
+	Removed              []string
+	BalancesUpdatedSince uint64
+}
+
 // StakeInfo is the structure that contains all information about stake for an account
 type StakeInfo struct {
 	DelegationLegacyWaiting    string  `json:"delegationLegacyWaiting,omitempty"`
@@ -131,8 +153,19 @@ type StakeInfo struct {
 	UnDelegateDelegationNum float64 `json:"unDelegateDelegationNum,omitempty"`
 	TotalUnDelegate         string  `json:"totalUnDelegate,omitempty"`
 	TotalUnDelegateNum      float64 `json:"totalUnDelegateNum,omitempty"`
+
+	StakeSources map[string]*SourceStake `json:"stakeSources,omitempty"`
+}
+
+// SourceStake is the structure that contains the amount fetched from a configured stake source
+type SourceStake struct {
+	Stake    string  `json:"stake"`
+	StakeNum float64 `json:"stakeNum"`
 }
 
+// StakeSourcesAccounts holds, for each address, the amounts fetched from the configured stake sources, by target field
+type StakeSourcesAccounts map[string]map[string]string
+
 // EnergyDetails is the structure that contains details about the user's energy
 type EnergyDetails struct {
 	LastUpdateEpoch   uint32 `json:"lastUpdateEpoch"`
@@ -140,12 +173,49 @@ type EnergyDetails struct {
 	TotalLockedTokens string `json:"totalLockedTokens"`
 }
 
+// StakeHistoryEntry is the dto for the stake history index: the stake of an account at an epoch
+type StakeHistoryEntry struct {
+	Address            string  `json:"address"`
+	Epoch              uint32  `json:"epoch"`
+	TotalStake         string  `json:"totalStake"`
+	TotalStakeNum      float64 `json:"totalStakeNum"`
+	TotalUnDelegate    string  `json:"totalUnDelegate"`
+	TotalUnDelegateNum float64 `json:"totalUnDelegateNum"`
+	Energy             string  `json:"energy"`
+	EnergyNum          float64 `json:"energyNum"`
+}
+
+// StakeDiffEntry holds how the stake of an account changed between two epochs
+type StakeDiffEntry struct {
+	Address              string `json:"address"`
+	FromTotalStake       string `json:"fromTotalStake"`
+	ToTotalStake         string `json:"toTotalStake"`
+	TotalStakeDelta      string `json:"totalStakeDelta"`
+	FromTotalUnDelegate  string `json:"fromTotalUnDelegate"`
+	ToTotalUnDelegate    string `json:"toTotalUnDelegate"`
+	TotalUnDelegateDelta string `json:"totalUnDelegateDelta"`
+	FromEnergy           string `json:"fromEnergy"`
+	ToEnergy             string `json:"toEnergy"`
+	EnergyDelta          string `json:"energyDelta"`
+}
+
 // KeyValueObj is the dto for values index
 type KeyValueObj struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
 
+// SnapshotInfo is the dto for values index that records the block an accounts snapshot was taken at
+type SnapshotInfo struct {
+	KeyValueObj
+	Epoch             uint32                `json:"epoch"`
+	Nonce             uint64                `json:"nonce"`
+	Hash              string                `json:"hash"`
+	RootHash          string                `json:"rootHash"`
+	ShardBlocks       map[uint32]*BlockInfo `json:"shardBlocks,omitempty"`
+	UnverifiedSources []string              `json:"unverifiedSources,omitempty"`
+}
+
 // EsClientConfig is a wrapper over the internally used field from elasticsearch.Config struct
 type EsClientConfig struct {
 	Address  string
@@ -21,6 +21,9 @@ const (
 	numOfErrorsToExtractBulkResponse = 5
 
 	errPolicyAlreadyExists = "document already exists"
+
+	blockWritesSettings   = `{"index.blocks.write": true}`
+	unblockWritesSettings = `{"index.blocks.write": null}`
 )
 
 var log = logger.GetOrCreate("elasticClient")
@@ -202,6 +205,83 @@ func (ec *esClient) CreateIndexWithMapping(index string, mapping *bytes.Buffer)
 	return nil
 }
 
+// CloneIndex will clone an index into a new one. The source index is made read-only before, as cloning requires it,
+// and writable again after, whether the cloning succeeded or not
+func (ec *esClient) CloneIndex(index, newIndex string, body *bytes.Buffer) error {
+	err := ec.putSettings(index, blockWritesSettings)
+	if err != nil {
+		return fmt.Errorf("error CloneIndex, cannot block writes: %w", err)
+	}
+
+	errClone := ec.cloneIndex(index, newIndex, body)
+
+	err = ec.putSettings(index, unblockWritesSettings)
+	if err != nil {
+		log.Warn("cannot unblock the writes of the cloned index", "index", index, "error", err.Error())
+		if errClone == nil {
+			return fmt.Errorf("error CloneIndex, cannot unblock writes: %w", err)
+		}
+	}
+
+	return errClone
+}
+
+func (ec *esClient) cloneIndex(index, newIndex string, body *bytes.Buffer) error {
+	res, err := ec.client.Indices.Clone(
+		index,
+		newIndex,
+		ec.client.Indices.Clone.WithBody(body),
+	)
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error CloneIndex: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
+func (ec *esClient) putSettings(index string, settings string) error {
+	res, err := ec.client.Indices.PutSettings(
+		strings.NewReader(settings),
+		ec.client.Indices.PutSettings.WithIndex(index),
+	)
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("%s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
+// DeleteIndex will delete an index, if it exists
+func (ec *esClient) DeleteIndex(index string) error {
+	res, err := ec.client.Indices.Delete(
+		[]string{index},
+		ec.client.Indices.Delete.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error DeleteIndex: %s, url: %s", res.String(), ec.clusterURL)
+	}
+
+	return nil
+}
+
 // PutPolicy will put in Elasticsearch cluster the provided policy with the given name
 func (ec *esClient) PutPolicy(policyName string, policy *bytes.Buffer) error {
 	res, err := ec.client.ILM.PutLifecycle(
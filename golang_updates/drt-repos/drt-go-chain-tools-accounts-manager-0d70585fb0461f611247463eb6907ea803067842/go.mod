@@ -1,6 +1,6 @@
 module github.com/TerraDharitri/drt-go-chain-tools-accounts-manager
 
-go 1.17
+go 1.20
 
 require (
 	github.com/elastic/go-elasticsearch/v7 v7.12.0
@@ -8,12 +8,14 @@ require (
 	github.com/TerraDharitri/drt-go-chain-es-indexer v1.3.8
 	github.com/TerraDharitri/drt-go-chain-logger v1.0.11
 	github.com/TerraDharitri/drt-go-chain-vm-common v1.3.36
+	github.com/TerraDharitri/drt-go-sdk-abi v0.3.0
 	github.com/stretchr/testify v1.7.0
 	github.com/tidwall/gjson v1.14.0
 	github.com/urfave/cli v1.22.9
 )
 
 require (
+	github.com/TerraDharitri/drt-go-bigint v1.0.0 // indirect
 	github.com/btcsuite/btcd/btcutil v1.1.3 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.0-20190314233015-f79a8a8ca69d // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
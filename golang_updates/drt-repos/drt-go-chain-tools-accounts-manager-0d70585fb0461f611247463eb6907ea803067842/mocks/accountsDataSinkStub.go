@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type AccountsDataSinkStub struct {
+	WriteAccountsDataCalled func(accountsData *data.AccountsData) error
+}
+
+func (a *AccountsDataSinkStub) WriteAccountsData(accountsData *data.AccountsData) error {
+	if a.WriteAccountsDataCalled != nil {
+		return a.WriteAccountsDataCalled(accountsData)
+	}
+	return nil
+}
+
+func (a *AccountsDataSinkStub) IsInterfaceNil() bool {
+	return a == nil
+}
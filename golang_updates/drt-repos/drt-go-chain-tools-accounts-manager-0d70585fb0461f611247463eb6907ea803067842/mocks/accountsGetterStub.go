@@ -5,36 +5,52 @@ import (
 )
 
 type AccountsGetterStub struct {
-	GetLegacyDelegatorsAccountsCalled func() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetValidatorsAccountsCalled       func() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetDelegatorsAccountsCalled       func() (map[string]*data.AccountInfoWithStakeValues, error)
+	GetLegacyDelegatorsAccountsCalled func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetValidatorsAccountsCalled       func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetDelegatorsAccountsCalled       func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetLKMOAStakeAccountsCalled       func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetAccountsWithEnergyCalled       func(currentEpoch uint32, blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetStakeSourcesAccountsCalled     func(blockInfo *data.BlockInfo) (data.StakeSourcesAccounts, error)
 }
 
-func (a *AccountsGetterStub) GetAccountsWithEnergy(_ uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
-	return nil, nil, nil
+func (a *AccountsGetterStub) GetAccountsWithEnergy(currentEpoch uint32, blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+	if a.GetAccountsWithEnergyCalled != nil {
+		return a.GetAccountsWithEnergyCalled(currentEpoch, blockInfo)
+	}
+	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetLKMOAStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (a *AccountsGetterStub) GetLKMOAStakeAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+	if a.GetLKMOAStakeAccountsCalled != nil {
+		return a.GetLKMOAStakeAccountsCalled(blockInfo)
+	}
 	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetLegacyDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (a *AccountsGetterStub) GetLegacyDelegatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if a.GetLegacyDelegatorsAccountsCalled != nil {
-		return a.GetLegacyDelegatorsAccountsCalled()
+		return a.GetLegacyDelegatorsAccountsCalled(blockInfo)
 	}
 	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (a *AccountsGetterStub) GetValidatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if a.GetValidatorsAccountsCalled != nil {
-		return a.GetValidatorsAccountsCalled()
+		return a.GetValidatorsAccountsCalled(blockInfo)
 	}
 	return nil, nil
 }
 
-func (a *AccountsGetterStub) GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+func (a *AccountsGetterStub) GetDelegatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if a.GetDelegatorsAccountsCalled != nil {
-		return a.GetDelegatorsAccountsCalled()
+		return a.GetDelegatorsAccountsCalled(blockInfo)
+	}
+	return nil, nil
+}
+
+func (a *AccountsGetterStub) GetStakeSourcesAccounts(blockInfo *data.BlockInfo) (data.StakeSourcesAccounts, error) {
+	if a.GetStakeSourcesAccountsCalled != nil {
+		return a.GetStakeSourcesAccountsCalled(blockInfo)
 	}
 	return nil, nil
 }
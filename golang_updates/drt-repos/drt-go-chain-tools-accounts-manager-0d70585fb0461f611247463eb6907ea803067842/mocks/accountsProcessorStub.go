@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type AccountsProcessorStub struct {
+	GetCurrentEpochCalled            func() (uint32, error)
+	GetAllAccountsWithStakeCalled    func(epoch uint32) (*data.AccountsData, error)
+	ComputeClonedAccountsIndexCalled func(epoch uint32) (string, error)
+}
+
+func (a *AccountsProcessorStub) GetCurrentEpoch() (uint32, error) {
+	if a.GetCurrentEpochCalled != nil {
+		return a.GetCurrentEpochCalled()
+	}
+	return 0, nil
+}
+
+func (a *AccountsProcessorStub) GetAllAccountsWithStake(epoch uint32) (*data.AccountsData, error) {
+	if a.GetAllAccountsWithStakeCalled != nil {
+		return a.GetAllAccountsWithStakeCalled(epoch)
+	}
+	return &data.AccountsData{}, nil
+}
+
+func (a *AccountsProcessorStub) ComputeClonedAccountsIndex(epoch uint32) (string, error) {
+	if a.ComputeClonedAccountsIndexCalled != nil {
+		return a.ComputeClonedAccountsIndexCalled(epoch)
+	}
+	return "", nil
+}
+
+func (a *AccountsProcessorStub) IsInterfaceNil() bool {
+	return a == nil
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type CursorStorerStub struct {
+	LoadCursorCalled func() (*data.EpochCursor, error)
+	SaveCursorCalled func(cursor *data.EpochCursor) error
+}
+
+func (c *CursorStorerStub) LoadCursor() (*data.EpochCursor, error) {
+	if c.LoadCursorCalled != nil {
+		return c.LoadCursorCalled()
+	}
+	return nil, nil
+}
+
+func (c *CursorStorerStub) SaveCursor(cursor *data.EpochCursor) error {
+	if c.SaveCursorCalled != nil {
+		return c.SaveCursorCalled(cursor)
+	}
+	return nil
+}
+
+func (c *CursorStorerStub) IsInterfaceNil() bool {
+	return c == nil
+}
@@ -6,6 +6,7 @@ import (
 
 // ElasticClientStub -
 type ElasticClientStub struct {
+	DoBulkRequestCalled               func(buff *bytes.Buffer, index string) error
 	DoScrollRequestAllDocumentsCalled func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
 }
 
@@ -15,7 +16,11 @@ func (e *ElasticClientStub) PutMapping(_ string, _ *bytes.Buffer) error {
 }
 
 // DoBulkRequest -
-func (e *ElasticClientStub) DoBulkRequest(_ *bytes.Buffer, _ string) error {
+func (e *ElasticClientStub) DoBulkRequest(buff *bytes.Buffer, index string) error {
+	if e.DoBulkRequestCalled != nil {
+		return e.DoBulkRequestCalled(buff, index)
+	}
+
 	panic("implement me")
 }
 
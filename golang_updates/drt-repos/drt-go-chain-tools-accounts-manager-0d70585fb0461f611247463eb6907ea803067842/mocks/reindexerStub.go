@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type ReindexerStub struct {
+	ReindexAccountsCalled        func(sourceIndex string, destinationIndex string, accountsData *data.AccountsData) error
+	ReindexChangedAccountsCalled func(sourceIndex string, previousIndex string, destinationIndex string, accountsData *data.AccountsData, diff *data.AccountsDiff) error
+	RemoveIndexCalled            func(index string) error
+}
+
+func (r *ReindexerStub) ReindexAccounts(sourceIndex string, destinationIndex string, accountsData *data.AccountsData) error {
+	if r.ReindexAccountsCalled != nil {
+		return r.ReindexAccountsCalled(sourceIndex, destinationIndex, accountsData)
+	}
+	return nil
+}
+
+func (r *ReindexerStub) ReindexChangedAccounts(sourceIndex string, previousIndex string, destinationIndex string, accountsData *data.AccountsData, diff *data.AccountsDiff) error {
+	if r.ReindexChangedAccountsCalled != nil {
+		return r.ReindexChangedAccountsCalled(sourceIndex, previousIndex, destinationIndex, accountsData, diff)
+	}
+	return nil
+}
+
+func (r *ReindexerStub) RemoveIndex(index string) error {
+	if r.RemoveIndexCalled != nil {
+		return r.RemoveIndexCalled(index)
+	}
+	return nil
+}
+
+func (r *ReindexerStub) IsInterfaceNil() bool {
+	return r == nil
+}
@@ -4,11 +4,16 @@ import "github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
 
 // RestClientStub -
 type RestClientStub struct {
+	CallGetRestEndPointCalled  func(path string, value interface{}, authenticationData data.RestApiAuthenticationData) error
 	CallPostRestEndPointCalled func(path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error
 }
 
 // CallGetRestEndPoint -
-func (r RestClientStub) CallGetRestEndPoint(_ string, _ interface{}, _ data.RestApiAuthenticationData) error {
+func (r RestClientStub) CallGetRestEndPoint(path string, value interface{}, authenticationData data.RestApiAuthenticationData) error {
+	if r.CallGetRestEndPointCalled != nil {
+		return r.CallGetRestEndPointCalled(path, value, authenticationData)
+	}
+
 	panic("implement me")
 }
 
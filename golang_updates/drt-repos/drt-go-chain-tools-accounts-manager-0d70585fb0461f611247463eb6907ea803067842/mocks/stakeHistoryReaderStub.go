@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type StakeHistoryReaderStub struct {
+	GetEpochStakeHistoryCalled func(epoch uint32) (map[string]*data.StakeHistoryEntry, error)
+}
+
+func (s *StakeHistoryReaderStub) GetEpochStakeHistory(epoch uint32) (map[string]*data.StakeHistoryEntry, error) {
+	if s.GetEpochStakeHistoryCalled != nil {
+		return s.GetEpochStakeHistoryCalled(epoch)
+	}
+	return nil, nil
+}
+
+func (s *StakeHistoryReaderStub) IsInterfaceNil() bool {
+	return s == nil
+}
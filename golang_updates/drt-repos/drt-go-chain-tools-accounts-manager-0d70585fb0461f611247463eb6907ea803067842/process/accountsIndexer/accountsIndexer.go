@@ -47,7 +47,7 @@ func (ai *accountsIndexer) GetAccounts(addresses []string, index string) (map[st
 		accounts, errGet := ai.getBulkOfAccounts(newSliceOfAddresses, index)
 		if errGet != nil {
 			log.Warn("accountsIndexer.GetAccounts: cannot get accounts", "error", errGet)
-			continue
+			return nil, errGet
 		}
 		mergeAccountsMaps(accountsES, accounts)
 	}
@@ -97,6 +97,27 @@ func (ai *accountsIndexer) IndexAccounts(accounts map[string]*data.AccountInfoWi
 	return nil
 }
 
+// RemoveAccounts will remove the accounts with the provided addresses from a given index
+func (ai *accountsIndexer) RemoveAccounts(addresses []string, index string) error {
+	buffSlice := dataIndexer.NewBufferSlice(0)
+	for _, address := range addresses {
+		meta := []byte(fmt.Sprintf(`{ "delete" : { "_id" : "%s" } }%s`, address, "\n"))
+		err := buffSlice.PutData(meta, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, buff := range buffSlice.Buffers() {
+		err := ai.elasticClient.DoBulkRequest(buff, index)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func serializeAccounts(accounts map[string]*data.AccountInfoWithStakeValues) ([]*bytes.Buffer, error) {
 	buffSlice := dataIndexer.NewBufferSlice(0)
 	for address, acc := range accounts {
@@ -3,6 +3,7 @@ package process
 import (
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/core"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
@@ -10,7 +11,8 @@ import (
 )
 
 const (
-	pathNodeStatusMeta = "/network/status/4294967295"
+	pathNodeStatusMeta   = "/network/status/4294967295"
+	pathBlockByNonceMeta = "/block/4294967295/by-nonce/%d"
 )
 
 type accountsProcessor struct {
@@ -26,41 +28,55 @@ func NewAccountsProcessor(restClient RestClientHandler, acctsGetter AccountsGett
 	}, nil
 }
 
-// GetAllAccountsWithStake will return all accounts with stake
+// GetAllAccountsWithStake will return all accounts with stake. All the sources are queried at the same block: the
+// latest final block of the metachain, when the call is made
 func (ap *accountsProcessor) GetAllAccountsWithStake(currentEpoch uint32) (*data.AccountsData, error) {
-	legacyDelegators, err := ap.GetLegacyDelegatorsAccounts()
+	blockInfo, err := ap.getFinalBlockInfo()
 	if err != nil {
 		return nil, err
 	}
 
-	validators, err := ap.GetValidatorsAccounts()
+	log.Info("Fetching accounts with stake", "block nonce", blockInfo.Nonce, "block hash", blockInfo.Hash, "root hash", blockInfo.RootHash)
+
+	legacyDelegators, err := ap.GetLegacyDelegatorsAccounts(blockInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := ap.GetValidatorsAccounts(blockInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	delegators, err := ap.GetDelegatorsAccounts()
+	delegators, err := ap.GetDelegatorsAccounts(blockInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	lkMoaAccountsWithStake, err := ap.GetLKMOAStakeAccounts()
+	lkMoaAccountsWithStake, err := ap.GetLKMOAStakeAccounts(blockInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	accountsWithEnergy, blockInfoEnergy, err := ap.GetAccountsWithEnergy(currentEpoch)
+	accountsWithEnergy, err := ap.GetAccountsWithEnergy(currentEpoch, blockInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeSourcesAccounts, err := ap.GetStakeSourcesAccounts(blockInfo)
 	if err != nil {
 		return nil, err
 	}
 
 	allAccounts, allAddresses := ap.mergeAccounts(legacyDelegators, validators, delegators, lkMoaAccountsWithStake, accountsWithEnergy)
+	allAddresses = mergeStakeSourcesAccounts(allAccounts, allAddresses, stakeSourcesAccounts)
 
 	calculateTotalStakeForAccountsAndTotalUnDelegated(allAccounts)
 
 	return &data.AccountsData{
 		AccountsWithStake: allAccounts,
 		Addresses:         allAddresses,
-		EnergyBlockInfo:   blockInfoEnergy,
+		BlockInfo:         blockInfo,
 		Epoch:             currentEpoch,
 	}, nil
 }
@@ -165,6 +181,32 @@ func (ap *accountsProcessor) mergeAccounts(
 	return mergedAccounts, allAddresses
 }
 
+// mergeStakeSourcesAccounts appends the new addresses sorted, so that the order of the addresses does not depend on
+// the iteration over the map
+func mergeStakeSourcesAccounts(
+	mergedAccounts map[string]*data.AccountInfoWithStakeValues,
+	allAddresses []string,
+	stakeSourcesAccounts data.StakeSourcesAccounts,
+) []string {
+	newAddresses := make([]string, 0)
+	for address, amounts := range stakeSourcesAccounts {
+		_, ok := mergedAccounts[address]
+		if !ok {
+			mergedAccounts[address] = &data.AccountInfoWithStakeValues{}
+
+			newAddresses = append(newAddresses, address)
+		}
+
+		for targetField, amount := range amounts {
+			addStakeValue(&mergedAccounts[address].StakeInfo, targetField, amount)
+		}
+	}
+
+	sort.Strings(newAddresses)
+
+	return append(allAddresses, newAddresses...)
+}
+
 // ComputeClonedAccountsIndex will compute cloned accounts index based on current epoch
 func (ap *accountsProcessor) ComputeClonedAccountsIndex(epoch uint32) (string, error) {
 	log.Info("Compute name of the new index...")
@@ -187,6 +229,44 @@ func (ap *accountsProcessor) GetCurrentEpoch() (uint32, error) {
 	return uint32(epoch.Num), nil
 }
 
+func (ap *accountsProcessor) getFinalBlockInfo() (*data.BlockInfo, error) {
+	statusResponse := &data.GenericAPIResponse{}
+	err := ap.restClient.CallGetRestEndPoint(pathNodeStatusMeta, statusResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return nil, err
+	}
+	if statusResponse.Error != "" {
+		return nil, fmt.Errorf("%w: %s", ErrCannotPinBlock, statusResponse.Error)
+	}
+
+	finalNonce := gjson.Get(string(statusResponse.Data), "status.drt_highest_final_nonce")
+	if !finalNonce.Exists() {
+		return nil, fmt.Errorf("%w: missing highest final nonce", ErrCannotPinBlock)
+	}
+
+	block, err := ap.getBlock(fmt.Sprintf(pathBlockByNonceMeta, finalNonce.Uint()))
+	if err != nil {
+		return nil, err
+	}
+
+	blockInfo := &data.BlockInfo{
+		Hash:      block.Get("hash").String(),
+		Nonce:     block.Get("nonce").Uint(),
+		RootHash:  block.Get("stateRootHash").String(),
+		Timestamp: block.Get("timestamp").Uint(),
+	}
+	if blockInfo.Hash == "" || blockInfo.Nonce != finalNonce.Uint() {
+		return nil, fmt.Errorf("%w: unexpected block response for nonce %d", ErrCannotPinBlock, finalNonce.Uint())
+	}
+
+	blockInfo.ShardBlocks, err = ap.getNotarizedShardBlocks(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockInfo, nil
+}
+
 func computeTotalBalance(balances ...string) (string, float64) {
 	totalBalance := big.NewInt(0)
 	totalBalanceFloat := float64(0)
@@ -2,9 +2,13 @@ package process
 
 import (
 	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
+	nodeCore "github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/core"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
@@ -27,22 +31,34 @@ func TestAccountsProcessor_GetAllAccountsWithStake(t *testing.T) {
 	mapLegacyDelegation := makeMapFromArrays(keys[5:35], accountsDelegationLegacy)
 	mapValidators := makeMapFromArrays(keys[15:45], accountsValidators)
 
-	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, &mocks.AccountsGetterStub{
-		GetDelegatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
+	ap, err := NewAccountsProcessor(createRestClientWithFinalBlock(testBlockInfo), &mocks.AccountsGetterStub{
+		GetDelegatorsAccountsCalled: func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+			require.Equal(t, testBlockInfo, blockInfo)
 			return mapDelegation, nil
 		},
-		GetLegacyDelegatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
+		GetLegacyDelegatorsAccountsCalled: func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+			require.Equal(t, testBlockInfo, blockInfo)
 			return mapLegacyDelegation, nil
 		},
-		GetValidatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
+		GetValidatorsAccountsCalled: func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+			require.Equal(t, testBlockInfo, blockInfo)
 			return mapValidators, nil
 		},
+		GetLKMOAStakeAccountsCalled: func(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+			require.Equal(t, testBlockInfo, blockInfo)
+			return nil, nil
+		},
+		GetAccountsWithEnergyCalled: func(_ uint32, blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+			require.Equal(t, testBlockInfo, blockInfo)
+			return nil, nil
+		},
 	})
 	require.Nil(t, err)
 
 	accountsData, err := ap.GetAllAccountsWithStake(0)
 	require.Nil(t, err)
 	require.Equal(t, len(accountsData.AccountsWithStake), len(accountsData.Addresses))
+	require.Equal(t, testBlockInfo, accountsData.BlockInfo)
 
 	for addr, processedAccount := range accountsData.AccountsWithStake {
 		acctDelegation, ok := mapDelegation[addr]
@@ -84,6 +100,220 @@ func TestAccountsProcessor_GetAllAccountsWithStake(t *testing.T) {
 	}
 }
 
+func TestAccountsProcessor_GetAllAccountsWithStakeMergesStakeSources(t *testing.T) {
+	t.Parallel()
+
+	ap, _ := NewAccountsProcessor(createRestClientWithFinalBlock(testBlockInfo), &mocks.AccountsGetterStub{
+		GetDelegatorsAccountsCalled: func(_ *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+			return map[string]*data.AccountInfoWithStakeValues{
+				"addr1": {StakeInfo: data.StakeInfo{Delegation: "1000000000000000000", DelegationNum: 1}},
+			}, nil
+		},
+		GetStakeSourcesAccountsCalled: func(blockInfo *data.BlockInfo) (data.StakeSourcesAccounts, error) {
+			require.Equal(t, testBlockInfo, blockInfo)
+			return data.StakeSourcesAccounts{
+				"addr1": {"farmStake": "2000000000000000000"},
+				"addr2": {"lkMoaStake": "3000000000000000000"},
+			}, nil
+		},
+	})
+
+	accountsData, err := ap.GetAllAccountsWithStake(0)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"addr1", "addr2"}, accountsData.Addresses)
+
+	addr1 := accountsData.AccountsWithStake["addr1"]
+	require.Equal(t, "1000000000000000000", addr1.Delegation)
+	require.Equal(t, &data.SourceStake{Stake: "2000000000000000000", StakeNum: 2}, addr1.StakeSources["farmStake"])
+
+	addr2 := accountsData.AccountsWithStake["addr2"]
+	require.Equal(t, "3000000000000000000", addr2.LKMOAStake)
+	require.Equal(t, float64(3), addr2.LKMOAStakeNum)
+}
+
+func TestMergeStakeSourcesAccountsAppendsSortedAddresses(t *testing.T) {
+	t.Parallel()
+
+	mergedAccounts := map[string]*data.AccountInfoWithStakeValues{
+		"addr3": {},
+	}
+	allAddresses := mergeStakeSourcesAccounts(mergedAccounts, []string{"addr3"}, data.StakeSourcesAccounts{
+		"addr5": {"farmStake": "1"},
+		"addr1": {"farmStake": "1"},
+		"addr3": {"farmStake": "1"},
+		"addr4": {"farmStake": "1"},
+		"addr2": {"farmStake": "1"},
+	})
+
+	require.Equal(t, []string{"addr3", "addr1", "addr2", "addr4", "addr5"}, allAddresses)
+	require.Len(t, mergedAccounts, 5)
+}
+
+func TestAccountsProcessor_GetAllAccountsWithStakeCannotPinBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("status error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		restClient := &mocks.RestClientStub{
+			CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+				return expectedErr
+			},
+		}
+
+		ap, _ := NewAccountsProcessor(restClient, &mocks.AccountsGetterStub{
+			GetLegacyDelegatorsAccountsCalled: func(_ *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
+				require.Fail(t, "should not have been called")
+				return nil, nil
+			},
+		})
+
+		accountsData, err := ap.GetAllAccountsWithStake(0)
+		require.Nil(t, accountsData)
+		require.Equal(t, expectedErr, err)
+	})
+
+	t.Run("block of another nonce", func(t *testing.T) {
+		t.Parallel()
+
+		restClient := createRestClientWithFinalBlock(testBlockInfo)
+		getFinalBlock := restClient.CallGetRestEndPointCalled
+		restClient.CallGetRestEndPointCalled = func(path string, value interface{}, auth data.RestApiAuthenticationData) error {
+			if path == pathNodeStatusMeta {
+				return getFinalBlock(path, value, auth)
+			}
+
+			value.(*data.GenericAPIResponse).Data = []byte(`{"block":{"nonce":1,"hash":"aa","stateRootHash":"bb"}}`)
+			return nil
+		}
+
+		ap, _ := NewAccountsProcessor(restClient, &mocks.AccountsGetterStub{})
+
+		accountsData, err := ap.GetAllAccountsWithStake(0)
+		require.Nil(t, accountsData)
+		require.True(t, errors.Is(err, ErrCannotPinBlock))
+	})
+
+	t.Run("shard without notarized block", func(t *testing.T) {
+		t.Parallel()
+
+		restClient := createRestClientWithFinalBlock(testBlockInfo)
+		getBlocks := restClient.CallGetRestEndPointCalled
+		restClient.CallGetRestEndPointCalled = func(path string, value interface{}, auth data.RestApiAuthenticationData) error {
+			if path == pathNetworkConfig {
+				value.(*data.GenericAPIResponse).Data = []byte(`{"config":{"drt_num_shards_without_meta":3}}`)
+				return nil
+			}
+
+			return getBlocks(path, value, auth)
+		}
+
+		ap, _ := NewAccountsProcessor(restClient, &mocks.AccountsGetterStub{})
+
+		accountsData, err := ap.GetAllAccountsWithStake(0)
+		require.Nil(t, accountsData)
+		require.True(t, errors.Is(err, ErrCannotPinBlock))
+	})
+}
+
+var testBlockInfo = &data.BlockInfo{
+	Hash:      "52a2e3c800d03b1499e3cbc57431ee5f122e1bf0e1065fa05578f2d58621f7a0",
+	Nonce:     3576295,
+	RootHash:  "1829f8c869318f1c5ddc8a887fc2bb206b42fa9a484b8beb94e7873b633cdc61",
+	Timestamp: 1697500000,
+	ShardBlocks: map[uint32]*data.BlockInfo{
+		0: {
+			Hash:     "8f0d2ab0a8a4b8c4a1e2b0b22d4f0f4fe0f3a50d5b2a1c6e1f1ad8b8a7c3e9d1",
+			Nonce:    3580120,
+			RootHash: "b27c2c6a1f0f0d6a1d4bda6b0e58e1e3b5bb2b1b7d6b5e4c0a9c1b1e0e7f2a33",
+		},
+		1: {
+			Hash:     "3b6d1a4f5e2c7b8a9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e",
+			Nonce:    3580098,
+			RootHash: "e1d2c3b4a5968778695a4b3c2d1e0f1a2b3c4d5e6f708192a3b4c5d6e7f80910",
+		},
+	},
+}
+
+// createRestClientWithFinalBlock answers the queries which pin a block: the pinned metablock notarizes the block of
+// shard 0, while the block of shard 1 was notarized by the previous metablock
+func createRestClientWithFinalBlock(blockInfo *data.BlockInfo) *mocks.RestClientStub {
+	notarizedBlock := func(shardID uint32, nonce uint64) map[string]interface{} {
+		return map[string]interface{}{
+			"shard": shardID,
+			"nonce": nonce,
+			"hash":  fmt.Sprintf("%d-%d", shardID, nonce),
+		}
+	}
+	notarizedBlockInfo := func(shardID uint32) map[string]interface{} {
+		return map[string]interface{}{
+			"shard": shardID,
+			"nonce": blockInfo.ShardBlocks[shardID].Nonce,
+			"hash":  blockInfo.ShardBlocks[shardID].Hash,
+		}
+	}
+	metaBlocks := map[string]interface{}{
+		fmt.Sprintf(pathBlockByNonceMeta, blockInfo.Nonce): map[string]interface{}{
+			"nonce":         blockInfo.Nonce,
+			"hash":          blockInfo.Hash,
+			"stateRootHash": blockInfo.RootHash,
+			"timestamp":     blockInfo.Timestamp,
+			"notarizedBlocks": []interface{}{
+				notarizedBlock(0, blockInfo.ShardBlocks[0].Nonce-1),
+				notarizedBlockInfo(0),
+				notarizedBlock(nodeCore.MetachainShardId, 0),
+			},
+		},
+		fmt.Sprintf(pathBlockByNonceMeta, blockInfo.Nonce-1): map[string]interface{}{
+			"nonce": blockInfo.Nonce - 1,
+			"hash":  "aa",
+			"notarizedBlocks": []interface{}{
+				notarizedBlock(0, blockInfo.ShardBlocks[0].Nonce-2),
+				notarizedBlockInfo(1),
+			},
+		},
+	}
+	for shardID, shardBlock := range blockInfo.ShardBlocks {
+		metaBlocks[fmt.Sprintf(pathBlockByHash, shardID, shardBlock.Hash)] = map[string]interface{}{
+			"nonce":         shardBlock.Nonce,
+			"hash":          shardBlock.Hash,
+			"stateRootHash": shardBlock.RootHash,
+		}
+	}
+
+	return &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			response := value.(*data.GenericAPIResponse)
+			switch path {
+			case pathNodeStatusMeta:
+				response.Data, _ = json.Marshal(map[string]interface{}{
+					"status": map[string]interface{}{
+						"drt_highest_final_nonce": blockInfo.Nonce,
+					},
+				})
+			case pathNetworkConfig:
+				response.Data, _ = json.Marshal(map[string]interface{}{
+					"config": map[string]interface{}{
+						"drt_num_shards_without_meta": len(blockInfo.ShardBlocks),
+					},
+				})
+			default:
+				block, found := metaBlocks[path]
+				if !found {
+					response.Error = "block not found"
+					return nil
+				}
+				response.Data, _ = json.Marshal(map[string]interface{}{
+					"block": block,
+				})
+			}
+
+			return nil
+		},
+	}
+}
+
 const (
 	delegation = iota
 	validator
@@ -34,6 +34,9 @@ type accountsGetter struct {
 	lkMoaContractAddress      string
 	energyContractAddress     string
 	validatorsContract        string
+	stakeSources              []*stakeSource
+	addressShards             map[string]uint32
+	allowUnverifiedSources    bool
 }
 
 // NewAccountsGetter will create a new instance of accountsGetter
@@ -44,6 +47,15 @@ func NewAccountsGetter(
 	generalConfig config.GeneralConfig,
 	esClient ElasticClientHandler,
 ) (*accountsGetter, error) {
+	stakeSources := make([]*stakeSource, 0)
+	if len(generalConfig.StakeSources) > 0 {
+		var err error
+		stakeSources, err = newStakeSources(generalConfig.StakeSources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &accountsGetter{
 		mutex:                     sync.Mutex{},
 		restClient:                restClient,
@@ -54,16 +66,24 @@ func NewAccountsGetter(
 		delegationContractAddress: generalConfig.DelegationLegacyContractAddress,
 		validatorsContract:        generalConfig.ValidatorsContract,
 		unDelegatedInfoProc:       newUnDelegateInfoProcessor(esClient),
+		stakeSources:              stakeSources,
+		addressShards:             make(map[string]uint32),
+		allowUnverifiedSources:    generalConfig.AllowUnverifiedSources,
 	}, nil
 }
 
-// GetLegacyDelegatorsAccounts will fetch all accounts with stake from API
-func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetLegacyDelegatorsAccounts will fetch all accounts with stake from API, at the provided block
+func (ag *accountsGetter) GetLegacyDelegatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from legacy delegation contract")
 
+	contractBlock, err := ag.blockForAddress(blockInfo, ag.delegationContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	responseKeys := &data.GenericAPIResponse{}
-	path := fmt.Sprintf(pathAddressKeys, ag.delegationContractAddress)
-	err := ag.restClient.CallGetRestEndPoint(path, responseKeys, core.GetEmptyApiCredentials())
+	path := pathWithBlockHash(fmt.Sprintf(pathAddressKeys, ag.delegationContractAddress), contractBlock)
+	err = ag.restClient.CallGetRestEndPoint(path, responseKeys, core.GetEmptyApiCredentials())
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +91,11 @@ func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.Accoun
 		return nil, fmt.Errorf("%s", responseKeys.Error)
 	}
 
+	err = ag.checkResponseBlockInfo(responseKeys.Data, contractBlock, "legacy delegation contract")
+	if err != nil {
+		return nil, err
+	}
+
 	pairs := gjson.Get(string(responseKeys.Data), "pairs")
 
 	pairsMap := make(map[string]string)
@@ -83,12 +108,12 @@ func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.Accoun
 	return ag.extractDelegationLegacyData(pairsMap)
 }
 
-// GetValidatorsAccounts will fetch all validators accounts
-func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetValidatorsAccounts will fetch all validators accounts, at the provided block
+func (ag *accountsGetter) GetValidatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from validators contract")
 
 	genericApiResponse := &data.GenericAPIResponse{}
-	err := ag.restClient.CallGetRestEndPoint(pathValidatorsStake, genericApiResponse, ag.authenticationData)
+	err := ag.restClient.CallGetRestEndPoint(pathWithBlockHash(pathValidatorsStake, blockInfo), genericApiResponse, ag.authenticationData)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +121,11 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 		return nil, fmt.Errorf("%s", genericApiResponse.Error)
 	}
 
+	err = ag.checkResponseBlockInfo(genericApiResponse.Data, blockInfo, "direct staked info")
+	if err != nil {
+		return nil, err
+	}
+
 	list := gjson.Get(string(genericApiResponse.Data), "list")
 	accountsInfo := make([]data.StakedInfo, 0)
 	err = json.Unmarshal([]byte(list.String()), &accountsInfo)
@@ -117,7 +147,7 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 
 	log.Info("validators accounts", "num", len(accountsStake))
 
-	err = ag.putUndelegatedValuesFromValidatorsContract(accountsStake)
+	err = ag.putUndelegatedValuesFromValidatorsContract(accountsStake, blockInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -125,12 +155,12 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 	return accountsStake, nil
 }
 
-// GetDelegatorsAccounts will fetch all delegators accounts
-func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetDelegatorsAccounts will fetch all delegators accounts, at the provided block
+func (ag *accountsGetter) GetDelegatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from delegation manager contracts")
 
 	genericApiResponse := &data.GenericAPIResponse{}
-	err := ag.restClient.CallGetRestEndPoint(pathDelegatorStake, genericApiResponse, ag.authenticationData)
+	err := ag.restClient.CallGetRestEndPoint(pathWithBlockHash(pathDelegatorStake, blockInfo), genericApiResponse, ag.authenticationData)
 	if err != nil {
 		log.Warn("CallGetRestEndPoint", "error", err.Error())
 		return nil, err
@@ -139,6 +169,11 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 		return nil, fmt.Errorf("cannot get delegators accounts %s", genericApiResponse.Error)
 	}
 
+	err = ag.checkResponseBlockInfo(genericApiResponse.Data, blockInfo, "delegated info")
+	if err != nil {
+		return nil, err
+	}
+
 	list := gjson.Get(string(genericApiResponse.Data), "list")
 	accountsInfo := make([]data.DelegatorStake, 0)
 	err = json.Unmarshal([]byte(list.String()), &accountsInfo)
@@ -167,8 +202,8 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 	return accountsStake, nil
 }
 
-// GetLKMOAStakeAccounts will fetch all accounts that have stake lkmoa tokens
-func (ag *accountsGetter) GetLKMOAStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
+// GetLKMOAStakeAccounts will fetch all accounts that have stake lkmoa tokens, at the provided block
+func (ag *accountsGetter) GetLKMOAStakeAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	accountsMap := make(map[string]*data.AccountInfoWithStakeValues)
 	if ag.lkMoaContractAddress == "" {
 		return accountsMap, nil
@@ -182,8 +217,13 @@ func (ag *accountsGetter) GetLKMOAStakeAccounts() (map[string]*data.AccountInfoW
 		CallerAddr: ag.lkMoaContractAddress,
 	}
 
+	contractBlock, err := ag.blockForAddress(blockInfo, ag.lkMoaContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	responseVmValue := &data.ResponseVmValue{}
-	err := ag.restClient.CallPostRestEndPoint(pathVMValues, vmRequest, responseVmValue, core.GetEmptyApiCredentials())
+	err = ag.restClient.CallPostRestEndPoint(pathWithBlockHash(pathVMValues, contractBlock), vmRequest, responseVmValue, core.GetEmptyApiCredentials())
 	if err != nil {
 		return nil, err
 	}
@@ -196,6 +236,11 @@ func (ag *accountsGetter) GetLKMOAStakeAccounts() (map[string]*data.AccountInfoW
 		}
 	}
 
+	err = ag.checkQueriedBlock(contractBlock, responseVmValue.Data.BlockInfo, "lkmoa staking contract")
+	if err != nil {
+		return nil, err
+	}
+
 	stepForLoop := 2
 	returnedData := responseVmValue.Data.Data.ReturnData
 	accountsStake := make(map[string]string, 0)
@@ -0,0 +1,238 @@
+package process
+
+import (
+	"fmt"
+
+	nodeCore "github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/core"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	urlParamBlockHash = "blockHash"
+	pathNetworkConfig = "/network/config"
+	pathBlockByHash   = "/block/%d/by-hash/%s"
+	pathAddressShard  = "/address/%s/shard"
+
+	// maxMetaBlocksToFindShardBlocks bounds the walk back from the pinned metablock to the metablocks which
+	// notarized the latest block of each shard
+	maxMetaBlocksToFindShardBlocks = 100
+)
+
+// pathWithBlockHash appends the block coordinate to the path, so that the API is queried at the given block. The API
+// accepts a single block coordinate, the hash is used so that the query cannot end up on another fork
+func pathWithBlockHash(path string, blockInfo *data.BlockInfo) string {
+	return fmt.Sprintf("%s?%s=%s", path, urlParamBlockHash, blockInfo.Hash)
+}
+
+// blockForAddress returns the block the state of an address has to be queried at: the pinned metablock for the
+// metachain accounts, or the shard block notarized by it for the accounts of a shard
+func (ag *accountsGetter) blockForAddress(pinnedBlock *data.BlockInfo, address string) (*data.BlockInfo, error) {
+	shardID, err := ag.getAddressShard(address)
+	if err != nil {
+		return nil, err
+	}
+	if shardID == nodeCore.MetachainShardId {
+		return pinnedBlock, nil
+	}
+
+	shardBlock, found := pinnedBlock.ShardBlocks[shardID]
+	if !found {
+		return nil, fmt.Errorf("%w: no block of shard %d notarized by the metablock %d", ErrCannotPinBlock, shardID, pinnedBlock.Nonce)
+	}
+
+	return shardBlock, nil
+}
+
+func (ag *accountsGetter) getAddressShard(address string) (uint32, error) {
+	ag.mutex.Lock()
+	shardID, found := ag.addressShards[address]
+	ag.mutex.Unlock()
+	if found {
+		return shardID, nil
+	}
+
+	genericAPIResponse := &data.GenericAPIResponse{}
+	err := ag.restClient.CallGetRestEndPoint(fmt.Sprintf(pathAddressShard, address), genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return 0, err
+	}
+	if genericAPIResponse.Error != "" {
+		return 0, fmt.Errorf("cannot get the shard of %s: %s", address, genericAPIResponse.Error)
+	}
+
+	shardIDData := gjson.Get(string(genericAPIResponse.Data), "shardID")
+	if !shardIDData.Exists() {
+		return 0, fmt.Errorf("cannot get the shard of %s: missing shard ID", address)
+	}
+
+	shardID = uint32(shardIDData.Uint())
+	ag.mutex.Lock()
+	ag.addressShards[address] = shardID
+	ag.mutex.Unlock()
+
+	return shardID, nil
+}
+
+func (ag *accountsGetter) checkResponseBlockInfo(responseData []byte, expectedBlock *data.BlockInfo, source string) error {
+	queriedBlock, err := extractBlockInfo(responseData)
+	if err != nil {
+		return fmt.Errorf("cannot extract block info %s", err.Error())
+	}
+
+	return ag.checkQueriedBlock(expectedBlock, queriedBlock, source)
+}
+
+// checkQueriedBlock checks that a source was queried at the expected block. A source which does not report the block
+// it was queried at (e.g. the staking lists, which are always computed on the node's current state) cannot be checked,
+// so it fails the run, unless the unverified sources are allowed. These are then recorded in the unverified sources of
+// the expected block, which are indexed together with the block
+func (ag *accountsGetter) checkQueriedBlock(expectedBlock *data.BlockInfo, queriedBlock *data.BlockInfo, source string) error {
+	if queriedBlock == nil {
+		if !ag.allowUnverifiedSources {
+			return fmt.Errorf("%w: %s, pinned block %d (%s)", ErrBlockNotReported, source, expectedBlock.Nonce, expectedBlock.Hash)
+		}
+
+		log.Warn("the source did not report the block it was queried at, its state might be newer than the pinned block",
+			"source", source, "pinned block nonce", expectedBlock.Nonce)
+		ag.addUnverifiedSource(expectedBlock, source)
+		return nil
+	}
+
+	sameNonce := queriedBlock.Nonce == expectedBlock.Nonce
+	sameHash := queriedBlock.Hash == expectedBlock.Hash
+	if !sameNonce || !sameHash {
+		return fmt.Errorf("%w for %s: pinned block %d (%s), queried block %d (%s)",
+			ErrBlockMismatch, source, expectedBlock.Nonce, expectedBlock.Hash, queriedBlock.Nonce, queriedBlock.Hash)
+	}
+
+	return nil
+}
+
+func (ag *accountsGetter) addUnverifiedSource(blockInfo *data.BlockInfo, source string) {
+	ag.mutex.Lock()
+	defer ag.mutex.Unlock()
+
+	for _, unverifiedSource := range blockInfo.UnverifiedSources {
+		if unverifiedSource == source {
+			return
+		}
+	}
+
+	blockInfo.UnverifiedSources = append(blockInfo.UnverifiedSources, source)
+}
+
+// getNotarizedShardBlocks returns, for each shard, the latest block notarized by the metachain up to the pinned
+// metablock. A metablock only lists the shard blocks it notarized itself, so the previous metablocks are walked back
+// until a block is found for every shard
+func (ap *accountsProcessor) getNotarizedShardBlocks(metaBlock gjson.Result) (map[uint32]*data.BlockInfo, error) {
+	numShards, err := ap.getNumShards()
+	if err != nil {
+		return nil, err
+	}
+
+	notarizedHeaders := make(map[uint32]gjson.Result, numShards)
+	pinnedNonce := metaBlock.Get("nonce").Uint()
+	metaNonce := pinnedNonce
+	for numMetaBlocks := 1; ; numMetaBlocks++ {
+		for shardID, header := range highestNotarizedHeaders(metaBlock, numShards) {
+			if _, found := notarizedHeaders[shardID]; !found {
+				notarizedHeaders[shardID] = header
+			}
+		}
+		if uint32(len(notarizedHeaders)) == numShards {
+			break
+		}
+		if numMetaBlocks == maxMetaBlocksToFindShardBlocks || metaNonce == 0 {
+			return nil, fmt.Errorf("%w: the metablocks %d-%d did not notarize a block of every shard",
+				ErrCannotPinBlock, metaNonce, pinnedNonce)
+		}
+
+		metaNonce--
+		metaBlock, err = ap.getBlock(fmt.Sprintf(pathBlockByNonceMeta, metaNonce))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shardBlocks := make(map[uint32]*data.BlockInfo, numShards)
+	for shardID, header := range notarizedHeaders {
+		shardBlocks[shardID], err = ap.getShardBlockInfo(shardID, header)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shardBlocks, nil
+}
+
+// highestNotarizedHeaders returns the notarized header of each shard with the highest nonce within a metablock
+func highestNotarizedHeaders(metaBlock gjson.Result, numShards uint32) map[uint32]gjson.Result {
+	headers := make(map[uint32]gjson.Result)
+	for _, header := range metaBlock.Get("notarizedBlocks").Array() {
+		shardID := uint32(header.Get("shard").Uint())
+		if shardID >= numShards {
+			continue
+		}
+
+		highest, found := headers[shardID]
+		if !found || header.Get("nonce").Uint() > highest.Get("nonce").Uint() {
+			headers[shardID] = header
+		}
+	}
+
+	return headers
+}
+
+func (ap *accountsProcessor) getShardBlockInfo(shardID uint32, notarizedHeader gjson.Result) (*data.BlockInfo, error) {
+	hash := notarizedHeader.Get("hash").String()
+	nonce := notarizedHeader.Get("nonce").Uint()
+
+	block, err := ap.getBlock(fmt.Sprintf(pathBlockByHash, shardID, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	blockInfo := &data.BlockInfo{
+		Hash:     block.Get("hash").String(),
+		Nonce:    block.Get("nonce").Uint(),
+		RootHash: block.Get("stateRootHash").String(),
+	}
+	if blockInfo.Hash != hash || blockInfo.Nonce != nonce {
+		return nil, fmt.Errorf("%w: unexpected block response for shard %d, hash %s", ErrCannotPinBlock, shardID, hash)
+	}
+
+	return blockInfo, nil
+}
+
+func (ap *accountsProcessor) getNumShards() (uint32, error) {
+	genericAPIResponse := &data.GenericAPIResponse{}
+	err := ap.restClient.CallGetRestEndPoint(pathNetworkConfig, genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return 0, err
+	}
+	if genericAPIResponse.Error != "" {
+		return 0, fmt.Errorf("%w: %s", ErrCannotPinBlock, genericAPIResponse.Error)
+	}
+
+	numShards := gjson.Get(string(genericAPIResponse.Data), "config.drt_num_shards_without_meta")
+	if !numShards.Exists() {
+		return 0, fmt.Errorf("%w: missing number of shards", ErrCannotPinBlock)
+	}
+
+	return uint32(numShards.Uint()), nil
+}
+
+func (ap *accountsProcessor) getBlock(path string) (gjson.Result, error) {
+	blockResponse := &data.GenericAPIResponse{}
+	err := ap.restClient.CallGetRestEndPoint(path, blockResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	if blockResponse.Error != "" {
+		return gjson.Result{}, fmt.Errorf("%w: %s", ErrCannotPinBlock, blockResponse.Error)
+	}
+
+	return gjson.Get(string(blockResponse.Data), "block"), nil
+}
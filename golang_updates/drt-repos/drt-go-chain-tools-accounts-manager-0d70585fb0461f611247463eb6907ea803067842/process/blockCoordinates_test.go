@@ -0,0 +1,183 @@
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	nodeCore "github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/config"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// answerAddressShard answers the query of the shard of an address, returning false for the other paths
+func answerAddressShard(path string, value interface{}, addressShards map[string]uint32) bool {
+	if !strings.HasSuffix(path, "/shard") {
+		return false
+	}
+
+	address := strings.TrimSuffix(strings.TrimPrefix(path, "/address/"), "/shard")
+	response := value.(*data.GenericAPIResponse)
+	shardID, found := addressShards[address]
+	if !found {
+		response.Error = "unknown address"
+		return true
+	}
+
+	response.Data, _ = json.Marshal(map[string]interface{}{"shardID": shardID})
+	return true
+}
+
+func copyTestBlockInfo() *data.BlockInfo {
+	blockInfo := *testBlockInfo
+	return &blockInfo
+}
+
+func TestPathWithBlockHash(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "/vm-values/query?blockHash=52a2e3c800d03b1499e3cbc57431ee5f122e1bf0e1065fa05578f2d58621f7a0", pathWithBlockHash(pathVMValues, testBlockInfo))
+}
+
+func TestAccountsGetter_BlockForAddress(t *testing.T) {
+	t.Parallel()
+
+	numShardQueries := 0
+	restClient := &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			numShardQueries++
+			answerAddressShard(path, value, map[string]uint32{
+				"drt1meta":   nodeCore.MetachainShardId,
+				"drt1shard1": 1,
+				"drt1shard2": 2,
+			})
+			return nil
+		},
+	}
+	ag, _ := NewAccountsGetter(restClient, nil, data.RestApiAuthenticationData{}, config.GeneralConfig{}, &mocks.ElasticClientStub{})
+
+	blockInfo, err := ag.blockForAddress(testBlockInfo, "drt1meta")
+	require.Nil(t, err)
+	require.Equal(t, testBlockInfo, blockInfo)
+
+	blockInfo, err = ag.blockForAddress(testBlockInfo, "drt1shard1")
+	require.Nil(t, err)
+	require.Equal(t, testBlockInfo.ShardBlocks[1], blockInfo)
+
+	blockInfo, err = ag.blockForAddress(testBlockInfo, "drt1shard2")
+	require.Nil(t, blockInfo)
+	require.True(t, errors.Is(err, ErrCannotPinBlock))
+
+	blockInfo, err = ag.blockForAddress(testBlockInfo, "drt1unknown")
+	require.Nil(t, blockInfo)
+	require.NotNil(t, err)
+
+	// the shard of an address is queried once
+	_, _ = ag.blockForAddress(testBlockInfo, "drt1shard1")
+	require.Equal(t, 4, numShardQueries)
+}
+
+func TestAccountsGetter_CheckQueriedBlock(t *testing.T) {
+	t.Parallel()
+
+	ag, _ := NewAccountsGetter(&mocks.RestClientStub{}, nil, data.RestApiAuthenticationData{}, config.GeneralConfig{}, &mocks.ElasticClientStub{})
+
+	t.Run("same block", func(t *testing.T) {
+		t.Parallel()
+
+		queriedBlock := *testBlockInfo
+		require.Nil(t, ag.checkQueriedBlock(testBlockInfo, &queriedBlock, "source"))
+	})
+
+	t.Run("block not reported should error", func(t *testing.T) {
+		t.Parallel()
+
+		blockInfo := copyTestBlockInfo()
+		err := ag.checkQueriedBlock(blockInfo, nil, "source")
+		require.True(t, errors.Is(err, ErrBlockNotReported))
+		require.Empty(t, blockInfo.UnverifiedSources)
+	})
+
+	t.Run("block not reported should record the unverified source, if allowed", func(t *testing.T) {
+		t.Parallel()
+
+		agAllowingUnverifiedSources, _ := NewAccountsGetter(&mocks.RestClientStub{}, nil, data.RestApiAuthenticationData{}, config.GeneralConfig{AllowUnverifiedSources: true}, &mocks.ElasticClientStub{})
+
+		blockInfo := copyTestBlockInfo()
+		require.Nil(t, agAllowingUnverifiedSources.checkQueriedBlock(blockInfo, nil, "source"))
+		require.Nil(t, agAllowingUnverifiedSources.checkQueriedBlock(blockInfo, nil, "source"))
+		require.Nil(t, agAllowingUnverifiedSources.checkQueriedBlock(blockInfo, nil, "other source"))
+		require.Equal(t, []string{"source", "other source"}, blockInfo.UnverifiedSources)
+		require.Empty(t, testBlockInfo.UnverifiedSources)
+	})
+
+	t.Run("block of another nonce", func(t *testing.T) {
+		t.Parallel()
+
+		queriedBlock := *testBlockInfo
+		queriedBlock.Nonce++
+
+		err := ag.checkQueriedBlock(testBlockInfo, &queriedBlock, "source")
+		require.True(t, errors.Is(err, ErrBlockMismatch))
+	})
+
+	t.Run("block of another fork", func(t *testing.T) {
+		t.Parallel()
+
+		queriedBlock := *testBlockInfo
+		queriedBlock.Hash = "aa"
+
+		err := ag.checkQueriedBlock(testBlockInfo, &queriedBlock, "source")
+		require.True(t, errors.Is(err, ErrBlockMismatch))
+	})
+
+	t.Run("block without hash", func(t *testing.T) {
+		t.Parallel()
+
+		queriedBlock := *testBlockInfo
+		queriedBlock.Hash = ""
+
+		err := ag.checkQueriedBlock(testBlockInfo, &queriedBlock, "source")
+		require.True(t, errors.Is(err, ErrBlockMismatch))
+	})
+}
+
+func TestAccountsGetter_GetAccountsWithEnergyAtTheShardBlock(t *testing.T) {
+	t.Parallel()
+
+	shardBlock := testBlockInfo.ShardBlocks[1]
+	queriedPath := ""
+	restClient := &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			if answerAddressShard(path, value, map[string]uint32{"drt1energy": 1}) {
+				return nil
+			}
+
+			queriedPath = path
+			response := value.(*data.GenericAPIResponse)
+			response.Data, _ = json.Marshal(map[string]interface{}{
+				"pairs":     map[string]string{},
+				"blockInfo": shardBlock,
+			})
+			return nil
+		},
+	}
+
+	ag, _ := NewAccountsGetter(restClient, nil, data.RestApiAuthenticationData{}, config.GeneralConfig{EnergyContractAddress: "drt1energy"}, &mocks.ElasticClientStub{})
+
+	accounts, err := ag.GetAccountsWithEnergy(0, testBlockInfo)
+	require.Nil(t, err)
+	require.Empty(t, accounts)
+	require.Equal(t, fmt.Sprintf("/address/drt1energy/keys?blockHash=%s", shardBlock.Hash), queriedPath)
+
+	// the metablock does not match the state of the shard
+	metaBlockInfo := copyTestBlockInfo()
+	metaBlockInfo.ShardBlocks = map[uint32]*data.BlockInfo{1: metaBlockInfo}
+	accounts, err = ag.GetAccountsWithEnergy(0, metaBlockInfo)
+	require.Nil(t, accounts)
+	require.True(t, errors.Is(err, ErrBlockMismatch))
+}
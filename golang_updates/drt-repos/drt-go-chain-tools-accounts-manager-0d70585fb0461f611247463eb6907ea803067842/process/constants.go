@@ -2,4 +2,6 @@ package process
 
 const (
 	accountsIndex = "accounts-000001"
+
+	outputTypeElasticsearch = "elasticsearch"
 )
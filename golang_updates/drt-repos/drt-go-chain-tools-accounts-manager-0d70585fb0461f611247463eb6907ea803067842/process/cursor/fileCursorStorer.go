@@ -0,0 +1,74 @@
+package cursor
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+const tmpFileSuffix = ".tmp"
+
+type fileCursorStorer struct {
+	path string
+}
+
+// NewFileCursorStorer will create a new instance of fileCursorStorer, which keeps the cursor in a json file
+func NewFileCursorStorer(path string) (*fileCursorStorer, error) {
+	if path == "" {
+		return nil, errors.New("empty cursor path")
+	}
+
+	return &fileCursorStorer{
+		path: path,
+	}, nil
+}
+
+// LoadCursor will load the cursor from the file. It returns a nil cursor if no epoch was processed yet
+func (fcs *fileCursorStorer) LoadCursor() (*data.EpochCursor, error) {
+	cursorBytes, err := ioutil.ReadFile(fcs.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	epochCursor := &data.EpochCursor{}
+	err = json.Unmarshal(cursorBytes, epochCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return epochCursor, nil
+}
+
+// SaveCursor will save the cursor in the file. The file is replaced atomically, so that a crash does not leave a
+// partially written cursor behind
+func (fcs *fileCursorStorer) SaveCursor(epochCursor *data.EpochCursor) error {
+	cursorBytes, err := json.Marshal(epochCursor)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fcs.path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := fcs.path + tmpFileSuffix
+	err = ioutil.WriteFile(tmpPath, cursorBytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, fcs.path)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fcs *fileCursorStorer) IsInterfaceNil() bool {
+	return fcs == nil
+}
@@ -0,0 +1,53 @@
+package cursor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileCursorStorer(t *testing.T) {
+	t.Parallel()
+
+	storer, err := NewFileCursorStorer("")
+	require.Nil(t, storer)
+	require.NotNil(t, err)
+
+	storer, err = NewFileCursorStorer("cursor.json")
+	require.Nil(t, err)
+	require.False(t, storer.IsInterfaceNil())
+}
+
+func TestFileCursorStorer_SaveAndLoadCursor(t *testing.T) {
+	t.Parallel()
+
+	storer, _ := NewFileCursorStorer(filepath.Join(t.TempDir(), "db", "cursor.json"))
+
+	epochCursor, err := storer.LoadCursor()
+	require.Nil(t, err)
+	require.Nil(t, epochCursor)
+
+	savedCursor := &data.EpochCursor{
+		Epoch: 7,
+		Index: "accounts-000001_7",
+		BlockInfo: &data.BlockInfo{
+			Hash:     "aa",
+			Nonce:    100,
+			RootHash: "bb",
+		},
+		AccountsWithStake: map[string]*data.StakeInfo{
+			"drt1a": {
+				Delegation:    "1000",
+				DelegationNum: 0.000000000000001,
+			},
+		},
+	}
+	err = storer.SaveCursor(savedCursor)
+	require.Nil(t, err)
+
+	epochCursor, err = storer.LoadCursor()
+	require.Nil(t, err)
+	require.Equal(t, savedCursor, epochCursor)
+}
@@ -0,0 +1,139 @@
+package process
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type daemonDataProcessor struct {
+	accountsProcessor AccountsProcessorHandler
+	reindexer         Reindexer
+	cursorStorer      CursorStorer
+}
+
+// NewDaemonDataProcessor will create a new instance of daemonDataProcessor
+func NewDaemonDataProcessor(
+	accountsProcessor AccountsProcessorHandler,
+	reindexer Reindexer,
+	cursorStorer CursorStorer,
+) (*daemonDataProcessor, error) {
+	if check.IfNil(accountsProcessor) {
+		return nil, ErrNilAccountsProcessor
+	}
+	if check.IfNil(reindexer) {
+		return nil, ErrNilReindexer
+	}
+	if check.IfNil(cursorStorer) {
+		return nil, ErrNilCursorStorer
+	}
+
+	return &daemonDataProcessor{
+		accountsProcessor: accountsProcessor,
+		reindexer:         reindexer,
+		cursorStorer:      cursorStorer,
+	}, nil
+}
+
+// ProcessAccountsData will process the accounts data of the current epoch, if it was not processed already. Only the
+// accounts whose stake changed since the last processed epoch, or whose balance was updated since its pinned block, are
+// reindexed. If no epoch was processed yet, or the timestamp of its pinned block is unknown, all the accounts are
+// reindexed
+func (dp *daemonDataProcessor) ProcessAccountsData() error {
+	epoch, err := dp.accountsProcessor.GetCurrentEpoch()
+	if err != nil {
+		return err
+	}
+
+	epochCursor, err := dp.cursorStorer.LoadCursor()
+	if err != nil {
+		return err
+	}
+	if epochCursor != nil && epochCursor.Epoch >= epoch {
+		log.Debug("epoch already processed", "epoch", epoch, "last processed epoch", epochCursor.Epoch)
+		return nil
+	}
+
+	log.Info("Processing accounts data", "epoch", epoch)
+
+	accountsRest, err := dp.accountsProcessor.GetAllAccountsWithStake(epoch)
+	if err != nil {
+		return err
+	}
+
+	newIndex, err := dp.accountsProcessor.ComputeClonedAccountsIndex(epoch)
+	if err != nil {
+		return err
+	}
+
+	// the index might be left over by a previous attempt, which did not complete
+	err = dp.reindexer.RemoveIndex(newIndex)
+	if err != nil {
+		return err
+	}
+
+	if epochCursor == nil || epochCursor.BlockInfo == nil || epochCursor.BlockInfo.Timestamp == 0 {
+		log.Info("No epoch with a known block timestamp was processed yet, reindexing all accounts", "newIndex", newIndex, "epoch", epoch)
+
+		err = dp.reindexer.ReindexAccounts(accountsIndex, newIndex, accountsRest)
+	} else {
+		diff := computeAccountsDiff(epochCursor.AccountsWithStake, accountsRest.AccountsWithStake)
+		diff.BalancesUpdatedSince = epochCursor.BlockInfo.Timestamp
+
+		log.Info("Reindexing changed accounts", "previousIndex", epochCursor.Index, "newIndex", newIndex, "epoch", epoch,
+			"num changed", len(diff.Changed), "num removed", len(diff.Removed))
+
+		err = dp.reindexer.ReindexChangedAccounts(accountsIndex, epochCursor.Index, newIndex, accountsRest, diff)
+	}
+	if err != nil {
+		return err
+	}
+
+	return dp.cursorStorer.SaveCursor(&data.EpochCursor{
+		Epoch:             epoch,
+		Index:             newIndex,
+		BlockInfo:         accountsRest.BlockInfo,
+		AccountsWithStake: extractStakeInfo(accountsRest.AccountsWithStake),
+	})
+}
+
+// computeAccountsDiff returns the addresses whose stake was added or changed, and the addresses which have no stake anymore
+func computeAccountsDiff(previous map[string]*data.StakeInfo, current map[string]*data.AccountInfoWithStakeValues) *data.AccountsDiff {
+	diff := &data.AccountsDiff{
+		Changed: make([]string, 0),
+		Removed: make([]string, 0),
+	}
+
+	for address, account := range current {
+		previousStake, found := previous[address]
+		if found && reflect.DeepEqual(*previousStake, account.StakeInfo) {
+			continue
+		}
+
+		diff.Changed = append(diff.Changed, address)
+	}
+
+	for address := range previous {
+		_, found := current[address]
+		if !found {
+			diff.Removed = append(diff.Removed, address)
+		}
+	}
+
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+func extractStakeInfo(accounts map[string]*data.AccountInfoWithStakeValues) map[string]*data.StakeInfo {
+	stakeInfo := make(map[string]*data.StakeInfo, len(accounts))
+	for address, account := range accounts {
+		accountStake := account.StakeInfo
+		stakeInfo[address] = &accountStake
+	}
+
+	return stakeInfo
+}
@@ -0,0 +1,223 @@
+package process
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountsData() *data.AccountsData {
+	return &data.AccountsData{
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"addr1": {StakeInfo: data.StakeInfo{ValidatorsActive: "10"}},
+			"addr2": {StakeInfo: data.StakeInfo{ValidatorsActive: "20"}},
+			"addr3": {StakeInfo: data.StakeInfo{Delegation: "30"}},
+		},
+		BlockInfo: testBlockInfo,
+	}
+}
+
+func TestNewDaemonDataProcessor(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewDaemonDataProcessor(nil, &mocks.ReindexerStub{}, &mocks.CursorStorerStub{})
+	require.Equal(t, ErrNilAccountsProcessor, err)
+
+	_, err = NewDaemonDataProcessor(&mocks.AccountsProcessorStub{}, nil, &mocks.CursorStorerStub{})
+	require.Equal(t, ErrNilReindexer, err)
+
+	_, err = NewDaemonDataProcessor(&mocks.AccountsProcessorStub{}, &mocks.ReindexerStub{}, nil)
+	require.Equal(t, ErrNilCursorStorer, err)
+
+	dp, err := NewDaemonDataProcessor(&mocks.AccountsProcessorStub{}, &mocks.ReindexerStub{}, &mocks.CursorStorerStub{})
+	require.Nil(t, err)
+	require.NotNil(t, dp)
+}
+
+func TestDaemonDataProcessor_ProcessAccountsDataNoCursorReindexesAll(t *testing.T) {
+	t.Parallel()
+
+	accountsData := createAccountsData()
+	fullReindexCalled := false
+	var savedCursor *data.EpochCursor
+	dp, _ := NewDaemonDataProcessor(&mocks.AccountsProcessorStub{
+		GetCurrentEpochCalled: func() (uint32, error) {
+			return 10, nil
+		},
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			return accountsData, nil
+		},
+		ComputeClonedAccountsIndexCalled: func(epoch uint32) (string, error) {
+			return "accounts-000001_10", nil
+		},
+	}, &mocks.ReindexerStub{
+		ReindexAccountsCalled: func(sourceIndex string, destinationIndex string, accounts *data.AccountsData) error {
+			fullReindexCalled = true
+			require.Equal(t, accountsIndex, sourceIndex)
+			require.Equal(t, "accounts-000001_10", destinationIndex)
+			return nil
+		},
+		ReindexChangedAccountsCalled: func(_ string, _ string, _ string, _ *data.AccountsData, _ *data.AccountsDiff) error {
+			require.Fail(t, "should not have been called")
+			return nil
+		},
+	}, &mocks.CursorStorerStub{
+		SaveCursorCalled: func(cursor *data.EpochCursor) error {
+			savedCursor = cursor
+			return nil
+		},
+	})
+
+	err := dp.ProcessAccountsData()
+	require.Nil(t, err)
+	require.True(t, fullReindexCalled)
+	require.Equal(t, uint32(10), savedCursor.Epoch)
+	require.Equal(t, "accounts-000001_10", savedCursor.Index)
+	require.Equal(t, testBlockInfo, savedCursor.BlockInfo)
+	require.Len(t, savedCursor.AccountsWithStake, 3)
+	require.Equal(t, "20", savedCursor.AccountsWithStake["addr2"].ValidatorsActive)
+}
+
+func TestDaemonDataProcessor_ProcessAccountsDataEpochAlreadyProcessed(t *testing.T) {
+	t.Parallel()
+
+	dp, _ := NewDaemonDataProcessor(&mocks.AccountsProcessorStub{
+		GetCurrentEpochCalled: func() (uint32, error) {
+			return 10, nil
+		},
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			require.Fail(t, "should not have been called")
+			return nil, nil
+		},
+	}, &mocks.ReindexerStub{}, &mocks.CursorStorerStub{
+		LoadCursorCalled: func() (*data.EpochCursor, error) {
+			return &data.EpochCursor{Epoch: 10}, nil
+		},
+		SaveCursorCalled: func(cursor *data.EpochCursor) error {
+			require.Fail(t, "should not have been called")
+			return nil
+		},
+	})
+
+	err := dp.ProcessAccountsData()
+	require.Nil(t, err)
+}
+
+func TestDaemonDataProcessor_ProcessAccountsDataNewEpochReindexesChanged(t *testing.T) {
+	t.Parallel()
+
+	var receivedDiff *data.AccountsDiff
+	removedIndex := ""
+	dp, _ := NewDaemonDataProcessor(&mocks.AccountsProcessorStub{
+		GetCurrentEpochCalled: func() (uint32, error) {
+			return 11, nil
+		},
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			return createAccountsData(), nil
+		},
+		ComputeClonedAccountsIndexCalled: func(epoch uint32) (string, error) {
+			return "accounts-000001_11", nil
+		},
+	}, &mocks.ReindexerStub{
+		RemoveIndexCalled: func(index string) error {
+			removedIndex = index
+			return nil
+		},
+		ReindexChangedAccountsCalled: func(sourceIndex string, previousIndex string, destinationIndex string, _ *data.AccountsData, diff *data.AccountsDiff) error {
+			require.Equal(t, accountsIndex, sourceIndex)
+			require.Equal(t, "accounts-000001_10", previousIndex)
+			require.Equal(t, "accounts-000001_11", destinationIndex)
+			receivedDiff = diff
+			return nil
+		},
+	}, &mocks.CursorStorerStub{
+		LoadCursorCalled: func() (*data.EpochCursor, error) {
+			return &data.EpochCursor{
+				Epoch:     10,
+				Index:     "accounts-000001_10",
+				BlockInfo: testBlockInfo,
+				AccountsWithStake: map[string]*data.StakeInfo{
+					"addr1": {ValidatorsActive: "10"},
+					"addr2": {ValidatorsActive: "15"},
+					"addr4": {Delegation: "40"},
+				},
+			}, nil
+		},
+	})
+
+	err := dp.ProcessAccountsData()
+	require.Nil(t, err)
+	require.Equal(t, "accounts-000001_11", removedIndex)
+	require.Equal(t, &data.AccountsDiff{
+		Changed:              []string{"addr2", "addr3"},
+		Removed:              []string{"addr4"},
+		BalancesUpdatedSince: testBlockInfo.Timestamp,
+	}, receivedDiff)
+}
+
+func TestDaemonDataProcessor_ProcessAccountsDataCursorWithoutBlockTimestampReindexesAll(t *testing.T) {
+	t.Parallel()
+
+	fullReindexCalled := false
+	dp, _ := NewDaemonDataProcessor(&mocks.AccountsProcessorStub{
+		GetCurrentEpochCalled: func() (uint32, error) {
+			return 11, nil
+		},
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			return createAccountsData(), nil
+		},
+		ComputeClonedAccountsIndexCalled: func(epoch uint32) (string, error) {
+			return "accounts-000001_11", nil
+		},
+	}, &mocks.ReindexerStub{
+		ReindexAccountsCalled: func(_ string, _ string, _ *data.AccountsData) error {
+			fullReindexCalled = true
+			return nil
+		},
+		ReindexChangedAccountsCalled: func(_ string, _ string, _ string, _ *data.AccountsData, _ *data.AccountsDiff) error {
+			require.Fail(t, "should not have been called")
+			return nil
+		},
+	}, &mocks.CursorStorerStub{
+		LoadCursorCalled: func() (*data.EpochCursor, error) {
+			return &data.EpochCursor{
+				Epoch:     10,
+				Index:     "accounts-000001_10",
+				BlockInfo: &data.BlockInfo{Hash: testBlockInfo.Hash, Nonce: testBlockInfo.Nonce},
+			}, nil
+		},
+	})
+
+	err := dp.ProcessAccountsData()
+	require.Nil(t, err)
+	require.True(t, fullReindexCalled)
+}
+
+func TestDaemonDataProcessor_ProcessAccountsDataReindexErrorDoesNotSaveCursor(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("local error")
+	dp, _ := NewDaemonDataProcessor(&mocks.AccountsProcessorStub{
+		GetCurrentEpochCalled: func() (uint32, error) {
+			return 10, nil
+		},
+		GetAllAccountsWithStakeCalled: func(epoch uint32) (*data.AccountsData, error) {
+			return createAccountsData(), nil
+		},
+	}, &mocks.ReindexerStub{
+		ReindexAccountsCalled: func(_ string, _ string, _ *data.AccountsData) error {
+			return expectedErr
+		},
+	}, &mocks.CursorStorerStub{
+		SaveCursorCalled: func(cursor *data.EpochCursor) error {
+			require.Fail(t, "should not have been called")
+			return nil
+		},
+	})
+
+	err := dp.ProcessAccountsData()
+	require.Equal(t, expectedErr, err)
+}
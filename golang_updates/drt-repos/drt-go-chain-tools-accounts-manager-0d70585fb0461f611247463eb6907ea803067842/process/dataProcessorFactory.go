@@ -10,6 +10,9 @@ import (
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/crossIndex"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/crossIndex/reindexer"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/elasticClient"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/process/cursor"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/process/output"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/process/stakeHistory"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/restClient"
 )
 
@@ -17,20 +20,111 @@ var log = logger.GetOrCreate("process")
 
 // CreateDataProcessor will create a new instance of a data processor
 func CreateDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
+	if !isElasticsearchOutput(cfg) {
+		return getSinkDataProcessor(cfg)
+	}
+
 	return getReindexerDataProcessor(cfg, indicesConfigPath)
 }
 
-func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
-	sourceEsClient, err := elasticClient.NewElasticClient(cfg.Reindexer.SourceElasticSearchClient)
+// CreateDaemonDataProcessor will create a new instance of a data processor which only reindexes the accounts changed
+// since the last processed epoch. With a file output, the accounts data of each new epoch is written
+func CreateDaemonDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
+	if !isElasticsearchOutput(cfg) {
+		return getSinkDataProcessor(cfg)
+	}
+
+	acctsProcessor, reindexerProc, err := createAccountsProcessorAndReindexer(cfg, indicesConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cursorStorer, err := cursor.NewFileCursorStorer(cfg.Daemon.CursorPath)
 	if err != nil {
 		return nil, err
 	}
 
+	return NewDaemonDataProcessor(acctsProcessor, reindexerProc, cursorStorer)
+}
+
+// CreateStakeDiffReporter will create a new instance of a stake diff reporter, which reads the stake history from the
+// first destination cluster
+func CreateStakeDiffReporter(cfg *config.Config) (StakeDiffReporter, error) {
 	destinationESClients, err := createESClients(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	historyReader, err := stakeHistory.NewStakeHistory(destinationESClients[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStakeDiffReporter(historyReader)
+}
+
+func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
+	acctsProcessor, reindexerProc, err := createAccountsProcessorAndReindexer(cfg, indicesConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReindexerDataProcessor(acctsProcessor, reindexerProc)
+}
+
+func getSinkDataProcessor(cfg *config.Config) (DataProcessor, error) {
+	sink, err := output.NewFileSink(cfg.Output.Type, cfg.Output.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the source cluster is optional for the file outputs: it only provides the undelegated values from the staking
+	// provider contracts
+	var sourceEsClient ElasticClientHandler
+	if cfg.Reindexer.SourceElasticSearchClient.Address != "" {
+		sourceEsClient, err = elasticClient.NewElasticClient(cfg.Reindexer.SourceElasticSearchClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	acctsProcessor, err := createAccountsProcessor(cfg, sourceEsClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSinkDataProcessor(acctsProcessor, sink)
+}
+
+func isElasticsearchOutput(cfg *config.Config) bool {
+	return cfg.Output.Type == "" || cfg.Output.Type == outputTypeElasticsearch
+}
+
+func createAccountsProcessorAndReindexer(cfg *config.Config, indicesConfigPath string) (AccountsProcessorHandler, Reindexer, error) {
+	sourceEsClient, err := elasticClient.NewElasticClient(cfg.Reindexer.SourceElasticSearchClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	destinationESClients, err := createESClients(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acctsProcessor, err := createAccountsProcessor(cfg, sourceEsClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reindexerProc, err := reindexer.New(sourceEsClient, destinationESClients, indicesConfigPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return acctsProcessor, reindexerProc, nil
+}
+
+func createAccountsProcessor(cfg *config.Config, sourceEsClient ElasticClientHandler) (AccountsProcessorHandler, error) {
 	rClient, err := restClient.NewRestClient(cfg.APIConfig.URL)
 	if err != nil {
 		return nil, err
@@ -53,17 +147,7 @@ func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (Da
 		return nil, err
 	}
 
-	acctsProcessor, err := NewAccountsProcessor(rClient, acctGetter)
-	if err != nil {
-		return nil, err
-	}
-
-	reindexerProc, err := reindexer.New(sourceEsClient, destinationESClients, indicesConfigPath)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewReindexerDataProcessor(acctsProcessor, reindexerProc)
+	return NewAccountsProcessor(rClient, acctGetter)
 }
 
 func createESClients(cfg *config.Config) ([]crossIndex.ElasticClientHandler, error) {
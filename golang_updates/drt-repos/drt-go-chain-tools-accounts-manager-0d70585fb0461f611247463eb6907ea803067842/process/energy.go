@@ -18,35 +18,40 @@ const (
 	hexEncodedEnergyPrefix = "75736572456e65726779"
 )
 
-// GetAccountsWithEnergy will return accounts with energy
-func (ag *accountsGetter) GetAccountsWithEnergy(currentEpoch uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error) {
+// GetAccountsWithEnergy will return accounts with energy, at the provided block
+func (ag *accountsGetter) GetAccountsWithEnergy(currentEpoch uint32, blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error) {
 	if ag.energyContractAddress == "" {
-		return map[string]*data.AccountInfoWithStakeValues{}, nil, nil
+		return map[string]*data.AccountInfoWithStakeValues{}, nil
 	}
 
 	defer logExecutionTime(time.Now(), "Fetched accounts from energy contract")
 
+	contractBlock, err := ag.blockForAddress(blockInfo, ag.energyContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	genericAPIResponse := &data.GenericAPIResponse{}
-	path := fmt.Sprintf(pathAccountKeys, ag.energyContractAddress)
-	err := ag.restClient.CallGetRestEndPoint(path, genericAPIResponse, core.GetEmptyApiCredentials())
+	path := pathWithBlockHash(fmt.Sprintf(pathAccountKeys, ag.energyContractAddress), contractBlock)
+	err = ag.restClient.CallGetRestEndPoint(path, genericAPIResponse, core.GetEmptyApiCredentials())
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	if genericAPIResponse.Error != "" {
-		return nil, nil, fmt.Errorf("cannot get accounts with energy %s", genericAPIResponse.Error)
+		return nil, fmt.Errorf("cannot get accounts with energy %s", genericAPIResponse.Error)
 	}
 
-	accountsWithEnergy, err := ag.extractAddressesAndEnergy(genericAPIResponse.Data, currentEpoch)
+	err = ag.checkResponseBlockInfo(genericAPIResponse.Data, contractBlock, "energy contract")
 	if err != nil {
-		return nil, nil, fmt.Errorf("cannot extract accounts with energy %s", err.Error())
+		return nil, err
 	}
 
-	blockInfo, err := extractBlockInfo(genericAPIResponse.Data)
+	accountsWithEnergy, err := ag.extractAddressesAndEnergy(genericAPIResponse.Data, currentEpoch)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cannot extract block info %s", err.Error())
+		return nil, fmt.Errorf("cannot extract accounts with energy %s", err.Error())
 	}
 
-	return accountsWithEnergy, blockInfo, nil
+	return accountsWithEnergy, nil
 }
 
 func (ag *accountsGetter) extractAddressesAndEnergy(accountStorage []byte, currentEpoch uint32) (map[string]*data.AccountInfoWithStakeValues, error) {
@@ -188,8 +193,12 @@ func calculateEnergyValueBasedOnCurrentEpoch(energy *data.EnergyDetails, current
 	return energyValue
 }
 
+// extractBlockInfo returns the block info of a response, or nil if the response does not contain one
 func extractBlockInfo(responseWithBlockInfo []byte) (*data.BlockInfo, error) {
 	blockInfoData := gjson.Get(string(responseWithBlockInfo), "blockInfo")
+	if !blockInfoData.Exists() {
+		return nil, nil
+	}
 
 	blockInfo := &data.BlockInfo{}
 	err := json.Unmarshal([]byte(blockInfoData.String()), &blockInfo)
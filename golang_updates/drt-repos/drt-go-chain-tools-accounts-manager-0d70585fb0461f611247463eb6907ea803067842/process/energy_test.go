@@ -123,3 +123,11 @@ func TestExtractBlockInfo(t *testing.T) {
 		RootHash: "1829f8c869318f1c5ddc8a887fc2bb206b42fa9a484b8beb94e7873b633cdc61",
 	}, blockInfo)
 }
+
+func TestExtractBlockInfoNotReported(t *testing.T) {
+	t.Parallel()
+
+	blockInfo, err := extractBlockInfo([]byte(`{"pairs":{}}`))
+	require.Nil(t, err)
+	require.Nil(t, blockInfo)
+}
@@ -13,3 +13,34 @@ var ErrNilReindexer = errors.New("nil reindexer")
 
 // ErrNilCloner signals that a nil cloner has been provided
 var ErrNilCloner = errors.New("nil cloner")
+
+// ErrBlockMismatch signals that a source was queried at another block than the pinned one
+var ErrBlockMismatch = errors.New("block mismatch")
+
+// ErrBlockNotReported signals that a source did not report the block it was queried at, while unverified sources are
+// not allowed
+var ErrBlockNotReported = errors.New("the source did not report the block it was queried at")
+
+// ErrCannotPinBlock signals that the block to query all the sources at cannot be determined
+var ErrCannotPinBlock = errors.New("cannot pin block")
+
+// ErrNilCursorStorer signals that a nil cursor storer has been provided
+var ErrNilCursorStorer = errors.New("nil cursor storer")
+
+// ErrInvalidStakeSourceConfig signals that a stake source is not properly configured
+var ErrInvalidStakeSourceConfig = errors.New("invalid stake source config")
+
+// ErrCannotDecodeStakeSourceData signals that the data of a stake source does not match its configured types
+var ErrCannotDecodeStakeSourceData = errors.New("cannot decode stake source data")
+
+// ErrNilStakeHistoryReader signals that a nil stake history reader has been provided
+var ErrNilStakeHistoryReader = errors.New("nil stake history reader")
+
+// ErrInvalidReportFormat signals that an unknown report format has been provided
+var ErrInvalidReportFormat = errors.New("invalid report format")
+
+// ErrEpochNotInStakeHistory signals that the stake history index holds no account for the requested epoch
+var ErrEpochNotInStakeHistory = errors.New("epoch not found in the stake history")
+
+// ErrNilAccountsDataSink signals that a nil accounts data sink has been provided
+var ErrNilAccountsDataSink = errors.New("nil accounts data sink")
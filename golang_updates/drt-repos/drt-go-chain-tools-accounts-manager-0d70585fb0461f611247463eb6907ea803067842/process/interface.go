@@ -2,6 +2,7 @@ package process
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
 )
@@ -25,6 +26,7 @@ type RestClientHandler interface {
 type AccountsIndexerHandler interface {
 	GetAccounts(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
 	IndexAccounts(accounts map[string]*data.AccountInfoWithStakeValues, index string) error
+	RemoveAccounts(addresses []string, index string) error
 	IsInterfaceNil() bool
 }
 
@@ -36,13 +38,14 @@ type AccountsProcessorHandler interface {
 	IsInterfaceNil() bool
 }
 
-// AccountsGetterHandler defines what an accounts getter should be able to do
+// AccountsGetterHandler defines what an accounts getter should be able to do. All the accounts are fetched at the provided block
 type AccountsGetterHandler interface {
-	GetLegacyDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetLKMOAStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error)
-	GetAccountsWithEnergy(currentEpoch uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
+	GetLegacyDelegatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetValidatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetDelegatorsAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetLKMOAStakeAccounts(blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetAccountsWithEnergy(currentEpoch uint32, blockInfo *data.BlockInfo) (map[string]*data.AccountInfoWithStakeValues, error)
+	GetStakeSourcesAccounts(blockInfo *data.BlockInfo) (data.StakeSourcesAccounts, error)
 }
 
 // Cloner defines what a clone should be able to do
@@ -54,6 +57,32 @@ type Cloner interface {
 // Reindexer defines what a reindexer should be able to do
 type Reindexer interface {
 	ReindexAccounts(sourceIndex string, destinationIndex string, accountsData *data.AccountsData) error
+	ReindexChangedAccounts(sourceIndex string, previousIndex string, destinationIndex string, accountsData *data.AccountsData, diff *data.AccountsDiff) error
+	RemoveIndex(index string) error
+	IsInterfaceNil() bool
+}
+
+// CursorStorer defines what a cursor storer should be able to do
+type CursorStorer interface {
+	LoadCursor() (*data.EpochCursor, error)
+	SaveCursor(cursor *data.EpochCursor) error
+	IsInterfaceNil() bool
+}
+
+// StakeHistoryReader defines what a stake history reader should be able to do
+type StakeHistoryReader interface {
+	GetEpochStakeHistory(epoch uint32) (map[string]*data.StakeHistoryEntry, error)
+	IsInterfaceNil() bool
+}
+
+// StakeDiffReporter defines what a stake diff reporter should be able to do
+type StakeDiffReporter interface {
+	WriteStakeDiff(fromEpoch uint32, toEpoch uint32, topN int, format string, writer io.Writer) error
+}
+
+// AccountsDataSink defines what an output of the accounts data should be able to do
+type AccountsDataSink interface {
+	WriteAccountsData(accountsData *data.AccountsData) error
 	IsInterfaceNil() bool
 }
 
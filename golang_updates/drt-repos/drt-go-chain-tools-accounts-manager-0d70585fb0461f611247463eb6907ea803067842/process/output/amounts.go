@@ -0,0 +1,28 @@
+package output
+
+import "github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+
+type amount struct {
+	name  string
+	value *string
+	num   *float64
+}
+
+// stakeAmounts returns the amounts of a stake info, in the order of the csv columns and of the binary snapshot
+// records. Changing the order, or the list, changes the binary format, so the snapshot version must be increased
+func stakeAmounts(stakeInfo *data.StakeInfo) []amount {
+	return []amount{
+		{"delegationLegacyWaiting", &stakeInfo.DelegationLegacyWaiting, &stakeInfo.DelegationLegacyWaitingNum},
+		{"delegationLegacyActive", &stakeInfo.DelegationLegacyActive, &stakeInfo.DelegationLegacyActiveNum},
+		{"validatorsActive", &stakeInfo.ValidatorsActive, &stakeInfo.ValidatorsActiveNum},
+		{"validatorsTopUp", &stakeInfo.ValidatorTopUp, &stakeInfo.ValidatorTopUpNum},
+		{"delegation", &stakeInfo.Delegation, &stakeInfo.DelegationNum},
+		{"lkMoaStake", &stakeInfo.LKMOAStake, &stakeInfo.LKMOAStakeNum},
+		{"energy", &stakeInfo.Energy, &stakeInfo.EnergyNum},
+		{"unDelegateLegacy", &stakeInfo.UnDelegateLegacy, &stakeInfo.UnDelegateLegacyNum},
+		{"unDelegateValidator", &stakeInfo.UnDelegateValidator, &stakeInfo.UnDelegateValidatorNum},
+		{"unDelegateDelegation", &stakeInfo.UnDelegateDelegation, &stakeInfo.UnDelegateDelegationNum},
+		{"totalStake", &stakeInfo.TotalStake, &stakeInfo.TotalStakeNum},
+		{"totalUnDelegate", &stakeInfo.TotalUnDelegate, &stakeInfo.TotalUnDelegateNum},
+	}
+}
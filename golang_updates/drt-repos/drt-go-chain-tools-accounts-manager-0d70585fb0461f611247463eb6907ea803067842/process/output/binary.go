@@ -0,0 +1,229 @@
+package output
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/core"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+// The binary snapshot is laid out as follows, all the integers being big endian:
+//
+//	magic "ACCSNAP" | version u8
+//	epoch u32 | block nonce u64 | block hash str | block root hash str
+//	num accounts u32, followed by the accounts, sorted by address:
+//	    address str | the amounts of stakeAmounts, in order | num stake sources u16 | (name str | amount) ...
+//
+// A str is a u16 length followed by the bytes. An amount is a header byte followed by the big endian magnitude: the
+// highest bit of the header is the sign and the other bits are the length of the magnitude. A negative amount with
+// no magnitude marks an amount which is not set. The energy details are not part of the snapshot
+const (
+	binaryMagic          = "ACCSNAP"
+	binaryVersion        = byte(1)
+	amountSignBit        = byte(0x80)
+	maxAmountNumBytes    = 0x7f
+	amountNotSetHeader   = amountSignBit
+	maxBinaryStringBytes = 0xffff
+)
+
+// ErrInvalidBinarySnapshot signals that the data is not a binary snapshot, or that it is corrupted
+var ErrInvalidBinarySnapshot = errors.New("invalid binary snapshot")
+
+type binaryWriter struct {
+	writer io.Writer
+	err    error
+}
+
+func (bw *binaryWriter) write(value interface{}) {
+	if bw.err != nil {
+		return
+	}
+
+	bw.err = binary.Write(bw.writer, binary.BigEndian, value)
+}
+
+func (bw *binaryWriter) writeString(value string) {
+	if bw.err == nil && len(value) > maxBinaryStringBytes {
+		bw.err = fmt.Errorf("string of %d bytes is too long", len(value))
+	}
+
+	bw.write(uint16(len(value)))
+	bw.write([]byte(value))
+}
+
+func (bw *binaryWriter) writeAmount(value string) {
+	if value == "" {
+		bw.write(amountNotSetHeader)
+		return
+	}
+
+	amount, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		if bw.err == nil {
+			bw.err = fmt.Errorf("invalid amount %s", value)
+		}
+		return
+	}
+
+	magnitude := amount.Bytes()
+	if bw.err == nil && len(magnitude) > maxAmountNumBytes {
+		bw.err = fmt.Errorf("amount %s is too big", value)
+	}
+
+	header := byte(len(magnitude))
+	if amount.Sign() < 0 {
+		header |= amountSignBit
+	}
+
+	bw.write(header)
+	bw.write(magnitude)
+}
+
+func encodeBinary(writer io.Writer, accountsData *data.AccountsData) error {
+	bw := &binaryWriter{writer: writer}
+
+	blockInfo := accountsData.BlockInfo
+	if blockInfo == nil {
+		blockInfo = &data.BlockInfo{}
+	}
+
+	bw.write([]byte(binaryMagic))
+	bw.write(binaryVersion)
+	bw.write(accountsData.Epoch)
+	bw.write(blockInfo.Nonce)
+	bw.writeString(blockInfo.Hash)
+	bw.writeString(blockInfo.RootHash)
+	bw.write(uint32(len(accountsData.AccountsWithStake)))
+
+	for _, address := range sortedAddresses(accountsData.AccountsWithStake) {
+		stakeInfo := accountsData.AccountsWithStake[address].StakeInfo
+
+		bw.writeString(address)
+		for _, stakeAmount := range stakeAmounts(&stakeInfo) {
+			bw.writeAmount(*stakeAmount.value)
+		}
+
+		names := make([]string, 0, len(stakeInfo.StakeSources))
+		for name := range stakeInfo.StakeSources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		bw.write(uint16(len(names)))
+		for _, name := range names {
+			bw.writeString(name)
+			bw.writeAmount(stakeInfo.StakeSources[name].Stake)
+		}
+	}
+
+	return bw.err
+}
+
+type binaryReader struct {
+	reader io.Reader
+	err    error
+}
+
+func (br *binaryReader) read(value interface{}) {
+	if br.err != nil {
+		return
+	}
+
+	br.err = binary.Read(br.reader, binary.BigEndian, value)
+}
+
+func (br *binaryReader) readBytes(numBytes int) []byte {
+	buff := make([]byte, numBytes)
+	br.read(buff)
+
+	return buff
+}
+
+func (br *binaryReader) readString() string {
+	var length uint16
+	br.read(&length)
+
+	return string(br.readBytes(int(length)))
+}
+
+func (br *binaryReader) readAmount() (string, float64) {
+	var header byte
+	br.read(&header)
+	if header == amountNotSetHeader {
+		return "", 0
+	}
+
+	amount := big.NewInt(0).SetBytes(br.readBytes(int(header &^ amountSignBit)))
+	if header&amountSignBit != 0 {
+		amount.Neg(amount)
+	}
+
+	return amount.String(), core.ComputeBalanceAsFloat(amount.String())
+}
+
+// ReadBinarySnapshot will read a binary snapshot, as written by the binary output
+func ReadBinarySnapshot(reader io.Reader) (*data.AccountsData, error) {
+	br := &binaryReader{reader: bufio.NewReader(reader)}
+
+	magic := br.readBytes(len(binaryMagic))
+	var version byte
+	br.read(&version)
+	if br.err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBinarySnapshot, br.err.Error())
+	}
+	if string(magic) != binaryMagic || version != binaryVersion {
+		return nil, fmt.Errorf("%w: unknown header or version %d", ErrInvalidBinarySnapshot, version)
+	}
+
+	accountsData := &data.AccountsData{
+		BlockInfo: &data.BlockInfo{},
+	}
+	br.read(&accountsData.Epoch)
+	br.read(&accountsData.BlockInfo.Nonce)
+	accountsData.BlockInfo.Hash = br.readString()
+	accountsData.BlockInfo.RootHash = br.readString()
+
+	var numAccounts uint32
+	br.read(&numAccounts)
+	if br.err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBinarySnapshot, br.err.Error())
+	}
+
+	accountsData.AccountsWithStake = make(map[string]*data.AccountInfoWithStakeValues)
+	accountsData.Addresses = make([]string, 0)
+	for idx := uint32(0); idx < numAccounts && br.err == nil; idx++ {
+		address := br.readString()
+
+		account := &data.AccountInfoWithStakeValues{}
+		for _, stakeAmount := range stakeAmounts(&account.StakeInfo) {
+			*stakeAmount.value, *stakeAmount.num = br.readAmount()
+		}
+
+		var numStakeSources uint16
+		br.read(&numStakeSources)
+		for sourceIdx := uint16(0); sourceIdx < numStakeSources && br.err == nil; sourceIdx++ {
+			if account.StakeSources == nil {
+				account.StakeSources = make(map[string]*data.SourceStake)
+			}
+
+			name := br.readString()
+			sourceStake := &data.SourceStake{}
+			sourceStake.Stake, sourceStake.StakeNum = br.readAmount()
+			account.StakeSources[name] = sourceStake
+		}
+
+		accountsData.AccountsWithStake[address] = account
+		accountsData.Addresses = append(accountsData.Addresses, address)
+	}
+	if br.err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBinarySnapshot, br.err.Error())
+	}
+
+	return accountsData, nil
+}
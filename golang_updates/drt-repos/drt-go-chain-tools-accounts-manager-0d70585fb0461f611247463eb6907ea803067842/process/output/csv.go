@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+const stakeSourceColumnPrefix = "stakeSources."
+
+func encodeCSV(writer io.Writer, accountsData *data.AccountsData) error {
+	stakeSourceNames := collectStakeSourceNames(accountsData.AccountsWithStake)
+
+	header := []string{"address", "epoch", "blockNonce"}
+	for _, stakeAmount := range stakeAmounts(&data.StakeInfo{}) {
+		header = append(header, stakeAmount.name)
+	}
+	for _, name := range stakeSourceNames {
+		header = append(header, stakeSourceColumnPrefix+name)
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range sortedAddresses(accountsData.AccountsWithStake) {
+		stakeInfo := accountsData.AccountsWithStake[address].StakeInfo
+
+		row := []string{address, fmt.Sprintf("%d", accountsData.Epoch), fmt.Sprintf("%d", blockNonce(accountsData))}
+		for _, stakeAmount := range stakeAmounts(&stakeInfo) {
+			row = append(row, *stakeAmount.value)
+		}
+		for _, name := range stakeSourceNames {
+			sourceStake, ok := stakeInfo.StakeSources[name]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+
+			row = append(row, sourceStake.Stake)
+		}
+
+		err = csvWriter.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func collectStakeSourceNames(accounts map[string]*data.AccountInfoWithStakeValues) []string {
+	namesMap := make(map[string]struct{})
+	for _, account := range accounts {
+		for name := range account.StakeSources {
+			namesMap[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(namesMap))
+	for name := range namesMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+const (
+	// TypeNDJSON writes one json object per account and per line
+	TypeNDJSON = "ndjson"
+	// TypeCSV writes one row per account, with a header
+	TypeCSV = "csv"
+	// TypeBinary writes a compact binary snapshot, readable with ReadBinarySnapshot
+	TypeBinary = "binary"
+
+	tmpFileSuffix = ".tmp"
+)
+
+var log = logger.GetOrCreate("process/output")
+
+type accountsEncoder func(writer io.Writer, accountsData *data.AccountsData) error
+
+type fileSink struct {
+	dir       string
+	extension string
+	encode    accountsEncoder
+}
+
+// NewFileSink will create a new instance of fileSink, which writes the accounts data of each epoch in a file of the
+// given folder, named accounts-<epoch>.<extension>
+func NewFileSink(outputType string, dir string) (*fileSink, error) {
+	if dir == "" {
+		return nil, errors.New("empty output path")
+	}
+
+	sink := &fileSink{
+		dir: dir,
+	}
+	switch outputType {
+	case TypeNDJSON:
+		sink.extension, sink.encode = "ndjson", encodeNDJSON
+	case TypeCSV:
+		sink.extension, sink.encode = "csv", encodeCSV
+	case TypeBinary:
+		sink.extension, sink.encode = "bin", encodeBinary
+	default:
+		return nil, fmt.Errorf("unknown output type %s", outputType)
+	}
+
+	return sink, nil
+}
+
+// WriteAccountsData will write the accounts data in the file of its epoch. The file is replaced atomically, so that
+// readers never see a partially written file
+func (fs *fileSink) WriteAccountsData(accountsData *data.AccountsData) error {
+	err := os.MkdirAll(fs.dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(fs.dir, fmt.Sprintf("accounts-%d.%s", accountsData.Epoch, fs.extension))
+	tmpPath := path + tmpFileSuffix
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	err = fs.encode(writer, accountsData)
+	if err == nil {
+		err = writer.Flush()
+	}
+	errClose := file.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	log.Info("Accounts data written", "file", path, "num accounts", len(accountsData.AccountsWithStake))
+
+	return os.Rename(tmpPath, path)
+}
+
+func sortedAddresses(accounts map[string]*data.AccountInfoWithStakeValues) []string {
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+func blockNonce(accountsData *data.AccountsData) uint64 {
+	if accountsData.BlockInfo == nil {
+		return 0
+	}
+
+	return accountsData.BlockInfo.Nonce
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fs *fileSink) IsInterfaceNil() bool {
+	return fs == nil
+}
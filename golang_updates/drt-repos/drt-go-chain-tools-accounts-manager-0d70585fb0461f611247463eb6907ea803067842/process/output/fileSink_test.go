@@ -0,0 +1,127 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountsData() *data.AccountsData {
+	return &data.AccountsData{
+		Epoch: 12,
+		BlockInfo: &data.BlockInfo{
+			Nonce:    3576295,
+			Hash:     "aabb",
+			RootHash: "ccdd",
+		},
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"addr2": {StakeInfo: data.StakeInfo{
+				Delegation:    "2000000000000000000",
+				DelegationNum: 2,
+				Energy:        "-1000000000000000000",
+				EnergyNum:     -1,
+				TotalStake:    "2000000000000000000",
+				TotalStakeNum: 2,
+				StakeSources: map[string]*data.SourceStake{
+					"farmStake": {Stake: "5000000000000000000", StakeNum: 5},
+				},
+			}},
+			"addr1": {StakeInfo: data.StakeInfo{
+				ValidatorsActive:    "2500000000000000000000",
+				ValidatorsActiveNum: 2500,
+				ValidatorTopUp:      "0",
+				TotalStake:          "2500000000000000000000",
+				TotalStakeNum:       2500,
+			}},
+		},
+	}
+}
+
+func writeAndRead(t *testing.T, outputType string, extension string) []byte {
+	dir := t.TempDir()
+	sink, err := NewFileSink(outputType, dir)
+	require.Nil(t, err)
+
+	err = sink.WriteAccountsData(createAccountsData())
+	require.Nil(t, err)
+
+	fileBytes, err := ioutil.ReadFile(filepath.Join(dir, "accounts-12."+extension))
+	require.Nil(t, err)
+
+	return fileBytes
+}
+
+func TestNewFileSink(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFileSink(TypeCSV, "")
+	require.NotNil(t, err)
+
+	_, err = NewFileSink("parquet", "output")
+	require.NotNil(t, err)
+
+	sink, err := NewFileSink(TypeNDJSON, "output")
+	require.Nil(t, err)
+	require.False(t, sink.IsInterfaceNil())
+}
+
+func TestFileSink_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	lines := strings.Split(strings.TrimSpace(string(writeAndRead(t, TypeNDJSON, "ndjson"))), "\n")
+	require.Len(t, lines, 2)
+
+	record := make(map[string]interface{})
+	err := json.Unmarshal([]byte(lines[1]), &record)
+	require.Nil(t, err)
+	require.Equal(t, "addr2", record["address"])
+	require.Equal(t, float64(12), record["epoch"])
+	require.Equal(t, float64(3576295), record["blockNonce"])
+	require.Equal(t, "2000000000000000000", record["delegation"])
+	require.Equal(t, map[string]interface{}{"stake": "5000000000000000000", "stakeNum": float64(5)},
+		record["stakeSources"].(map[string]interface{})["farmStake"])
+}
+
+func TestFileSink_CSV(t *testing.T) {
+	t.Parallel()
+
+	lines := strings.Split(strings.TrimSpace(string(writeAndRead(t, TypeCSV, "csv"))), "\n")
+	require.Equal(t, []string{
+		"address,epoch,blockNonce,delegationLegacyWaiting,delegationLegacyActive,validatorsActive,validatorsTopUp," +
+			"delegation,lkMoaStake,energy,unDelegateLegacy,unDelegateValidator,unDelegateDelegation,totalStake," +
+			"totalUnDelegate,stakeSources.farmStake",
+		"addr1,12,3576295,,,2500000000000000000000,0,,,,,,,2500000000000000000000,,",
+		"addr2,12,3576295,,,,,2000000000000000000,,-1000000000000000000,,,,2000000000000000000,,5000000000000000000",
+	}, lines)
+}
+
+func TestFileSink_BinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	fileBytes := writeAndRead(t, TypeBinary, "bin")
+
+	accountsData, err := ReadBinarySnapshot(bytes.NewReader(fileBytes))
+	require.Nil(t, err)
+
+	expected := createAccountsData()
+	expected.Addresses = []string{"addr1", "addr2"}
+	require.Equal(t, expected, accountsData)
+}
+
+func TestReadBinarySnapshotInvalidData(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadBinarySnapshot(strings.NewReader("not a snapshot"))
+	require.True(t, errors.Is(err, ErrInvalidBinarySnapshot))
+
+	fileBytes := writeAndRead(t, TypeBinary, "bin")
+	_, err = ReadBinarySnapshot(bytes.NewReader(fileBytes[:len(fileBytes)-3]))
+	require.True(t, errors.Is(err, ErrInvalidBinarySnapshot))
+}
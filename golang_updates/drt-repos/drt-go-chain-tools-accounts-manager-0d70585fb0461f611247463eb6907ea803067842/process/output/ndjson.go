@@ -0,0 +1,32 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+type accountRecord struct {
+	Address    string `json:"address"`
+	Epoch      uint32 `json:"epoch"`
+	BlockNonce uint64 `json:"blockNonce"`
+	data.StakeInfo
+}
+
+func encodeNDJSON(writer io.Writer, accountsData *data.AccountsData) error {
+	encoder := json.NewEncoder(writer)
+	for _, address := range sortedAddresses(accountsData.AccountsWithStake) {
+		err := encoder.Encode(&accountRecord{
+			Address:    address,
+			Epoch:      accountsData.Epoch,
+			BlockNonce: blockNonce(accountsData),
+			StakeInfo:  accountsData.AccountsWithStake[address].StakeInfo,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package process
+
+import "github.com/TerraDharitri/drt-go-chain-core/core/check"
+
+type sinkDataProcessor struct {
+	accountsProcessor AccountsProcessorHandler
+	sink              AccountsDataSink
+	lastEpoch         uint32
+	processedAnyEpoch bool
+}
+
+// NewSinkDataProcessor will create a new instance of sinkDataProcessor, which writes the accounts data to a sink
+// instead of reindexing it
+func NewSinkDataProcessor(
+	accountsProcessor AccountsProcessorHandler,
+	sink AccountsDataSink,
+) (*sinkDataProcessor, error) {
+	if check.IfNil(accountsProcessor) {
+		return nil, ErrNilAccountsProcessor
+	}
+	if check.IfNil(sink) {
+		return nil, ErrNilAccountsDataSink
+	}
+
+	return &sinkDataProcessor{
+		accountsProcessor: accountsProcessor,
+		sink:              sink,
+	}, nil
+}
+
+// ProcessAccountsData will write the accounts data of the current epoch to the sink, if it was not written already
+func (dp *sinkDataProcessor) ProcessAccountsData() error {
+	epoch, err := dp.accountsProcessor.GetCurrentEpoch()
+	if err != nil {
+		return err
+	}
+	if dp.processedAnyEpoch && dp.lastEpoch >= epoch {
+		log.Debug("epoch already processed", "epoch", epoch)
+		return nil
+	}
+
+	log.Info("Processing accounts data", "epoch", epoch)
+
+	accountsRest, err := dp.accountsProcessor.GetAllAccountsWithStake(epoch)
+	if err != nil {
+		return err
+	}
+
+	err = dp.sink.WriteAccountsData(accountsRest)
+	if err != nil {
+		return err
+	}
+
+	dp.lastEpoch = epoch
+	dp.processedAnyEpoch = true
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package process
+
+import (
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSinkDataProcessor(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSinkDataProcessor(nil, &mocks.AccountsDataSinkStub{})
+	require.Equal(t, ErrNilAccountsProcessor, err)
+
+	_, err = NewSinkDataProcessor(&mocks.AccountsProcessorStub{}, nil)
+	require.Equal(t, ErrNilAccountsDataSink, err)
+
+	dp, err := NewSinkDataProcessor(&mocks.AccountsProcessorStub{}, &mocks.AccountsDataSinkStub{})
+	require.Nil(t, err)
+	require.NotNil(t, dp)
+}
+
+func TestSinkDataProcessor_ProcessAccountsDataWritesEachEpochOnce(t *testing.T) {
+	t.Parallel()
+
+	epoch := uint32(10)
+	writtenEpochs := make([]uint32, 0)
+	dp, _ := NewSinkDataProcessor(&mocks.AccountsProcessorStub{
+		GetCurrentEpochCalled: func() (uint32, error) {
+			return epoch, nil
+		},
+		GetAllAccountsWithStakeCalled: func(currentEpoch uint32) (*data.AccountsData, error) {
+			return &data.AccountsData{Epoch: currentEpoch}, nil
+		},
+	}, &mocks.AccountsDataSinkStub{
+		WriteAccountsDataCalled: func(accountsData *data.AccountsData) error {
+			writtenEpochs = append(writtenEpochs, accountsData.Epoch)
+			return nil
+		},
+	})
+
+	require.Nil(t, dp.ProcessAccountsData())
+	require.Nil(t, dp.ProcessAccountsData())
+
+	epoch = 11
+	require.Nil(t, dp.ProcessAccountsData())
+
+	require.Equal(t, []uint32{10, 11}, writtenEpochs)
+}
@@ -0,0 +1,54 @@
+package process
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/process/stakeHistory"
+)
+
+type stakeDiffReporter struct {
+	historyReader StakeHistoryReader
+}
+
+// NewStakeDiffReporter will create a new instance of stakeDiffReporter
+func NewStakeDiffReporter(historyReader StakeHistoryReader) (*stakeDiffReporter, error) {
+	if check.IfNil(historyReader) {
+		return nil, ErrNilStakeHistoryReader
+	}
+
+	return &stakeDiffReporter{
+		historyReader: historyReader,
+	}, nil
+}
+
+// WriteStakeDiff will write, in the given format, how the stake of the accounts changed between two epochs, the
+// biggest movers first. Only the first topN accounts are written, if topN is greater than 0
+func (sdr *stakeDiffReporter) WriteStakeDiff(fromEpoch uint32, toEpoch uint32, topN int, format string, writer io.Writer) error {
+	if format != stakeHistory.ReportFormatJSON && format != stakeHistory.ReportFormatCSV {
+		return fmt.Errorf("%w: %s", ErrInvalidReportFormat, format)
+	}
+
+	fromStake, err := sdr.historyReader.GetEpochStakeHistory(fromEpoch)
+	if err != nil {
+		return err
+	}
+	if len(fromStake) == 0 {
+		return fmt.Errorf("%w: %d", ErrEpochNotInStakeHistory, fromEpoch)
+	}
+
+	toStake, err := sdr.historyReader.GetEpochStakeHistory(toEpoch)
+	if err != nil {
+		return err
+	}
+	if len(toStake) == 0 {
+		return fmt.Errorf("%w: %d", ErrEpochNotInStakeHistory, toEpoch)
+	}
+
+	entries := stakeHistory.ComputeStakeDiff(fromStake, toStake, topN)
+
+	log.Info("Writing stake diff", "from epoch", fromEpoch, "to epoch", toEpoch, "num accounts", len(entries))
+
+	return stakeHistory.WriteStakeDiffReport(writer, entries, format)
+}
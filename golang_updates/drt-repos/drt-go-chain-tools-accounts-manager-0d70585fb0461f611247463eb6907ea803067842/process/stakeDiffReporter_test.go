@@ -0,0 +1,53 @@
+package process
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStakeDiffReporter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStakeDiffReporter(nil)
+	require.Equal(t, ErrNilStakeHistoryReader, err)
+
+	reporter, err := NewStakeDiffReporter(&mocks.StakeHistoryReaderStub{})
+	require.Nil(t, err)
+	require.NotNil(t, reporter)
+}
+
+func TestStakeDiffReporter_WriteStakeDiff(t *testing.T) {
+	t.Parallel()
+
+	history := map[uint32]map[string]*data.StakeHistoryEntry{
+		10: {"addr1": {TotalStake: "1"}},
+		11: {"addr1": {TotalStake: "4"}},
+	}
+	reporter, _ := NewStakeDiffReporter(&mocks.StakeHistoryReaderStub{
+		GetEpochStakeHistoryCalled: func(epoch uint32) (map[string]*data.StakeHistoryEntry, error) {
+			return history[epoch], nil
+		},
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		err := reporter.WriteStakeDiff(10, 11, 0, "xml", &bytes.Buffer{})
+		require.True(t, errors.Is(err, ErrInvalidReportFormat))
+	})
+
+	t.Run("epoch not in history", func(t *testing.T) {
+		err := reporter.WriteStakeDiff(10, 12, 0, "csv", &bytes.Buffer{})
+		require.True(t, errors.Is(err, ErrEpochNotInStakeHistory))
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		buff := &bytes.Buffer{}
+		err := reporter.WriteStakeDiff(10, 11, 0, "csv", buff)
+		require.Nil(t, err)
+		require.Contains(t, buff.String(), "addr1,1,4,3,0,0,0,0,0,0\n")
+	})
+}
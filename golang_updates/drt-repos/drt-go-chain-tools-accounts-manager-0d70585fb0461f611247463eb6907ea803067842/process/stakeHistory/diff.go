@@ -0,0 +1,92 @@
+package stakeHistory
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+// ComputeStakeDiff will compute how the stake of each account changed between two epochs. An account missing at an
+// epoch had no stake. The accounts are sorted by the absolute change of their total stake, the biggest movers first,
+// and only the first topN are returned, if topN is greater than 0. Unchanged accounts are left out
+func ComputeStakeDiff(from, to map[string]*data.StakeHistoryEntry, topN int) []*data.StakeDiffEntry {
+	addresses := make(map[string]struct{}, len(to))
+	for address := range from {
+		addresses[address] = struct{}{}
+	}
+	for address := range to {
+		addresses[address] = struct{}{}
+	}
+
+	entries := make([]*data.StakeDiffEntry, 0)
+	totalStakeDeltas := make(map[string]*big.Int)
+	for address := range addresses {
+		fromEntry := getEntryOrEmpty(from, address)
+		toEntry := getEntryOrEmpty(to, address)
+
+		totalStakeDelta := computeDelta(fromEntry.TotalStake, toEntry.TotalStake)
+		totalUnDelegateDelta := computeDelta(fromEntry.TotalUnDelegate, toEntry.TotalUnDelegate)
+		energyDelta := computeDelta(fromEntry.Energy, toEntry.Energy)
+		if totalStakeDelta.Sign() == 0 && totalUnDelegateDelta.Sign() == 0 && energyDelta.Sign() == 0 {
+			continue
+		}
+
+		totalStakeDeltas[address] = totalStakeDelta
+		entries = append(entries, &data.StakeDiffEntry{
+			Address:              address,
+			FromTotalStake:       valueOrZero(fromEntry.TotalStake),
+			ToTotalStake:         valueOrZero(toEntry.TotalStake),
+			TotalStakeDelta:      totalStakeDelta.String(),
+			FromTotalUnDelegate:  valueOrZero(fromEntry.TotalUnDelegate),
+			ToTotalUnDelegate:    valueOrZero(toEntry.TotalUnDelegate),
+			TotalUnDelegateDelta: totalUnDelegateDelta.String(),
+			FromEnergy:           valueOrZero(fromEntry.Energy),
+			ToEnergy:             valueOrZero(toEntry.Energy),
+			EnergyDelta:          energyDelta.String(),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		absI := big.NewInt(0).Abs(totalStakeDeltas[entries[i].Address])
+		absJ := big.NewInt(0).Abs(totalStakeDeltas[entries[j].Address])
+		cmp := absI.Cmp(absJ)
+		if cmp != 0 {
+			return cmp > 0
+		}
+
+		return entries[i].Address < entries[j].Address
+	})
+
+	if topN > 0 && len(entries) > topN {
+		entries = entries[:topN]
+	}
+
+	return entries
+}
+
+func getEntryOrEmpty(entries map[string]*data.StakeHistoryEntry, address string) *data.StakeHistoryEntry {
+	entry, ok := entries[address]
+	if !ok {
+		return &data.StakeHistoryEntry{}
+	}
+
+	return entry
+}
+
+func computeDelta(fromValue, toValue string) *big.Int {
+	return big.NewInt(0).Sub(parseBigInt(toValue), parseBigInt(fromValue))
+}
+
+func parseBigInt(value string) *big.Int {
+	bigValue, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+
+	return bigValue
+}
+
+func valueOrZero(value string) string {
+	return parseBigInt(value).String()
+}
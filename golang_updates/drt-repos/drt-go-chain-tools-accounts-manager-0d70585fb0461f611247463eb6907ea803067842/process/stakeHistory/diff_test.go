@@ -0,0 +1,87 @@
+package stakeHistory
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createStakeDiff() []*data.StakeDiffEntry {
+	from := map[string]*data.StakeHistoryEntry{
+		"addr1": {TotalStake: "100", Energy: "5"},
+		"addr2": {TotalStake: "100"},
+		"addr3": {TotalStake: "50"},
+		"addr4": {TotalStake: "30", TotalUnDelegate: "1"},
+	}
+	to := map[string]*data.StakeHistoryEntry{
+		"addr1": {TotalStake: "110", Energy: "5"},
+		"addr2": {TotalStake: "100"},
+		"addr4": {TotalStake: "30", TotalUnDelegate: "2"},
+		"addr5": {TotalStake: "70"},
+	}
+
+	return ComputeStakeDiff(from, to, 0)
+}
+
+func TestComputeStakeDiff(t *testing.T) {
+	t.Parallel()
+
+	entries := createStakeDiff()
+
+	addresses := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		addresses = append(addresses, entry.Address)
+	}
+	// addr2 is unchanged, addr5 and addr3 are the biggest movers
+	require.Equal(t, []string{"addr5", "addr3", "addr1", "addr4"}, addresses)
+
+	require.Equal(t, &data.StakeDiffEntry{
+		Address:              "addr3",
+		FromTotalStake:       "50",
+		ToTotalStake:         "0",
+		TotalStakeDelta:      "-50",
+		FromTotalUnDelegate:  "0",
+		ToTotalUnDelegate:    "0",
+		TotalUnDelegateDelta: "0",
+		FromEnergy:           "0",
+		ToEnergy:             "0",
+		EnergyDelta:          "0",
+	}, entries[1])
+	require.Equal(t, "1", entries[3].TotalUnDelegateDelta)
+}
+
+func TestComputeStakeDiffTopN(t *testing.T) {
+	t.Parallel()
+
+	from := map[string]*data.StakeHistoryEntry{"addr1": {TotalStake: "1"}}
+	to := map[string]*data.StakeHistoryEntry{"addr2": {TotalStake: "3"}, "addr3": {TotalStake: "2"}}
+
+	entries := ComputeStakeDiff(from, to, 2)
+	require.Len(t, entries, 2)
+	require.Equal(t, "addr2", entries[0].Address)
+	require.Equal(t, "addr3", entries[1].Address)
+}
+
+func TestWriteStakeDiffReport(t *testing.T) {
+	t.Parallel()
+
+	entries := createStakeDiff()[:1]
+
+	buff := &bytes.Buffer{}
+	err := WriteStakeDiffReport(buff, entries, ReportFormatCSV)
+	require.Nil(t, err)
+	require.Equal(t, "address,fromTotalStake,toTotalStake,totalStakeDelta,fromTotalUnDelegate,toTotalUnDelegate,"+
+		"totalUnDelegateDelta,fromEnergy,toEnergy,energyDelta\naddr5,0,70,70,0,0,0,0,0,0\n", buff.String())
+
+	buff.Reset()
+	err = WriteStakeDiffReport(buff, entries, ReportFormatJSON)
+	require.Nil(t, err)
+	require.JSONEq(t, `[{"address":"addr5","fromTotalStake":"0","toTotalStake":"70","totalStakeDelta":"70",
+		"fromTotalUnDelegate":"0","toTotalUnDelegate":"0","totalUnDelegateDelta":"0","fromEnergy":"0","toEnergy":"0",
+		"energyDelta":"0"}]`, buff.String())
+
+	err = WriteStakeDiffReport(buff, entries, "xml")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,10 @@
+package stakeHistory
+
+import "bytes"
+
+// ElasticClientHandler defines what an elastic client should be able to do
+type ElasticClientHandler interface {
+	DoBulkRequest(buff *bytes.Buffer, index string) error
+	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,73 @@
+package stakeHistory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+const (
+	// ReportFormatJSON writes the stake diff report as a json array
+	ReportFormatJSON = "json"
+	// ReportFormatCSV writes the stake diff report as csv, with a header
+	ReportFormatCSV = "csv"
+)
+
+var csvHeader = []string{
+	"address",
+	"fromTotalStake",
+	"toTotalStake",
+	"totalStakeDelta",
+	"fromTotalUnDelegate",
+	"toTotalUnDelegate",
+	"totalUnDelegateDelta",
+	"fromEnergy",
+	"toEnergy",
+	"energyDelta",
+}
+
+// WriteStakeDiffReport will write the stake diff entries in the given format
+func WriteStakeDiffReport(writer io.Writer, entries []*data.StakeDiffEntry, format string) error {
+	switch format {
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case ReportFormatCSV:
+		return writeCSVReport(writer, entries)
+	default:
+		return fmt.Errorf("unknown report format %s, expected %s or %s", format, ReportFormatJSON, ReportFormatCSV)
+	}
+}
+
+func writeCSVReport(writer io.Writer, entries []*data.StakeDiffEntry) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err = csvWriter.Write([]string{
+			entry.Address,
+			entry.FromTotalStake,
+			entry.ToTotalStake,
+			entry.TotalStakeDelta,
+			entry.FromTotalUnDelegate,
+			entry.ToTotalUnDelegate,
+			entry.TotalUnDelegateDelta,
+			entry.FromEnergy,
+			entry.ToEnergy,
+			entry.EnergyDelta,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,130 @@
+package stakeHistory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	dataIndexer "github.com/TerraDharitri/drt-go-chain-es-indexer/data"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/crossIndex"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+)
+
+// StakeHistoryIndex is the index holding the stake of each account, for each processed epoch
+const StakeHistoryIndex = "accounts-stake-history"
+
+var log = logger.GetOrCreate("process/stakeHistory")
+
+type stakeHistory struct {
+	elasticClient ElasticClientHandler
+}
+
+// NewStakeHistory will create a new instance of stakeHistory, which writes and reads the stake history index
+func NewStakeHistory(elasticClient ElasticClientHandler) (*stakeHistory, error) {
+	if check.IfNil(elasticClient) {
+		return nil, errors.New("nil elastic client")
+	}
+
+	return &stakeHistory{
+		elasticClient: elasticClient,
+	}, nil
+}
+
+// IndexStakeHistory will index the stake of all the provided accounts, at their epoch. Reindexing an epoch overwrites
+// its entries
+func (sh *stakeHistory) IndexStakeHistory(accountsData *data.AccountsData) error {
+	log.Info(fmt.Sprintf("Indexing stake history in `%s` index...", StakeHistoryIndex), "epoch", accountsData.Epoch,
+		"num accounts", len(accountsData.AccountsWithStake))
+
+	buffSlice := dataIndexer.NewBufferSlice(0)
+	for address, account := range accountsData.AccountsWithStake {
+		entry := &data.StakeHistoryEntry{
+			Address:            address,
+			Epoch:              accountsData.Epoch,
+			TotalStake:         account.TotalStake,
+			TotalStakeNum:      account.TotalStakeNum,
+			TotalUnDelegate:    account.TotalUnDelegate,
+			TotalUnDelegateNum: account.TotalUnDelegateNum,
+			Energy:             account.Energy,
+			EnergyNum:          account.EnergyNum,
+		}
+
+		meta := []byte(fmt.Sprintf(`{ "index" : { "_id" : "%s" } }%s`, entryID(address, accountsData.Epoch), "\n"))
+		serializedData, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		err = buffSlice.PutData(meta, serializedData)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, buff := range buffSlice.Buffers() {
+		err := sh.elasticClient.DoBulkRequest(buff, StakeHistoryIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetEpochStakeHistory will return the stake of all the accounts indexed at the given epoch, by address
+func (sh *stakeHistory) GetEpochStakeHistory(epoch uint32) (map[string]*data.StakeHistoryEntry, error) {
+	entries := make(map[string]*data.StakeHistoryEntry)
+	handlerFunc := func(responseBytes []byte) error {
+		response := &stakeHistoryResponse{}
+		err := json.Unmarshal(responseBytes, response)
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range response.Hits.Hits {
+			entry := hit.Entry
+			entries[entry.Address] = &entry
+		}
+
+		return nil
+	}
+
+	query, err := crossIndex.EncodeQuery(map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{
+				"epoch": epoch,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = sh.elasticClient.DoScrollRequestAllDocuments(StakeHistoryIndex, query.Bytes(), handlerFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("fetched stake history", "epoch", epoch, "num accounts", len(entries))
+
+	return entries, nil
+}
+
+func entryID(address string, epoch uint32) string {
+	return fmt.Sprintf("%s_%d", address, epoch)
+}
+
+type stakeHistoryResponse struct {
+	Hits struct {
+		Hits []struct {
+			Entry data.StakeHistoryEntry `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sh *stakeHistory) IsInterfaceNil() bool {
+	return sh == nil
+}
@@ -0,0 +1,91 @@
+package stakeHistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStakeHistory(t *testing.T) {
+	t.Parallel()
+
+	sh, err := NewStakeHistory(nil)
+	require.Nil(t, sh)
+	require.NotNil(t, err)
+
+	sh, err = NewStakeHistory(&mocks.ElasticClientStub{})
+	require.Nil(t, err)
+	require.NotNil(t, sh)
+}
+
+func TestStakeHistory_IndexStakeHistory(t *testing.T) {
+	t.Parallel()
+
+	indexedData := ""
+	sh, _ := NewStakeHistory(&mocks.ElasticClientStub{
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			require.Equal(t, StakeHistoryIndex, index)
+			indexedData += buff.String()
+			return nil
+		},
+	})
+
+	err := sh.IndexStakeHistory(&data.AccountsData{
+		Epoch: 7,
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"addr1": {StakeInfo: data.StakeInfo{
+				TotalStake:    "1000000000000000000",
+				TotalStakeNum: 1,
+				Energy:        "5",
+				EnergyNum:     0.000000000000000005,
+			}},
+		},
+	})
+	require.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(indexedData), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, `{ "index" : { "_id" : "addr1_7" } }`, lines[0])
+
+	entry := &data.StakeHistoryEntry{}
+	err = json.Unmarshal([]byte(lines[1]), entry)
+	require.Nil(t, err)
+	require.Equal(t, &data.StakeHistoryEntry{
+		Address:       "addr1",
+		Epoch:         7,
+		TotalStake:    "1000000000000000000",
+		TotalStakeNum: 1,
+		Energy:        "5",
+		EnergyNum:     0.000000000000000005,
+	}, entry)
+}
+
+func TestStakeHistory_GetEpochStakeHistory(t *testing.T) {
+	t.Parallel()
+
+	sh, _ := NewStakeHistory(&mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			require.Equal(t, StakeHistoryIndex, index)
+			require.JSONEq(t, `{"query":{"term":{"epoch":7}}}`, string(body))
+
+			err := handlerFunc([]byte(`{"hits":{"hits":[{"_source":{"address":"addr1","epoch":7,"totalStake":"10"}}]}}`))
+			if err != nil {
+				return err
+			}
+
+			return handlerFunc([]byte(`{"hits":{"hits":[{"_source":{"address":"addr2","epoch":7,"totalStake":"20"}}]}}`))
+		},
+	})
+
+	entries, err := sh.GetEpochStakeHistory(7)
+	require.Nil(t, err)
+	require.Equal(t, map[string]*data.StakeHistoryEntry{
+		"addr1": {Address: "addr1", Epoch: 7, TotalStake: "10"},
+		"addr2": {Address: "addr2", Epoch: 7, TotalStake: "20"},
+	}, entries)
+}
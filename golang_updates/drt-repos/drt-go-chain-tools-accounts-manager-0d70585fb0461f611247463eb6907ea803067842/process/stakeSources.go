@@ -0,0 +1,483 @@
+package process
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/config"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/core"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-sdk-abi/abi"
+	"github.com/tidwall/gjson"
+)
+
+const numSuffix = "Num"
+
+// abiValueCreators creates, for each known ABI type, the value its encoding is decoded into. A TokenIdentifier is
+// decoded as bytes: its encoding is the one of bytes (length-prefixed, when nested), and abi.TokenIdentifierValue is
+// not part of the pinned drt-go-sdk-abi v0.3.0
+var abiValueCreators = map[string]func() abi.SingleValue{
+	"Address":         func() abi.SingleValue { return &abi.AddressValue{} },
+	"BigUint":         func() abi.SingleValue { return &abi.BigUIntValue{} },
+	"BigInt":          func() abi.SingleValue { return &abi.BigIntValue{} },
+	"u8":              func() abi.SingleValue { return &abi.U8Value{} },
+	"u16":             func() abi.SingleValue { return &abi.U16Value{} },
+	"u32":             func() abi.SingleValue { return &abi.U32Value{} },
+	"u64":             func() abi.SingleValue { return &abi.U64Value{} },
+	"bool":            func() abi.SingleValue { return &abi.BoolValue{} },
+	"TokenIdentifier": func() abi.SingleValue { return &abi.BytesValue{} },
+	"bytes":           func() abi.SingleValue { return &abi.BytesValue{} },
+}
+
+// computedStakeFields cannot be the target of a stake source, as they are computed from the other fields
+var computedStakeFields = map[string]struct{}{
+	"totalStake":      {},
+	"totalUnDelegate": {},
+}
+
+type stakeInfoField struct {
+	valueIndex int
+	numIndex   int
+}
+
+// stakeInfoFields maps the json name of each amount of data.StakeInfo to its field and to the field holding the
+// amount as float
+var stakeInfoFields = mapStakeInfoFields()
+
+type stakeSource struct {
+	name              string
+	contractAddress   string
+	hexKeyPrefix      string
+	viewFunction      string
+	keyTypes          []string
+	valueTypes        []string
+	addressFieldIndex int
+	amountFieldIndex  int
+	targetField       string
+}
+
+func newStakeSources(sourcesConfig []config.StakeSourceConfig) ([]*stakeSource, error) {
+	sources := make([]*stakeSource, 0, len(sourcesConfig))
+	for _, sourceConfig := range sourcesConfig {
+		source, err := newStakeSource(sourceConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+func newStakeSource(cfg config.StakeSourceConfig) (*stakeSource, error) {
+	invalidConfig := func(reason string) error {
+		return fmt.Errorf("%w %s: %s", ErrInvalidStakeSourceConfig, cfg.Name, reason)
+	}
+
+	if cfg.ContractAddress == "" {
+		return nil, invalidConfig("empty contract address")
+	}
+	isStorageSource := cfg.StorageKeyPrefix != ""
+	isViewSource := cfg.ViewFunction != ""
+	if isStorageSource == isViewSource {
+		return nil, invalidConfig("exactly one of the storage key prefix and the view function must be set")
+	}
+	if isViewSource && len(cfg.KeyTypes) > 0 {
+		return nil, invalidConfig("key types can only be set for a storage source")
+	}
+	if len(cfg.ValueTypes) == 0 {
+		return nil, invalidConfig("empty value types")
+	}
+
+	allValues, err := createAbiValues(append(append([]string{}, cfg.KeyTypes...), cfg.ValueTypes...))
+	if err != nil {
+		return nil, invalidConfig(err.Error())
+	}
+	if cfg.AddressFieldIndex < 0 || cfg.AddressFieldIndex >= len(allValues) || !isAddressValue(allValues[cfg.AddressFieldIndex]) {
+		return nil, invalidConfig("the address field index must point to a field of type Address")
+	}
+	if cfg.AmountFieldIndex < 0 || cfg.AmountFieldIndex >= len(allValues) || !isAmountValue(allValues[cfg.AmountFieldIndex]) {
+		return nil, invalidConfig("the amount field index must point to a number field")
+	}
+
+	err = checkTargetField(cfg.TargetField)
+	if err != nil {
+		return nil, invalidConfig(err.Error())
+	}
+
+	return &stakeSource{
+		name:              cfg.Name,
+		contractAddress:   cfg.ContractAddress,
+		hexKeyPrefix:      hex.EncodeToString([]byte(cfg.StorageKeyPrefix)),
+		viewFunction:      cfg.ViewFunction,
+		keyTypes:          cfg.KeyTypes,
+		valueTypes:        cfg.ValueTypes,
+		addressFieldIndex: cfg.AddressFieldIndex,
+		amountFieldIndex:  cfg.AmountFieldIndex,
+		targetField:       cfg.TargetField,
+	}, nil
+}
+
+func checkTargetField(targetField string) error {
+	if targetField == "" || strings.Contains(targetField, ".") {
+		return fmt.Errorf("invalid target field %q", targetField)
+	}
+	if _, isComputed := computedStakeFields[targetField]; isComputed {
+		return fmt.Errorf("target field %s is computed from the other fields", targetField)
+	}
+
+	return nil
+}
+
+// GetStakeSourcesAccounts will fetch the amounts of all the accounts from the configured stake sources, at the
+// provided block. The amounts of the sources with the same target field are summed
+func (ag *accountsGetter) GetStakeSourcesAccounts(blockInfo *data.BlockInfo) (data.StakeSourcesAccounts, error) {
+	amounts := make(map[string]map[string]*big.Int)
+	for _, source := range ag.stakeSources {
+		sourceAmounts, err := ag.getStakeSourceAmounts(source, blockInfo)
+		if err != nil {
+			return nil, fmt.Errorf("stake source %s: %w", source.name, err)
+		}
+
+		for address, amount := range sourceAmounts {
+			_, ok := amounts[address]
+			if !ok {
+				amounts[address] = make(map[string]*big.Int)
+			}
+			total, ok := amounts[address][source.targetField]
+			if !ok {
+				total = big.NewInt(0)
+				amounts[address][source.targetField] = total
+			}
+
+			total.Add(total, amount)
+		}
+
+		log.Info("stake source accounts", "source", source.name, "num", len(sourceAmounts))
+	}
+
+	accounts := make(data.StakeSourcesAccounts, len(amounts))
+	for address, fields := range amounts {
+		accounts[address] = make(map[string]string, len(fields))
+		for field, amount := range fields {
+			accounts[address][field] = amount.String()
+		}
+	}
+
+	return accounts, nil
+}
+
+func (ag *accountsGetter) getStakeSourceAmounts(source *stakeSource, blockInfo *data.BlockInfo) (map[string]*big.Int, error) {
+	defer logExecutionTime(time.Now(), fmt.Sprintf("Fetched accounts from stake source %s", source.name))
+
+	if source.viewFunction != "" {
+		return ag.getViewStakeSourceAmounts(source, blockInfo)
+	}
+
+	return ag.getStorageStakeSourceAmounts(source, blockInfo)
+}
+
+func (ag *accountsGetter) getStorageStakeSourceAmounts(source *stakeSource, blockInfo *data.BlockInfo) (map[string]*big.Int, error) {
+	contractBlock, err := ag.blockForAddress(blockInfo, source.contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	genericAPIResponse := &data.GenericAPIResponse{}
+	path := pathWithBlockHash(fmt.Sprintf(pathAccountKeys, source.contractAddress), contractBlock)
+	err = ag.restClient.CallGetRestEndPoint(path, genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return nil, err
+	}
+	if genericAPIResponse.Error != "" {
+		return nil, fmt.Errorf("%s", genericAPIResponse.Error)
+	}
+
+	err = ag.checkResponseBlockInfo(genericAPIResponse.Data, contractBlock, source.name)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := gjson.Get(string(genericAPIResponse.Data), "pairs")
+	keyValueMap := make(map[string]string)
+	err = json.Unmarshal([]byte(pairs.String()), &keyValueMap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal account storage, error: %s", err.Error())
+	}
+
+	amounts := make(map[string]*big.Int)
+	for key, value := range keyValueMap {
+		if !strings.HasPrefix(key, source.hexKeyPrefix) {
+			continue
+		}
+
+		keyValues, ok := decodeStorageKey(strings.TrimPrefix(key, source.hexKeyPrefix), source.keyTypes)
+		if !ok {
+			// another storage entry, whose name starts with the prefix
+			continue
+		}
+
+		valueBytes, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: value of key %s: %s", ErrCannotDecodeStakeSourceData, key, err.Error())
+		}
+		valueValues, err := decodeStorageValue(valueBytes, source.valueTypes)
+		if err != nil {
+			return nil, fmt.Errorf("value of key %s: %w", key, err)
+		}
+
+		address, amount := ag.extractAddressAndAmount(source, append(keyValues, valueValues...))
+		addAmount(amounts, address, amount)
+	}
+
+	return amounts, nil
+}
+
+func decodeStorageKey(hexEncodedKeyArgs string, keyTypes []string) ([]abi.SingleValue, bool) {
+	keyArgs, err := hex.DecodeString(hexEncodedKeyArgs)
+	if err != nil {
+		return nil, false
+	}
+
+	keyValues, err := decodeNestedValues(keyArgs, keyTypes)
+	if err != nil {
+		return nil, false
+	}
+
+	return keyValues, true
+}
+
+// decodeStorageValue decodes a top level encoded storage value. A single field is encoded as is, while several fields
+// are the top level encoding of a struct, which is the nested encoding of its fields
+func decodeStorageValue(encoded []byte, valueTypes []string) ([]abi.SingleValue, error) {
+	if len(valueTypes) != 1 {
+		return decodeNestedValues(encoded, valueTypes)
+	}
+
+	return decodeTopLevelValues([][]byte{encoded}, valueTypes)
+}
+
+func (ag *accountsGetter) getViewStakeSourceAmounts(source *stakeSource, blockInfo *data.BlockInfo) (map[string]*big.Int, error) {
+	vmRequest := &data.VmValueRequest{
+		Address:    source.contractAddress,
+		FuncName:   source.viewFunction,
+		CallerAddr: source.contractAddress,
+	}
+
+	contractBlock, err := ag.blockForAddress(blockInfo, source.contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	responseVmValue := &data.ResponseVmValue{}
+	err = ag.restClient.CallPostRestEndPoint(pathWithBlockHash(pathVMValues, contractBlock), vmRequest, responseVmValue, core.GetEmptyApiCredentials())
+	if err != nil {
+		return nil, err
+	}
+	if responseVmValue.Error != "" {
+		return nil, fmt.Errorf("%s", responseVmValue.Error)
+	}
+	if responseVmValue.Data.Data == nil {
+		return map[string]*big.Int{}, nil
+	}
+	if responseVmValue.Data.Data.ReturnCode != vmcommon.Ok.String() {
+		return nil, fmt.Errorf("%s: %s", responseVmValue.Data.Data.ReturnCode, responseVmValue.Data.Data.ReturnMessage)
+	}
+
+	err = ag.checkQueriedBlock(contractBlock, responseVmValue.Data.BlockInfo, source.name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the view function returns a multi value: each group of len(valueTypes) items is an entry, and each item is top
+	// level encoded
+	returnedData := responseVmValue.Data.Data.ReturnData
+	groupSize := len(source.valueTypes)
+	if len(returnedData)%groupSize != 0 {
+		return nil, fmt.Errorf("%w: %d returned items, expected a multiple of %d", ErrCannotDecodeStakeSourceData, len(returnedData), groupSize)
+	}
+
+	amounts := make(map[string]*big.Int)
+	for idx := 0; idx < len(returnedData); idx += groupSize {
+		values, err := decodeTopLevelValues(returnedData[idx:idx+groupSize], source.valueTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		address, amount := ag.extractAddressAndAmount(source, values)
+		addAmount(amounts, address, amount)
+	}
+
+	return amounts, nil
+}
+
+// extractAddressAndAmount returns the address and the amount of a decoded entry. The types of the fields were checked
+// when the source was created
+func (ag *accountsGetter) extractAddressAndAmount(source *stakeSource, values []abi.SingleValue) (string, *big.Int) {
+	address := ag.pubKeyConverter.Encode(values[source.addressFieldIndex].(*abi.AddressValue).Value)
+	amount, _ := amountOfValue(values[source.amountFieldIndex])
+
+	return address, amount
+}
+
+func createAbiValues(abiTypes []string) ([]abi.SingleValue, error) {
+	values := make([]abi.SingleValue, 0, len(abiTypes))
+	for _, abiType := range abiTypes {
+		createValue, isKnownType := abiValueCreators[abiType]
+		if !isKnownType {
+			return nil, fmt.Errorf("unknown type %s", abiType)
+		}
+
+		values = append(values, createValue())
+	}
+
+	return values, nil
+}
+
+// decodeNestedValues decodes nested encoded data (the encoding of the fields of a struct, or of the arguments of a
+// storage key) into values of the given types. All the bytes must be consumed by the given types
+func decodeNestedValues(encoded []byte, abiTypes []string) ([]abi.SingleValue, error) {
+	values, err := createAbiValues(abiTypes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCannotDecodeStakeSourceData, err.Error())
+	}
+
+	reader := bytes.NewReader(encoded)
+	for idx, value := range values {
+		err = value.DecodeNested(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %d of type %s: %s", ErrCannotDecodeStakeSourceData, idx, abiTypes[idx], err.Error())
+		}
+	}
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d bytes left after decoding", ErrCannotDecodeStakeSourceData, reader.Len())
+	}
+
+	return values, nil
+}
+
+// decodeTopLevelValues decodes top level encoded fields, each of them into a value of the corresponding type
+func decodeTopLevelValues(fields [][]byte, abiTypes []string) ([]abi.SingleValue, error) {
+	values, err := createAbiValues(abiTypes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCannotDecodeStakeSourceData, err.Error())
+	}
+
+	for idx, value := range values {
+		err = value.DecodeTopLevel(fields[idx])
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %d of type %s: %s", ErrCannotDecodeStakeSourceData, idx, abiTypes[idx], err.Error())
+		}
+	}
+
+	return values, nil
+}
+
+func isAddressValue(value abi.SingleValue) bool {
+	_, ok := value.(*abi.AddressValue)
+	return ok
+}
+
+func isAmountValue(value abi.SingleValue) bool {
+	_, ok := amountOfValue(value)
+	return ok
+}
+
+// amountOfValue returns the amount held by a decoded number value, false being returned for the other values
+func amountOfValue(value abi.SingleValue) (*big.Int, bool) {
+	switch number := value.(type) {
+	case *abi.BigUIntValue:
+		return bigIntOrZero(number.Value), true
+	case *abi.BigIntValue:
+		return bigIntOrZero(number.Value), true
+	case *abi.U8Value:
+		return big.NewInt(0).SetUint64(uint64(number.Value)), true
+	case *abi.U16Value:
+		return big.NewInt(0).SetUint64(uint64(number.Value)), true
+	case *abi.U32Value:
+		return big.NewInt(0).SetUint64(uint64(number.Value)), true
+	case *abi.U64Value:
+		return big.NewInt(0).SetUint64(number.Value), true
+	default:
+		return nil, false
+	}
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(value)
+}
+
+func addAmount(amounts map[string]*big.Int, address string, amount *big.Int) {
+	total, ok := amounts[address]
+	if !ok {
+		amounts[address] = big.NewInt(0).Set(amount)
+		return
+	}
+
+	total.Add(total, amount)
+}
+
+// addStakeValue adds an amount to the target field of a stake info. A target field which is not one of the amounts
+// of data.StakeInfo is kept in the stake sources of the account
+func addStakeValue(stakeInfo *data.StakeInfo, targetField string, amount string) {
+	field, isStakeInfoField := stakeInfoFields[targetField]
+	if isStakeInfoField {
+		stakeInfoValue := reflect.ValueOf(stakeInfo).Elem()
+		total, totalNum := computeTotalBalance(stakeInfoValue.Field(field.valueIndex).String(), amount)
+
+		stakeInfoValue.Field(field.valueIndex).SetString(total)
+		stakeInfoValue.Field(field.numIndex).SetFloat(totalNum)
+		return
+	}
+
+	if stakeInfo.StakeSources == nil {
+		stakeInfo.StakeSources = make(map[string]*data.SourceStake)
+	}
+	sourceStake, ok := stakeInfo.StakeSources[targetField]
+	if !ok {
+		sourceStake = &data.SourceStake{}
+		stakeInfo.StakeSources[targetField] = sourceStake
+	}
+
+	sourceStake.Stake, sourceStake.StakeNum = computeTotalBalance(sourceStake.Stake, amount)
+}
+
+func mapStakeInfoFields() map[string]stakeInfoField {
+	stakeInfoType := reflect.TypeOf(data.StakeInfo{})
+
+	fieldsByJsonName := make(map[string]int)
+	for idx := 0; idx < stakeInfoType.NumField(); idx++ {
+		jsonName := strings.Split(stakeInfoType.Field(idx).Tag.Get("json"), ",")[0]
+		fieldsByJsonName[jsonName] = idx
+	}
+
+	fields := make(map[string]stakeInfoField)
+	for jsonName, valueIndex := range fieldsByJsonName {
+		numIndex, hasNum := fieldsByJsonName[jsonName+numSuffix]
+		if !hasNum {
+			continue
+		}
+		if stakeInfoType.Field(valueIndex).Type.Kind() != reflect.String || stakeInfoType.Field(numIndex).Type.Kind() != reflect.Float64 {
+			continue
+		}
+
+		fields[jsonName] = stakeInfoField{
+			valueIndex: valueIndex,
+			numIndex:   numIndex,
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,330 @@
+package process
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	nodeCore "github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/pubkeyConverter"
+	"github.com/TerraDharitri/drt-go-chain-core/data/vm"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/config"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
+	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/mocks"
+	vmcommon "github.com/TerraDharitri/drt-go-chain-vm-common"
+	"github.com/TerraDharitri/drt-go-sdk-abi/abi"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addressBytes1 = bytes.Repeat([]byte{1}, 32)
+	addressBytes2 = bytes.Repeat([]byte{2}, 32)
+
+	stakeSourceContractShards = map[string]uint32{
+		"drt1farm":    0,
+		"drt1liquid":  nodeCore.MetachainShardId,
+		"drt1liquid2": 1,
+	}
+)
+
+func createStorageSourceConfig() config.StakeSourceConfig {
+	return config.StakeSourceConfig{
+		Name:              "farm",
+		ContractAddress:   "drt1farm",
+		StorageKeyPrefix:  "userStake",
+		KeyTypes:          []string{"Address"},
+		ValueTypes:        []string{"BigUint"},
+		AddressFieldIndex: 0,
+		AmountFieldIndex:  1,
+		TargetField:       "farmStake",
+	}
+}
+
+func createViewSourceConfig() config.StakeSourceConfig {
+	return config.StakeSourceConfig{
+		Name:              "liquid",
+		ContractAddress:   "drt1liquid",
+		ViewFunction:      "getSnapshot",
+		ValueTypes:        []string{"Address", "BigUint"},
+		AddressFieldIndex: 0,
+		AmountFieldIndex:  1,
+		TargetField:       "lkMoaStake",
+	}
+}
+
+func createStakeSourcesGetter(t *testing.T, restClient *mocks.RestClientStub, sources ...config.StakeSourceConfig) *accountsGetter {
+	pubKeyConv, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	ag, err := NewAccountsGetter(restClient, pubKeyConv, data.RestApiAuthenticationData{}, config.GeneralConfig{
+		StakeSources: sources,
+	}, &mocks.ElasticClientStub{})
+	require.Nil(t, err)
+
+	return ag
+}
+
+func storageKey(prefix string, args ...[]byte) string {
+	return hex.EncodeToString(append([]byte(prefix), bytes.Join(args, nil)...))
+}
+
+func TestNewStakeSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.StakeSourceConfig)
+	}{
+		{"no contract", func(cfg *config.StakeSourceConfig) { cfg.ContractAddress = "" }},
+		{"both prefix and view", func(cfg *config.StakeSourceConfig) { cfg.ViewFunction = "getStake" }},
+		{"no prefix and no view", func(cfg *config.StakeSourceConfig) { cfg.StorageKeyPrefix = "" }},
+		{"unknown type", func(cfg *config.StakeSourceConfig) { cfg.ValueTypes = []string{"u128"} }},
+		{"address index out of range", func(cfg *config.StakeSourceConfig) { cfg.AddressFieldIndex = 2 }},
+		{"address index not an address", func(cfg *config.StakeSourceConfig) { cfg.AddressFieldIndex = 1 }},
+		{"amount index not a number", func(cfg *config.StakeSourceConfig) { cfg.AmountFieldIndex = 0 }},
+		{"empty target field", func(cfg *config.StakeSourceConfig) { cfg.TargetField = "" }},
+		{"computed target field", func(cfg *config.StakeSourceConfig) { cfg.TargetField = "totalStake" }},
+	}
+
+	for _, tt := range tests {
+		cfg := createStorageSourceConfig()
+		tt.modify(&cfg)
+
+		_, err := newStakeSource(cfg)
+		require.True(t, errors.Is(err, ErrInvalidStakeSourceConfig), tt.name)
+	}
+
+	viewCfg := createViewSourceConfig()
+	viewCfg.KeyTypes = []string{"Address"}
+	_, err := newStakeSource(viewCfg)
+	require.True(t, errors.Is(err, ErrInvalidStakeSourceConfig))
+
+	_, err = newStakeSource(createStorageSourceConfig())
+	require.Nil(t, err)
+	_, err = newStakeSource(createViewSourceConfig())
+	require.Nil(t, err)
+}
+
+func TestDecodeNestedValues(t *testing.T) {
+	t.Parallel()
+
+	encoded := append([]byte{0, 0, 0, 2, 0x01, 0x00}, 0, 0, 0, 0, 0, 0, 0, 7)
+	encoded = append(encoded, addressBytes1...)
+
+	values, err := decodeNestedValues(encoded, []string{"BigUint", "u64", "Address"})
+	require.Nil(t, err)
+	require.Equal(t, []abi.SingleValue{
+		&abi.BigUIntValue{Value: big.NewInt(256)},
+		&abi.U64Value{Value: 7},
+		&abi.AddressValue{Value: addressBytes1},
+	}, values)
+
+	_, err = decodeNestedValues(encoded, []string{"BigUint", "u64"})
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+
+	_, err = decodeNestedValues([]byte{0, 0, 0, 5, 1}, []string{"BigUint"})
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+
+	_, err = decodeNestedValues(encoded, []string{"u128"})
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+}
+
+func TestDecodeTopLevelValues(t *testing.T) {
+	t.Parallel()
+
+	values, err := decodeTopLevelValues([][]byte{addressBytes1, {0xff}}, []string{"Address", "BigInt"})
+	require.Nil(t, err)
+	require.Equal(t, addressBytes1, values[0].(*abi.AddressValue).Value)
+	amount, _ := amountOfValue(values[1])
+	require.Equal(t, big.NewInt(-1), amount)
+
+	_, err = decodeTopLevelValues([][]byte{addressBytes1[:31]}, []string{"Address"})
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+
+	_, err = decodeTopLevelValues([][]byte{{1, 0}}, []string{"u8"})
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+}
+
+func TestAmountOfValue(t *testing.T) {
+	t.Parallel()
+
+	amount, ok := amountOfValue(&abi.BigUIntValue{Value: big.NewInt(255)})
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(255), amount)
+
+	amount, ok = amountOfValue(&abi.U64Value{Value: 7})
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(7), amount)
+
+	amount, ok = amountOfValue(&abi.BigIntValue{})
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(0), amount)
+
+	_, ok = amountOfValue(&abi.AddressValue{})
+	require.False(t, ok)
+}
+
+func TestAccountsGetter_GetStakeSourcesAccountsFromStorage(t *testing.T) {
+	t.Parallel()
+
+	pairs := map[string]string{
+		storageKey("userStake", addressBytes1):            hex.EncodeToString(big.NewInt(1000).Bytes()),
+		storageKey("userStake", addressBytes2):            hex.EncodeToString(big.NewInt(2000).Bytes()),
+		storageKey("userStakeCount"):                      "02",
+		storageKey("otherEntry", addressBytes1):           "05",
+		storageKey("userStake", addressBytes1[:31]):       "05",
+		storageKey("userStake", addressBytes2, []byte{1}): "05",
+	}
+
+	ag := createStakeSourcesGetter(t, &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			if answerAddressShard(path, value, stakeSourceContractShards) {
+				return nil
+			}
+			require.Equal(t, pathWithBlockHash("/address/drt1farm/keys", testBlockInfo.ShardBlocks[0]), path)
+
+			response := value.(*data.GenericAPIResponse)
+			response.Data, _ = json.Marshal(map[string]interface{}{
+				"pairs":     pairs,
+				"blockInfo": testBlockInfo.ShardBlocks[0],
+			})
+			return nil
+		},
+	}, createStorageSourceConfig())
+
+	accounts, err := ag.GetStakeSourcesAccounts(testBlockInfo)
+	require.Nil(t, err)
+
+	pkConv := ag.pubKeyConverter
+	require.Equal(t, data.StakeSourcesAccounts{
+		pkConv.Encode(addressBytes1): {"farmStake": "1000"},
+		pkConv.Encode(addressBytes2): {"farmStake": "2000"},
+	}, accounts)
+}
+
+func TestAccountsGetter_GetStakeSourcesAccountsFromStorageInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	cfg := createStorageSourceConfig()
+	cfg.ValueTypes = []string{"BigUint", "u64"}
+	ag := createStakeSourcesGetter(t, &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			if answerAddressShard(path, value, stakeSourceContractShards) {
+				return nil
+			}
+
+			response := value.(*data.GenericAPIResponse)
+			response.Data, _ = json.Marshal(map[string]interface{}{
+				"pairs":     map[string]string{storageKey("userStake", addressBytes1): "0000000103"},
+				"blockInfo": testBlockInfo.ShardBlocks[0],
+			})
+			return nil
+		},
+	}, cfg)
+
+	_, err := ag.GetStakeSourcesAccounts(testBlockInfo)
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+}
+
+func TestAccountsGetter_GetStakeSourcesAccountsSumsSourcesWithTheSameTarget(t *testing.T) {
+	t.Parallel()
+
+	secondViewCfg := createViewSourceConfig()
+	secondViewCfg.Name = "liquid-2"
+	secondViewCfg.ContractAddress = "drt1liquid2"
+	ag := createStakeSourcesGetter(t, &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			require.True(t, answerAddressShard(path, value, stakeSourceContractShards))
+			return nil
+		},
+		CallPostRestEndPointCalled: func(path string, dataD interface{}, response interface{}, _ data.RestApiAuthenticationData) error {
+			require.Equal(t, "getSnapshot", dataD.(*data.VmValueRequest).FuncName)
+
+			// the first contract is on the metachain, the second one on shard 1
+			contractBlock := testBlockInfo
+			if dataD.(*data.VmValueRequest).Address == "drt1liquid2" {
+				contractBlock = testBlockInfo.ShardBlocks[1]
+			}
+			require.Equal(t, pathWithBlockHash("/vm-values/query", contractBlock), path)
+
+			responseVmValue := response.(*data.ResponseVmValue)
+			responseVmValue.Data = data.VmValuesResponseData{
+				Data: &vm.VMOutputApi{
+					ReturnData: [][]byte{addressBytes1, big.NewInt(10).Bytes(), addressBytes2, big.NewInt(20).Bytes()},
+					ReturnCode: vmcommon.Ok.String(),
+				},
+				BlockInfo: contractBlock,
+			}
+			return nil
+		},
+	}, createViewSourceConfig(), secondViewCfg)
+
+	accounts, err := ag.GetStakeSourcesAccounts(testBlockInfo)
+	require.Nil(t, err)
+
+	pkConv := ag.pubKeyConverter
+	require.Equal(t, data.StakeSourcesAccounts{
+		pkConv.Encode(addressBytes1): {"lkMoaStake": "20"},
+		pkConv.Encode(addressBytes2): {"lkMoaStake": "40"},
+	}, accounts)
+}
+
+func TestAccountsGetter_GetStakeSourcesAccountsFromViewInvalidReturnData(t *testing.T) {
+	t.Parallel()
+
+	ag := createStakeSourcesGetter(t, &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			require.True(t, answerAddressShard(path, value, stakeSourceContractShards))
+			return nil
+		},
+		CallPostRestEndPointCalled: func(_ string, _ interface{}, response interface{}, _ data.RestApiAuthenticationData) error {
+			responseVmValue := response.(*data.ResponseVmValue)
+			responseVmValue.Data = data.VmValuesResponseData{
+				Data: &vm.VMOutputApi{
+					ReturnData: [][]byte{addressBytes1, big.NewInt(10).Bytes(), addressBytes2},
+					ReturnCode: vmcommon.Ok.String(),
+				},
+				BlockInfo: testBlockInfo,
+			}
+			return nil
+		},
+	}, createViewSourceConfig())
+
+	_, err := ag.GetStakeSourcesAccounts(testBlockInfo)
+	require.True(t, errors.Is(err, ErrCannotDecodeStakeSourceData))
+}
+
+func TestAddStakeValue(t *testing.T) {
+	t.Parallel()
+
+	stakeInfo := &data.StakeInfo{
+		LKMOAStake:    "1000000000000000000",
+		LKMOAStakeNum: 1,
+	}
+
+	addStakeValue(stakeInfo, "lkMoaStake", "2000000000000000000")
+	addStakeValue(stakeInfo, "farmStake", "3000000000000000000")
+	addStakeValue(stakeInfo, "farmStake", "1000000000000000000")
+
+	require.Equal(t, &data.StakeInfo{
+		LKMOAStake:    "3000000000000000000",
+		LKMOAStakeNum: 3,
+		StakeSources: map[string]*data.SourceStake{
+			"farmStake": {Stake: "4000000000000000000", StakeNum: 4},
+		},
+	}, stakeInfo)
+}
+
+func TestMapStakeInfoFields(t *testing.T) {
+	t.Parallel()
+
+	for _, field := range []string{"delegation", "validatorsActive", "lkMoaStake", "energy", "unDelegateLegacy"} {
+		_, ok := stakeInfoFields[field]
+		require.True(t, ok, fmt.Sprintf("missing field %s", field))
+	}
+
+	_, ok := stakeInfoFields["energyDetails"]
+	require.False(t, ok)
+}
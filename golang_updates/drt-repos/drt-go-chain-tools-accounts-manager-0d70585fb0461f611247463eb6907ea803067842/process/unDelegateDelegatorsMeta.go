@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
 	"github.com/TerraDharitri/drt-go-chain-es-indexer/process/dataindexer"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/core"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/data"
@@ -49,6 +50,11 @@ func newUnDelegateInfoProcessor(esClient ElasticClientHandler) *unDelegatedInfoP
 }
 
 func (up *unDelegatedInfoProcessor) putUnDelegateInfoFromStakingProviders(accountsWithStake map[string]*data.AccountInfoWithStakeValues) error {
+	if check.IfNil(up.esClient) {
+		log.Warn("no source elasticsearch cluster configured, the undelegated values from staking provider contracts are not fetched")
+		return nil
+	}
+
 	defer logExecutionTime(time.Now(), "Fetched undelegated values from staking provider contracts")
 	handlerFunc := func(responseBytes []byte) error {
 		delegatorsResp := &delegatorsResponse{}
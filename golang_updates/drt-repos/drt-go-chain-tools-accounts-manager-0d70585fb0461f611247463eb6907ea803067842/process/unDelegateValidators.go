@@ -17,12 +17,17 @@ const (
 	getUnStakedTokensListEndpoint = "getUnStakedTokensList"
 )
 
-func (ag *accountsGetter) putUndelegatedValuesFromValidatorsContract(accountsWithStake map[string]*data.AccountInfoWithStakeValues) error {
+func (ag *accountsGetter) putUndelegatedValuesFromValidatorsContract(accountsWithStake map[string]*data.AccountInfoWithStakeValues, blockInfo *data.BlockInfo) error {
 	if ag.validatorsContract == "" {
 		return nil
 	}
 
-	unDelegatedValues, err := ag.getUnDelegatedValuesFromValidatorsContract(accountsWithStake)
+	contractBlock, err := ag.blockForAddress(blockInfo, ag.validatorsContract)
+	if err != nil {
+		return err
+	}
+
+	unDelegatedValues, err := ag.getUnDelegatedValuesFromValidatorsContract(accountsWithStake, contractBlock)
 	if err != nil {
 		return err
 	}
@@ -39,7 +44,7 @@ func (ag *accountsGetter) putUndelegatedValuesFromValidatorsContract(accountsWit
 	return nil
 }
 
-func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWithStake map[string]*data.AccountInfoWithStakeValues) (map[string]*big.Int, error) {
+func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWithStake map[string]*data.AccountInfoWithStakeValues, blockInfo *data.BlockInfo) (map[string]*big.Int, error) {
 	defer logExecutionTime(time.Now(), "Fetched undelegated values from validators contract")
 
 	unDelegatedValue := make(map[string]*big.Int)
@@ -56,7 +61,7 @@ func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWit
 				wg.Done()
 			}()
 
-			value, err := ag.getUnDelegatedValueForAddressValidatorsContract(addr)
+			value, err := ag.getUnDelegatedValueForAddressValidatorsContract(addr, blockInfo)
 			if err != nil {
 				ag.mutex.Lock()
 				errors = append(errors, err.Error())
@@ -82,8 +87,7 @@ func (ag *accountsGetter) getUnDelegatedValuesFromValidatorsContract(accountsWit
 	return unDelegatedValue, nil
 }
 
-func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(address string) (*big.Int, error) {
-
+func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(address string, blockInfo *data.BlockInfo) (*big.Int, error) {
 	decodedAddr, err := ag.pubKeyConverter.Decode(address)
 	if err != nil {
 		return nil, err
@@ -97,7 +101,7 @@ func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(addres
 	}
 
 	responseVmValue := &data.ResponseVmValue{}
-	err = ag.restClient.CallPostRestEndPoint(pathVMValues, vmRequest, responseVmValue, ag.authenticationData)
+	err = ag.restClient.CallPostRestEndPoint(pathWithBlockHash(pathVMValues, blockInfo), vmRequest, responseVmValue, ag.authenticationData)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +114,11 @@ func (ag *accountsGetter) getUnDelegatedValueForAddressValidatorsContract(addres
 		}
 	}
 
+	err = ag.checkQueriedBlock(blockInfo, responseVmValue.Data.BlockInfo, "validators contract")
+	if err != nil {
+		return nil, err
+	}
+
 	if responseVmValue.Data.Data == nil {
 		return big.NewInt(0), nil
 	}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	nodeCore "github.com/TerraDharitri/drt-go-chain-core/core"
 	"github.com/TerraDharitri/drt-go-chain-core/core/pubkeyConverter"
 	"github.com/TerraDharitri/drt-go-chain-core/data/vm"
 	"github.com/TerraDharitri/drt-go-chain-tools-accounts-manager/config"
@@ -19,20 +20,28 @@ func TestAccountsGetter_ValidatorsAccountsPutUnDelegatedValues(t *testing.T) {
 
 	pubKeyConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
 
+	validatorsContract := "drt1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqplllskzf8kp"
 	ag, err := NewAccountsGetter(&mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			require.True(t, answerAddressShard(path, value, map[string]uint32{validatorsContract: nodeCore.MetachainShardId}))
+			return nil
+		},
 		CallPostRestEndPointCalled: func(path string, dataD interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error {
+			require.Equal(t, pathWithBlockHash(pathVMValues, testBlockInfo), path)
+
 			responseVmValue := response.(*data.ResponseVmValue)
 			responseVmValue.Data = data.VmValuesResponseData{
 				Data: &vm.VMOutputApi{
 					ReturnData: [][]byte{big.NewInt(1000000000000000000).Bytes(), []byte("")},
 					ReturnCode: vmcommon.Ok.String(),
 				},
+				BlockInfo: testBlockInfo,
 			}
 
 			return nil
 		},
 	}, pubKeyConverter, data.RestApiAuthenticationData{}, config.GeneralConfig{
-		ValidatorsContract: "drt1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqplllskzf8kp",
+		ValidatorsContract: validatorsContract,
 	}, &mocks.ElasticClientStub{})
 	require.Nil(t, err)
 
@@ -41,7 +50,7 @@ func TestAccountsGetter_ValidatorsAccountsPutUnDelegatedValues(t *testing.T) {
 	err = json.Unmarshal([]byte(accountsWithStakeJson), &accountsWithStake)
 	require.Nil(t, err)
 
-	err = ag.putUndelegatedValuesFromValidatorsContract(accountsWithStake)
+	err = ag.putUndelegatedValuesFromValidatorsContract(accountsWithStake, testBlockInfo)
 	require.Nil(t, err)
 
 	for _, account := range accountsWithStake {
@@ -0,0 +1,92 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// AbiDefinition is the Go representation of a contract's ABI file (*.abi.json)
+type AbiDefinition struct {
+	Name               string                     `json:"name"`
+	Constructor        *EndpointDefinition        `json:"constructor"`
+	UpgradeConstructor *EndpointDefinition        `json:"upgradeConstructor"`
+	Endpoints          []*EndpointDefinition      `json:"endpoints"`
+	Events             []*EventDefinition         `json:"events"`
+	Types              map[string]*TypeDefinition `json:"types"`
+}
+
+// EndpointDefinition describes an endpoint (or a constructor) of a contract
+type EndpointDefinition struct {
+	Name            string                 `json:"name"`
+	Docs            []string               `json:"docs"`
+	Mutability      string                 `json:"mutability"`
+	PayableInTokens []string               `json:"payableInTokens"`
+	Inputs          []*ParameterDefinition `json:"inputs"`
+	Outputs         []*ParameterDefinition `json:"outputs"`
+}
+
+// ParameterDefinition describes an input or an output of an endpoint
+type ParameterDefinition struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MultiArg    bool   `json:"multi_arg"`
+	MultiResult bool   `json:"multi_result"`
+}
+
+// EventDefinition describes an event emitted by a contract
+type EventDefinition struct {
+	Identifier string                  `json:"identifier"`
+	Docs       []string                `json:"docs"`
+	Inputs     []*EventInputDefinition `json:"inputs"`
+}
+
+// EventInputDefinition describes an input of an event (either an indexed topic or the data field)
+type EventInputDefinition struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+// TypeDefinition describes a custom type (struct or enum)
+type TypeDefinition struct {
+	Type     string               `json:"type"`
+	Docs     []string             `json:"docs"`
+	Fields   []*FieldDefinition   `json:"fields"`
+	Variants []*VariantDefinition `json:"variants"`
+}
+
+// FieldDefinition describes a field of a struct or of an enum variant
+type FieldDefinition struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// VariantDefinition describes a variant of an enum
+type VariantDefinition struct {
+	Name         string             `json:"name"`
+	Discriminant uint8              `json:"discriminant"`
+	Fields       []*FieldDefinition `json:"fields"`
+}
+
+// LoadAbiDefinitionFromFile loads an ABI definition from the given file (*.abi.json)
+func LoadAbiDefinitionFromFile(path string) (*AbiDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ABI file '%s', because of: %w", path, err)
+	}
+
+	return ParseAbiDefinition(data)
+}
+
+// ParseAbiDefinition parses an ABI definition from the given JSON content
+func ParseAbiDefinition(data []byte) (*AbiDefinition, error) {
+	definition := &AbiDefinition{}
+
+	err := json.Unmarshal(data, definition)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse ABI definition, because of: %w", err)
+	}
+
+	return definition, nil
+}
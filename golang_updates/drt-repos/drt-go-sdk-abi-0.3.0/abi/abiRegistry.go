@@ -0,0 +1,685 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const constructorName = "init"
+const upgradeConstructorName = "upgrade"
+const customTypeStruct = "struct"
+const customTypeEnum = "enum"
+const managedDecimalVariableScale = "usize"
+
+// arrayTypeNamePattern matches the names of fixed-size arrays, e.g. "array32" (as in "array32<u8>")
+var arrayTypeNamePattern = regexp.MustCompile(`^array(\d+)$`)
+
+// builtInCustomTypes are custom types which are known even if missing from an ABI definition
+var builtInCustomTypes = map[string]*TypeDefinition{
+	"DcdtTokenPayment": {
+		Type: customTypeStruct,
+		Fields: []*FieldDefinition{
+			{Name: "token_identifier", Type: "TokenIdentifier"},
+			{Name: "token_nonce", Type: "u64"},
+			{Name: "amount", Type: "BigUint"},
+		},
+	},
+}
+
+// abiRegistry indexes an ABI definition and creates (placeholder) value trees for endpoints and events.
+// The value trees can be directly passed to the serializer, for decoding (or encoding, once filled).
+type abiRegistry struct {
+	definition  *AbiDefinition
+	endpoints   map[string]*EndpointDefinition
+	events      map[string]*EventDefinition
+	customTypes map[string]*TypeDefinition
+}
+
+// NewAbiRegistry creates a new registry for the given ABI definition.
+// All type expressions found in the definition are validated upfront.
+func NewAbiRegistry(definition *AbiDefinition) (*abiRegistry, error) {
+	if definition == nil {
+		return nil, errors.New("cannot create ABI registry: definition is nil")
+	}
+
+	registry := &abiRegistry{
+		definition:  definition,
+		endpoints:   make(map[string]*EndpointDefinition),
+		events:      make(map[string]*EventDefinition),
+		customTypes: make(map[string]*TypeDefinition),
+	}
+
+	for name, customType := range builtInCustomTypes {
+		registry.customTypes[name] = customType
+	}
+
+	// Custom types defined in the ABI take precedence over the built-in ones.
+	for name, customType := range definition.Types {
+		if customType == nil {
+			return nil, fmt.Errorf("cannot create ABI registry: definition of type '%s' is nil", name)
+		}
+
+		registry.customTypes[name] = customType
+	}
+
+	if definition.Constructor != nil {
+		registry.endpoints[constructorName] = definition.Constructor
+	}
+
+	if definition.UpgradeConstructor != nil {
+		registry.endpoints[upgradeConstructorName] = definition.UpgradeConstructor
+	}
+
+	for index, endpoint := range definition.Endpoints {
+		if endpoint == nil {
+			return nil, fmt.Errorf("cannot create ABI registry: definition of endpoint at index %d is nil", index)
+		}
+
+		registry.endpoints[endpoint.Name] = endpoint
+	}
+
+	for index, event := range definition.Events {
+		if event == nil {
+			return nil, fmt.Errorf("cannot create ABI registry: definition of event at index %d is nil", index)
+		}
+
+		registry.events[event.Identifier] = event
+	}
+
+	err := registry.validate()
+	if err != nil {
+		return nil, fmt.Errorf("cannot create ABI registry: %w", err)
+	}
+
+	return registry, nil
+}
+
+// NewAbiRegistryFromFile loads the given ABI file (*.abi.json) and creates a new registry for it
+func NewAbiRegistryFromFile(path string) (*abiRegistry, error) {
+	definition, err := LoadAbiDefinitionFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAbiRegistry(definition)
+}
+
+// GetDefinition returns the underlying ABI definition
+func (registry *abiRegistry) GetDefinition() *AbiDefinition {
+	return registry.definition
+}
+
+// GetEndpoint returns the definition of an endpoint.
+// The constructor and the upgrade constructor can be accessed as "init" and "upgrade", respectively.
+func (registry *abiRegistry) GetEndpoint(name string) (*EndpointDefinition, error) {
+	endpoint, ok := registry.endpoints[name]
+	if !ok {
+		return nil, fmt.Errorf("endpoint '%s' not found in ABI", name)
+	}
+
+	return endpoint, nil
+}
+
+// GetEvent returns the definition of an event
+func (registry *abiRegistry) GetEvent(identifier string) (*EventDefinition, error) {
+	event, ok := registry.events[identifier]
+	if !ok {
+		return nil, fmt.Errorf("event '%s' not found in ABI", identifier)
+	}
+
+	return event, nil
+}
+
+// GetCustomType returns the definition of a custom type (struct or enum), including the built-in ones (e.g. "DcdtTokenPayment")
+func (registry *abiRegistry) GetCustomType(name string) (*TypeDefinition, error) {
+	customType, ok := registry.customTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("type '%s' not found in ABI", name)
+	}
+
+	return customType, nil
+}
+
+// CreateInputValues creates placeholder values for the inputs of the given endpoint
+func (registry *abiRegistry) CreateInputValues(endpointName string) ([]any, error) {
+	endpoint, err := registry.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.createParametersValues(endpoint.Inputs)
+}
+
+// CreateOutputValues creates placeholder values for the outputs of the given endpoint
+func (registry *abiRegistry) CreateOutputValues(endpointName string) ([]any, error) {
+	endpoint, err := registry.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.createParametersValues(endpoint.Outputs)
+}
+
+// CreateEventTopicValues creates placeholder values for the indexed inputs (topics) of the given event.
+// The event identifier itself (the first topic of a log event) is not included.
+func (registry *abiRegistry) CreateEventTopicValues(identifier string) ([]any, error) {
+	return registry.createEventValues(identifier, true)
+}
+
+// CreateEventDataValues creates placeholder values for the non-indexed inputs (data) of the given event
+func (registry *abiRegistry) CreateEventDataValues(identifier string) ([]any, error) {
+	return registry.createEventValues(identifier, false)
+}
+
+// CreateValue creates a placeholder value for the given type expression (e.g. "List<Option<u64>>")
+func (registry *abiRegistry) CreateValue(typeExpression string) (any, error) {
+	formula, err := ParseTypeFormula(typeExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	err = registry.validateFormula(formula, make(map[string]struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.createValue(formula)
+}
+
+func (registry *abiRegistry) createParametersValues(parameters []*ParameterDefinition) ([]any, error) {
+	values := make([]any, 0, len(parameters))
+
+	for _, parameter := range parameters {
+		value, err := registry.CreateValue(parameter.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create value for parameter '%s': %w", parameter.Name, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (registry *abiRegistry) createEventValues(identifier string, indexed bool) ([]any, error) {
+	event, err := registry.GetEvent(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0, len(event.Inputs))
+
+	for _, input := range event.Inputs {
+		if input.Indexed != indexed {
+			continue
+		}
+
+		value, err := registry.CreateValue(input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create value for event input '%s': %w", input.Name, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// createValue creates a value (single value or multi-value) for an already validated formula
+func (registry *abiRegistry) createValue(formula *TypeFormula) (any, error) {
+	switch formula.Name {
+	case "optional":
+		value, err := registry.createValue(formula.TypeParameters[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return &OptionalValue{Value: value}, nil
+	case "variadic":
+		itemFormula := formula.TypeParameters[0]
+
+		return &VariadicValues{
+			Items: []any{},
+			ItemCreator: func() any {
+				// The formula has already been validated, thus no error is expected here.
+				item, _ := registry.createValue(itemFormula)
+				return item
+			},
+		}, nil
+	case "counted-variadic":
+		itemFormula := formula.TypeParameters[0]
+
+		return &CountedVariadicValues{
+			Items: []any{},
+			ItemCreator: func() any {
+				// The formula has already been validated, thus no error is expected here.
+				item, _ := registry.createValue(itemFormula)
+				return item
+			},
+		}, nil
+	case "multi":
+		items := make([]any, 0, len(formula.TypeParameters))
+
+		for _, itemFormula := range formula.TypeParameters {
+			item, err := registry.createValue(itemFormula)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return &MultiValue{Items: items}, nil
+	default:
+		return registry.createSingleValue(formula)
+	}
+}
+
+// createSingleValue creates a single value for an already validated formula
+func (registry *abiRegistry) createSingleValue(formula *TypeFormula) (SingleValue, error) {
+	switch formula.Name {
+	case "u8":
+		return &U8Value{}, nil
+	case "u16":
+		return &U16Value{}, nil
+	case "u32", "usize":
+		return &U32Value{}, nil
+	case "u64":
+		return &U64Value{}, nil
+	case "i8":
+		return &I8Value{}, nil
+	case "i16":
+		return &I16Value{}, nil
+	case "i32", "isize":
+		return &I32Value{}, nil
+	case "i64":
+		return &I64Value{}, nil
+	case "BigUint":
+		return &BigUIntValue{}, nil
+	case "BigInt":
+		return &BigIntValue{}, nil
+	case "bool":
+		return &BoolValue{}, nil
+	case "bytes", "BoxedBytes", "ManagedBuffer":
+		return &BytesValue{}, nil
+	case "utf-8 string", "String":
+		return &StringValue{}, nil
+	case "Address", "ManagedAddress":
+		return &AddressValue{}, nil
+	case "TokenIdentifier", "RewaOrDcdtTokenIdentifier":
+		return &TokenIdentifierValue{}, nil
+	case "CodeMetadata":
+		return &CodeMetadataValue{}, nil
+	case "ManagedDecimal":
+		scale, isVariable := parseManagedDecimalScale(formula.TypeParameters[0])
+		return &ManagedDecimalValue{Scale: scale, IsVariable: isVariable}, nil
+	case "ManagedDecimalSigned":
+		scale, isVariable := parseManagedDecimalScale(formula.TypeParameters[0])
+		return &ManagedDecimalSignedValue{Scale: scale, IsVariable: isVariable}, nil
+	case "tuple":
+		items := make([]SingleValue, 0, len(formula.TypeParameters))
+
+		for _, itemFormula := range formula.TypeParameters {
+			item, err := registry.createSingleValue(itemFormula)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return &TupleValue{Items: items}, nil
+	case "List":
+		itemFormula := formula.TypeParameters[0]
+
+		return &ListValue{
+			Items: []SingleValue{},
+			ItemCreator: func() SingleValue {
+				// The formula has already been validated, thus no error is expected here.
+				item, _ := registry.createSingleValue(itemFormula)
+				return item
+			},
+		}, nil
+	case "Option":
+		value, err := registry.createSingleValue(formula.TypeParameters[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return &OptionValue{Value: value}, nil
+	}
+
+	length, isArray := parseArrayLength(formula.Name)
+	if isArray {
+		itemFormula := formula.TypeParameters[0]
+
+		return &ArrayValue{
+			Length: length,
+			Items:  []SingleValue{},
+			ItemCreator: func() SingleValue {
+				// The formula has already been validated, thus no error is expected here.
+				item, _ := registry.createSingleValue(itemFormula)
+				return item
+			},
+		}, nil
+	}
+
+	customType, ok := registry.customTypes[formula.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type: %s", formula.Name)
+	}
+
+	switch customType.Type {
+	case customTypeStruct:
+		fields, err := registry.createFields(customType.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		return &StructValue{Fields: fields}, nil
+	case customTypeEnum:
+		return &EnumValue{
+			Fields:         []Field{},
+			FieldsProvider: registry.createEnumFieldsProvider(customType),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind of custom type '%s': %s", formula.Name, customType.Type)
+	}
+}
+
+func (registry *abiRegistry) createFields(definitions []*FieldDefinition) ([]Field, error) {
+	fields := make([]Field, 0, len(definitions))
+
+	for _, definition := range definitions {
+		value, err := registry.CreateValue(definition.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		singleValue, ok := value.(SingleValue)
+		if !ok {
+			return nil, fmt.Errorf("field '%s' must be a single value, but has type %s", definition.Name, definition.Type)
+		}
+
+		fields = append(fields, Field{
+			Name:  definition.Name,
+			Value: singleValue,
+		})
+	}
+
+	return fields, nil
+}
+
+func (registry *abiRegistry) createEnumFieldsProvider(customType *TypeDefinition) func(uint8) []Field {
+	return func(discriminant uint8) []Field {
+		for _, variant := range customType.Variants {
+			if variant.Discriminant != discriminant {
+				continue
+			}
+
+			// The fields have already been validated, thus no error is expected here.
+			fields, _ := registry.createFields(variant.Fields)
+			return fields
+		}
+
+		return nil
+	}
+}
+
+// validate checks all type expressions found in the ABI definition
+func (registry *abiRegistry) validate() error {
+	for name, customType := range registry.customTypes {
+		err := registry.validateCustomType(name, customType)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, endpoint := range registry.endpoints {
+		for _, parameter := range append(endpoint.Inputs, endpoint.Outputs...) {
+			if parameter == nil {
+				return fmt.Errorf("nil parameter of endpoint '%s'", name)
+			}
+
+			err := registry.validateTypeExpression(parameter.Type)
+			if err != nil {
+				return fmt.Errorf("bad parameter '%s' of endpoint '%s': %w", parameter.Name, name, err)
+			}
+		}
+	}
+
+	for identifier, event := range registry.events {
+		for _, input := range event.Inputs {
+			if input == nil {
+				return fmt.Errorf("nil input of event '%s'", identifier)
+			}
+
+			err := registry.validateTypeExpression(input.Type)
+			if err != nil {
+				return fmt.Errorf("bad input '%s' of event '%s': %w", input.Name, identifier, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (registry *abiRegistry) validateCustomType(name string, customType *TypeDefinition) error {
+	var fields []*FieldDefinition
+
+	switch customType.Type {
+	case customTypeStruct:
+		fields = customType.Fields
+	case customTypeEnum:
+		for _, variant := range customType.Variants {
+			fields = append(fields, variant.Fields...)
+		}
+	default:
+		return fmt.Errorf("unsupported kind of custom type '%s': %s", name, customType.Type)
+	}
+
+	for _, field := range fields {
+		formula, err := ParseTypeFormula(field.Type)
+		if err != nil {
+			return fmt.Errorf("bad field '%s' of type '%s': %w", field.Name, name, err)
+		}
+
+		err = registry.validateSingleValueFormula(formula, make(map[string]struct{}))
+		if err != nil {
+			return fmt.Errorf("bad field '%s' of type '%s': %w", field.Name, name, err)
+		}
+	}
+
+	if customType.Type == customTypeStruct {
+		return registry.validateFormula(&TypeFormula{Name: name}, make(map[string]struct{}))
+	}
+
+	return nil
+}
+
+func (registry *abiRegistry) validateTypeExpression(typeExpression string) error {
+	formula, err := ParseTypeFormula(typeExpression)
+	if err != nil {
+		return err
+	}
+
+	return registry.validateFormula(formula, make(map[string]struct{}))
+}
+
+// validateFormula checks that all the types referenced by the formula are known and well-formed.
+// It also detects structs that (eagerly) contain themselves, e.g. through an "Option", since they would lead to infinite values.
+// The "eagerPath" holds the structs being expanded. Lists, variadics and enums create their items lazily, thus they pass a nil path:
+// within them, custom types are only looked up, since each custom type is validated on its own (see validateCustomType).
+func (registry *abiRegistry) validateFormula(formula *TypeFormula, eagerPath map[string]struct{}) error {
+	err := validateNumTypeParameters(formula)
+	if err != nil {
+		return err
+	}
+
+	switch formula.Name {
+	case "optional", "multi":
+		for _, parameter := range formula.TypeParameters {
+			err := registry.validateFormula(parameter, eagerPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "variadic", "counted-variadic":
+		return registry.validateFormula(formula.TypeParameters[0], nil)
+	case "List":
+		return registry.validateSingleValueFormula(formula.TypeParameters[0], nil)
+	case "Option":
+		return registry.validateSingleValueFormula(formula.TypeParameters[0], eagerPath)
+	case "tuple":
+		for _, parameter := range formula.TypeParameters {
+			err := registry.validateSingleValueFormula(parameter, eagerPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "ManagedDecimal", "ManagedDecimalSigned":
+		return validateManagedDecimalScale(formula)
+	}
+
+	_, isArray := parseArrayLength(formula.Name)
+	if isArray {
+		return registry.validateSingleValueFormula(formula.TypeParameters[0], eagerPath)
+	}
+
+	if isPrimitiveTypeName(formula.Name) {
+		return nil
+	}
+
+	customType, ok := registry.customTypes[formula.Name]
+	if !ok {
+		return fmt.Errorf("unknown type: %s", formula.Name)
+	}
+
+	if customType.Type != customTypeStruct || eagerPath == nil {
+		return nil
+	}
+
+	_, isOnPath := eagerPath[formula.Name]
+	if isOnPath {
+		return fmt.Errorf("type '%s' recursively contains itself", formula.Name)
+	}
+
+	eagerPath[formula.Name] = struct{}{}
+	defer delete(eagerPath, formula.Name)
+
+	for _, field := range customType.Fields {
+		fieldFormula, err := ParseTypeFormula(field.Type)
+		if err != nil {
+			return err
+		}
+
+		err = registry.validateFormula(fieldFormula, eagerPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateNumTypeParameters(formula *TypeFormula) error {
+	numTypeParameters := len(formula.TypeParameters)
+	_, isArray := parseArrayLength(formula.Name)
+
+	switch {
+	case isArray, formula.Name == "optional", formula.Name == "variadic", formula.Name == "counted-variadic",
+		formula.Name == "List", formula.Name == "Option",
+		formula.Name == "ManagedDecimal", formula.Name == "ManagedDecimalSigned":
+		if numTypeParameters != 1 {
+			return fmt.Errorf("type '%s' must have exactly one type parameter", formula.Name)
+		}
+	case formula.Name == "multi", formula.Name == "tuple":
+		if numTypeParameters == 0 {
+			return fmt.Errorf("type '%s' must have at least one type parameter", formula.Name)
+		}
+	default:
+		if numTypeParameters != 0 {
+			return fmt.Errorf("type '%s' must not have type parameters", formula.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateManagedDecimalScale(formula *TypeFormula) error {
+	scaleFormula := formula.TypeParameters[0]
+	if scaleFormula.Name == managedDecimalVariableScale {
+		return nil
+	}
+
+	_, err := strconv.ParseUint(scaleFormula.Name, 10, 32)
+	if err != nil || len(scaleFormula.TypeParameters) != 0 {
+		return fmt.Errorf("type '%s' must have a number or '%s' as scale, but got '%s'", formula.Name, managedDecimalVariableScale, scaleFormula)
+	}
+
+	return nil
+}
+
+// parseManagedDecimalScale parses the (already validated) scale of a "ManagedDecimal<N>" or "ManagedDecimal<usize>"
+func parseManagedDecimalScale(scaleFormula *TypeFormula) (uint32, bool) {
+	if scaleFormula.Name == managedDecimalVariableScale {
+		return 0, true
+	}
+
+	scale, _ := strconv.ParseUint(scaleFormula.Name, 10, 32)
+	return uint32(scale), false
+}
+
+// parseArrayLength parses the length of a fixed-size array out of its type name (e.g. "array32")
+func parseArrayLength(name string) (uint32, bool) {
+	match := arrayTypeNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0, false
+	}
+
+	length, err := strconv.ParseUint(match[1], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(length), true
+}
+
+func (registry *abiRegistry) validateSingleValueFormula(formula *TypeFormula, eagerPath map[string]struct{}) error {
+	if isMultiValueTypeName(formula.Name) {
+		return fmt.Errorf("multi-value type '%s' is not allowed within a single value", formula.Name)
+	}
+
+	return registry.validateFormula(formula, eagerPath)
+}
+
+func isMultiValueTypeName(name string) bool {
+	switch name {
+	case "optional", "variadic", "counted-variadic", "multi":
+		return true
+	default:
+		return false
+	}
+}
+
+func isPrimitiveTypeName(name string) bool {
+	switch name {
+	case "u8", "u16", "u32", "usize", "u64",
+		"i8", "i16", "i32", "isize", "i64",
+		"BigUint", "BigInt", "bool",
+		"bytes", "BoxedBytes", "ManagedBuffer",
+		"utf-8 string", "String",
+		"Address", "ManagedAddress",
+		"TokenIdentifier", "RewaOrDcdtTokenIdentifier",
+		"CodeMetadata":
+		return true
+	default:
+		return false
+	}
+}
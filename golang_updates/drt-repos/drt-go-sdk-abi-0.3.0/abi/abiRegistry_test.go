@@ -0,0 +1,319 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAbiRegistry(t *testing.T) {
+	t.Run("should err on nil definition", func(t *testing.T) {
+		_, err := NewAbiRegistry(nil)
+		require.ErrorContains(t, err, "cannot create ABI registry: definition is nil")
+	})
+
+	t.Run("should err on nil endpoint", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Endpoints: []*EndpointDefinition{{Name: "foo"}, nil},
+		})
+
+		require.ErrorContains(t, err, "cannot create ABI registry: definition of endpoint at index 1 is nil")
+	})
+
+	t.Run("should err on nil event", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Events: []*EventDefinition{nil},
+		})
+
+		require.ErrorContains(t, err, "cannot create ABI registry: definition of event at index 0 is nil")
+	})
+
+	t.Run("should err on nil endpoint parameter", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Endpoints: []*EndpointDefinition{{Name: "foo", Inputs: []*ParameterDefinition{nil}}},
+		})
+
+		require.ErrorContains(t, err, "nil parameter of endpoint 'foo'")
+	})
+
+	t.Run("should err on null entries in JSON", func(t *testing.T) {
+		definition := &AbiDefinition{}
+		err := json.Unmarshal([]byte(`{"endpoints": [null], "events": [null]}`), definition)
+		require.Nil(t, err)
+
+		_, err = NewAbiRegistry(definition)
+		require.ErrorContains(t, err, "definition of endpoint at index 0 is nil")
+	})
+
+	t.Run("should err on unknown type", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Endpoints: []*EndpointDefinition{
+				{
+					Name:    "foo",
+					Inputs:  []*ParameterDefinition{{Name: "a", Type: "List<Foobar>"}},
+					Outputs: []*ParameterDefinition{},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "bad parameter 'a' of endpoint 'foo': unknown type: Foobar")
+	})
+
+	t.Run("should err on multi-value within single value", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Endpoints: []*EndpointDefinition{
+				{
+					Name:   "foo",
+					Inputs: []*ParameterDefinition{{Name: "a", Type: "List<variadic<u8>>"}},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "multi-value type 'variadic' is not allowed within a single value")
+	})
+
+	t.Run("should err on struct which eagerly contains itself", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Types: map[string]*TypeDefinition{
+				"Node": {
+					Type:   "struct",
+					Fields: []*FieldDefinition{{Name: "next", Type: "Option<Node>"}},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "type 'Node' recursively contains itself")
+	})
+
+	t.Run("should accept struct which lazily contains itself", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Types: map[string]*TypeDefinition{
+				"Node": {
+					Type:   "struct",
+					Fields: []*FieldDefinition{{Name: "children", Type: "List<Node>"}},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should err on bad scale of managed decimal", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Endpoints: []*EndpointDefinition{
+				{
+					Name:    "foo",
+					Outputs: []*ParameterDefinition{{Name: "a", Type: "ManagedDecimal<u8>"}},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "type 'ManagedDecimal' must have a number or 'usize' as scale, but got 'u8'")
+	})
+
+	t.Run("should err on array without item type", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Endpoints: []*EndpointDefinition{
+				{
+					Name:    "foo",
+					Outputs: []*ParameterDefinition{{Name: "a", Type: "array2"}},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "type 'array2' must have exactly one type parameter")
+	})
+
+	t.Run("should err on unsupported kind of custom type", func(t *testing.T) {
+		_, err := NewAbiRegistry(&AbiDefinition{
+			Types: map[string]*TypeDefinition{
+				"Foo": {Type: "union"},
+			},
+		})
+
+		require.ErrorContains(t, err, "unsupported kind of custom type 'Foo': union")
+	})
+}
+
+func TestAbiRegistry_CreateValues(t *testing.T) {
+	registry, err := NewAbiRegistryFromFile("testdata/multisig.abi.json")
+	require.NoError(t, err)
+
+	t.Run("should err on unknown endpoint", func(t *testing.T) {
+		_, err := registry.CreateInputValues("foobar")
+		require.ErrorContains(t, err, "endpoint 'foobar' not found in ABI")
+	})
+
+	t.Run("constructor inputs", func(t *testing.T) {
+		values, err := registry.CreateInputValues("init")
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		require.IsType(t, &U32Value{}, values[0])
+		require.IsType(t, &AddressValue{}, values[1].(*VariadicValues).ItemCreator())
+	})
+
+	t.Run("optional multi-value input", func(t *testing.T) {
+		values, err := registry.CreateInputValues("getPendingActionFullInfo")
+		require.NoError(t, err)
+		require.Equal(t, []any{
+			&OptionalValue{
+				Value: &MultiValue{
+					Items: []any{&U32Value{}, &U32Value{}},
+				},
+			},
+		}, values)
+	})
+
+	t.Run("event values", func(t *testing.T) {
+		topics, err := registry.CreateEventTopicValues("performChangeUser")
+		require.NoError(t, err)
+		require.Len(t, topics, 4)
+		require.IsType(t, &EnumValue{}, topics[3])
+
+		data, err := registry.CreateEventDataValues("performChangeUser")
+		require.NoError(t, err)
+		require.Len(t, data, 0)
+
+		data, err = registry.CreateEventDataValues("startPerformAction")
+		require.NoError(t, err)
+		require.Len(t, data, 1)
+		require.IsType(t, &StructValue{}, data[0])
+	})
+
+	t.Run("value for type expression", func(t *testing.T) {
+		value, err := registry.CreateValue("List<Option<DcdtTokenPayment>>")
+		require.NoError(t, err)
+
+		item := value.(*ListValue).ItemCreator()
+		require.Equal(t, &OptionValue{
+			Value: &StructValue{
+				Fields: []Field{
+					{Name: "token_identifier", Value: &TokenIdentifierValue{}},
+					{Name: "token_nonce", Value: &U64Value{}},
+					{Name: "amount", Value: &BigUIntValue{}},
+				},
+			},
+		}, item)
+	})
+}
+
+func TestAbiRegistry_CreateValuesOfAdditionalTypes(t *testing.T) {
+	registry, err := NewAbiRegistry(&AbiDefinition{})
+	require.NoError(t, err)
+
+	t.Run("fixed array", func(t *testing.T) {
+		value, err := registry.CreateValue("array4<u16>")
+		require.NoError(t, err)
+
+		array := value.(*ArrayValue)
+		require.Equal(t, uint32(4), array.Length)
+		require.Equal(t, &U16Value{}, array.ItemCreator())
+	})
+
+	t.Run("tuple", func(t *testing.T) {
+		value, err := registry.CreateValue("tuple<u8,TokenIdentifier,CodeMetadata>")
+		require.NoError(t, err)
+		require.Equal(t, &TupleValue{
+			Items: []SingleValue{&U8Value{}, &TokenIdentifierValue{}, &CodeMetadataValue{}},
+		}, value)
+	})
+
+	t.Run("managed decimals", func(t *testing.T) {
+		value, err := registry.CreateValue("ManagedDecimal<18>")
+		require.NoError(t, err)
+		require.Equal(t, &ManagedDecimalValue{Scale: 18}, value)
+
+		value, err = registry.CreateValue("ManagedDecimalSigned<usize>")
+		require.NoError(t, err)
+		require.Equal(t, &ManagedDecimalSignedValue{IsVariable: true}, value)
+	})
+
+	t.Run("counted variadic", func(t *testing.T) {
+		value, err := registry.CreateValue("counted-variadic<multi<Address,BigUint>>")
+		require.NoError(t, err)
+		require.Equal(t, &MultiValue{
+			Items: []any{&AddressValue{}, &BigUIntValue{}},
+		}, value.(*CountedVariadicValues).ItemCreator())
+	})
+
+	t.Run("built-in token payment", func(t *testing.T) {
+		value, err := registry.CreateValue("DcdtTokenPayment")
+		require.NoError(t, err)
+		require.Equal(t, &StructValue{
+			Fields: []Field{
+				{Name: "token_identifier", Value: &TokenIdentifierValue{}},
+				{Name: "token_nonce", Value: &U64Value{}},
+				{Name: "amount", Value: &BigUIntValue{}},
+			},
+		}, value)
+	})
+}
+
+func TestAbiRegistry_WithSerializer(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: "@",
+	})
+	require.NoError(t, err)
+
+	registry, err := NewAbiRegistryFromFile("testdata/multisig.abi.json")
+	require.NoError(t, err)
+
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	bobPubKey, _ := hex.DecodeString("8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8")
+	oneQuintillion := big.NewInt(0).SetUint64(1_000_000_000_000_000_000)
+
+	t.Run("deserialize output of multisig.getPendingActionFullInfo()", func(t *testing.T) {
+		data := strings.Replace(strings.Join([]string{
+			"0000002A",
+			"0000002A",
+			"05|0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1|000000080de0b6b3a7640000|010000000000e4e1c0|000000076578616d706c65|00000002000000020342000000020743",
+			"00000002|0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1|8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8",
+		}, ""), "|", "", -1)
+
+		outputValues, err := registry.CreateOutputValues("getPendingActionFullInfo")
+		require.NoError(t, err)
+
+		err = serializer.Deserialize(data, outputValues)
+		require.NoError(t, err)
+
+		items := outputValues[0].(*VariadicValues).Items
+		require.Len(t, items, 1)
+
+		info := items[0].(*StructValue)
+		require.Equal(t, uint32(42), info.Fields[0].Value.(*U32Value).Value)
+		require.Equal(t, uint32(42), info.Fields[1].Value.(*U32Value).Value)
+
+		action := info.Fields[2].Value.(*EnumValue)
+		require.Equal(t, uint8(5), action.Discriminant)
+
+		callActionData := action.Fields[0].Value.(*StructValue)
+		require.Equal(t, alicePubKey, callActionData.Fields[0].Value.(*AddressValue).Value)
+		require.Equal(t, oneQuintillion, callActionData.Fields[1].Value.(*BigUIntValue).Value)
+		require.Equal(t, uint64(15000000), callActionData.Fields[2].Value.(*OptionValue).Value.(*U64Value).Value)
+		require.Equal(t, []byte("example"), callActionData.Fields[3].Value.(*BytesValue).Value)
+		require.Len(t, callActionData.Fields[4].Value.(*ListValue).Items, 2)
+
+		signers := info.Fields[3].Value.(*ListValue)
+		require.Equal(t, alicePubKey, signers.Items[0].(*AddressValue).Value)
+		require.Equal(t, bobPubKey, signers.Items[1].(*AddressValue).Value)
+	})
+
+	t.Run("deserialize input of multisig.getPendingActionFullInfo() (optional, provided)", func(t *testing.T) {
+		inputValues, err := registry.CreateInputValues("getPendingActionFullInfo")
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("2A@2B", inputValues)
+		require.NoError(t, err)
+		require.Equal(t, []any{
+			&OptionalValue{
+				Value: &MultiValue{
+					Items: []any{&U32Value{Value: 42}, &U32Value{Value: 43}},
+				},
+			},
+		}, inputValues)
+	})
+}
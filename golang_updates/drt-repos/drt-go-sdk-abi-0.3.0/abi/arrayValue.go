@@ -0,0 +1,75 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ArrayValue is a fixed-size array of values (e.g. "array32<u8>")
+type ArrayValue struct {
+	Length      uint32
+	Items       []SingleValue
+	ItemCreator func() SingleValue
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *ArrayValue) EncodeNested(writer io.Writer) error {
+	if uint32(len(value.Items)) != value.Length {
+		return fmt.Errorf("array has invalid number of items: %d != %d", len(value.Items), value.Length)
+	}
+
+	// The length is not encoded, since it's known from the type.
+	for _, item := range value.Items {
+		err := item.EncodeNested(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *ArrayValue) EncodeTopLevel(writer io.Writer) error {
+	return value.EncodeNested(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *ArrayValue) DecodeNested(reader io.Reader) error {
+	if value.ItemCreator == nil {
+		return errors.New("cannot decode array: item creator is nil")
+	}
+
+	value.Items = make([]SingleValue, 0, value.Length)
+
+	for i := uint32(0); i < value.Length; i++ {
+		newItem := value.ItemCreator()
+
+		err := newItem.DecodeNested(reader)
+		if err != nil {
+			return err
+		}
+
+		value.Items = append(value.Items, newItem)
+	}
+
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *ArrayValue) DecodeTopLevel(data []byte) error {
+	reader := bytes.NewReader(data)
+
+	err := value.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+
+	if reader.Len() > 0 {
+		return fmt.Errorf("unexpected data after array: %d bytes", reader.Len())
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package abi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayValue(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec,
+			&ArrayValue{
+				Length: 3,
+				Items: []SingleValue{
+					&U16Value{Value: 1},
+					&U16Value{Value: 2},
+					&U16Value{Value: 3},
+				},
+			},
+			"000100020003",
+		)
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec,
+			&ArrayValue{
+				Length: 2,
+				Items: []SingleValue{
+					&BytesValue{Value: []byte{0x42}},
+					&BytesValue{Value: []byte{0x43}},
+				},
+			},
+			"00000001420000000143",
+		)
+	})
+
+	t.Run("should err on encode when length doesn't match", func(t *testing.T) {
+		_, err := codec.EncodeNested(&ArrayValue{
+			Length: 3,
+			Items:  []SingleValue{&U16Value{Value: 1}},
+		})
+
+		require.ErrorContains(t, err, "array has invalid number of items: 1 != 3")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		data, _ := hex.DecodeString("00010002000300ff")
+
+		destination := &ArrayValue{
+			Length:      3,
+			ItemCreator: func() SingleValue { return &U16Value{} },
+		}
+
+		err := codec.DecodeNested(data, destination)
+		require.NoError(t, err)
+		require.Equal(t,
+			[]SingleValue{
+				&U16Value{Value: 1},
+				&U16Value{Value: 2},
+				&U16Value{Value: 3},
+			},
+			destination.Items,
+		)
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		data, _ := hex.DecodeString("0102")
+
+		destination := &ArrayValue{
+			Length:      2,
+			ItemCreator: func() SingleValue { return &U8Value{} },
+		}
+
+		err := codec.DecodeTopLevel(data, destination)
+		require.NoError(t, err)
+		require.Equal(t,
+			[]SingleValue{
+				&U8Value{Value: 1},
+				&U8Value{Value: 2},
+			},
+			destination.Items,
+		)
+	})
+
+	t.Run("should err on decode top-level when there are too many items", func(t *testing.T) {
+		data, _ := hex.DecodeString("010203")
+
+		destination := &ArrayValue{
+			Length:      2,
+			ItemCreator: func() SingleValue { return &U8Value{} },
+		}
+
+		err := codec.DecodeTopLevel(data, destination)
+		require.ErrorContains(t, err, "unexpected data after array: 1 bytes")
+	})
+
+	t.Run("should err on decode when there are too few items", func(t *testing.T) {
+		data, _ := hex.DecodeString("01")
+
+		destination := &ArrayValue{
+			Length:      2,
+			ItemCreator: func() SingleValue { return &U8Value{} },
+		}
+
+		err := codec.DecodeTopLevel(data, destination)
+		require.Error(t, err)
+	})
+}
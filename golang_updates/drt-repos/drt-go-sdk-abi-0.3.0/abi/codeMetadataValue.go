@@ -0,0 +1,58 @@
+package abi
+
+import (
+	"fmt"
+	"io"
+)
+
+// CodeMetadataValue is a wrapper for the metadata of a contract's code (e.g. upgradeable, readable, payable).
+// Both the nested and the top-level forms are exactly "codeMetadataLength" bytes long.
+type CodeMetadataValue struct {
+	Value []byte
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *CodeMetadataValue) EncodeNested(writer io.Writer) error {
+	err := value.checkLength(value.Value)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(value.Value)
+	return err
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *CodeMetadataValue) EncodeTopLevel(writer io.Writer) error {
+	return value.EncodeNested(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *CodeMetadataValue) DecodeNested(reader io.Reader) error {
+	data, err := readBytesExactly(reader, codeMetadataLength)
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *CodeMetadataValue) DecodeTopLevel(data []byte) error {
+	err := value.checkLength(data)
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+func (value *CodeMetadataValue) checkLength(data []byte) error {
+	if len(data) != codeMetadataLength {
+		return fmt.Errorf("code metadata has invalid length: %d", len(data))
+	}
+
+	return nil
+}
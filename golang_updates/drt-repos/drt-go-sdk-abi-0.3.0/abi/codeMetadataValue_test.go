@@ -0,0 +1,36 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeMetadataValue(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec, &CodeMetadataValue{Value: []byte{0x05, 0x06}}, "0506")
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec, &CodeMetadataValue{Value: []byte{0x05, 0x06}}, "0506")
+	})
+
+	t.Run("should err on encode when length is invalid", func(t *testing.T) {
+		_, err := codec.EncodeNested(&CodeMetadataValue{Value: []byte{0x05}})
+		require.ErrorContains(t, err, "code metadata has invalid length: 1")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec, "0506", &CodeMetadataValue{}, &CodeMetadataValue{Value: []byte{0x05, 0x06}})
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec, "0506", &CodeMetadataValue{}, &CodeMetadataValue{Value: []byte{0x05, 0x06}})
+	})
+
+	t.Run("should err on decode top-level when length is invalid", func(t *testing.T) {
+		testDecodeTopLevelWithError(t, codec, "050607", &CodeMetadataValue{}, "code metadata has invalid length: 3")
+	})
+}
@@ -0,0 +1,141 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LogEvent is a log event emitted by a contract (e.g. through the "WriteEventLog" VM hook).
+// The first topic holds the identifier of the (ABI) event, while the remaining topics hold its indexed inputs.
+// The non-indexed inputs are held by "AdditionalData", if provided, or by "Data", otherwise.
+type LogEvent struct {
+	Topics         [][]byte
+	Data           []byte
+	AdditionalData [][]byte
+}
+
+// DecodedEvent is a log event decoded according to its ABI definition
+type DecodedEvent struct {
+	Identifier string
+	Fields     []EventField
+}
+
+// EventField is an (indexed or non-indexed) input of a decoded event.
+// The value is a single value or a multi-value, as created by the ABI registry.
+type EventField struct {
+	Name    string
+	Indexed bool
+	Value   any
+}
+
+// GetFieldValue returns the value of the given input of the event
+func (event *DecodedEvent) GetFieldValue(name string) (any, error) {
+	for _, field := range event.Fields {
+		if field.Name == name {
+			return field.Value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("event '%s' has no field '%s'", event.Identifier, name)
+}
+
+type eventDecoder struct {
+	registry   *abiRegistry
+	serializer *serializer
+}
+
+// NewEventDecoder creates a new decoder of log events, for the events defined in the ABI held by the given registry
+func NewEventDecoder(registry *abiRegistry) (*eventDecoder, error) {
+	if registry == nil {
+		return nil, errors.New("cannot create event decoder: registry is nil")
+	}
+
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: "@",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventDecoder{
+		registry:   registry,
+		serializer: serializer,
+	}, nil
+}
+
+// DecodeEvent decodes a log event: the indexed inputs are decoded from the topics, while the others are decoded from the data.
+// The fields of the decoded event follow the order of the inputs in the ABI definition.
+func (decoder *eventDecoder) DecodeEvent(logEvent *LogEvent) (*DecodedEvent, error) {
+	if logEvent == nil {
+		return nil, errors.New("cannot decode event: log event is nil")
+	}
+	if len(logEvent.Topics) == 0 {
+		return nil, errors.New("cannot decode event: log event has no topics (identifier is missing)")
+	}
+
+	identifier := string(logEvent.Topics[0])
+
+	definition, err := decoder.registry.GetEvent(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	topicValues, err := decoder.registry.CreateEventTopicValues(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	dataValues, err := decoder.registry.CreateEventDataValues(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.serializer.DeserializeParts(logEvent.Topics[1:], topicValues)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode topics of event '%s': %w", identifier, err)
+	}
+
+	err = decoder.serializer.DeserializeParts(getEventDataParts(logEvent), dataValues)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode data of event '%s': %w", identifier, err)
+	}
+
+	return &DecodedEvent{
+		Identifier: identifier,
+		Fields:     collectEventFields(definition, topicValues, dataValues),
+	}, nil
+}
+
+func getEventDataParts(logEvent *LogEvent) [][]byte {
+	if len(logEvent.AdditionalData) > 0 {
+		return logEvent.AdditionalData
+	}
+
+	return [][]byte{logEvent.Data}
+}
+
+// collectEventFields interleaves the decoded topics and data values, in order of the inputs of the event
+func collectEventFields(definition *EventDefinition, topicValues []any, dataValues []any) []EventField {
+	fields := make([]EventField, 0, len(definition.Inputs))
+	nextTopic := 0
+	nextData := 0
+
+	for _, input := range definition.Inputs {
+		field := EventField{
+			Name:    input.Name,
+			Indexed: input.Indexed,
+		}
+
+		if input.Indexed {
+			field.Value = topicValues[nextTopic]
+			nextTopic++
+		} else {
+			field.Value = dataValues[nextData]
+			nextData++
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
@@ -0,0 +1,123 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventDecoder(t *testing.T) {
+	_, err := NewEventDecoder(nil)
+	require.ErrorContains(t, err, "cannot create event decoder: registry is nil")
+}
+
+func TestEventDecoder_DecodeEvent(t *testing.T) {
+	registry, err := NewAbiRegistryFromFile("testdata/multisig.abi.json")
+	require.NoError(t, err)
+
+	decoder, err := NewEventDecoder(registry)
+	require.NoError(t, err)
+
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	t.Run("should err on nil event", func(t *testing.T) {
+		_, err := decoder.DecodeEvent(nil)
+		require.ErrorContains(t, err, "cannot decode event: log event is nil")
+	})
+
+	t.Run("should err on missing identifier", func(t *testing.T) {
+		_, err := decoder.DecodeEvent(&LogEvent{})
+		require.ErrorContains(t, err, "cannot decode event: log event has no topics (identifier is missing)")
+	})
+
+	t.Run("should err on unknown event", func(t *testing.T) {
+		_, err := decoder.DecodeEvent(&LogEvent{Topics: [][]byte{[]byte("foobar")}})
+		require.ErrorContains(t, err, "foobar")
+	})
+
+	t.Run("should err on missing topics", func(t *testing.T) {
+		_, err := decoder.DecodeEvent(&LogEvent{
+			Topics: [][]byte{[]byte("performChangeUser"), {0x2a}},
+		})
+		require.ErrorContains(t, err, "cannot decode topics of event 'performChangeUser'")
+	})
+
+	t.Run("should decode indexed inputs (topics)", func(t *testing.T) {
+		event, err := decoder.DecodeEvent(&LogEvent{
+			Topics: [][]byte{[]byte("performChangeUser"), {0x2a}, alicePubKey, {}, {0x02}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "performChangeUser", event.Identifier)
+		require.Len(t, event.Fields, 4)
+
+		require.Equal(t, "action_id", event.Fields[0].Name)
+		require.True(t, event.Fields[0].Indexed)
+		require.Equal(t, &U32Value{Value: 42}, event.Fields[0].Value)
+
+		changedUser, err := event.GetFieldValue("changed_user")
+		require.NoError(t, err)
+		require.Equal(t, alicePubKey, changedUser.(*AddressValue).Value)
+
+		require.Equal(t, uint8(0), event.Fields[2].Value.(*EnumValue).Discriminant)
+		require.Equal(t, uint8(2), event.Fields[3].Value.(*EnumValue).Discriminant)
+
+		_, err = event.GetFieldValue("foobar")
+		require.ErrorContains(t, err, "event 'performChangeUser' has no field 'foobar'")
+	})
+
+	t.Run("should decode non-indexed inputs (data)", func(t *testing.T) {
+		data, _ := hex.DecodeString("0000002a" + "0000002a" + "00" + "00000000")
+
+		event, err := decoder.DecodeEvent(&LogEvent{
+			Topics: [][]byte{[]byte("startPerformAction")},
+			Data:   data,
+		})
+		require.NoError(t, err)
+		require.Len(t, event.Fields, 1)
+		require.Equal(t, "data", event.Fields[0].Name)
+		require.False(t, event.Fields[0].Indexed)
+
+		info := event.Fields[0].Value.(*StructValue)
+		require.Equal(t, uint32(42), info.Fields[0].Value.(*U32Value).Value)
+		require.Equal(t, uint8(0), info.Fields[2].Value.(*EnumValue).Discriminant)
+		require.Empty(t, info.Fields[3].Value.(*ListValue).Items)
+	})
+
+	t.Run("should decode mixed inputs, with additional data taking precedence over data", func(t *testing.T) {
+		registry, err := NewAbiRegistry(&AbiDefinition{
+			Events: []*EventDefinition{
+				{
+					Identifier: "deposit",
+					Inputs: []*EventInputDefinition{
+						{Name: "caller", Type: "Address", Indexed: true},
+						{Name: "amount", Type: "BigUint"},
+						{Name: "token", Type: "TokenIdentifier", Indexed: true},
+						{Name: "nonces", Type: "variadic<u64>"},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		decoder, err := NewEventDecoder(registry)
+		require.NoError(t, err)
+
+		event, err := decoder.DecodeEvent(&LogEvent{
+			Topics:         [][]byte{[]byte("deposit"), alicePubKey, []byte("WREWA-abcdef")},
+			Data:           []byte{0xff},
+			AdditionalData: [][]byte{{0x03, 0xe8}, {0x01}, {0x02}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []EventField{
+			{Name: "caller", Indexed: true, Value: &AddressValue{Value: alicePubKey}},
+			{Name: "amount", Indexed: false, Value: &BigUIntValue{Value: big.NewInt(1000)}},
+			{Name: "token", Indexed: true, Value: &TokenIdentifierValue{Value: "WREWA-abcdef"}},
+			{Name: "nonces", Indexed: false, Value: event.Fields[3].Value},
+		}, event.Fields)
+
+		nonces := event.Fields[3].Value.(*VariadicValues).Items
+		require.Equal(t, []any{&U64Value{Value: 1}, &U64Value{Value: 2}}, nonces)
+	})
+}
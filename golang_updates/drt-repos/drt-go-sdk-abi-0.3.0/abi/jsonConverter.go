@@ -0,0 +1,961 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+)
+
+const bytesEncodingHex = "hex"
+const bytesEncodingBase64 = "base64"
+
+// ArgsNewJsonConverter defines the arguments needed for a new JSON converter
+type ArgsNewJsonConverter struct {
+	Registry      *abiRegistry
+	AddressHrp    string
+	BytesEncoding string
+}
+
+// jsonConverter converts ABI value trees to (and from) a canonical, human-readable JSON form:
+//   - u8 ... u32, i8 ... i32 as JSON numbers; u64, i64, BigUint and BigInt as strings (when parsing, both numbers and strings are accepted)
+//   - ManagedDecimal and ManagedDecimalSigned as decimal strings (e.g. "1.50")
+//   - bytes and CodeMetadata as hex or base64 strings (see "BytesEncoding"); addresses as bech32 strings
+//   - strings and token identifiers as strings; booleans as booleans
+//   - Option<T> and optional<T> as null (if missing) or as the inner value
+//   - List<T>, arrayN<T>, tuple<...>, variadic<T>, counted-variadic<T> and multi<...> as arrays
+//   - structs as objects (fields in order of definition)
+//   - enums as the name of the variant (e.g. "Pending") or, for variants with fields, as {"name": "...", "fields": {...}}
+//
+// Since enums are rendered by variant name, the conversion is guided by the type expressions known by the ABI registry.
+type jsonConverter struct {
+	registry      *abiRegistry
+	addressHrp    string
+	bytesEncoding string
+}
+
+// NewJsonConverter creates a new JSON converter
+func NewJsonConverter(args ArgsNewJsonConverter) (*jsonConverter, error) {
+	if args.Registry == nil {
+		return nil, errors.New("cannot create JSON converter: registry is nil")
+	}
+	if args.AddressHrp == "" {
+		return nil, errors.New("cannot create JSON converter: address HRP must not be empty")
+	}
+	if args.BytesEncoding != bytesEncodingHex && args.BytesEncoding != bytesEncodingBase64 {
+		return nil, fmt.Errorf("cannot create JSON converter: unknown bytes encoding '%s'", args.BytesEncoding)
+	}
+
+	return &jsonConverter{
+		registry:      args.Registry,
+		addressHrp:    args.AddressHrp,
+		bytesEncoding: args.BytesEncoding,
+	}, nil
+}
+
+// ToJson renders the given value (single value or multi-value) as JSON, according to the given type expression
+func (converter *jsonConverter) ToJson(value any, typeExpression string) ([]byte, error) {
+	formula, err := converter.parseFormula(typeExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := converter.toJsonValue(value, formula)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rendered)
+}
+
+// FromJson creates a value (single value or multi-value) for the given type expression, and fills it with the given JSON
+func (converter *jsonConverter) FromJson(data []byte, typeExpression string) (any, error) {
+	formula, err := converter.parseFormula(typeExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := unmarshalJson(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.createValueFromJson(parsed, formula)
+}
+
+// InputValuesFromJson creates the input values of an endpoint out of a JSON array (with one item for each input).
+// The results can be passed to the serializer, for encoding.
+func (converter *jsonConverter) InputValuesFromJson(endpointName string, data []byte) ([]any, error) {
+	endpoint, err := converter.registry.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := unmarshalJson(data)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := parsed.([]any)
+	if !ok {
+		return nil, fmt.Errorf("inputs of endpoint '%s' must be given as a JSON array", endpointName)
+	}
+
+	values := make([]any, 0, len(endpoint.Inputs))
+
+	for i, input := range endpoint.Inputs {
+		formula, err := converter.parseFormula(input.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		// Trailing inputs might be missing (e.g. optional or variadic inputs).
+		var item any
+		if i < len(items) {
+			item = items[i]
+		} else if !isMultiValueTypeName(formula.Name) {
+			return nil, fmt.Errorf("missing input '%s' of endpoint '%s'", input.Name, endpointName)
+		}
+
+		value, err := converter.createValueFromJson(item, formula)
+		if err != nil {
+			return nil, fmt.Errorf("bad input '%s' of endpoint '%s': %w", input.Name, endpointName, err)
+		}
+
+		values = append(values, value)
+	}
+
+	if len(items) > len(endpoint.Inputs) {
+		return nil, fmt.Errorf("too many inputs for endpoint '%s': %d > %d", endpointName, len(items), len(endpoint.Inputs))
+	}
+
+	return values, nil
+}
+
+// OutputValuesToJson renders the (decoded) output values of an endpoint as a JSON array (with one item for each output)
+func (converter *jsonConverter) OutputValuesToJson(endpointName string, values []any) ([]byte, error) {
+	endpoint, err := converter.registry.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(endpoint.Outputs) {
+		return nil, fmt.Errorf("endpoint '%s' has %d outputs, but %d values were given", endpointName, len(endpoint.Outputs), len(values))
+	}
+
+	rendered := make([]any, 0, len(values))
+
+	for i, output := range endpoint.Outputs {
+		formula, err := converter.parseFormula(output.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := converter.toJsonValue(values[i], formula)
+		if err != nil {
+			return nil, fmt.Errorf("bad output %d of endpoint '%s': %w", i, endpointName, err)
+		}
+
+		rendered = append(rendered, item)
+	}
+
+	return json.Marshal(rendered)
+}
+
+// EventToJson renders a decoded event as a JSON object: {"identifier": "...", "fields": {...}}
+func (converter *jsonConverter) EventToJson(event *DecodedEvent) ([]byte, error) {
+	if event == nil {
+		return nil, errors.New("cannot render event: event is nil")
+	}
+
+	definition, err := converter.registry.GetEvent(event.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	inputTypes := make(map[string]string, len(definition.Inputs))
+	for _, input := range definition.Inputs {
+		inputTypes[input.Name] = input.Type
+	}
+
+	fields := make(jsonObject, 0, len(event.Fields))
+
+	for _, field := range event.Fields {
+		formula, err := converter.parseFormula(inputTypes[field.Name])
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := converter.toJsonValue(field.Value, formula)
+		if err != nil {
+			return nil, fmt.Errorf("bad field '%s' of event '%s': %w", field.Name, event.Identifier, err)
+		}
+
+		fields = append(fields, jsonProperty{Key: field.Name, Value: item})
+	}
+
+	return json.Marshal(jsonObject{
+		{Key: "identifier", Value: event.Identifier},
+		{Key: "fields", Value: fields},
+	})
+}
+
+func (converter *jsonConverter) parseFormula(typeExpression string) (*TypeFormula, error) {
+	formula, err := ParseTypeFormula(typeExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	err = converter.registry.validateFormula(formula, make(map[string]struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return formula, nil
+}
+
+// toJsonValue converts a value into a tree of JSON-friendly values (to be passed to json.Marshal)
+func (converter *jsonConverter) toJsonValue(value any, formula *TypeFormula) (any, error) {
+	switch value := value.(type) {
+	case *OptionalValue:
+		if value.Value == nil {
+			return nil, nil
+		}
+
+		return converter.toJsonValue(value.Value, formula.TypeParameters[0])
+	case *VariadicValues:
+		return converter.itemsToJsonValue(value.Items, formula.TypeParameters[0])
+	case *CountedVariadicValues:
+		return converter.itemsToJsonValue(value.Items, formula.TypeParameters[0])
+	case *MultiValue:
+		if len(value.Items) != len(formula.TypeParameters) {
+			return nil, fmt.Errorf("multi-value has invalid number of items: %d != %d", len(value.Items), len(formula.TypeParameters))
+		}
+
+		items := make([]any, 0, len(value.Items))
+		for i, item := range value.Items {
+			rendered, err := converter.toJsonValue(item, formula.TypeParameters[i])
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, rendered)
+		}
+
+		return items, nil
+	case SingleValue:
+		return converter.singleValueToJsonValue(value, formula)
+	default:
+		return nil, fmt.Errorf("cannot render value of type %T", value)
+	}
+}
+
+func (converter *jsonConverter) itemsToJsonValue(items []any, itemFormula *TypeFormula) (any, error) {
+	rendered := make([]any, 0, len(items))
+
+	for _, item := range items {
+		renderedItem, err := converter.toJsonValue(item, itemFormula)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered = append(rendered, renderedItem)
+	}
+
+	return rendered, nil
+}
+
+func (converter *jsonConverter) singleValueToJsonValue(value SingleValue, formula *TypeFormula) (any, error) {
+	switch value := value.(type) {
+	case *U8Value:
+		return value.Value, nil
+	case *U16Value:
+		return value.Value, nil
+	case *U32Value:
+		return value.Value, nil
+	case *U64Value:
+		return fmt.Sprintf("%d", value.Value), nil
+	case *I8Value:
+		return value.Value, nil
+	case *I16Value:
+		return value.Value, nil
+	case *I32Value:
+		return value.Value, nil
+	case *I64Value:
+		return fmt.Sprintf("%d", value.Value), nil
+	case *BigUIntValue:
+		return bigIntToString(value.Value), nil
+	case *BigIntValue:
+		return bigIntToString(value.Value), nil
+	case *ManagedDecimalValue:
+		return formatDecimal(value.Value, value.Scale), nil
+	case *ManagedDecimalSignedValue:
+		return formatDecimal(value.Value, value.Scale), nil
+	case *BoolValue:
+		return value.Value, nil
+	case *BytesValue:
+		return converter.encodeBytes(value.Value), nil
+	case *CodeMetadataValue:
+		return converter.encodeBytes(value.Value), nil
+	case *StringValue:
+		return value.Value, nil
+	case *TokenIdentifierValue:
+		return value.Value, nil
+	case *AddressValue:
+		return converter.encodeAddress(value.Value)
+	case *OptionValue:
+		if value.Value == nil {
+			return nil, nil
+		}
+
+		return converter.singleValueToJsonValue(value.Value, formula.TypeParameters[0])
+	case *ListValue:
+		return converter.singleValuesToJsonValue(value.Items, formula.TypeParameters[0])
+	case *ArrayValue:
+		return converter.singleValuesToJsonValue(value.Items, formula.TypeParameters[0])
+	case *TupleValue:
+		if len(value.Items) != len(formula.TypeParameters) {
+			return nil, fmt.Errorf("tuple has invalid number of items: %d != %d", len(value.Items), len(formula.TypeParameters))
+		}
+
+		items := make([]any, 0, len(value.Items))
+		for i, item := range value.Items {
+			rendered, err := converter.singleValueToJsonValue(item, formula.TypeParameters[i])
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, rendered)
+		}
+
+		return items, nil
+	case *StructValue:
+		customType, err := converter.registry.GetCustomType(formula.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return converter.fieldsToJsonValue(value.Fields, customType.Fields)
+	case *EnumValue:
+		return converter.enumToJsonValue(value, formula)
+	default:
+		return nil, fmt.Errorf("cannot render value of type %T", value)
+	}
+}
+
+func (converter *jsonConverter) singleValuesToJsonValue(items []SingleValue, itemFormula *TypeFormula) (any, error) {
+	rendered := make([]any, 0, len(items))
+
+	for _, item := range items {
+		renderedItem, err := converter.singleValueToJsonValue(item, itemFormula)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered = append(rendered, renderedItem)
+	}
+
+	return rendered, nil
+}
+
+func (converter *jsonConverter) fieldsToJsonValue(fields []Field, definitions []*FieldDefinition) (any, error) {
+	if len(fields) != len(definitions) {
+		return nil, fmt.Errorf("invalid number of fields: %d != %d", len(fields), len(definitions))
+	}
+
+	object := make(jsonObject, 0, len(fields))
+
+	for i, field := range fields {
+		formula, err := ParseTypeFormula(definitions[i].Type)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered, err := converter.singleValueToJsonValue(field.Value, formula)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render field '%s': %w", field.Name, err)
+		}
+
+		object = append(object, jsonProperty{Key: field.Name, Value: rendered})
+	}
+
+	return object, nil
+}
+
+func (converter *jsonConverter) enumToJsonValue(value *EnumValue, formula *TypeFormula) (any, error) {
+	customType, err := converter.registry.GetCustomType(formula.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := findVariantByDiscriminant(customType, value.Discriminant)
+	if variant == nil {
+		return nil, fmt.Errorf("enum '%s' has no variant with discriminant %d", formula.Name, value.Discriminant)
+	}
+
+	if len(variant.Fields) == 0 {
+		return variant.Name, nil
+	}
+
+	fields, err := converter.fieldsToJsonValue(value.Fields, variant.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonObject{
+		{Key: "name", Value: variant.Name},
+		{Key: "fields", Value: fields},
+	}, nil
+}
+
+// createValueFromJson creates a value for the given formula, then fills it with the given (unmarshalled) JSON
+func (converter *jsonConverter) createValueFromJson(data any, formula *TypeFormula) (any, error) {
+	value, err := converter.registry.createValue(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	err = converter.fillFromJson(value, data, formula)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (converter *jsonConverter) fillFromJson(value any, data any, formula *TypeFormula) error {
+	switch value := value.(type) {
+	case *OptionalValue:
+		if data == nil {
+			value.Value = nil
+			return nil
+		}
+
+		return converter.fillFromJson(value.Value, data, formula.TypeParameters[0])
+	case *VariadicValues:
+		items, err := converter.createItemsFromJson(data, formula.TypeParameters[0], value.ItemCreator)
+		if err != nil {
+			return err
+		}
+
+		value.Items = items
+		return nil
+	case *CountedVariadicValues:
+		items, err := converter.createItemsFromJson(data, formula.TypeParameters[0], value.ItemCreator)
+		if err != nil {
+			return err
+		}
+
+		value.Items = items
+		return nil
+	case *MultiValue:
+		items, err := expectJsonArray(data, len(value.Items))
+		if err != nil {
+			return err
+		}
+
+		for i, item := range items {
+			err := converter.fillFromJson(value.Items[i], item, formula.TypeParameters[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case SingleValue:
+		return converter.fillSingleValueFromJson(value, data, formula)
+	default:
+		return fmt.Errorf("cannot fill value of type %T", value)
+	}
+}
+
+func (converter *jsonConverter) createItemsFromJson(data any, itemFormula *TypeFormula, itemCreator func() any) ([]any, error) {
+	// A missing variadic input is the same as an empty one.
+	if data == nil {
+		return []any{}, nil
+	}
+
+	items, err := expectJsonArray(data, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0, len(items))
+
+	for _, item := range items {
+		value := itemCreator()
+
+		err := converter.fillFromJson(value, item, itemFormula)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (converter *jsonConverter) fillSingleValueFromJson(value SingleValue, data any, formula *TypeFormula) error {
+	var err error
+
+	switch value := value.(type) {
+	case *U8Value:
+		value.Value, err = parseJsonUint[uint8](data, 8)
+	case *U16Value:
+		value.Value, err = parseJsonUint[uint16](data, 16)
+	case *U32Value:
+		value.Value, err = parseJsonUint[uint32](data, 32)
+	case *U64Value:
+		value.Value, err = parseJsonUint[uint64](data, 64)
+	case *I8Value:
+		value.Value, err = parseJsonInt[int8](data, 8)
+	case *I16Value:
+		value.Value, err = parseJsonInt[int16](data, 16)
+	case *I32Value:
+		value.Value, err = parseJsonInt[int32](data, 32)
+	case *I64Value:
+		value.Value, err = parseJsonInt[int64](data, 64)
+	case *BigUIntValue:
+		value.Value, err = parseJsonBigInt(data)
+		if err == nil && value.Value.Sign() < 0 {
+			err = fmt.Errorf("negative number for unsigned type: %s", value.Value)
+		}
+	case *BigIntValue:
+		value.Value, err = parseJsonBigInt(data)
+	case *ManagedDecimalValue:
+		value.Value, err = parseJsonDecimal(data, &value.Scale, value.IsVariable)
+		if err == nil && value.Value.Sign() < 0 {
+			err = fmt.Errorf("negative number for unsigned type: %s", formatDecimal(value.Value, value.Scale))
+		}
+	case *ManagedDecimalSignedValue:
+		value.Value, err = parseJsonDecimal(data, &value.Scale, value.IsVariable)
+	case *BoolValue:
+		value.Value, err = expectJson[bool](data)
+	case *BytesValue:
+		value.Value, err = converter.decodeBytes(data)
+	case *CodeMetadataValue:
+		value.Value, err = converter.decodeBytes(data)
+	case *StringValue:
+		value.Value, err = expectJson[string](data)
+	case *TokenIdentifierValue:
+		value.Value, err = expectJson[string](data)
+	case *AddressValue:
+		value.Value, err = converter.decodeAddress(data)
+	case *OptionValue:
+		if data == nil {
+			value.Value = nil
+			return nil
+		}
+
+		return converter.fillSingleValueFromJson(value.Value, data, formula.TypeParameters[0])
+	case *ListValue:
+		value.Items, err = converter.createSingleValuesFromJson(data, -1, formula.TypeParameters[0], value.ItemCreator)
+	case *ArrayValue:
+		value.Items, err = converter.createSingleValuesFromJson(data, int(value.Length), formula.TypeParameters[0], value.ItemCreator)
+	case *TupleValue:
+		var items []any
+		items, err = expectJsonArray(data, len(value.Items))
+		for i := 0; err == nil && i < len(items); i++ {
+			err = converter.fillSingleValueFromJson(value.Items[i], items[i], formula.TypeParameters[i])
+		}
+	case *StructValue:
+		err = converter.fillFieldsFromJson(value.Fields, data, formula.Name)
+	case *EnumValue:
+		err = converter.fillEnumFromJson(value, data, formula)
+	default:
+		err = fmt.Errorf("cannot fill value of type %T", value)
+	}
+
+	return err
+}
+
+func (converter *jsonConverter) createSingleValuesFromJson(data any, expectedLength int, itemFormula *TypeFormula, itemCreator func() SingleValue) ([]SingleValue, error) {
+	items, err := expectJsonArray(data, expectedLength)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]SingleValue, 0, len(items))
+
+	for _, item := range items {
+		value := itemCreator()
+
+		err := converter.fillSingleValueFromJson(value, item, itemFormula)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (converter *jsonConverter) fillFieldsFromJson(fields []Field, data any, typeName string) error {
+	object, err := expectJson[map[string]any](data)
+	if err != nil {
+		return err
+	}
+
+	if len(object) > len(fields) {
+		return fmt.Errorf("object has unknown fields, for type '%s'", typeName)
+	}
+
+	customType, _ := converter.registry.GetCustomType(typeName)
+	definitions := customType.Fields
+
+	for i, field := range fields {
+		item, ok := object[field.Name]
+		if !ok {
+			return fmt.Errorf("missing field '%s' of type '%s'", field.Name, typeName)
+		}
+
+		formula, err := ParseTypeFormula(definitions[i].Type)
+		if err != nil {
+			return err
+		}
+
+		err = converter.fillSingleValueFromJson(field.Value, item, formula)
+		if err != nil {
+			return fmt.Errorf("bad field '%s' of type '%s': %w", field.Name, typeName, err)
+		}
+	}
+
+	return nil
+}
+
+func (converter *jsonConverter) fillEnumFromJson(value *EnumValue, data any, formula *TypeFormula) error {
+	customType, err := converter.registry.GetCustomType(formula.Name)
+	if err != nil {
+		return err
+	}
+
+	variantName, isString := data.(string)
+	fieldsData := any(map[string]any{})
+
+	if !isString {
+		object, err := expectJson[map[string]any](data)
+		if err != nil {
+			return err
+		}
+
+		variantName, err = expectJson[string](object["name"])
+		if err != nil {
+			return fmt.Errorf("bad name of variant of enum '%s': %w", formula.Name, err)
+		}
+
+		fieldsData = object["fields"]
+	}
+
+	variant := findVariantByName(customType, variantName)
+	if variant == nil {
+		return fmt.Errorf("enum '%s' has no variant '%s'", formula.Name, variantName)
+	}
+
+	if value.FieldsProvider == nil {
+		return errors.New("cannot fill enum: fields provider is nil")
+	}
+
+	value.Discriminant = variant.Discriminant
+	value.Fields = value.FieldsProvider(variant.Discriminant)
+
+	object, err := expectJson[map[string]any](fieldsData)
+	if err != nil {
+		return fmt.Errorf("bad fields of variant '%s' of enum '%s': %w", variantName, formula.Name, err)
+	}
+
+	if len(object) > len(value.Fields) {
+		return fmt.Errorf("object has unknown fields, for variant '%s' of enum '%s'", variantName, formula.Name)
+	}
+
+	for i, field := range value.Fields {
+		item, ok := object[field.Name]
+		if !ok {
+			return fmt.Errorf("missing field '%s' of variant '%s' of enum '%s'", field.Name, variantName, formula.Name)
+		}
+
+		fieldFormula, err := ParseTypeFormula(variant.Fields[i].Type)
+		if err != nil {
+			return err
+		}
+
+		err = converter.fillSingleValueFromJson(field.Value, item, fieldFormula)
+		if err != nil {
+			return fmt.Errorf("bad field '%s' of variant '%s' of enum '%s': %w", field.Name, variantName, formula.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (converter *jsonConverter) encodeBytes(data []byte) string {
+	if converter.bytesEncoding == bytesEncodingBase64 {
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	return hex.EncodeToString(data)
+}
+
+func (converter *jsonConverter) decodeBytes(data any) ([]byte, error) {
+	encoded, err := expectJson[string](data)
+	if err != nil {
+		return nil, err
+	}
+
+	if converter.bytesEncoding == bytesEncodingBase64 {
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+
+	return hex.DecodeString(encoded)
+}
+
+func (converter *jsonConverter) encodeAddress(pubKey []byte) (string, error) {
+	if len(pubKey) != pubKeyLength {
+		return "", fmt.Errorf("public key (address) has invalid length: %d", len(pubKey))
+	}
+
+	converted, err := bech32.ConvertBits(pubKey, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.Encode(converter.addressHrp, converted)
+}
+
+func (converter *jsonConverter) decodeAddress(data any) ([]byte, error) {
+	encoded, err := expectJson[string](data)
+	if err != nil {
+		return nil, err
+	}
+
+	hrp, decoded, err := bech32.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("bad address '%s': %w", encoded, err)
+	}
+	if hrp != converter.addressHrp {
+		return nil, fmt.Errorf("bad address '%s': unexpected HRP '%s'", encoded, hrp)
+	}
+
+	pubKey, err := bech32.ConvertBits(decoded, 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("bad address '%s': %w", encoded, err)
+	}
+	if len(pubKey) != pubKeyLength {
+		return nil, fmt.Errorf("bad address '%s': invalid length %d", encoded, len(pubKey))
+	}
+
+	return pubKey, nil
+}
+
+func findVariantByDiscriminant(customType *TypeDefinition, discriminant uint8) *VariantDefinition {
+	for _, variant := range customType.Variants {
+		if variant.Discriminant == discriminant {
+			return variant
+		}
+	}
+
+	return nil
+}
+
+func findVariantByName(customType *TypeDefinition, name string) *VariantDefinition {
+	for _, variant := range customType.Variants {
+		if variant.Name == name {
+			return variant
+		}
+	}
+
+	return nil
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
+
+// formatDecimal renders a scaled number as a decimal string (e.g. 150 with scale 2 as "1.50")
+func formatDecimal(value *big.Int, scale uint32) string {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	digits := new(big.Int).Abs(value).String()
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+
+	if scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(scale) {
+		digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+	}
+
+	integerPart := digits[:len(digits)-int(scale)]
+	fractionalPart := digits[len(digits)-int(scale):]
+	return sign + integerPart + "." + fractionalPart
+}
+
+// parseJsonDecimal parses a decimal string (or number) into a scaled number.
+// For variable scales, the scale is given by the number of fractional digits.
+func parseJsonDecimal(data any, scale *uint32, isVariable bool) (*big.Int, error) {
+	text, err := jsonNumberToString(data)
+	if err != nil {
+		return nil, err
+	}
+
+	integerPart, fractionalPart, _ := strings.Cut(text, ".")
+
+	if isVariable {
+		*scale = uint32(len(fractionalPart))
+	} else if len(fractionalPart) > int(*scale) {
+		return nil, fmt.Errorf("decimal '%s' has more than %d fractional digits", text, *scale)
+	}
+
+	fractionalPart += strings.Repeat("0", int(*scale)-len(fractionalPart))
+
+	value, ok := new(big.Int).SetString(integerPart+fractionalPart, 10)
+	if !ok || strings.ContainsAny(fractionalPart, "+-") {
+		return nil, fmt.Errorf("bad decimal: '%s'", text)
+	}
+
+	return value, nil
+}
+
+func parseJsonBigInt(data any) (*big.Int, error) {
+	text, err := jsonNumberToString(data)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return nil, fmt.Errorf("bad integer: '%s'", text)
+	}
+
+	return value, nil
+}
+
+func parseJsonUint[T uint8 | uint16 | uint32 | uint64](data any, bitSize int) (T, error) {
+	value, err := parseJsonBigInt(data)
+	if err != nil {
+		return 0, err
+	}
+
+	if value.Sign() < 0 || value.BitLen() > bitSize {
+		return 0, fmt.Errorf("number %s does not fit in %d bits (unsigned)", value, bitSize)
+	}
+
+	return T(value.Uint64()), nil
+}
+
+func parseJsonInt[T int8 | int16 | int32 | int64](data any, bitSize int) (T, error) {
+	value, err := parseJsonBigInt(data)
+	if err != nil {
+		return 0, err
+	}
+
+	if !value.IsInt64() || value.Int64() < -(1<<(bitSize-1)) || value.Int64() > (1<<(bitSize-1))-1 {
+		return 0, fmt.Errorf("number %s does not fit in %d bits (signed)", value, bitSize)
+	}
+
+	return T(value.Int64()), nil
+}
+
+// jsonNumberToString accepts both JSON numbers and strings holding numbers
+func jsonNumberToString(data any) (string, error) {
+	switch data := data.(type) {
+	case json.Number:
+		return data.String(), nil
+	case string:
+		return data, nil
+	default:
+		return "", fmt.Errorf("expected number or string, but got %T", data)
+	}
+}
+
+func expectJson[T any](data any) (T, error) {
+	typed, ok := data.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("expected %T, but got %T", zero, data)
+	}
+
+	return typed, nil
+}
+
+// expectJsonArray checks that the data is a JSON array (of the given length, if not negative)
+func expectJsonArray(data any, expectedLength int) ([]any, error) {
+	items, err := expectJson[[]any](data)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedLength >= 0 && len(items) != expectedLength {
+		return nil, fmt.Errorf("array has invalid number of items: %d != %d", len(items), expectedLength)
+	}
+
+	return items, nil
+}
+
+func unmarshalJson(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var parsed any
+
+	err := decoder.Decode(&parsed)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse JSON, because of: %w", err)
+	}
+
+	return parsed, nil
+}
+
+// jsonObject is a JSON object which preserves the order of its properties (unlike a map)
+type jsonObject []jsonProperty
+
+type jsonProperty struct {
+	Key   string
+	Value any
+}
+
+// MarshalJSON renders the object, keeping the order of its properties
+func (object jsonObject) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteString("{")
+
+	for i, property := range object {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		key, err := json.Marshal(property.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(property.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,250 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJsonConverter(t *testing.T) {
+	registry, err := NewAbiRegistry(&AbiDefinition{})
+	require.NoError(t, err)
+
+	t.Run("should err on nil registry", func(t *testing.T) {
+		_, err := NewJsonConverter(ArgsNewJsonConverter{AddressHrp: "drt", BytesEncoding: "hex"})
+		require.ErrorContains(t, err, "cannot create JSON converter: registry is nil")
+	})
+
+	t.Run("should err on missing HRP", func(t *testing.T) {
+		_, err := NewJsonConverter(ArgsNewJsonConverter{Registry: registry, BytesEncoding: "hex"})
+		require.ErrorContains(t, err, "cannot create JSON converter: address HRP must not be empty")
+	})
+
+	t.Run("should err on unknown bytes encoding", func(t *testing.T) {
+		_, err := NewJsonConverter(ArgsNewJsonConverter{Registry: registry, AddressHrp: "drt", BytesEncoding: "base32"})
+		require.ErrorContains(t, err, "cannot create JSON converter: unknown bytes encoding 'base32'")
+	})
+}
+
+func TestJsonConverter_ToJson(t *testing.T) {
+	converter := createJsonConverterForTests(t, "hex")
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	aliceAddress := testToJson(t, converter, &AddressValue{Value: alicePubKey}, "Address")
+
+	t.Run("primitives", func(t *testing.T) {
+		require.Equal(t, `42`, testToJson(t, converter, &U8Value{Value: 42}, "u8"))
+		require.Equal(t, `-42`, testToJson(t, converter, &I32Value{Value: -42}, "i32"))
+		require.Equal(t, `"18446744073709551615"`, testToJson(t, converter, &U64Value{Value: 18446744073709551615}, "u64"))
+		require.Equal(t, `"-1000000000000000000000"`, testToJson(t, converter, &BigIntValue{Value: big.NewInt(0).Mul(big.NewInt(-1_000_000_000_000), big.NewInt(1_000_000_000))}, "BigInt"))
+		require.Equal(t, `"0"`, testToJson(t, converter, &BigUIntValue{}, "BigUint"))
+		require.Equal(t, `true`, testToJson(t, converter, &BoolValue{Value: true}, "bool"))
+		require.Equal(t, `"abba"`, testToJson(t, converter, &BytesValue{Value: []byte{0xab, 0xba}}, "bytes"))
+		require.Equal(t, `"hello"`, testToJson(t, converter, &StringValue{Value: "hello"}, "utf-8 string"))
+		require.Equal(t, `"WREWA-abcdef"`, testToJson(t, converter, &TokenIdentifierValue{Value: "WREWA-abcdef"}, "TokenIdentifier"))
+		require.Equal(t, `"0500"`, testToJson(t, converter, &CodeMetadataValue{Value: []byte{0x05, 0x00}}, "CodeMetadata"))
+		require.Equal(t, `"1.50"`, testToJson(t, converter, &ManagedDecimalValue{Value: big.NewInt(150), Scale: 2}, "ManagedDecimal<2>"))
+		require.Equal(t, `"-0.005"`, testToJson(t, converter, &ManagedDecimalSignedValue{Value: big.NewInt(-5), Scale: 3, IsVariable: true}, "ManagedDecimalSigned<usize>"))
+		require.Regexp(t, `^"drt1[a-z0-9]{58}"$`, aliceAddress)
+	})
+
+	t.Run("base64 bytes", func(t *testing.T) {
+		converter := createJsonConverterForTests(t, "base64")
+		require.Equal(t, `"q7o="`, testToJson(t, converter, &BytesValue{Value: []byte{0xab, 0xba}}, "bytes"))
+	})
+
+	t.Run("containers", func(t *testing.T) {
+		require.Equal(t, `null`, testToJson(t, converter, &OptionValue{}, "Option<u8>"))
+		require.Equal(t, `7`, testToJson(t, converter, &OptionValue{Value: &U8Value{Value: 7}}, "Option<u8>"))
+		require.Equal(t, `[1,2]`, testToJson(t, converter, &ListValue{Items: []SingleValue{&U16Value{Value: 1}, &U16Value{Value: 2}}}, "List<u16>"))
+		require.Equal(t, `[1,2]`, testToJson(t, converter, &ArrayValue{Length: 2, Items: []SingleValue{&U8Value{Value: 1}, &U8Value{Value: 2}}}, "array2<u8>"))
+		require.Equal(t, `[1,"x"]`, testToJson(t, converter, &TupleValue{Items: []SingleValue{&U8Value{Value: 1}, &StringValue{Value: "x"}}}, "tuple<u8,utf-8 string>"))
+		require.Equal(t, `null`, testToJson(t, converter, &OptionalValue{}, "optional<u8>"))
+		require.Equal(t, `[[1,"2"]]`, testToJson(t, converter, &VariadicValues{Items: []any{&MultiValue{Items: []any{&U8Value{Value: 1}, &U64Value{Value: 2}}}}}, "variadic<multi<u8,u64>>"))
+		require.Equal(t, `[3]`, testToJson(t, converter, &CountedVariadicValues{Items: []any{&U32Value{Value: 3}}}, "counted-variadic<u32>"))
+	})
+
+	t.Run("structs and enums", func(t *testing.T) {
+		require.Equal(t, `"Proposer"`, testToJson(t, converter, &EnumValue{Discriminant: 1}, "UserRole"))
+		require.Equal(t, `{"token_identifier":"WREWA-abcdef","token_nonce":"0","amount":"1000"}`, testToJson(t, converter, &StructValue{
+			Fields: []Field{
+				{Name: "token_identifier", Value: &TokenIdentifierValue{Value: "WREWA-abcdef"}},
+				{Name: "token_nonce", Value: &U64Value{}},
+				{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(1000)}},
+			},
+		}, "DcdtTokenPayment"))
+		require.Equal(t, `{"name":"AddBoardMember","fields":{"0":`+aliceAddress+`}}`, testToJson(t, converter, &EnumValue{
+			Discriminant: 1,
+			Fields:       []Field{{Name: "0", Value: &AddressValue{Value: alicePubKey}}},
+		}, "Action"))
+	})
+
+	t.Run("should err on unknown variant", func(t *testing.T) {
+		_, err := converter.ToJson(&EnumValue{Discriminant: 42}, "UserRole")
+		require.ErrorContains(t, err, "enum 'UserRole' has no variant with discriminant 42")
+	})
+
+	t.Run("should err on bad address", func(t *testing.T) {
+		_, err := converter.ToJson(&AddressValue{Value: []byte{0x01}}, "Address")
+		require.ErrorContains(t, err, "public key (address) has invalid length: 1")
+	})
+}
+
+func TestJsonConverter_FromJson(t *testing.T) {
+	converter := createJsonConverterForTests(t, "hex")
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	aliceAddress := testToJson(t, converter, &AddressValue{Value: alicePubKey}, "Address")
+
+	t.Run("primitives", func(t *testing.T) {
+		require.Equal(t, &U8Value{Value: 42}, testFromJson(t, converter, `42`, "u8"))
+		require.Equal(t, &U64Value{Value: 18446744073709551615}, testFromJson(t, converter, `"18446744073709551615"`, "u64"))
+		require.Equal(t, &I16Value{Value: -300}, testFromJson(t, converter, `"-300"`, "i16"))
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(1000)}, testFromJson(t, converter, `1000`, "BigUint"))
+		require.Equal(t, &BoolValue{Value: true}, testFromJson(t, converter, `true`, "bool"))
+		require.Equal(t, &BytesValue{Value: []byte{0xab, 0xba}}, testFromJson(t, converter, `"abba"`, "bytes"))
+		require.Equal(t, &TokenIdentifierValue{Value: "WREWA-abcdef"}, testFromJson(t, converter, `"WREWA-abcdef"`, "TokenIdentifier"))
+		require.Equal(t, &AddressValue{Value: alicePubKey}, testFromJson(t, converter, aliceAddress, "Address"))
+		require.Equal(t, &ManagedDecimalValue{Value: big.NewInt(150), Scale: 2}, testFromJson(t, converter, `"1.5"`, "ManagedDecimal<2>"))
+		require.Equal(t, &ManagedDecimalSignedValue{Value: big.NewInt(-1500), Scale: 3, IsVariable: true}, testFromJson(t, converter, `"-1.500"`, "ManagedDecimalSigned<usize>"))
+	})
+
+	t.Run("containers", func(t *testing.T) {
+		require.Equal(t, &OptionValue{}, testFromJson(t, converter, `null`, "Option<u8>"))
+		require.Equal(t, &OptionValue{Value: &U8Value{Value: 7}}, testFromJson(t, converter, `7`, "Option<u8>"))
+
+		list := testFromJson(t, converter, `[1, 2]`, "List<u16>").(*ListValue)
+		require.Equal(t, []SingleValue{&U16Value{Value: 1}, &U16Value{Value: 2}}, list.Items)
+
+		array := testFromJson(t, converter, `[1, 2]`, "array2<u8>").(*ArrayValue)
+		require.Equal(t, []SingleValue{&U8Value{Value: 1}, &U8Value{Value: 2}}, array.Items)
+
+		require.Equal(t, &TupleValue{Items: []SingleValue{&U8Value{Value: 1}, &StringValue{Value: "x"}}}, testFromJson(t, converter, `[1, "x"]`, "tuple<u8,utf-8 string>"))
+
+		variadic := testFromJson(t, converter, `[[1, "2"]]`, "variadic<multi<u8,u64>>").(*VariadicValues)
+		require.Equal(t, []any{&MultiValue{Items: []any{&U8Value{Value: 1}, &U64Value{Value: 2}}}}, variadic.Items)
+	})
+
+	t.Run("structs and enums", func(t *testing.T) {
+		role := testFromJson(t, converter, `"Proposer"`, "UserRole").(*EnumValue)
+		require.Equal(t, uint8(1), role.Discriminant)
+
+		action := testFromJson(t, converter, `{"name": "AddBoardMember", "fields": {"0": `+aliceAddress+`}}`, "Action").(*EnumValue)
+		require.Equal(t, uint8(1), action.Discriminant)
+		require.Equal(t, []Field{{Name: "0", Value: &AddressValue{Value: alicePubKey}}}, action.Fields)
+
+		payment := testFromJson(t, converter, `{"token_identifier": "WREWA-abcdef", "token_nonce": 0, "amount": "1000"}`, "DcdtTokenPayment").(*StructValue)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(1000)}, payment.Fields[2].Value)
+	})
+
+	t.Run("should err on out of range numbers", func(t *testing.T) {
+		_, err := converter.FromJson([]byte(`256`), "u8")
+		require.ErrorContains(t, err, "number 256 does not fit in 8 bits (unsigned)")
+
+		_, err = converter.FromJson([]byte(`-129`), "i8")
+		require.ErrorContains(t, err, "number -129 does not fit in 8 bits (signed)")
+
+		_, err = converter.FromJson([]byte(`"-1"`), "BigUint")
+		require.ErrorContains(t, err, "negative number for unsigned type: -1")
+
+		_, err = converter.FromJson([]byte(`"1.234"`), "ManagedDecimal<2>")
+		require.ErrorContains(t, err, "decimal '1.234' has more than 2 fractional digits")
+	})
+
+	t.Run("should err on bad structs and enums", func(t *testing.T) {
+		_, err := converter.FromJson([]byte(`{"token_identifier": "WREWA-abcdef", "amount": "1000"}`), "DcdtTokenPayment")
+		require.ErrorContains(t, err, "missing field 'token_nonce' of type 'DcdtTokenPayment'")
+
+		_, err = converter.FromJson([]byte(`"Foobar"`), "UserRole")
+		require.ErrorContains(t, err, "enum 'UserRole' has no variant 'Foobar'")
+
+		_, err = converter.FromJson([]byte(`"AddBoardMember"`), "Action")
+		require.ErrorContains(t, err, "missing field '0' of variant 'AddBoardMember' of enum 'Action'")
+	})
+
+	t.Run("should err on bad address", func(t *testing.T) {
+		_, err := converter.FromJson([]byte(`"erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3"`), "Address")
+		require.ErrorContains(t, err, "unexpected HRP 'erd'")
+	})
+}
+
+func TestJsonConverter_WithSerializer(t *testing.T) {
+	converter := createJsonConverterForTests(t, "hex")
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should parse inputs, then serialize them", func(t *testing.T) {
+		values, err := converter.InputValuesFromJson("getPendingActionFullInfo", []byte(`[[42, 43]]`))
+		require.NoError(t, err)
+
+		data, err := serializer.Serialize(values)
+		require.NoError(t, err)
+		require.Equal(t, "2a@2b", data)
+	})
+
+	t.Run("should parse missing optional inputs", func(t *testing.T) {
+		values, err := converter.InputValuesFromJson("getPendingActionFullInfo", []byte(`[]`))
+		require.NoError(t, err)
+		require.Equal(t, []any{&OptionalValue{}}, values)
+	})
+
+	t.Run("should err on missing or extra inputs", func(t *testing.T) {
+		_, err := converter.InputValuesFromJson("getActionSignerCount", []byte(`[]`))
+		require.ErrorContains(t, err, "missing input 'action_id' of endpoint 'getActionSignerCount'")
+
+		_, err = converter.InputValuesFromJson("getActionSignerCount", []byte(`[1, 2]`))
+		require.ErrorContains(t, err, "too many inputs for endpoint 'getActionSignerCount': 2 > 1")
+	})
+
+	t.Run("should deserialize outputs, then render them", func(t *testing.T) {
+		values, err := converter.registry.CreateOutputValues("getActionSignerCount")
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("07", values)
+		require.NoError(t, err)
+
+		data, err := converter.OutputValuesToJson("getActionSignerCount", values)
+		require.NoError(t, err)
+		require.Equal(t, `[7]`, string(data))
+	})
+
+	t.Run("should render decoded events", func(t *testing.T) {
+		decoder, err := NewEventDecoder(converter.registry)
+		require.NoError(t, err)
+
+		event, err := decoder.DecodeEvent(&LogEvent{
+			Topics: [][]byte{[]byte("performChangeUser"), {0x2a}, make([]byte, 32), {0x01}, {0x02}},
+		})
+		require.NoError(t, err)
+
+		data, err := converter.EventToJson(event)
+		require.NoError(t, err)
+		require.Equal(t, `{"identifier":"performChangeUser","fields":{"action_id":42,"changed_user":"drt1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq85hk5z","old_role":"Proposer","new_role":"BoardMember"}}`, string(data))
+	})
+}
+
+func createJsonConverterForTests(t *testing.T, bytesEncoding string) *jsonConverter {
+	registry, err := NewAbiRegistryFromFile("testdata/multisig.abi.json")
+	require.NoError(t, err)
+
+	converter, err := NewJsonConverter(ArgsNewJsonConverter{
+		Registry:      registry,
+		AddressHrp:    "drt",
+		BytesEncoding: bytesEncoding,
+	})
+	require.NoError(t, err)
+
+	return converter
+}
+
+func testToJson(t *testing.T, converter *jsonConverter, value any, typeExpression string) string {
+	data, err := converter.ToJson(value, typeExpression)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func testFromJson(t *testing.T, converter *jsonConverter, data string, typeExpression string) any {
+	value, err := converter.FromJson([]byte(data), typeExpression)
+	require.NoError(t, err)
+	return value
+}
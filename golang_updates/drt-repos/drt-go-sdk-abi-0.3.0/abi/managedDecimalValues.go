@@ -0,0 +1,168 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// ManagedDecimalValue is a wrapper for an unsigned fixed-point decimal number (e.g. "ManagedDecimal<18>", "ManagedDecimal<usize>").
+// The number is held as "Value", scaled by 10^Scale.
+// If the scale is constant (known from the type), only the scaled value is encoded.
+// If the scale is variable ("usize"), it's encoded (as u32) right after the scaled value.
+type ManagedDecimalValue struct {
+	Value      *big.Int
+	Scale      uint32
+	IsVariable bool
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *ManagedDecimalValue) EncodeNested(writer io.Writer) error {
+	return encodeManagedDecimalNested(writer, &BigUIntValue{Value: bigIntOrZero(value.Value)}, value.Scale, value.IsVariable)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *ManagedDecimalValue) EncodeTopLevel(writer io.Writer) error {
+	return encodeManagedDecimalTopLevel(writer, &BigUIntValue{Value: bigIntOrZero(value.Value)}, value.Scale, value.IsVariable)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *ManagedDecimalValue) DecodeNested(reader io.Reader) error {
+	inner := &BigUIntValue{}
+
+	err := decodeManagedDecimalNested(reader, inner, &value.Scale, value.IsVariable)
+	if err != nil {
+		return err
+	}
+
+	value.Value = inner.Value
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *ManagedDecimalValue) DecodeTopLevel(data []byte) error {
+	inner := &BigUIntValue{}
+
+	err := decodeManagedDecimalTopLevel(data, inner, &value.Scale, value.IsVariable)
+	if err != nil {
+		return err
+	}
+
+	value.Value = inner.Value
+	return nil
+}
+
+// ManagedDecimalSignedValue is a wrapper for a signed fixed-point decimal number (e.g. "ManagedDecimalSigned<18>").
+// The encoding rules are the ones of ManagedDecimalValue.
+type ManagedDecimalSignedValue struct {
+	Value      *big.Int
+	Scale      uint32
+	IsVariable bool
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *ManagedDecimalSignedValue) EncodeNested(writer io.Writer) error {
+	return encodeManagedDecimalNested(writer, &BigIntValue{Value: bigIntOrZero(value.Value)}, value.Scale, value.IsVariable)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *ManagedDecimalSignedValue) EncodeTopLevel(writer io.Writer) error {
+	return encodeManagedDecimalTopLevel(writer, &BigIntValue{Value: bigIntOrZero(value.Value)}, value.Scale, value.IsVariable)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *ManagedDecimalSignedValue) DecodeNested(reader io.Reader) error {
+	inner := &BigIntValue{}
+
+	err := decodeManagedDecimalNested(reader, inner, &value.Scale, value.IsVariable)
+	if err != nil {
+		return err
+	}
+
+	value.Value = inner.Value
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *ManagedDecimalSignedValue) DecodeTopLevel(data []byte) error {
+	inner := &BigIntValue{}
+
+	err := decodeManagedDecimalTopLevel(data, inner, &value.Scale, value.IsVariable)
+	if err != nil {
+		return err
+	}
+
+	value.Value = inner.Value
+	return nil
+}
+
+// bigIntOrZero treats a missing scaled value (e.g. of a zero-valued managed decimal) as 0
+func bigIntOrZero(number *big.Int) *big.Int {
+	if number == nil {
+		return big.NewInt(0)
+	}
+
+	return number
+}
+
+func encodeManagedDecimalNested(writer io.Writer, scaledValue SingleValue, scale uint32, isVariable bool) error {
+	err := scaledValue.EncodeNested(writer)
+	if err != nil {
+		return err
+	}
+
+	if !isVariable {
+		return nil
+	}
+
+	return (&U32Value{Value: scale}).EncodeNested(writer)
+}
+
+func encodeManagedDecimalTopLevel(writer io.Writer, scaledValue SingleValue, scale uint32, isVariable bool) error {
+	if isVariable {
+		// With a variable scale, the top-level form is the same as the nested one.
+		return encodeManagedDecimalNested(writer, scaledValue, scale, isVariable)
+	}
+
+	return scaledValue.EncodeTopLevel(writer)
+}
+
+func decodeManagedDecimalNested(reader io.Reader, scaledValue SingleValue, scale *uint32, isVariable bool) error {
+	err := scaledValue.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+
+	if !isVariable {
+		return nil
+	}
+
+	scaleValue := &U32Value{}
+	err = scaleValue.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+
+	*scale = scaleValue.Value
+	return nil
+}
+
+func decodeManagedDecimalTopLevel(data []byte, scaledValue SingleValue, scale *uint32, isVariable bool) error {
+	if !isVariable {
+		return scaledValue.DecodeTopLevel(data)
+	}
+
+	reader := bytes.NewReader(data)
+
+	err := decodeManagedDecimalNested(reader, scaledValue, scale, isVariable)
+	if err != nil {
+		return err
+	}
+
+	if reader.Len() > 0 {
+		return fmt.Errorf("unexpected data after managed decimal: %d bytes", reader.Len())
+	}
+
+	return nil
+}
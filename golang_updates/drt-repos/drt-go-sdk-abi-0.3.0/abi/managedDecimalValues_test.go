@@ -0,0 +1,88 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestManagedDecimalValue(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec, &ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2}, "0000000204d2")
+		testEncodeNested(t, codec, &ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2, IsVariable: true}, "0000000204d200000002")
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec, &ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2}, "04d2")
+		testEncodeTopLevel(t, codec, &ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2, IsVariable: true}, "0000000204d200000002")
+	})
+
+	t.Run("should encode zero value", func(t *testing.T) {
+		testEncodeNested(t, codec, &ManagedDecimalValue{Scale: 2}, "00000000")
+		testEncodeTopLevel(t, codec, &ManagedDecimalValue{Scale: 2}, "")
+		testEncodeTopLevel(t, codec, &ManagedDecimalValue{Scale: 2, IsVariable: true}, "0000000000000002")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec, "0000000204d2",
+			&ManagedDecimalValue{Scale: 2},
+			&ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2},
+		)
+		testDecodeNested(t, codec, "0000000204d200000002",
+			&ManagedDecimalValue{IsVariable: true},
+			&ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2, IsVariable: true},
+		)
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec, "04d2",
+			&ManagedDecimalValue{Scale: 2},
+			&ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2},
+		)
+		testDecodeTopLevel(t, codec, "0000000204d200000002",
+			&ManagedDecimalValue{IsVariable: true},
+			&ManagedDecimalValue{Value: big.NewInt(1234), Scale: 2, IsVariable: true},
+		)
+	})
+
+	t.Run("should err on decode top-level when there is unexpected data", func(t *testing.T) {
+		testDecodeTopLevelWithError(t, codec, "0000000204d200000002ff",
+			&ManagedDecimalValue{IsVariable: true},
+			"unexpected data after managed decimal: 1 bytes",
+		)
+	})
+}
+
+func TestManagedDecimalSignedValue(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec, &ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 18}, "00000001ff")
+		testEncodeNested(t, codec, &ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 18, IsVariable: true}, "00000001ff00000012")
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec, &ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 18}, "ff")
+		testEncodeTopLevel(t, codec, &ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 18, IsVariable: true}, "00000001ff00000012")
+	})
+
+	t.Run("should encode zero value", func(t *testing.T) {
+		testEncodeNested(t, codec, &ManagedDecimalSignedValue{Scale: 18}, "00000000")
+		testEncodeTopLevel(t, codec, &ManagedDecimalSignedValue{Scale: 18}, "")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec, "00000001ff00000012",
+			&ManagedDecimalSignedValue{IsVariable: true},
+			&ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 18, IsVariable: true},
+		)
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec, "ff",
+			&ManagedDecimalSignedValue{Scale: 18},
+			&ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 18},
+		)
+	})
+}
@@ -15,3 +15,10 @@ type VariadicValues struct {
 type OptionalValue struct {
 	Value any
 }
+
+// CountedVariadicValues holds variadic values, preceded (when serialized) by their number.
+// Unlike VariadicValues, they aren't required to be last among input / output values.
+type CountedVariadicValues struct {
+	Items       []any
+	ItemCreator func() any
+}
@@ -0,0 +1,725 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+const abiTagName = "abi"
+const abiTagTypePrefix = "type="
+
+var singleValueType = reflect.TypeOf((*SingleValue)(nil)).Elem()
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// defaultIntegerTypeNames holds the ABI types of the Go integers, used in the absence of a type hint
+var defaultIntegerTypeNames = map[reflect.Kind]string{
+	reflect.Uint8:  "u8",
+	reflect.Uint16: "u16",
+	reflect.Uint32: "u32",
+	reflect.Uint64: "u64",
+	reflect.Uint:   "u64",
+	reflect.Int8:   "i8",
+	reflect.Int16:  "i16",
+	reflect.Int32:  "i32",
+	reflect.Int64:  "i64",
+	reflect.Int:    "i64",
+}
+
+// reflectedValue binds a plain Go value (accessed through reflection) to the Dharitri Serialization format.
+// It maps Go types to ABI types as follows:
+//   - bool, uint8 ... uint64, int8 ... int64 to bool, u8 ... u64, i8 ... i64
+//   - uint, int (platform-sized) to u64, i64
+//   - integers to other sizes of the same signedness, given as type hint (the value must fit)
+//   - unsigned integers to simple enums (custom types), given as type hint
+//   - string to "utf-8 string" (or "bytes", "TokenIdentifier", given as type hint)
+//   - []byte to "bytes" (or "Address", "utf-8 string", "CodeMetadata", given as type hint)
+//   - *big.Int to "BigUint" (or "BigInt", given as type hint)
+//   - other slices to "List<T>", fixed-size arrays to "arrayN<T>"
+//   - other pointers to "Option<T>" (nil meaning "absent")
+//   - structs to (ABI) structs (or tuples), field by field, in order of declaration
+//
+// Values that already implement SingleValue are used as they are (managed decimals receive their scale from the type hint, if any).
+type reflectedValue struct {
+	value   reflect.Value
+	formula *TypeFormula
+}
+
+// Bind creates a SingleValue which reads from (when encoding) and writes into (when decoding) the given Go value.
+// In order to decode, a pointer must be provided. The resulting value can be passed to the serializer.
+func Bind(value any) (SingleValue, error) {
+	return BindWithType(value, "")
+}
+
+// BindWithType is like Bind, but also receives a type expression (e.g. "List<TokenIdentifier>"), as a hint.
+func BindWithType(value any, typeExpression string) (SingleValue, error) {
+	if value == nil {
+		return nil, errors.New("cannot bind nil value")
+	}
+
+	singleValue, ok := value.(SingleValue)
+	if ok {
+		return singleValue, nil
+	}
+
+	var formula *TypeFormula
+	var err error
+
+	if typeExpression != "" {
+		formula, err = ParseTypeFormula(typeExpression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Ptr && reflected.Type() != reflect.PtrTo(bigIntType) {
+		if reflected.IsNil() {
+			return nil, errors.New("cannot bind nil pointer")
+		}
+
+		return &reflectedValue{value: reflected.Elem(), formula: formula}, nil
+	}
+
+	// Make an addressable copy (for values passed by value, only encoding makes sense).
+	addressable := reflect.New(reflected.Type()).Elem()
+	addressable.Set(reflected)
+
+	return &reflectedValue{value: addressable, formula: formula}, nil
+}
+
+// Marshal encodes the given Go value, following the top-level encoding rules
+func Marshal(value any) ([]byte, error) {
+	bound, err := Bind(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&codec{}).EncodeTopLevel(bound)
+}
+
+// MarshalNested encodes the given Go value, following the nested encoding rules
+func MarshalNested(value any) ([]byte, error) {
+	bound, err := Bind(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&codec{}).EncodeNested(bound)
+}
+
+// Unmarshal decodes the given data into the Go value pointed to by "destination", following the top-level decoding rules
+func Unmarshal(data []byte, destination any) error {
+	bound, err := bindDestination(destination)
+	if err != nil {
+		return err
+	}
+
+	return (&codec{}).DecodeTopLevel(data, bound)
+}
+
+// UnmarshalNested decodes the given data into the Go value pointed to by "destination", following the nested decoding rules
+func UnmarshalNested(data []byte, destination any) error {
+	bound, err := bindDestination(destination)
+	if err != nil {
+		return err
+	}
+
+	return (&codec{}).DecodeNested(data, bound)
+}
+
+func bindDestination(destination any) (SingleValue, error) {
+	if destination == nil || reflect.ValueOf(destination).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("cannot decode into non-pointer %T", destination)
+	}
+
+	return Bind(destination)
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *reflectedValue) EncodeNested(writer io.Writer) error {
+	abiValue, err := value.toAbiValue(false)
+	if err != nil {
+		return err
+	}
+
+	return abiValue.EncodeNested(writer)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *reflectedValue) EncodeTopLevel(writer io.Writer) error {
+	abiValue, err := value.toAbiValue(false)
+	if err != nil {
+		return err
+	}
+
+	return abiValue.EncodeTopLevel(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *reflectedValue) DecodeNested(reader io.Reader) error {
+	abiValue, err := value.toAbiValue(true)
+	if err != nil {
+		return err
+	}
+
+	err = abiValue.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+
+	return value.fromAbiValue(abiValue)
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *reflectedValue) DecodeTopLevel(data []byte) error {
+	abiValue, err := value.toAbiValue(true)
+	if err != nil {
+		return err
+	}
+
+	err = abiValue.DecodeTopLevel(data)
+	if err != nil {
+		return err
+	}
+
+	return value.fromAbiValue(abiValue)
+}
+
+func (value *reflectedValue) typeName() string {
+	if value.formula == nil {
+		return ""
+	}
+
+	return value.formula.Name
+}
+
+// typeParameter returns the hint for the inner type (e.g. "u8" for "List<u8>"), if any
+func (value *reflectedValue) typeParameter() *TypeFormula {
+	if value.formula == nil || len(value.formula.TypeParameters) != 1 {
+		return nil
+	}
+
+	return value.formula.TypeParameters[0]
+}
+
+// toAbiValue creates the ABI value corresponding to the Go value.
+// When decoding, it creates placeholders instead (thus, the current Go value is not used).
+func (value *reflectedValue) toAbiValue(forDecoding bool) (SingleValue, error) {
+	goValue := value.value
+	goType := goValue.Type()
+
+	if goType.Implements(singleValueType) {
+		if goValue.Kind() == reflect.Interface && goValue.IsNil() {
+			return nil, errors.New("cannot bind nil SingleValue")
+		}
+
+		if goValue.Kind() == reflect.Ptr && goValue.IsNil() {
+			goValue.Set(reflect.New(goType.Elem()))
+		}
+
+		return value.applyTypeHint(goValue.Interface().(SingleValue))
+	}
+
+	if reflect.PtrTo(goType).Implements(singleValueType) {
+		return value.applyTypeHint(goValue.Addr().Interface().(SingleValue))
+	}
+
+	if goType == reflect.PtrTo(bigIntType) {
+		return value.bigIntToAbiValue()
+	}
+
+	switch goType.Kind() {
+	case reflect.Bool:
+		return value.boolToAbiValue()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return value.uintToAbiValue()
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return value.intToAbiValue()
+	case reflect.String:
+		return value.stringToAbiValue()
+	case reflect.Slice:
+		if goType.Elem().Kind() == reflect.Uint8 {
+			return value.bytesToAbiValue()
+		}
+
+		return value.sliceToAbiValue(forDecoding), nil
+	case reflect.Array:
+		if goType.Elem().Kind() == reflect.Uint8 && isBytesLikeArrayTypeName(value.typeName()) {
+			return value.bytesToAbiValue()
+		}
+
+		return value.arrayToAbiValue()
+	case reflect.Ptr:
+		return value.pointerToAbiValue(forDecoding), nil
+	case reflect.Struct:
+		return value.structToAbiValue()
+	default:
+		return nil, fmt.Errorf("unsupported type for ABI encoding: %s", goType)
+	}
+}
+
+// applyTypeHint configures values which depend on their ABI type, such as managed decimals (whose scale is part of the type)
+func (value *reflectedValue) applyTypeHint(singleValue SingleValue) (SingleValue, error) {
+	if value.formula == nil {
+		return singleValue, nil
+	}
+
+	switch singleValue := singleValue.(type) {
+	case *ManagedDecimalValue:
+		if value.typeName() != "ManagedDecimal" {
+			return nil, value.newIncompatibleTypeError()
+		}
+
+		return singleValue, value.applyManagedDecimalScale(&singleValue.Scale, &singleValue.IsVariable)
+	case *ManagedDecimalSignedValue:
+		if value.typeName() != "ManagedDecimalSigned" {
+			return nil, value.newIncompatibleTypeError()
+		}
+
+		return singleValue, value.applyManagedDecimalScale(&singleValue.Scale, &singleValue.IsVariable)
+	}
+
+	return singleValue, nil
+}
+
+func (value *reflectedValue) applyManagedDecimalScale(scale *uint32, isVariable *bool) error {
+	err := validateManagedDecimalScale(value.formula)
+	if err != nil {
+		return err
+	}
+
+	constantScale, variable := parseManagedDecimalScale(value.formula.TypeParameters[0])
+	*isVariable = variable
+	if !variable {
+		*scale = constantScale
+	}
+
+	return nil
+}
+
+func (value *reflectedValue) boolToAbiValue() (SingleValue, error) {
+	switch value.typeName() {
+	case "", "bool":
+		return &BoolValue{Value: value.value.Bool()}, nil
+	default:
+		return nil, value.newIncompatibleTypeError()
+	}
+}
+
+// uintToAbiValue honours type hints of other (unsigned) sizes, as long as the Go value fits the hinted type
+func (value *reflectedValue) uintToAbiValue() (SingleValue, error) {
+	number := value.value.Uint()
+	typeName := value.typeName()
+	if typeName == "" {
+		typeName = defaultIntegerTypeNames[value.value.Kind()]
+	}
+	if !isPrimitiveTypeName(typeName) && !isMultiValueTypeName(typeName) {
+		// A custom type given as hint is a simple enum, whose discriminant is a u8.
+		typeName = "u8"
+	}
+
+	switch typeName {
+	case "u8":
+		if number > math.MaxUint8 {
+			return nil, value.newOverflowError(number)
+		}
+		return &U8Value{Value: uint8(number)}, nil
+	case "u16":
+		if number > math.MaxUint16 {
+			return nil, value.newOverflowError(number)
+		}
+		return &U16Value{Value: uint16(number)}, nil
+	case "u32", "usize":
+		if number > math.MaxUint32 {
+			return nil, value.newOverflowError(number)
+		}
+		return &U32Value{Value: uint32(number)}, nil
+	case "u64":
+		return &U64Value{Value: number}, nil
+	default:
+		return nil, value.newIncompatibleTypeError()
+	}
+}
+
+// intToAbiValue honours type hints of other (signed) sizes, as long as the Go value fits the hinted type
+func (value *reflectedValue) intToAbiValue() (SingleValue, error) {
+	number := value.value.Int()
+	typeName := value.typeName()
+	if typeName == "" {
+		typeName = defaultIntegerTypeNames[value.value.Kind()]
+	}
+
+	switch typeName {
+	case "i8":
+		if number < math.MinInt8 || number > math.MaxInt8 {
+			return nil, value.newOverflowError(number)
+		}
+		return &I8Value{Value: int8(number)}, nil
+	case "i16":
+		if number < math.MinInt16 || number > math.MaxInt16 {
+			return nil, value.newOverflowError(number)
+		}
+		return &I16Value{Value: int16(number)}, nil
+	case "i32", "isize":
+		if number < math.MinInt32 || number > math.MaxInt32 {
+			return nil, value.newOverflowError(number)
+		}
+		return &I32Value{Value: int32(number)}, nil
+	case "i64":
+		return &I64Value{Value: number}, nil
+	default:
+		return nil, value.newIncompatibleTypeError()
+	}
+}
+
+func (value *reflectedValue) bigIntToAbiValue() (SingleValue, error) {
+	number, _ := value.value.Interface().(*big.Int)
+	if number == nil {
+		number = big.NewInt(0)
+	}
+
+	switch value.typeName() {
+	case "", "BigUint":
+		return &BigUIntValue{Value: number}, nil
+	case "BigInt":
+		return &BigIntValue{Value: number}, nil
+	default:
+		return nil, value.newIncompatibleTypeError()
+	}
+}
+
+func (value *reflectedValue) stringToAbiValue() (SingleValue, error) {
+	switch value.typeName() {
+	case "", "utf-8 string", "String":
+		return &StringValue{Value: value.value.String()}, nil
+	case "TokenIdentifier", "RewaOrDcdtTokenIdentifier":
+		return &TokenIdentifierValue{Value: value.value.String()}, nil
+	case "bytes", "BoxedBytes", "ManagedBuffer":
+		return &BytesValue{Value: []byte(value.value.String())}, nil
+	default:
+		return nil, value.newIncompatibleTypeError()
+	}
+}
+
+func (value *reflectedValue) bytesToAbiValue() (SingleValue, error) {
+	data := value.getBytes()
+
+	switch value.typeName() {
+	case "", "bytes", "BoxedBytes", "ManagedBuffer", "List":
+		// "List<u8>" has the same encoding as "bytes".
+		return &BytesValue{Value: data}, nil
+	case "Address", "ManagedAddress":
+		return &AddressValue{Value: data}, nil
+	case "utf-8 string", "String":
+		return &StringValue{Value: string(data)}, nil
+	case "CodeMetadata":
+		return &CodeMetadataValue{Value: data}, nil
+	default:
+		return nil, value.newIncompatibleTypeError()
+	}
+}
+
+// getBytes returns the bytes of a byte slice or a (possibly non-addressable) byte array
+func (value *reflectedValue) getBytes() []byte {
+	goValue := value.value
+	if goValue.Kind() == reflect.Slice {
+		return goValue.Bytes()
+	}
+
+	data := make([]byte, goValue.Len())
+	reflect.Copy(reflect.ValueOf(data), goValue)
+	return data
+}
+
+func (value *reflectedValue) sliceToAbiValue(forDecoding bool) SingleValue {
+	goValue := value.value
+	itemFormula := value.typeParameter()
+
+	if forDecoding {
+		return &ListValue{
+			Items: []SingleValue{},
+			ItemCreator: func() SingleValue {
+				item := reflect.New(goValue.Type().Elem()).Elem()
+				return &reflectedValue{value: item, formula: itemFormula}
+			},
+		}
+	}
+
+	items := make([]SingleValue, goValue.Len())
+	for i := 0; i < goValue.Len(); i++ {
+		items[i] = &reflectedValue{value: goValue.Index(i), formula: itemFormula}
+	}
+
+	return &ListValue{Items: items}
+}
+
+// arrayToAbiValue handles fixed-size arrays ("arrayN<T>").
+// The items of the ABI value are bound directly to the items of the Go array, thus no copying is needed when decoding.
+func (value *reflectedValue) arrayToAbiValue() (SingleValue, error) {
+	goValue := value.value
+	itemFormula := value.typeParameter()
+	length := uint32(goValue.Len())
+
+	if value.formula != nil {
+		hintedLength, isArray := parseArrayLength(value.typeName())
+		if !isArray || hintedLength != length {
+			return nil, value.newIncompatibleTypeError()
+		}
+	}
+
+	items := make([]SingleValue, length)
+	for i := range items {
+		items[i] = &reflectedValue{value: goValue.Index(i), formula: itemFormula}
+	}
+
+	nextItem := 0
+
+	return &ArrayValue{
+		Length: length,
+		Items:  items,
+		ItemCreator: func() SingleValue {
+			item := items[nextItem]
+			nextItem++
+			return item
+		},
+	}, nil
+}
+
+func (value *reflectedValue) pointerToAbiValue(forDecoding bool) SingleValue {
+	goValue := value.value
+	itemFormula := value.typeParameter()
+
+	if forDecoding {
+		placeholder := reflect.New(goValue.Type().Elem())
+		return &OptionValue{Value: &reflectedValue{value: placeholder.Elem(), formula: itemFormula}}
+	}
+
+	if goValue.IsNil() {
+		return &OptionValue{}
+	}
+
+	return &OptionValue{Value: &reflectedValue{value: goValue.Elem(), formula: itemFormula}}
+}
+
+func (value *reflectedValue) structToAbiValue() (SingleValue, error) {
+	goValue := value.value
+	goType := goValue.Type()
+
+	if goType == bigIntType {
+		return nil, fmt.Errorf("unsupported type for ABI encoding: %s (use *big.Int instead)", goType)
+	}
+
+	fields := make([]Field, 0, goType.NumField())
+
+	for i := 0; i < goType.NumField(); i++ {
+		structField := goType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, typeExpression := parseAbiTag(structField)
+		if name == "-" {
+			continue
+		}
+
+		var formula *TypeFormula
+		var err error
+
+		if typeExpression != "" {
+			formula, err = ParseTypeFormula(typeExpression)
+			if err != nil {
+				return nil, fmt.Errorf("bad tag of field '%s': %w", structField.Name, err)
+			}
+		}
+
+		fields = append(fields, Field{
+			Name:  name,
+			Value: &reflectedValue{value: goValue.Field(i), formula: formula},
+		})
+	}
+
+	if value.typeName() == "tuple" {
+		return value.fieldsToTupleValue(fields)
+	}
+
+	return &StructValue{Fields: fields}, nil
+}
+
+// fieldsToTupleValue creates a tuple out of the fields of a struct.
+// The type parameters of the tuple act as type hints for the fields (unless given in tags).
+func (value *reflectedValue) fieldsToTupleValue(fields []Field) (SingleValue, error) {
+	if len(fields) != len(value.formula.TypeParameters) {
+		return nil, value.newIncompatibleTypeError()
+	}
+
+	items := make([]SingleValue, len(fields))
+	for i, field := range fields {
+		item := field.Value.(*reflectedValue)
+		if item.formula == nil {
+			item.formula = value.formula.TypeParameters[i]
+		}
+
+		items[i] = item
+	}
+
+	return &TupleValue{Items: items}, nil
+}
+
+// fromAbiValue copies the decoded data from the ABI value (previously created by toAbiValue) into the Go value.
+// Structs and fixed-size arrays need no copying, since their fields (items) are bound directly to the Go value.
+func (value *reflectedValue) fromAbiValue(abiValue SingleValue) error {
+	goValue := value.value
+
+	switch abiValue := abiValue.(type) {
+	case *BoolValue:
+		goValue.SetBool(abiValue.Value)
+	case *U8Value:
+		return value.setUint(uint64(abiValue.Value))
+	case *U16Value:
+		return value.setUint(uint64(abiValue.Value))
+	case *U32Value:
+		return value.setUint(uint64(abiValue.Value))
+	case *U64Value:
+		return value.setUint(abiValue.Value)
+	case *I8Value:
+		return value.setInt(int64(abiValue.Value))
+	case *I16Value:
+		return value.setInt(int64(abiValue.Value))
+	case *I32Value:
+		return value.setInt(int64(abiValue.Value))
+	case *I64Value:
+		return value.setInt(abiValue.Value)
+	case *BigUIntValue:
+		value.setBigInt(abiValue.Value)
+	case *BigIntValue:
+		value.setBigInt(abiValue.Value)
+	case *StringValue:
+		return value.setStringOrBytes([]byte(abiValue.Value))
+	case *TokenIdentifierValue:
+		return value.setStringOrBytes([]byte(abiValue.Value))
+	case *CodeMetadataValue:
+		return value.setStringOrBytes(abiValue.Value)
+	case *BytesValue:
+		return value.setStringOrBytes(abiValue.Value)
+	case *AddressValue:
+		return value.setStringOrBytes(abiValue.Value)
+	case *ListValue:
+		if goValue.Kind() != reflect.Slice {
+			return nil
+		}
+
+		slice := reflect.MakeSlice(goValue.Type(), 0, len(abiValue.Items))
+		for _, item := range abiValue.Items {
+			slice = reflect.Append(slice, item.(*reflectedValue).value)
+		}
+
+		goValue.Set(slice)
+	case *OptionValue:
+		if goValue.Kind() != reflect.Ptr {
+			return nil
+		}
+
+		if abiValue.Value == nil {
+			goValue.Set(reflect.Zero(goValue.Type()))
+			return nil
+		}
+
+		goValue.Set(abiValue.Value.(*reflectedValue).value.Addr())
+	}
+
+	return nil
+}
+
+// setUint checks for overflow, since the type hint may be larger than the Go type
+func (value *reflectedValue) setUint(number uint64) error {
+	if value.value.OverflowUint(number) {
+		return fmt.Errorf("decoded value %d overflows %s", number, value.value.Type())
+	}
+
+	value.value.SetUint(number)
+	return nil
+}
+
+// setInt checks for overflow, since the type hint may be larger than the Go type
+func (value *reflectedValue) setInt(number int64) error {
+	if value.value.OverflowInt(number) {
+		return fmt.Errorf("decoded value %d overflows %s", number, value.value.Type())
+	}
+
+	value.value.SetInt(number)
+	return nil
+}
+
+func (value *reflectedValue) setBigInt(number *big.Int) {
+	existing, _ := value.value.Interface().(*big.Int)
+	if existing != nil {
+		existing.Set(number)
+		return
+	}
+
+	value.value.Set(reflect.ValueOf(number))
+}
+
+func (value *reflectedValue) setStringOrBytes(data []byte) error {
+	goValue := value.value
+
+	switch goValue.Kind() {
+	case reflect.String:
+		goValue.SetString(string(data))
+	case reflect.Slice:
+		goValue.SetBytes(data)
+	case reflect.Array:
+		if len(data) != goValue.Len() {
+			return fmt.Errorf("cannot copy %d bytes into %s", len(data), goValue.Type())
+		}
+
+		reflect.Copy(goValue, reflect.ValueOf(data))
+	}
+
+	return nil
+}
+
+func isBytesLikeArrayTypeName(name string) bool {
+	switch name {
+	case "Address", "ManagedAddress", "CodeMetadata":
+		return true
+	default:
+		return false
+	}
+}
+
+func (value *reflectedValue) newIncompatibleTypeError() error {
+	return fmt.Errorf("ABI type '%s' is not compatible with Go type %s", value.formula, value.value.Type())
+}
+
+func (value *reflectedValue) newOverflowError(number any) error {
+	return fmt.Errorf("value %v of Go type %s overflows ABI type '%s'", number, value.value.Type(), value.formula)
+}
+
+// parseAbiTag parses a struct tag such as `abi:"name,type=TokenIdentifier"`
+func parseAbiTag(structField reflect.StructField) (string, string) {
+	name := structField.Name
+	typeExpression := ""
+
+	tag, ok := structField.Tag.Lookup(abiTagName)
+	if !ok {
+		return name, typeExpression
+	}
+
+	// The type expression comes last, since it may contain commas (e.g. "multi<u8,u16>").
+	parts := strings.SplitN(tag, ",", 2)
+	if parts[0] != "" {
+		name = parts[0]
+	}
+
+	if len(parts) == 2 && strings.HasPrefix(parts[1], abiTagTypePrefix) {
+		typeExpression = strings.TrimPrefix(parts[1], abiTagTypePrefix)
+	}
+
+	return name, typeExpression
+}
@@ -0,0 +1,367 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPayment struct {
+	TokenIdentifier string `abi:"token_identifier,type=TokenIdentifier"`
+	TokenNonce      uint64 `abi:"token_nonce"`
+	Amount          *big.Int
+}
+
+type testCallActionData struct {
+	To           [32]byte `abi:"to,type=Address"`
+	RewaAmount   *big.Int `abi:"rewa_amount"`
+	OptGasLimit  *uint64  `abi:"opt_gas_limit"`
+	EndpointName []byte   `abi:"endpoint_name"`
+	Arguments    [][]byte `abi:"arguments"`
+	Skipped      uint8    `abi:"-"`
+}
+
+type testPair struct {
+	A uint8
+	B uint16
+}
+
+func TestMarshal(t *testing.T) {
+	t.Run("primitives (top-level)", func(t *testing.T) {
+		data, err := Marshal(uint16(0x4142))
+		require.NoError(t, err)
+		require.Equal(t, "4142", hex.EncodeToString(data))
+
+		data, err = Marshal(int8(-1))
+		require.NoError(t, err)
+		require.Equal(t, "ff", hex.EncodeToString(data))
+
+		data, err = Marshal(true)
+		require.NoError(t, err)
+		require.Equal(t, "01", hex.EncodeToString(data))
+
+		data, err = Marshal("abc")
+		require.NoError(t, err)
+		require.Equal(t, "616263", hex.EncodeToString(data))
+	})
+
+	t.Run("platform-sized integers, as 64-bit values", func(t *testing.T) {
+		data, err := MarshalNested(uint(0x4142))
+		require.NoError(t, err)
+		require.Equal(t, "0000000000004142", hex.EncodeToString(data))
+
+		data, err = MarshalNested(-2)
+		require.NoError(t, err)
+		require.Equal(t, "fffffffffffffffe", hex.EncodeToString(data))
+
+		data, err = Marshal(struct {
+			A uint
+			B int
+		}{A: 1, B: -1})
+		require.NoError(t, err)
+		require.Equal(t, "0000000000000001ffffffffffffffff", hex.EncodeToString(data))
+	})
+
+	t.Run("primitives (nested)", func(t *testing.T) {
+		data, err := MarshalNested(uint16(0x42))
+		require.NoError(t, err)
+		require.Equal(t, "0042", hex.EncodeToString(data))
+
+		data, err = MarshalNested([]byte{0x42})
+		require.NoError(t, err)
+		require.Equal(t, "0000000142", hex.EncodeToString(data))
+
+		data, err = MarshalNested(big.NewInt(256))
+		require.NoError(t, err)
+		require.Equal(t, "000000020100", hex.EncodeToString(data))
+	})
+
+	t.Run("list, option, fixed array", func(t *testing.T) {
+		data, err := MarshalNested([]uint16{1, 2})
+		require.NoError(t, err)
+		require.Equal(t, "0000000200010002", hex.EncodeToString(data))
+
+		data, err = Marshal([]uint16{1, 2})
+		require.NoError(t, err)
+		require.Equal(t, "00010002", hex.EncodeToString(data))
+
+		value := uint32(7)
+		data, err = MarshalNested(struct{ A *uint32 }{A: &value})
+		require.NoError(t, err)
+		require.Equal(t, "0100000007", hex.EncodeToString(data))
+
+		data, err = MarshalNested(struct{ A *uint32 }{})
+		require.NoError(t, err)
+		require.Equal(t, "00", hex.EncodeToString(data))
+
+		data, err = MarshalNested([3]uint8{1, 2, 3})
+		require.NoError(t, err)
+		require.Equal(t, "010203", hex.EncodeToString(data))
+	})
+
+	t.Run("struct with tags", func(t *testing.T) {
+		data, err := MarshalNested(testPayment{
+			TokenIdentifier: "beer",
+			TokenNonce:      0,
+			Amount:          big.NewInt(0).SetUint64(1_000_000_000_000_000_000),
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "0000000462656572"+"0000000000000000"+"000000080de0b6b3a7640000", hex.EncodeToString(data))
+	})
+
+	t.Run("SingleValue fields are used as they are", func(t *testing.T) {
+		data, err := MarshalNested(struct {
+			A U8Value
+			B *U16Value
+		}{
+			A: U8Value{Value: 1},
+			B: &U16Value{Value: 2},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "010002", hex.EncodeToString(data))
+	})
+
+	t.Run("additional types, with type hints", func(t *testing.T) {
+		data, err := MarshalNested(struct {
+			Pair   testPair                  `abi:"pair,type=tuple<u8,u16>"`
+			Hashes [2]uint8                  `abi:"hashes,type=array2<u8>"`
+			Code   [2]byte                   `abi:"code,type=CodeMetadata"`
+			Price  ManagedDecimalValue       `abi:"price,type=ManagedDecimal<2>"`
+			Delta  ManagedDecimalSignedValue `abi:"delta,type=ManagedDecimalSigned<usize>"`
+		}{
+			Pair:   testPair{A: 1, B: 2},
+			Hashes: [2]uint8{3, 4},
+			Code:   [2]byte{0x05, 0x00},
+			Price:  ManagedDecimalValue{Value: big.NewInt(1234)},
+			Delta:  ManagedDecimalSignedValue{Value: big.NewInt(-1), Scale: 3},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "010002"+"0304"+"0500"+"0000000204d2"+"00000001ff00000003", hex.EncodeToString(data))
+	})
+
+	t.Run("zero-valued managed decimals", func(t *testing.T) {
+		data, err := Marshal(struct {
+			P ManagedDecimalValue       `abi:"p,type=ManagedDecimal<18>"`
+			D ManagedDecimalSignedValue `abi:"d,type=ManagedDecimalSigned<usize>"`
+		}{})
+
+		require.NoError(t, err)
+		require.Equal(t, "00000000"+"0000000000000000", hex.EncodeToString(data))
+	})
+
+	t.Run("should err on array with bad length hint", func(t *testing.T) {
+		_, err := Marshal(struct {
+			A [2]uint8 `abi:"a,type=array3<u8>"`
+		}{})
+
+		require.ErrorContains(t, err, "ABI type 'array3<u8>' is not compatible with Go type [2]uint8")
+	})
+
+	t.Run("should err on unsupported type", func(t *testing.T) {
+		_, err := Marshal(map[string]int{})
+		require.ErrorContains(t, err, "unsupported type for ABI encoding: map[string]int")
+
+		_, err = Marshal(struct{ A float64 }{})
+		require.ErrorContains(t, err, "cannot encode field 'A' of struct, because of: unsupported type for ABI encoding: float64")
+	})
+
+	t.Run("should err on incompatible type hint", func(t *testing.T) {
+		_, err := Marshal(struct {
+			A string `abi:"a,type=Address"`
+		}{})
+
+		require.ErrorContains(t, err, "ABI type 'Address' is not compatible with Go type string")
+
+		_, err = Marshal(struct {
+			A bool `abi:"a,type=u8"`
+		}{})
+
+		require.ErrorContains(t, err, "ABI type 'u8' is not compatible with Go type bool")
+
+		_, err = Marshal(struct {
+			A uint64 `abi:"a,type=i32"`
+		}{})
+
+		require.ErrorContains(t, err, "ABI type 'i32' is not compatible with Go type uint64")
+	})
+
+	t.Run("integers, with type hints of other sizes", func(t *testing.T) {
+		data, err := MarshalNested(struct {
+			A uint64 `abi:"a,type=u32"`
+			B int    `abi:"b,type=i16"`
+			C uint8  `abi:"c,type=u16"`
+			D uint   `abi:"d,type=usize"`
+			E uint8  `abi:"e,type=Status"`
+		}{
+			A: 1,
+			B: -2,
+			C: 3,
+			D: 4,
+			E: 5,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "00000001"+"fffe"+"0003"+"00000004"+"05", hex.EncodeToString(data))
+	})
+
+	t.Run("should err on integer overflowing the type hint", func(t *testing.T) {
+		_, err := Marshal(struct {
+			A uint64 `abi:"a,type=u32"`
+		}{A: math.MaxUint32 + 1})
+
+		require.ErrorContains(t, err, "value 4294967296 of Go type uint64 overflows ABI type 'u32'")
+
+		_, err = Marshal(struct {
+			A int32 `abi:"a,type=i8"`
+		}{A: -129})
+
+		require.ErrorContains(t, err, "value -129 of Go type int32 overflows ABI type 'i8'")
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	t.Run("should err on non-pointer destination", func(t *testing.T) {
+		err := Unmarshal([]byte{0x01}, uint8(0))
+		require.ErrorContains(t, err, "cannot decode into non-pointer uint8")
+	})
+
+	t.Run("primitives", func(t *testing.T) {
+		var u16 uint16
+		err := Unmarshal([]byte{0x41, 0x42}, &u16)
+		require.NoError(t, err)
+		require.Equal(t, uint16(0x4142), u16)
+
+		var i32 int32
+		err = UnmarshalNested([]byte{0xff, 0xff, 0xff, 0xfe}, &i32)
+		require.NoError(t, err)
+		require.Equal(t, int32(-2), i32)
+
+		var u uint
+		err = UnmarshalNested([]byte{0, 0, 0, 0, 0, 0, 0x41, 0x42}, &u)
+		require.NoError(t, err)
+		require.Equal(t, uint(0x4142), u)
+
+		var i int
+		err = Unmarshal([]byte{0xfe}, &i)
+		require.NoError(t, err)
+		require.Equal(t, -2, i)
+
+		var text string
+		err = UnmarshalNested([]byte{0, 0, 0, 1, 0x41}, &text)
+		require.NoError(t, err)
+		require.Equal(t, "A", text)
+
+		number := big.NewInt(0)
+		err = Unmarshal([]byte{0x01, 0x00}, number)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(256), number)
+	})
+
+	t.Run("list (top-level)", func(t *testing.T) {
+		var items []uint16
+		err := Unmarshal([]byte{0x00, 0x01, 0x00, 0x02}, &items)
+		require.NoError(t, err)
+		require.Equal(t, []uint16{1, 2}, items)
+	})
+
+	t.Run("signed big integer, with type hint", func(t *testing.T) {
+		destination := struct {
+			A *big.Int `abi:"a,type=BigInt"`
+		}{}
+
+		err := Unmarshal([]byte{0x00, 0x00, 0x00, 0x01, 0xff}, &destination)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(-1), destination.A)
+	})
+
+	t.Run("struct with tags, options and lists", func(t *testing.T) {
+		dataHex := "0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1|000000080de0b6b3a7640000|010000000000e4e1c0|000000076578616d706c65|00000002000000020342000000020743"
+		// Drop the delimiters (were added for readability)
+		data, _ := hex.DecodeString(strings.Replace(dataHex, "|", "", -1))
+
+		destination := &testCallActionData{}
+		err := Unmarshal(data, destination)
+		require.NoError(t, err)
+
+		gasLimit := uint64(15000000)
+		require.Equal(t, &testCallActionData{
+			To:           [32]byte(alicePubKey),
+			RewaAmount:   big.NewInt(0).SetUint64(1_000_000_000_000_000_000),
+			OptGasLimit:  &gasLimit,
+			EndpointName: []byte("example"),
+			Arguments:    [][]byte{{0x03, 0x42}, {0x07, 0x43}},
+		}, destination)
+	})
+
+	t.Run("additional types, with type hints", func(t *testing.T) {
+		destination := struct {
+			Token  string              `abi:"token,type=TokenIdentifier"`
+			Hashes [2]uint16           `abi:"hashes,type=array2<u16>"`
+			Code   []byte              `abi:"code,type=CodeMetadata"`
+			Price  ManagedDecimalValue `abi:"price,type=ManagedDecimal<18>"`
+		}{}
+
+		data, _ := hex.DecodeString("0000000452455741" + "00010002" + "0100" + "0000000101")
+		err := Unmarshal(data, &destination)
+		require.NoError(t, err)
+		require.Equal(t, "REWA", destination.Token)
+		require.Equal(t, [2]uint16{1, 2}, destination.Hashes)
+		require.Equal(t, []byte{0x01, 0x00}, destination.Code)
+		require.Equal(t, ManagedDecimalValue{Value: big.NewInt(1), Scale: 18}, destination.Price)
+	})
+
+	t.Run("integers, with type hints of other sizes", func(t *testing.T) {
+		destination := struct {
+			A uint64 `abi:"a,type=u32"`
+			B uint8  `abi:"b,type=u16"`
+		}{}
+
+		err := UnmarshalNested([]byte{0, 0, 0, 1, 0, 2}, &destination)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), destination.A)
+		require.Equal(t, uint8(2), destination.B)
+
+		err = UnmarshalNested([]byte{0, 0, 0, 1, 1, 0}, &destination)
+		require.ErrorContains(t, err, "decoded value 256 overflows uint8")
+	})
+
+	t.Run("missing option", func(t *testing.T) {
+		gasLimit := uint64(42)
+		destination := struct{ A *uint64 }{A: &gasLimit}
+
+		err := UnmarshalNested([]byte{0x00}, &destination)
+		require.NoError(t, err)
+		require.Nil(t, destination.A)
+	})
+
+	t.Run("with serializer", func(t *testing.T) {
+		serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+		require.NoError(t, err)
+
+		var first uint32
+		var second testPayment
+
+		firstBound, err := Bind(&first)
+		require.NoError(t, err)
+		secondBound, err := Bind(&second)
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("2a@0000000462656572000000000000000100000001ff", []any{firstBound, secondBound})
+		require.NoError(t, err)
+		require.Equal(t, uint32(42), first)
+		require.Equal(t, testPayment{TokenIdentifier: "beer", TokenNonce: 1, Amount: big.NewInt(255)}, second)
+
+		encoded, err := serializer.Serialize([]any{firstBound, secondBound})
+		require.NoError(t, err)
+		require.Equal(t, "2a@0000000462656572000000000000000100000001ff", encoded)
+	})
+}
@@ -35,7 +35,7 @@ func NewSerializer(args ArgsNewSerializer) (*serializer, error) {
 
 // Serialize serializes the given input values into a string
 func (s *serializer) Serialize(inputValues []any) (string, error) {
-	parts, err := s.serializeToParts(inputValues)
+	parts, err := s.SerializeToParts(inputValues)
 	if err != nil {
 		return "", err
 	}
@@ -43,7 +43,8 @@ func (s *serializer) Serialize(inputValues []any) (string, error) {
 	return s.encodeParts(parts), nil
 }
 
-func (s *serializer) serializeToParts(inputValues []any) ([][]byte, error) {
+// SerializeToParts serializes the given input values into raw parts (e.g. raw contract call arguments)
+func (s *serializer) SerializeToParts(inputValues []any) ([][]byte, error) {
 	partsHolder := newEmptyPartsHolder()
 
 	err := s.doSerialize(partsHolder, inputValues)
@@ -82,6 +83,8 @@ func (s *serializer) doSerialize(partsHolder *partsHolder, inputValues []any) er
 			}
 
 			err = s.doSerialize(partsHolder, value.Items)
+		case *CountedVariadicValues:
+			err = s.serializeCountedVariadicValues(partsHolder, value)
 		case SingleValue:
 			partsHolder.appendEmptyPart()
 			err = s.serializeSingleValue(partsHolder, value)
@@ -104,10 +107,11 @@ func (s *serializer) Deserialize(data string, outputValues []any) error {
 		return err
 	}
 
-	return s.deserializeParts(parts, outputValues)
+	return s.DeserializeParts(parts, outputValues)
 }
 
-func (s *serializer) deserializeParts(parts [][]byte, outputValues []any) error {
+// DeserializeParts deserializes the given raw parts (e.g. raw contract return values) into the output values
+func (s *serializer) DeserializeParts(parts [][]byte, outputValues []any) error {
 	partsHolder := newPartsHolder(parts)
 
 	err := s.doDeserialize(partsHolder, outputValues)
@@ -148,6 +152,8 @@ func (s *serializer) doDeserialize(partsHolder *partsHolder, outputValues []any)
 			}
 
 			err = s.deserializeVariadicValues(partsHolder, value)
+		case *CountedVariadicValues:
+			err = s.deserializeCountedVariadicValues(partsHolder, value)
 		case SingleValue:
 			err = s.deserializeSingleValue(partsHolder, value)
 		default:
@@ -190,6 +196,43 @@ func (s *serializer) deserializeVariadicValues(partsHolder *partsHolder, value *
 	return nil
 }
 
+func (s *serializer) serializeCountedVariadicValues(partsHolder *partsHolder, value *CountedVariadicValues) error {
+	count := &U32Value{Value: uint32(len(value.Items))}
+
+	partsHolder.appendEmptyPart()
+	err := s.serializeSingleValue(partsHolder, count)
+	if err != nil {
+		return err
+	}
+
+	return s.doSerialize(partsHolder, value.Items)
+}
+
+func (s *serializer) deserializeCountedVariadicValues(partsHolder *partsHolder, value *CountedVariadicValues) error {
+	if value.ItemCreator == nil {
+		return errors.New("cannot deserialize counted variadic values: item creator is nil")
+	}
+
+	count := &U32Value{}
+	err := s.deserializeSingleValue(partsHolder, count)
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count.Value; i++ {
+		newItem := value.ItemCreator()
+
+		err := s.doDeserialize(partsHolder, []any{newItem})
+		if err != nil {
+			return err
+		}
+
+		value.Items = append(value.Items, newItem)
+	}
+
+	return nil
+}
+
 func (s *serializer) deserializeSingleValue(partsHolder *partsHolder, value SingleValue) error {
 	part, err := partsHolder.readWholeFocusedPart()
 	if err != nil {
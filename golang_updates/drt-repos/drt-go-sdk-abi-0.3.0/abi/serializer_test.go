@@ -172,6 +172,35 @@ func TestSerializer_Serialize(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, "41@42@43", data)
 	})
+
+	t.Run("counted-variadic<u8>, u8", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&CountedVariadicValues{
+				Items: []any{
+					&U8Value{Value: 0x42},
+					&U8Value{Value: 0x43},
+				},
+			},
+			&U8Value{Value: 0x44},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "02@42@43@44", data)
+	})
+
+	t.Run("variadic<multi<u8, u16>>", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&VariadicValues{
+				Items: []any{
+					&MultiValue{Items: []any{&U8Value{Value: 0x42}, &U16Value{Value: 0x4243}}},
+					&MultiValue{Items: []any{&U8Value{Value: 0x43}, &U16Value{Value: 0x4344}}},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "42@4243@43@4344", data)
+	})
 }
 
 func TestSerializer_Deserialize(t *testing.T) {
@@ -395,6 +424,50 @@ func TestSerializer_Deserialize(t *testing.T) {
 		err := serializer.Deserialize("0100", []any{destination})
 		require.ErrorContains(t, err, "cannot decode (top-level) *abi.U8Value, because of: decoded value is too large: 256 > 255")
 	})
+
+	t.Run("variadic<multi<u8, u16>>", func(t *testing.T) {
+		destination := &VariadicValues{
+			Items: []any{},
+			ItemCreator: func() any {
+				return &MultiValue{Items: []any{&U8Value{}, &U16Value{}}}
+			},
+		}
+
+		err := serializer.Deserialize("42@4243@43@4344", []any{destination})
+		require.NoError(t, err)
+
+		require.Equal(t, []any{
+			&MultiValue{Items: []any{&U8Value{Value: 0x42}, &U16Value{Value: 0x4243}}},
+			&MultiValue{Items: []any{&U8Value{Value: 0x43}, &U16Value{Value: 0x4344}}},
+		}, destination.Items)
+	})
+
+	t.Run("counted-variadic<u8>, u8", func(t *testing.T) {
+		destination := &CountedVariadicValues{
+			Items:       []any{},
+			ItemCreator: func() any { return &U8Value{} },
+		}
+		last := &U8Value{}
+
+		err := serializer.Deserialize("02@42@43@44", []any{destination, last})
+		require.NoError(t, err)
+
+		require.Equal(t, []any{
+			&U8Value{Value: 0x42},
+			&U8Value{Value: 0x43},
+		}, destination.Items)
+		require.Equal(t, uint8(0x44), last.Value)
+	})
+
+	t.Run("counted-variadic<u8>, should err because of missing items", func(t *testing.T) {
+		destination := &CountedVariadicValues{
+			Items:       []any{},
+			ItemCreator: func() any { return &U8Value{} },
+		}
+
+		err := serializer.Deserialize("03@42@43", []any{destination})
+		require.ErrorContains(t, err, "cannot wholly read part 3: unexpected end of data")
+	})
 }
 
 func TestSerializer_InRealWorldScenarios(t *testing.T) {
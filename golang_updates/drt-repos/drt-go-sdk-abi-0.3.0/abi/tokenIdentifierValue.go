@@ -0,0 +1,42 @@
+package abi
+
+import (
+	"io"
+)
+
+// TokenIdentifierValue is a wrapper for a token identifier (e.g. "TokenIdentifier", "RewaOrDcdtTokenIdentifier").
+// It's encoded just like a string.
+type TokenIdentifierValue struct {
+	Value string
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *TokenIdentifierValue) EncodeNested(writer io.Writer) error {
+	inner := &StringValue{Value: value.Value}
+	return inner.EncodeNested(writer)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *TokenIdentifierValue) EncodeTopLevel(writer io.Writer) error {
+	inner := &StringValue{Value: value.Value}
+	return inner.EncodeTopLevel(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *TokenIdentifierValue) DecodeNested(reader io.Reader) error {
+	inner := &StringValue{}
+
+	err := inner.DecodeNested(reader)
+	if err != nil {
+		return err
+	}
+
+	value.Value = inner.Value
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *TokenIdentifierValue) DecodeTopLevel(data []byte) error {
+	value.Value = string(data)
+	return nil
+}
@@ -0,0 +1,29 @@
+package abi
+
+import (
+	"testing"
+)
+
+func TestTokenIdentifierValue(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec, &TokenIdentifierValue{Value: "REWA"}, "0000000452455741")
+		testEncodeNested(t, codec, &TokenIdentifierValue{Value: "TEST-abcdef"}, "0000000b544553542d616263646566")
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec, &TokenIdentifierValue{Value: "REWA"}, "52455741")
+		testEncodeTopLevel(t, codec, &TokenIdentifierValue{Value: "TEST-abcdef"}, "544553542d616263646566")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec, "0000000452455741", &TokenIdentifierValue{}, &TokenIdentifierValue{Value: "REWA"})
+		testDecodeNested(t, codec, "0000000b544553542d616263646566", &TokenIdentifierValue{}, &TokenIdentifierValue{Value: "TEST-abcdef"})
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec, "52455741", &TokenIdentifierValue{}, &TokenIdentifierValue{Value: "REWA"})
+		testDecodeTopLevel(t, codec, "544553542d616263646566", &TokenIdentifierValue{}, &TokenIdentifierValue{Value: "TEST-abcdef"})
+	})
+}
@@ -0,0 +1,47 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// TupleValue is a tuple (e.g. "tuple<u8,BigUint>"), encoded just like a struct with unnamed fields
+type TupleValue struct {
+	Items []SingleValue
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *TupleValue) EncodeNested(writer io.Writer) error {
+	for i, item := range value.Items {
+		err := item.EncodeNested(writer)
+		if err != nil {
+			return fmt.Errorf("cannot encode item %d of tuple, because of: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *TupleValue) EncodeTopLevel(writer io.Writer) error {
+	return value.EncodeNested(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *TupleValue) DecodeNested(reader io.Reader) error {
+	for i, item := range value.Items {
+		err := item.DecodeNested(reader)
+		if err != nil {
+			return fmt.Errorf("cannot decode item %d of tuple, because of: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *TupleValue) DecodeTopLevel(data []byte) error {
+	reader := bytes.NewReader(data)
+	return value.DecodeNested(reader)
+}
@@ -0,0 +1,67 @@
+package abi
+
+import (
+	"testing"
+)
+
+func TestTupleValue(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec,
+			&TupleValue{
+				Items: []SingleValue{
+					&U8Value{Value: 0x01},
+					&U16Value{Value: 0x4243},
+				},
+			},
+			"014243",
+		)
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec,
+			&TupleValue{
+				Items: []SingleValue{
+					&U8Value{Value: 0x01},
+					&StringValue{Value: "abc"},
+				},
+			},
+			"0100000003616263",
+		)
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec,
+			"014243",
+			&TupleValue{
+				Items: []SingleValue{&U8Value{}, &U16Value{}},
+			},
+			&TupleValue{
+				Items: []SingleValue{&U8Value{Value: 0x01}, &U16Value{Value: 0x4243}},
+			},
+		)
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec,
+			"0100000003616263",
+			&TupleValue{
+				Items: []SingleValue{&U8Value{}, &StringValue{}},
+			},
+			&TupleValue{
+				Items: []SingleValue{&U8Value{Value: 0x01}, &StringValue{Value: "abc"}},
+			},
+		)
+	})
+
+	t.Run("should err on decode nested when data is missing", func(t *testing.T) {
+		testDecodeNestedWithError(t, codec,
+			"01",
+			&TupleValue{
+				Items: []SingleValue{&U8Value{}, &U16Value{}},
+			},
+			"cannot decode item 1 of tuple",
+		)
+	})
+}
@@ -0,0 +1,110 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeFormula is the parsed form of a type expression such as "variadic<multi<BigUint,u64>>".
+type TypeFormula struct {
+	Name           string
+	TypeParameters []*TypeFormula
+}
+
+// String returns the canonical form of the type expression
+func (formula *TypeFormula) String() string {
+	if len(formula.TypeParameters) == 0 {
+		return formula.Name
+	}
+
+	parameters := make([]string, len(formula.TypeParameters))
+	for i, parameter := range formula.TypeParameters {
+		parameters[i] = parameter.String()
+	}
+
+	return fmt.Sprintf("%s<%s>", formula.Name, strings.Join(parameters, ","))
+}
+
+// ParseTypeFormula parses a type expression (as found in ABI files) into a TypeFormula.
+func ParseTypeFormula(expression string) (*TypeFormula, error) {
+	parser := &typeFormulaParser{
+		expression: expression,
+	}
+
+	formula, err := parser.parseFormula()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse type expression '%s', because of: %w", expression, err)
+	}
+
+	if !parser.isAtEnd() {
+		return nil, fmt.Errorf("cannot parse type expression '%s': unexpected character at position %d", expression, parser.position)
+	}
+
+	return formula, nil
+}
+
+type typeFormulaParser struct {
+	expression string
+	position   int
+}
+
+func (parser *typeFormulaParser) parseFormula() (*TypeFormula, error) {
+	name := parser.readName()
+	if name == "" {
+		return nil, fmt.Errorf("missing type name at position %d", parser.position)
+	}
+
+	formula := &TypeFormula{
+		Name:           name,
+		TypeParameters: []*TypeFormula{},
+	}
+
+	if parser.isAtEnd() || parser.peek() != '<' {
+		return formula, nil
+	}
+
+	// Skip "<"
+	parser.position++
+
+	for {
+		parameter, err := parser.parseFormula()
+		if err != nil {
+			return nil, err
+		}
+
+		formula.TypeParameters = append(formula.TypeParameters, parameter)
+
+		if parser.isAtEnd() {
+			return nil, fmt.Errorf("missing '>' for type '%s'", name)
+		}
+
+		switch parser.peek() {
+		case ',':
+			parser.position++
+		case '>':
+			parser.position++
+			return formula, nil
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", parser.peek(), parser.position)
+		}
+	}
+}
+
+// readName reads a type name, which may contain spaces (e.g. "utf-8 string") or dashes (e.g. "counted-variadic").
+func (parser *typeFormulaParser) readName() string {
+	start := parser.position
+
+	for !parser.isAtEnd() && !strings.ContainsRune("<>,", rune(parser.peek())) {
+		parser.position++
+	}
+
+	return strings.TrimSpace(parser.expression[start:parser.position])
+}
+
+func (parser *typeFormulaParser) peek() byte {
+	return parser.expression[parser.position]
+}
+
+func (parser *typeFormulaParser) isAtEnd() bool {
+	return parser.position >= len(parser.expression)
+}
@@ -0,0 +1,44 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypeFormula(t *testing.T) {
+	t.Run("simple type", func(t *testing.T) {
+		formula, err := ParseTypeFormula("u64")
+		require.NoError(t, err)
+		require.Equal(t, &TypeFormula{Name: "u64", TypeParameters: []*TypeFormula{}}, formula)
+	})
+
+	t.Run("type with spaces", func(t *testing.T) {
+		formula, err := ParseTypeFormula("utf-8 string")
+		require.NoError(t, err)
+		require.Equal(t, "utf-8 string", formula.Name)
+	})
+
+	t.Run("nested generic types", func(t *testing.T) {
+		formula, err := ParseTypeFormula("variadic<multi<BigUint, List<Option<u64>>>>")
+		require.NoError(t, err)
+		require.Equal(t, "variadic<multi<BigUint,List<Option<u64>>>>", formula.String())
+		require.Equal(t, "multi", formula.TypeParameters[0].Name)
+		require.Len(t, formula.TypeParameters[0].TypeParameters, 2)
+	})
+
+	t.Run("should err on missing closing bracket", func(t *testing.T) {
+		_, err := ParseTypeFormula("List<u8")
+		require.ErrorContains(t, err, "missing '>' for type 'List'")
+	})
+
+	t.Run("should err on missing type name", func(t *testing.T) {
+		_, err := ParseTypeFormula("List<>")
+		require.ErrorContains(t, err, "missing type name at position 5")
+	})
+
+	t.Run("should err on trailing characters", func(t *testing.T) {
+		_, err := ParseTypeFormula("List<u8>>")
+		require.ErrorContains(t, err, "unexpected character at position 8")
+	})
+}
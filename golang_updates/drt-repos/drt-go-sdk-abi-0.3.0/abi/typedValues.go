@@ -0,0 +1,281 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// TypedValue couples a plain Go value with its ABI type expression (e.g. "variadic<multi<Address,BigUint>>")
+type TypedValue struct {
+	Value any
+	Type  string
+}
+
+// BindArguments binds plain Go values to ABI values (single values or multi-values), according to their type expressions.
+// The results can be passed to the serializer, for encoding. Multi-values are mapped as follows:
+//   - "optional<T>" to a pointer (nil meaning "missing")
+//   - "variadic<T>" and "counted-variadic<T>" to a slice
+//   - "multi<T1,T2,...>" to a struct, field by field (only exported fields are considered)
+func BindArguments(arguments []TypedValue) ([]any, error) {
+	values := make([]any, 0, len(arguments))
+
+	for i, argument := range arguments {
+		if argument.Value == nil {
+			return nil, fmt.Errorf("cannot bind argument %d: value is nil", i)
+		}
+
+		formula, err := ParseTypeFormula(argument.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot bind argument %d: %w", i, err)
+		}
+
+		// Make an addressable copy, since some bindings require addressable values.
+		reflected := reflect.ValueOf(argument.Value)
+		addressable := reflect.New(reflected.Type()).Elem()
+		addressable.Set(reflected)
+
+		value, err := bindArgument(addressable, formula)
+		if err != nil {
+			return nil, fmt.Errorf("cannot bind argument %d: %w", i, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// BindResults binds plain Go destinations (pointers) to ABI placeholders, according to their type expressions.
+// The results can be passed to the serializer, for decoding. The mapping of multi-values is the one of BindArguments.
+// Destinations are reset (to their zero values) before binding.
+func BindResults(destinations []TypedValue) ([]any, error) {
+	values := make([]any, 0, len(destinations))
+
+	for i, destination := range destinations {
+		reflected := reflect.ValueOf(destination.Value)
+		if destination.Value == nil || reflected.Kind() != reflect.Ptr || reflected.IsNil() {
+			return nil, fmt.Errorf("cannot bind result %d: destination must be a non-nil pointer", i)
+		}
+
+		formula, err := ParseTypeFormula(destination.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot bind result %d: %w", i, err)
+		}
+
+		target := reflected.Elem()
+		target.Set(reflect.Zero(target.Type()))
+
+		value, err := bindResult(func() reflect.Value { return target }, target.Type(), formula)
+		if err != nil {
+			return nil, fmt.Errorf("cannot bind result %d: %w", i, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func bindArgument(value reflect.Value, formula *TypeFormula) (any, error) {
+	switch formula.Name {
+	case "optional":
+		err := checkKind(value.Type(), reflect.Ptr, formula)
+		if err != nil {
+			return nil, err
+		}
+
+		if value.IsNil() {
+			return &OptionalValue{}, nil
+		}
+
+		inner, err := bindArgument(value.Elem(), formula.TypeParameters[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return &OptionalValue{Value: inner}, nil
+	case "variadic", "counted-variadic":
+		err := checkKind(value.Type(), reflect.Slice, formula)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := bindArgument(value.Index(i), formula.TypeParameters[0])
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		if formula.Name == "counted-variadic" {
+			return &CountedVariadicValues{Items: items}, nil
+		}
+
+		return &VariadicValues{Items: items}, nil
+	case "multi":
+		fieldsIndexes, err := getMultiValueFieldsIndexes(value.Type(), formula)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]any, 0, len(fieldsIndexes))
+		for i, fieldIndex := range fieldsIndexes {
+			item, err := bindArgument(value.Field(fieldIndex), formula.TypeParameters[i])
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return &MultiValue{Items: items}, nil
+	default:
+		return &reflectedValue{value: value, formula: formula}, nil
+	}
+}
+
+// bindResult binds a destination, given as a "target" function, so that pointers (for optional values)
+// are only allocated (and slices, for variadic values, only extended) if there is actual data to decode.
+func bindResult(target func() reflect.Value, goType reflect.Type, formula *TypeFormula) (any, error) {
+	switch formula.Name {
+	case "optional":
+		err := checkKind(goType, reflect.Ptr, formula)
+		if err != nil {
+			return nil, err
+		}
+
+		innerTarget := func() reflect.Value {
+			pointer := target()
+			if pointer.IsNil() {
+				pointer.Set(reflect.New(goType.Elem()))
+			}
+
+			return pointer.Elem()
+		}
+
+		inner, err := bindResult(innerTarget, goType.Elem(), formula.TypeParameters[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return &OptionalValue{Value: inner}, nil
+	case "variadic", "counted-variadic":
+		err := checkKind(goType, reflect.Slice, formula)
+		if err != nil {
+			return nil, err
+		}
+
+		itemType := goType.Elem()
+		itemFormula := formula.TypeParameters[0]
+
+		// Check the item type upfront, since the item creator cannot return errors.
+		_, err = bindResult(func() reflect.Value { return reflect.New(itemType).Elem() }, itemType, itemFormula)
+		if err != nil {
+			return nil, err
+		}
+
+		itemCreator := func() any {
+			slice := target()
+			index := slice.Len()
+			slice.Set(reflect.Append(slice, reflect.Zero(itemType)))
+
+			// The binding has already been checked, thus no error is expected here.
+			item, _ := bindResult(func() reflect.Value { return target().Index(index) }, itemType, itemFormula)
+			return item
+		}
+
+		if formula.Name == "counted-variadic" {
+			return &CountedVariadicValues{Items: []any{}, ItemCreator: itemCreator}, nil
+		}
+
+		return &VariadicValues{Items: []any{}, ItemCreator: itemCreator}, nil
+	case "multi":
+		fieldsIndexes, err := getMultiValueFieldsIndexes(goType, formula)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]any, 0, len(fieldsIndexes))
+		for i, fieldIndex := range fieldsIndexes {
+			index := fieldIndex
+			itemTarget := func() reflect.Value { return target().Field(index) }
+
+			item, err := bindResult(itemTarget, goType.Field(fieldIndex).Type, formula.TypeParameters[i])
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return &MultiValue{Items: items}, nil
+	default:
+		return &deferredValue{target: target, formula: formula}, nil
+	}
+}
+
+func checkKind(goType reflect.Type, expectedKind reflect.Kind, formula *TypeFormula) error {
+	if goType.Kind() != expectedKind {
+		return fmt.Errorf("ABI type '%s' requires a Go %s, but got %s", formula, expectedKind, goType)
+	}
+
+	if len(formula.TypeParameters) != 1 {
+		return fmt.Errorf("type '%s' must have exactly one type parameter", formula.Name)
+	}
+
+	return nil
+}
+
+func getMultiValueFieldsIndexes(goType reflect.Type, formula *TypeFormula) ([]int, error) {
+	if goType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ABI type '%s' requires a Go struct, but got %s", formula, goType)
+	}
+
+	indexes := make([]int, 0, goType.NumField())
+	for i := 0; i < goType.NumField(); i++ {
+		if goType.Field(i).IsExported() {
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(indexes) != len(formula.TypeParameters) {
+		return nil, fmt.Errorf("ABI type '%s' requires a Go struct with %d (exported) fields, but got %s", formula, len(formula.TypeParameters), goType)
+	}
+
+	return indexes, nil
+}
+
+// deferredValue is a single value whose destination (Go value) is resolved (thus, allocated, if necessary) only when decoding
+type deferredValue struct {
+	target  func() reflect.Value
+	formula *TypeFormula
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *deferredValue) EncodeNested(_ io.Writer) error {
+	return errors.New("cannot encode a value bound for decoding")
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *deferredValue) EncodeTopLevel(_ io.Writer) error {
+	return errors.New("cannot encode a value bound for decoding")
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *deferredValue) DecodeNested(reader io.Reader) error {
+	return value.resolve().DecodeNested(reader)
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *deferredValue) DecodeTopLevel(data []byte) error {
+	return value.resolve().DecodeTopLevel(data)
+}
+
+func (value *deferredValue) resolve() *reflectedValue {
+	return &reflectedValue{value: value.target(), formula: value.formula}
+}
@@ -0,0 +1,184 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRange struct {
+	Start uint32
+	End   uint32
+}
+
+type testTransfer struct {
+	Token  string
+	Amount *big.Int
+}
+
+func TestBindArguments(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("single values and optional (provided)", func(t *testing.T) {
+		values, err := BindArguments([]TypedValue{
+			{Value: uint8(0x42), Type: "u8"},
+			{Value: &testRange{Start: 1, End: 2}, Type: "optional<multi<u32,u32>>"},
+		})
+		require.NoError(t, err)
+
+		data, err := serializer.Serialize(values)
+		require.NoError(t, err)
+		require.Equal(t, "42@01@02", data)
+	})
+
+	t.Run("optional (missing)", func(t *testing.T) {
+		var missing *testRange
+		values, err := BindArguments([]TypedValue{
+			{Value: uint8(0x42), Type: "u8"},
+			{Value: missing, Type: "optional<multi<u32,u32>>"},
+		})
+		require.NoError(t, err)
+
+		data, err := serializer.Serialize(values)
+		require.NoError(t, err)
+		require.Equal(t, "42", data)
+	})
+
+	t.Run("variadic<multi<TokenIdentifier,BigUint>>", func(t *testing.T) {
+		values, err := BindArguments([]TypedValue{
+			{
+				Value: []testTransfer{
+					{Token: "A", Amount: big.NewInt(1)},
+					{Token: "B", Amount: big.NewInt(2)},
+				},
+				Type: "variadic<multi<TokenIdentifier,BigUint>>",
+			},
+		})
+		require.NoError(t, err)
+
+		data, err := serializer.Serialize(values)
+		require.NoError(t, err)
+		require.Equal(t, "41@01@42@02", data)
+	})
+
+	t.Run("counted-variadic<multi<TokenIdentifier,BigUint>>, u8", func(t *testing.T) {
+		values, err := BindArguments([]TypedValue{
+			{
+				Value: []testTransfer{{Token: "A", Amount: big.NewInt(1)}},
+				Type:  "counted-variadic<multi<TokenIdentifier,BigUint>>",
+			},
+			{Value: uint8(0x42), Type: "u8"},
+		})
+		require.NoError(t, err)
+
+		data, err := serializer.Serialize(values)
+		require.NoError(t, err)
+		require.Equal(t, "01@41@01@42", data)
+	})
+
+	t.Run("should err on kind mismatch", func(t *testing.T) {
+		_, err := BindArguments([]TypedValue{{Value: uint8(1), Type: "variadic<u8>"}})
+		require.ErrorContains(t, err, "cannot bind argument 0: ABI type 'variadic<u8>' requires a Go slice, but got uint8")
+
+		_, err = BindArguments([]TypedValue{{Value: testRange{}, Type: "multi<u32,u32,u32>"}})
+		require.ErrorContains(t, err, "requires a Go struct with 3 (exported) fields")
+	})
+}
+
+func TestBindResults(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should err on non-pointer destination", func(t *testing.T) {
+		_, err := BindResults([]TypedValue{{Value: uint8(0), Type: "u8"}})
+		require.ErrorContains(t, err, "cannot bind result 0: destination must be a non-nil pointer")
+	})
+
+	t.Run("single value and optional (provided)", func(t *testing.T) {
+		var first uint8
+		var second *testRange
+
+		values, err := BindResults([]TypedValue{
+			{Value: &first, Type: "u8"},
+			{Value: &second, Type: "optional<multi<u32,u32>>"},
+		})
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("42@01@02", values)
+		require.NoError(t, err)
+		require.Equal(t, uint8(0x42), first)
+		require.Equal(t, &testRange{Start: 1, End: 2}, second)
+	})
+
+	t.Run("optional (missing)", func(t *testing.T) {
+		var first uint8
+		second := &testRange{Start: 7}
+
+		values, err := BindResults([]TypedValue{
+			{Value: &first, Type: "u8"},
+			{Value: &second, Type: "optional<multi<u32,u32>>"},
+		})
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("42", values)
+		require.NoError(t, err)
+		require.Nil(t, second)
+	})
+
+	t.Run("variadic<multi<TokenIdentifier,BigUint>>", func(t *testing.T) {
+		transfers := []testTransfer{{Token: "previous"}}
+
+		values, err := BindResults([]TypedValue{
+			{Value: &transfers, Type: "variadic<multi<TokenIdentifier,BigUint>>"},
+		})
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("41@01@42@02@43@03", values)
+		require.NoError(t, err)
+		require.Equal(t, []testTransfer{
+			{Token: "A", Amount: big.NewInt(1)},
+			{Token: "B", Amount: big.NewInt(2)},
+			{Token: "C", Amount: big.NewInt(3)},
+		}, transfers)
+	})
+
+	t.Run("counted-variadic<multi<TokenIdentifier,BigUint>>, u8", func(t *testing.T) {
+		var transfers []testTransfer
+		var last uint8
+
+		values, err := BindResults([]TypedValue{
+			{Value: &transfers, Type: "counted-variadic<multi<TokenIdentifier,BigUint>>"},
+			{Value: &last, Type: "u8"},
+		})
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("02@41@01@42@02@2a", values)
+		require.NoError(t, err)
+		require.Equal(t, []testTransfer{
+			{Token: "A", Amount: big.NewInt(1)},
+			{Token: "B", Amount: big.NewInt(2)},
+		}, transfers)
+		require.Equal(t, uint8(42), last)
+	})
+
+	t.Run("variadic<List<Address>>", func(t *testing.T) {
+		var lists [][][]byte
+
+		values, err := BindResults([]TypedValue{{Value: &lists, Type: "variadic<List<Address>>"}})
+		require.NoError(t, err)
+
+		err = serializer.Deserialize("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1@", values)
+		require.NoError(t, err)
+		require.Len(t, lists, 2)
+		require.Len(t, lists[0], 1)
+		require.Len(t, lists[1], 0)
+	})
+
+	t.Run("should err on kind mismatch", func(t *testing.T) {
+		var destination []uint8
+		_, err := BindResults([]TypedValue{{Value: &destination, Type: "variadic<multi<u8,u8>>"}})
+		require.ErrorContains(t, err, "ABI type 'multi<u8,u8>' requires a Go struct, but got uint8")
+	})
+}
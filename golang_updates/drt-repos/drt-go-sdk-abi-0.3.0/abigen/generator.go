@@ -0,0 +1,723 @@
+package abigen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/TerraDharitri/drt-go-sdk-abi/abi"
+)
+
+const abiPackagePath = "github.com/TerraDharitri/drt-go-sdk-abi/abi"
+
+// ArgsNewGenerator defines the arguments needed for a new generator
+type ArgsNewGenerator struct {
+	PackageName string
+}
+
+// generator emits Go code (types and a typed client) out of a contract's ABI
+type generator struct {
+	packageName string
+}
+
+// generation holds the state of a single generation
+type generation struct {
+	definition *abi.AbiDefinition
+	buffer     *bytes.Buffer
+	imports    map[string]struct{}
+	multiTypes map[string]*abi.TypeFormula
+}
+
+// NewGenerator creates a new generator
+func NewGenerator(args ArgsNewGenerator) (*generator, error) {
+	if !isValidPackageName(args.PackageName) {
+		return nil, fmt.Errorf("cannot create generator: invalid package name '%s'", args.PackageName)
+	}
+
+	return &generator{
+		packageName: args.PackageName,
+	}, nil
+}
+
+// Generate generates the (formatted) Go code for the given ABI definition
+func (g *generator) Generate(definition *abi.AbiDefinition) ([]byte, error) {
+	if definition == nil {
+		return nil, errors.New("cannot generate code: definition is nil")
+	}
+
+	// Validates all type expressions found in the definition.
+	_, err := abi.NewAbiRegistry(definition)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate code: %w", err)
+	}
+
+	gen := &generation{
+		definition: definition,
+		buffer:     bytes.NewBuffer(nil),
+		imports: map[string]struct{}{
+			"encoding/hex": {},
+			"fmt":          {},
+			"strings":      {},
+			abiPackagePath: {},
+		},
+		multiTypes: make(map[string]*abi.TypeFormula),
+	}
+
+	err = gen.generateBody()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate code: %w", err)
+	}
+
+	// The header (package clause and imports) is written last, since the imports are only known at the end.
+	body := gen.buffer.Bytes()
+	gen.buffer = bytes.NewBuffer(nil)
+	gen.writeHeader(g.packageName)
+	gen.buffer.Write(body)
+
+	formatted, err := format.Source(gen.buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+func (gen *generation) writeHeader(packageName string) {
+	gen.printf("// Code generated by abigen, from the ABI of the contract \"%s\". DO NOT EDIT.\n\n", gen.definition.Name)
+	gen.printf("package %s\n\n", packageName)
+
+	imports := make([]string, 0, len(gen.imports))
+	for path := range gen.imports {
+		if path != abiPackagePath {
+			imports = append(imports, path)
+		}
+	}
+	sort.Strings(imports)
+
+	gen.printf("import (\n")
+	for _, path := range imports {
+		gen.printf("\t%q\n", path)
+	}
+	gen.printf("\n\t%q\n", abiPackagePath)
+	gen.printf(")\n\n")
+}
+
+func (gen *generation) generateBody() error {
+	err := gen.generateCustomTypes()
+	if err != nil {
+		return err
+	}
+
+	err = gen.generateEvents()
+	if err != nil {
+		return err
+	}
+
+	err = gen.generateClient()
+	if err != nil {
+		return err
+	}
+
+	return gen.generateMultiTypes()
+}
+
+func (gen *generation) generateCustomTypes() error {
+	names := make([]string, 0, len(gen.definition.Types))
+	for name := range gen.definition.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		customType := gen.definition.Types[name]
+
+		var err error
+		switch {
+		case customType.Type == "struct":
+			err = gen.generateStruct(name, customType)
+		case isSimpleEnum(customType):
+			gen.generateSimpleEnum(name, customType)
+		default:
+			err = gen.generateEnum(name, customType)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (gen *generation) generateStruct(name string, customType *abi.TypeDefinition) error {
+	goName := toExportedName(name)
+
+	gen.writeDocs(customType.Docs)
+	gen.printf("// %s is the Go representation of the struct \"%s\"\n", goName, name)
+	gen.printf("type %s struct {\n", goName)
+
+	err := gen.writeStructFields(customType.Fields)
+	if err != nil {
+		return fmt.Errorf("bad type '%s': %w", name, err)
+	}
+
+	gen.printf("}\n\n")
+	return nil
+}
+
+func (gen *generation) writeStructFields(fields []*abi.FieldDefinition) error {
+	for _, field := range fields {
+		goType, err := gen.goTypeOf(field.Type)
+		if err != nil {
+			return err
+		}
+
+		gen.printf("\t%s %s `abi:\"%s,type=%s\"`\n", toExportedName(field.Name), goType, field.Name, field.Type)
+	}
+
+	return nil
+}
+
+// generateSimpleEnum handles enums without fields, which are represented as (named) uint8 values
+func (gen *generation) generateSimpleEnum(name string, customType *abi.TypeDefinition) {
+	goName := toExportedName(name)
+
+	gen.writeDocs(customType.Docs)
+	gen.printf("// %s is the Go representation of the enum \"%s\"\n", goName, name)
+	gen.printf("type %s uint8\n\n", goName)
+
+	gen.printf("// Variants of the enum \"%s\"\n", name)
+	gen.printf("const (\n")
+	for _, variant := range customType.Variants {
+		gen.printf("\t%s%s %s = %d\n", goName, toExportedName(variant.Name), goName, variant.Discriminant)
+	}
+	gen.printf(")\n\n")
+}
+
+// generateEnum handles enums with fields, which are represented as structs (a discriminant and a pointer for each variant with fields)
+func (gen *generation) generateEnum(name string, customType *abi.TypeDefinition) error {
+	goName := toExportedName(name)
+	gen.imports["io"] = struct{}{}
+
+	gen.writeDocs(customType.Docs)
+	gen.printf("// %s is the Go representation of the enum \"%s\".\n", goName, name)
+	gen.printf("// Only the variant designated by the discriminant is considered (when encoding) or set (when decoding).\n")
+	gen.printf("type %s struct {\n", goName)
+	gen.printf("\tDiscriminant uint8\n")
+	for _, variant := range customType.Variants {
+		if len(variant.Fields) > 0 {
+			variantName := toExportedName(variant.Name)
+			gen.printf("\t%s *%s%sVariant\n", variantName, goName, variantName)
+		}
+	}
+	gen.printf("}\n\n")
+
+	gen.printf("// Discriminants of the enum \"%s\"\n", name)
+	gen.printf("const (\n")
+	for _, variant := range customType.Variants {
+		gen.printf("\t%s%s uint8 = %d\n", goName, toExportedName(variant.Name), variant.Discriminant)
+	}
+	gen.printf(")\n\n")
+
+	for _, variant := range customType.Variants {
+		if len(variant.Fields) == 0 {
+			continue
+		}
+
+		variantName := toExportedName(variant.Name)
+		gen.printf("// %s%sVariant holds the fields of the variant \"%s\" of the enum \"%s\"\n", goName, variantName, variant.Name, name)
+		gen.printf("type %s%sVariant struct {\n", goName, variantName)
+
+		err := gen.writeStructFields(variant.Fields)
+		if err != nil {
+			return fmt.Errorf("bad variant '%s' of type '%s': %w", variant.Name, name, err)
+		}
+
+		gen.printf("}\n\n")
+	}
+
+	gen.printf(`// EncodeNested encodes the value in the nested form
+func (value *%[1]s) EncodeNested(writer io.Writer) error {
+	return value.toEnumValue().EncodeNested(writer)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *%[1]s) EncodeTopLevel(writer io.Writer) error {
+	return value.toEnumValue().EncodeTopLevel(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *%[1]s) DecodeNested(reader io.Reader) error {
+	enumValue := value.toEnumValue()
+	err := enumValue.DecodeNested(reader)
+	value.Discriminant = enumValue.Discriminant
+	return err
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *%[1]s) DecodeTopLevel(data []byte) error {
+	enumValue := value.toEnumValue()
+	err := enumValue.DecodeTopLevel(data)
+	value.Discriminant = enumValue.Discriminant
+	return err
+}
+
+func (value *%[1]s) toEnumValue() *abi.EnumValue {
+	return &abi.EnumValue{
+		Discriminant:   value.Discriminant,
+		Fields:         value.getVariantFields(value.Discriminant),
+		FieldsProvider: value.getVariantFields,
+	}
+}
+
+`, goName)
+
+	gen.printf("func (value *%s) getVariantFields(discriminant uint8) []abi.Field {\n", goName)
+	gen.printf("\tswitch discriminant {\n")
+	for _, variant := range customType.Variants {
+		if len(variant.Fields) == 0 {
+			continue
+		}
+
+		variantName := toExportedName(variant.Name)
+		gen.printf("\tcase %s%s:\n", goName, variantName)
+		gen.printf("\t\tif value.%s == nil {\n", variantName)
+		gen.printf("\t\t\tvalue.%s = &%s%sVariant{}\n", variantName, goName, variantName)
+		gen.printf("\t\t}\n\n")
+		gen.printf("\t\treturn bindVariant(%q, value.%s)\n", variant.Name, variantName)
+	}
+	gen.printf("\tdefault:\n")
+	gen.printf("\t\treturn []abi.Field{}\n")
+	gen.printf("\t}\n")
+	gen.printf("}\n\n")
+
+	return nil
+}
+
+func (gen *generation) generateEvents() error {
+	for _, event := range gen.definition.Events {
+		goName := toExportedName(event.Identifier) + "Event"
+
+		gen.writeDocs(event.Docs)
+		gen.printf("// %s is the Go representation of the event \"%s\"\n", goName, event.Identifier)
+		gen.printf("type %s struct {\n", goName)
+
+		for _, input := range event.Inputs {
+			goType, err := gen.goTypeOf(input.Type)
+			if err != nil {
+				return fmt.Errorf("bad event '%s': %w", event.Identifier, err)
+			}
+
+			gen.printf("\t%s %s\n", toExportedName(input.Name), goType)
+		}
+
+		gen.printf("}\n\n")
+	}
+
+	return nil
+}
+
+func (gen *generation) generateClient() error {
+	gen.printf(`// partsSerializer is the subset of the ABI serializer used by the client
+type partsSerializer interface {
+	SerializeToParts(inputValues []any) ([][]byte, error)
+	DeserializeParts(parts [][]byte, outputValues []any) error
+}
+
+// Client is a typed client of the contract "%[1]s": it builds call data and decodes return data and events
+type Client struct {
+	serializer partsSerializer
+}
+
+// NewClient creates a new typed client of the contract "%[1]s"
+func NewClient() (*Client, error) {
+	serializer, err := abi.NewSerializer(abi.ArgsNewSerializer{
+		PartsSeparator: "@",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		serializer: serializer,
+	}, nil
+}
+
+`, gen.definition.Name)
+
+	if gen.definition.Constructor != nil {
+		err := gen.generateArgumentsEncoder("EncodeConstructorArguments", "the constructor", gen.definition.Constructor)
+		if err != nil {
+			return err
+		}
+	}
+
+	if gen.definition.UpgradeConstructor != nil {
+		err := gen.generateArgumentsEncoder("EncodeUpgradeConstructorArguments", "the upgrade constructor", gen.definition.UpgradeConstructor)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, endpoint := range gen.definition.Endpoints {
+		err := gen.generateEndpoint(endpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range gen.definition.Events {
+		err := gen.generateEventDecoder(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	gen.generateClientHelpers()
+	return nil
+}
+
+func (gen *generation) generateArgumentsEncoder(functionName string, description string, endpoint *abi.EndpointDefinition) error {
+	parameters, typedValues, err := gen.describeInputs(endpoint)
+	if err != nil {
+		return fmt.Errorf("bad inputs of %s: %w", description, err)
+	}
+
+	gen.printf("// %s encodes the arguments of %s (to be appended to the deployment or upgrade data)\n", functionName, description)
+	gen.printf("func (client *Client) %s(%s) ([][]byte, error) {\n", functionName, parameters)
+	gen.printf("\treturn client.encodeArguments([]abi.TypedValue{\n%s\t})\n", typedValues)
+	gen.printf("}\n\n")
+
+	return nil
+}
+
+func (gen *generation) generateEndpoint(endpoint *abi.EndpointDefinition) error {
+	goName := toExportedName(endpoint.Name)
+
+	parameters, typedValues, err := gen.describeInputs(endpoint)
+	if err != nil {
+		return fmt.Errorf("bad inputs of endpoint '%s': %w", endpoint.Name, err)
+	}
+
+	gen.writeDocs(endpoint.Docs)
+	gen.printf("// Build%sCall builds the call data of the endpoint \"%s\"\n", goName, endpoint.Name)
+	gen.printf("func (client *Client) Build%sCall(%s) (string, error) {\n", goName, parameters)
+	gen.printf("\treturn client.buildCall(%q, []abi.TypedValue{\n%s\t})\n", endpoint.Name, typedValues)
+	gen.printf("}\n\n")
+
+	if len(endpoint.Outputs) == 0 {
+		return nil
+	}
+
+	resultTypes := make([]string, 0, len(endpoint.Outputs))
+	resultNames := make([]string, 0, len(endpoint.Outputs))
+	declarations := strings.Builder{}
+	destinations := strings.Builder{}
+
+	for i, output := range endpoint.Outputs {
+		goType, err := gen.goTypeOf(output.Type)
+		if err != nil {
+			return fmt.Errorf("bad outputs of endpoint '%s': %w", endpoint.Name, err)
+		}
+
+		resultName := fmt.Sprintf("result%d", i)
+		resultTypes = append(resultTypes, goType)
+		resultNames = append(resultNames, resultName)
+		declarations.WriteString(fmt.Sprintf("\tvar %s %s\n", resultName, goType))
+		destinations.WriteString(fmt.Sprintf("\t\t{Value: &%s, Type: %q},\n", resultName, output.Type))
+	}
+
+	gen.printf("// Decode%sResults decodes the return data of the endpoint \"%s\"\n", goName, endpoint.Name)
+	gen.printf("func (client *Client) Decode%sResults(returnData [][]byte) (%s, error) {\n", goName, strings.Join(resultTypes, ", "))
+	gen.printf("%s\n", declarations.String())
+	gen.printf("\terr := client.decodeParts(returnData, []abi.TypedValue{\n%s\t})\n", destinations.String())
+	gen.printf("\treturn %s, err\n", strings.Join(resultNames, ", "))
+	gen.printf("}\n\n")
+
+	return nil
+}
+
+func (gen *generation) generateEventDecoder(event *abi.EventDefinition) error {
+	goName := toExportedName(event.Identifier) + "Event"
+
+	topics := strings.Builder{}
+	dataParts := strings.Builder{}
+
+	for _, input := range event.Inputs {
+		line := fmt.Sprintf("\t\t{Value: &event.%s, Type: %q},\n", toExportedName(input.Name), input.Type)
+
+		if input.Indexed {
+			topics.WriteString(line)
+		} else {
+			dataParts.WriteString(line)
+		}
+	}
+
+	gen.printf("// Decode%s decodes the event \"%s\", given the topics (the first one being the event identifier) and the data parts of a log event\n", goName, event.Identifier)
+	gen.printf("func (client *Client) Decode%s(topics [][]byte, dataParts [][]byte) (*%s, error) {\n", goName, goName)
+	gen.printf("\tif len(topics) == 0 || string(topics[0]) != %q {\n", event.Identifier)
+	gen.printf("\t\treturn nil, fmt.Errorf(\"cannot decode event %%s: unexpected identifier\", %q)\n", event.Identifier)
+	gen.printf("\t}\n\n")
+	gen.printf("\tevent := &%s{}\n\n", goName)
+	gen.printf("\terr := client.decodeParts(topics[1:], []abi.TypedValue{\n%s\t})\n", topics.String())
+	gen.printf("\tif err != nil {\n\t\treturn nil, fmt.Errorf(\"cannot decode topics of event %%s: %%w\", %q, err)\n\t}\n\n", event.Identifier)
+	gen.printf("\terr = client.decodeParts(dataParts, []abi.TypedValue{\n%s\t})\n", dataParts.String())
+	gen.printf("\tif err != nil {\n\t\treturn nil, fmt.Errorf(\"cannot decode data of event %%s: %%w\", %q, err)\n\t}\n\n", event.Identifier)
+	gen.printf("\treturn event, nil\n")
+	gen.printf("}\n\n")
+
+	return nil
+}
+
+func (gen *generation) generateClientHelpers() {
+	gen.printf(`func (client *Client) buildCall(functionName string, arguments []abi.TypedValue) (string, error) {
+	parts, err := client.encodeArguments(arguments)
+	if err != nil {
+		return "", fmt.Errorf("cannot build call of %%s: %%w", functionName, err)
+	}
+
+	callData := strings.Builder{}
+	callData.WriteString(functionName)
+
+	for _, part := range parts {
+		callData.WriteString("@")
+		callData.WriteString(hex.EncodeToString(part))
+	}
+
+	return callData.String(), nil
+}
+
+func (client *Client) encodeArguments(arguments []abi.TypedValue) ([][]byte, error) {
+	values, err := abi.BindArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.serializer.SerializeToParts(values)
+}
+
+func (client *Client) decodeParts(parts [][]byte, destinations []abi.TypedValue) error {
+	values, err := abi.BindResults(destinations)
+	if err != nil {
+		return err
+	}
+
+	return client.serializer.DeserializeParts(parts, values)
+}
+
+`)
+
+	if gen.hasEnumsWithFields() {
+		gen.printf(`func bindVariant(name string, variant any) []abi.Field {
+	// Binding a non-nil pointer never fails.
+	value, _ := abi.Bind(variant)
+
+	return []abi.Field{
+		{
+			Name:  name,
+			Value: value,
+		},
+	}
+}
+
+`)
+	}
+}
+
+// generateMultiTypes generates the structs corresponding to the "multi<...>" types encountered so far
+func (gen *generation) generateMultiTypes() error {
+	// All multi types are known at this point, since goTypeOfFormula() visits the items of a multi type upon registering it.
+	names := make([]string, 0, len(gen.multiTypes))
+	for name := range gen.multiTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		formula := gen.multiTypes[name]
+
+		gen.printf("// %s is the Go representation of \"%s\"\n", name, formula)
+		gen.printf("type %s struct {\n", name)
+		for i, parameter := range formula.TypeParameters {
+			goType, err := gen.goTypeOfFormula(parameter)
+			if err != nil {
+				return err
+			}
+
+			gen.printf("\tItem%d %s\n", i, goType)
+		}
+		gen.printf("}\n\n")
+	}
+
+	return nil
+}
+
+// describeInputs returns the parameters list (Go code) and the typed values (Go code), for the inputs of an endpoint
+func (gen *generation) describeInputs(endpoint *abi.EndpointDefinition) (string, string, error) {
+	parameters := make([]string, 0, len(endpoint.Inputs))
+	typedValues := strings.Builder{}
+
+	for i, input := range endpoint.Inputs {
+		goType, err := gen.goTypeOf(input.Type)
+		if err != nil {
+			return "", "", err
+		}
+
+		parameterName := toParameterName(input.Name, i)
+		parameters = append(parameters, fmt.Sprintf("%s %s", parameterName, goType))
+		typedValues.WriteString(fmt.Sprintf("\t\t{Value: %s, Type: %q},\n", parameterName, input.Type))
+	}
+
+	return strings.Join(parameters, ", "), typedValues.String(), nil
+}
+
+func (gen *generation) goTypeOf(typeExpression string) (string, error) {
+	formula, err := abi.ParseTypeFormula(typeExpression)
+	if err != nil {
+		return "", err
+	}
+
+	return gen.goTypeOfFormula(formula)
+}
+
+// goTypeOfFormula maps an ABI type to a Go type, following the conventions of abi.Bind() and abi.BindArguments()
+func (gen *generation) goTypeOfFormula(formula *abi.TypeFormula) (string, error) {
+	switch formula.Name {
+	case "u8":
+		return "uint8", nil
+	case "u16":
+		return "uint16", nil
+	case "u32", "usize":
+		return "uint32", nil
+	case "u64":
+		return "uint64", nil
+	case "i8":
+		return "int8", nil
+	case "i16":
+		return "int16", nil
+	case "i32", "isize":
+		return "int32", nil
+	case "i64":
+		return "int64", nil
+	case "BigUint", "BigInt":
+		gen.imports["math/big"] = struct{}{}
+		return "*big.Int", nil
+	case "bool":
+		return "bool", nil
+	case "bytes", "BoxedBytes", "ManagedBuffer", "Address", "ManagedAddress", "CodeMetadata":
+		return "[]byte", nil
+	case "utf-8 string", "String", "TokenIdentifier", "RewaOrDcdtTokenIdentifier":
+		return "string", nil
+	case "ManagedDecimal":
+		return "abi.ManagedDecimalValue", nil
+	case "ManagedDecimalSigned":
+		return "abi.ManagedDecimalSignedValue", nil
+	case "List", "variadic", "counted-variadic":
+		itemType, err := gen.goTypeOfFormula(formula.TypeParameters[0])
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + itemType, nil
+	case "Option", "optional":
+		itemType, err := gen.goTypeOfFormula(formula.TypeParameters[0])
+		if err != nil {
+			return "", err
+		}
+
+		return "*" + itemType, nil
+	case "multi", "tuple":
+		name := toMultiTypeName(formula)
+		gen.multiTypes[name] = formula
+
+		// Make sure all item types are known.
+		for _, parameter := range formula.TypeParameters {
+			_, err := gen.goTypeOfFormula(parameter)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		return name, nil
+	}
+
+	length, isArray := toArrayLength(formula.Name)
+	if isArray {
+		itemType, err := gen.goTypeOfFormula(formula.TypeParameters[0])
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("[%d]%s", length, itemType), nil
+	}
+
+	_, ok := gen.definition.Types[formula.Name]
+	if !ok {
+		return "", fmt.Errorf("unsupported type: %s", formula.Name)
+	}
+
+	return toExportedName(formula.Name), nil
+}
+
+func (gen *generation) hasEnumsWithFields() bool {
+	for _, customType := range gen.definition.Types {
+		if customType.Type == "enum" && !isSimpleEnum(customType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (gen *generation) writeDocs(docs []string) {
+	for _, line := range docs {
+		gen.printf("// %s\n", strings.TrimSpace(line))
+	}
+
+	if len(docs) > 0 {
+		gen.printf("//\n")
+	}
+}
+
+func (gen *generation) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(gen.buffer, format, args...)
+}
+
+func toMultiTypeName(formula *abi.TypeFormula) string {
+	name := toExportedName(formula.Name)
+	for _, parameter := range formula.TypeParameters {
+		name += toMultiTypeName(parameter)
+	}
+
+	return name
+}
+
+func isSimpleEnum(customType *abi.TypeDefinition) bool {
+	for _, variant := range customType.Variants {
+		if len(variant.Fields) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidPackageName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		isLetter := (r >= 'a' && r <= 'z') || r == '_'
+		isDigit := r >= '0' && r <= '9'
+
+		if !isLetter && !(isDigit && i > 0) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,132 @@
+package abigen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/TerraDharitri/drt-go-sdk-abi/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerator(t *testing.T) {
+	_, err := NewGenerator(ArgsNewGenerator{PackageName: ""})
+	require.ErrorContains(t, err, "cannot create generator: invalid package name ''")
+
+	_, err = NewGenerator(ArgsNewGenerator{PackageName: "My-Package"})
+	require.ErrorContains(t, err, "cannot create generator: invalid package name 'My-Package'")
+
+	_, err = NewGenerator(ArgsNewGenerator{PackageName: "adder2"})
+	require.NoError(t, err)
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	generator, err := NewGenerator(ArgsNewGenerator{PackageName: "multisig"})
+	require.NoError(t, err)
+
+	t.Run("should err on nil definition", func(t *testing.T) {
+		_, err := generator.Generate(nil)
+		require.ErrorContains(t, err, "cannot generate code: definition is nil")
+	})
+
+	t.Run("should err on bad definition", func(t *testing.T) {
+		_, err := generator.Generate(&abi.AbiDefinition{
+			Endpoints: []*abi.EndpointDefinition{
+				{Name: "foo", Inputs: []*abi.ParameterDefinition{{Name: "a", Type: "Foobar"}}},
+			},
+		})
+		require.ErrorContains(t, err, "unknown type: Foobar")
+	})
+
+	t.Run("should generate the (committed) multisig client", func(t *testing.T) {
+		// The generated code is committed (see "internal/multisig"), so that it is compiled and tested, as well.
+		definition, err := abi.LoadAbiDefinitionFromFile("../abi/testdata/multisig.abi.json")
+		require.NoError(t, err)
+
+		code, err := generator.Generate(definition)
+		require.NoError(t, err)
+
+		expected, err := os.ReadFile("internal/multisig/multisig.go")
+		require.NoError(t, err)
+		require.Equal(t, string(expected), string(code))
+	})
+
+	t.Run("should generate valid code for an ABI without enums and events", func(t *testing.T) {
+		code, err := generator.Generate(&abi.AbiDefinition{
+			Name: "Adder",
+			Constructor: &abi.EndpointDefinition{
+				Inputs: []*abi.ParameterDefinition{{Name: "initial_value", Type: "BigUint"}},
+			},
+			Endpoints: []*abi.EndpointDefinition{
+				{
+					Name:    "add",
+					Inputs:  []*abi.ParameterDefinition{{Name: "type", Type: "variadic<multi<TokenIdentifier,u64>>"}},
+					Outputs: []*abi.ParameterDefinition{},
+				},
+				{
+					Name:    "getSum",
+					Outputs: []*abi.ParameterDefinition{{Type: "BigUint"}, {Type: "optional<u8>"}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = parser.ParseFile(token.NewFileSet(), "adder.go", code, parser.AllErrors)
+		require.NoError(t, err)
+		require.Contains(t, string(code), "func (client *Client) BuildAddCall(typeArg []MultiTokenIdentifierU64) (string, error)")
+		require.Contains(t, string(code), "func (client *Client) DecodeGetSumResults(returnData [][]byte) (*big.Int, *uint8, error)")
+		require.NotContains(t, string(code), "func (client *Client) DecodeAddResults")
+		require.NotContains(t, string(code), "\"io\"")
+	})
+
+	t.Run("should map arrays, tuples, managed decimals and counted variadics", func(t *testing.T) {
+		code, err := generator.Generate(&abi.AbiDefinition{
+			Name: "Pricer",
+			Endpoints: []*abi.EndpointDefinition{
+				{
+					Name: "setPrices",
+					Inputs: []*abi.ParameterDefinition{
+						{Name: "hash", Type: "array32<u8>"},
+						{Name: "prices", Type: "counted-variadic<tuple<TokenIdentifier,ManagedDecimal<18>>>"},
+						{Name: "metadata", Type: "CodeMetadata"},
+					},
+					Outputs: []*abi.ParameterDefinition{{Type: "ManagedDecimalSigned<usize>"}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = parser.ParseFile(token.NewFileSet(), "pricer.go", code, parser.AllErrors)
+		require.NoError(t, err)
+		require.Contains(t, string(code), "func (client *Client) BuildSetPricesCall(hash [32]uint8, prices []TupleTokenIdentifierManagedDecimalField18, metadata []byte) (string, error)")
+		require.Contains(t, string(code), "func (client *Client) DecodeSetPricesResults(returnData [][]byte) (abi.ManagedDecimalSignedValue, error)")
+		require.Contains(t, string(code), "\tItem1 abi.ManagedDecimalValue\n")
+	})
+}
+
+func TestToExportedName(t *testing.T) {
+	require.Equal(t, "ActionId", toExportedName("action_id"))
+	require.Equal(t, "Utf8String", toExportedName("utf-8 string"))
+	require.Equal(t, "Field0", toExportedName("0"))
+	require.Equal(t, "GetSum", toExportedName("getSum"))
+}
+
+func TestToParameterName(t *testing.T) {
+	require.Equal(t, "actionId", toParameterName("action_id", 0))
+	require.Equal(t, "arg1", toParameterName("", 1))
+	require.Equal(t, "rangeArg", toParameterName("range", 0))
+	require.Equal(t, "clientArg", toParameterName("client", 0))
+}
+
+func TestToArrayLength(t *testing.T) {
+	length, ok := toArrayLength("array32")
+	require.True(t, ok)
+	require.Equal(t, 32, length)
+
+	_, ok = toArrayLength("arrayX")
+	require.False(t, ok)
+
+	_, ok = toArrayLength("List")
+	require.False(t, ok)
+}
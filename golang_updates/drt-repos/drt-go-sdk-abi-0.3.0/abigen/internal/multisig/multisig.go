@@ -0,0 +1,385 @@
+// Code generated by abigen, from the ABI of the contract "Multisig". DO NOT EDIT.
+
+package multisig
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/TerraDharitri/drt-go-sdk-abi/abi"
+)
+
+// Action is the Go representation of the enum "Action".
+// Only the variant designated by the discriminant is considered (when encoding) or set (when decoding).
+type Action struct {
+	Discriminant            uint8
+	AddBoardMember          *ActionAddBoardMemberVariant
+	AddProposer             *ActionAddProposerVariant
+	RemoveUser              *ActionRemoveUserVariant
+	ChangeQuorum            *ActionChangeQuorumVariant
+	SendTransferExecuteRewa *ActionSendTransferExecuteRewaVariant
+	SendTransferExecuteDcdt *ActionSendTransferExecuteDcdtVariant
+}
+
+// Discriminants of the enum "Action"
+const (
+	ActionNothing                 uint8 = 0
+	ActionAddBoardMember          uint8 = 1
+	ActionAddProposer             uint8 = 2
+	ActionRemoveUser              uint8 = 3
+	ActionChangeQuorum            uint8 = 4
+	ActionSendTransferExecuteRewa uint8 = 5
+	ActionSendTransferExecuteDcdt uint8 = 6
+)
+
+// ActionAddBoardMemberVariant holds the fields of the variant "AddBoardMember" of the enum "Action"
+type ActionAddBoardMemberVariant struct {
+	Field0 []byte `abi:"0,type=Address"`
+}
+
+// ActionAddProposerVariant holds the fields of the variant "AddProposer" of the enum "Action"
+type ActionAddProposerVariant struct {
+	Field0 []byte `abi:"0,type=Address"`
+}
+
+// ActionRemoveUserVariant holds the fields of the variant "RemoveUser" of the enum "Action"
+type ActionRemoveUserVariant struct {
+	Field0 []byte `abi:"0,type=Address"`
+}
+
+// ActionChangeQuorumVariant holds the fields of the variant "ChangeQuorum" of the enum "Action"
+type ActionChangeQuorumVariant struct {
+	Field0 uint32 `abi:"0,type=u32"`
+}
+
+// ActionSendTransferExecuteRewaVariant holds the fields of the variant "SendTransferExecuteRewa" of the enum "Action"
+type ActionSendTransferExecuteRewaVariant struct {
+	Field0 CallActionData `abi:"0,type=CallActionData"`
+}
+
+// ActionSendTransferExecuteDcdtVariant holds the fields of the variant "SendTransferExecuteDcdt" of the enum "Action"
+type ActionSendTransferExecuteDcdtVariant struct {
+	Field0 DcdtTransferExecuteData `abi:"0,type=DcdtTransferExecuteData"`
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *Action) EncodeNested(writer io.Writer) error {
+	return value.toEnumValue().EncodeNested(writer)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *Action) EncodeTopLevel(writer io.Writer) error {
+	return value.toEnumValue().EncodeTopLevel(writer)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *Action) DecodeNested(reader io.Reader) error {
+	enumValue := value.toEnumValue()
+	err := enumValue.DecodeNested(reader)
+	value.Discriminant = enumValue.Discriminant
+	return err
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *Action) DecodeTopLevel(data []byte) error {
+	enumValue := value.toEnumValue()
+	err := enumValue.DecodeTopLevel(data)
+	value.Discriminant = enumValue.Discriminant
+	return err
+}
+
+func (value *Action) toEnumValue() *abi.EnumValue {
+	return &abi.EnumValue{
+		Discriminant:   value.Discriminant,
+		Fields:         value.getVariantFields(value.Discriminant),
+		FieldsProvider: value.getVariantFields,
+	}
+}
+
+func (value *Action) getVariantFields(discriminant uint8) []abi.Field {
+	switch discriminant {
+	case ActionAddBoardMember:
+		if value.AddBoardMember == nil {
+			value.AddBoardMember = &ActionAddBoardMemberVariant{}
+		}
+
+		return bindVariant("AddBoardMember", value.AddBoardMember)
+	case ActionAddProposer:
+		if value.AddProposer == nil {
+			value.AddProposer = &ActionAddProposerVariant{}
+		}
+
+		return bindVariant("AddProposer", value.AddProposer)
+	case ActionRemoveUser:
+		if value.RemoveUser == nil {
+			value.RemoveUser = &ActionRemoveUserVariant{}
+		}
+
+		return bindVariant("RemoveUser", value.RemoveUser)
+	case ActionChangeQuorum:
+		if value.ChangeQuorum == nil {
+			value.ChangeQuorum = &ActionChangeQuorumVariant{}
+		}
+
+		return bindVariant("ChangeQuorum", value.ChangeQuorum)
+	case ActionSendTransferExecuteRewa:
+		if value.SendTransferExecuteRewa == nil {
+			value.SendTransferExecuteRewa = &ActionSendTransferExecuteRewaVariant{}
+		}
+
+		return bindVariant("SendTransferExecuteRewa", value.SendTransferExecuteRewa)
+	case ActionSendTransferExecuteDcdt:
+		if value.SendTransferExecuteDcdt == nil {
+			value.SendTransferExecuteDcdt = &ActionSendTransferExecuteDcdtVariant{}
+		}
+
+		return bindVariant("SendTransferExecuteDcdt", value.SendTransferExecuteDcdt)
+	default:
+		return []abi.Field{}
+	}
+}
+
+// ActionFullInfo is the Go representation of the struct "ActionFullInfo"
+type ActionFullInfo struct {
+	ActionId   uint32   `abi:"action_id,type=u32"`
+	GroupId    uint32   `abi:"group_id,type=u32"`
+	ActionData Action   `abi:"action_data,type=Action"`
+	Signers    [][]byte `abi:"signers,type=List<Address>"`
+}
+
+// CallActionData is the Go representation of the struct "CallActionData"
+type CallActionData struct {
+	To           []byte   `abi:"to,type=Address"`
+	RewaAmount   *big.Int `abi:"rewa_amount,type=BigUint"`
+	OptGasLimit  *uint64  `abi:"opt_gas_limit,type=Option<u64>"`
+	EndpointName []byte   `abi:"endpoint_name,type=bytes"`
+	Arguments    [][]byte `abi:"arguments,type=List<bytes>"`
+}
+
+// DcdtTokenPayment is the Go representation of the struct "DcdtTokenPayment"
+type DcdtTokenPayment struct {
+	TokenIdentifier string   `abi:"token_identifier,type=TokenIdentifier"`
+	TokenNonce      uint64   `abi:"token_nonce,type=u64"`
+	Amount          *big.Int `abi:"amount,type=BigUint"`
+}
+
+// DcdtTransferExecuteData is the Go representation of the struct "DcdtTransferExecuteData"
+type DcdtTransferExecuteData struct {
+	To           []byte             `abi:"to,type=Address"`
+	Tokens       []DcdtTokenPayment `abi:"tokens,type=List<DcdtTokenPayment>"`
+	OptGasLimit  *uint64            `abi:"opt_gas_limit,type=Option<u64>"`
+	EndpointName []byte             `abi:"endpoint_name,type=bytes"`
+	Arguments    [][]byte           `abi:"arguments,type=List<bytes>"`
+}
+
+// UserRole is the Go representation of the enum "UserRole"
+type UserRole uint8
+
+// Variants of the enum "UserRole"
+const (
+	UserRoleNone        UserRole = 0
+	UserRoleProposer    UserRole = 1
+	UserRoleBoardMember UserRole = 2
+)
+
+// StartPerformActionEvent is the Go representation of the event "startPerformAction"
+type StartPerformActionEvent struct {
+	Data ActionFullInfo
+}
+
+// PerformChangeUserEvent is the Go representation of the event "performChangeUser"
+type PerformChangeUserEvent struct {
+	ActionId    uint32
+	ChangedUser []byte
+	OldRole     UserRole
+	NewRole     UserRole
+}
+
+// partsSerializer is the subset of the ABI serializer used by the client
+type partsSerializer interface {
+	SerializeToParts(inputValues []any) ([][]byte, error)
+	DeserializeParts(parts [][]byte, outputValues []any) error
+}
+
+// Client is a typed client of the contract "Multisig": it builds call data and decodes return data and events
+type Client struct {
+	serializer partsSerializer
+}
+
+// NewClient creates a new typed client of the contract "Multisig"
+func NewClient() (*Client, error) {
+	serializer, err := abi.NewSerializer(abi.ArgsNewSerializer{
+		PartsSeparator: "@",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		serializer: serializer,
+	}, nil
+}
+
+// EncodeConstructorArguments encodes the arguments of the constructor (to be appended to the deployment or upgrade data)
+func (client *Client) EncodeConstructorArguments(quorum uint32, board [][]byte) ([][]byte, error) {
+	return client.encodeArguments([]abi.TypedValue{
+		{Value: quorum, Type: "u32"},
+		{Value: board, Type: "variadic<Address>"},
+	})
+}
+
+// BuildProposeBatchCall builds the call data of the endpoint "proposeBatch"
+func (client *Client) BuildProposeBatchCall(actions []Action) (string, error) {
+	return client.buildCall("proposeBatch", []abi.TypedValue{
+		{Value: actions, Type: "variadic<Action>"},
+	})
+}
+
+// DecodeProposeBatchResults decodes the return data of the endpoint "proposeBatch"
+func (client *Client) DecodeProposeBatchResults(returnData [][]byte) (uint32, error) {
+	var result0 uint32
+
+	err := client.decodeParts(returnData, []abi.TypedValue{
+		{Value: &result0, Type: "u32"},
+	})
+	return result0, err
+}
+
+// BuildGetPendingActionFullInfoCall builds the call data of the endpoint "getPendingActionFullInfo"
+func (client *Client) BuildGetPendingActionFullInfoCall(optRange *MultiU32U32) (string, error) {
+	return client.buildCall("getPendingActionFullInfo", []abi.TypedValue{
+		{Value: optRange, Type: "optional<multi<u32,u32>>"},
+	})
+}
+
+// DecodeGetPendingActionFullInfoResults decodes the return data of the endpoint "getPendingActionFullInfo"
+func (client *Client) DecodeGetPendingActionFullInfoResults(returnData [][]byte) ([]ActionFullInfo, error) {
+	var result0 []ActionFullInfo
+
+	err := client.decodeParts(returnData, []abi.TypedValue{
+		{Value: &result0, Type: "variadic<ActionFullInfo>"},
+	})
+	return result0, err
+}
+
+// BuildGetActionSignerCountCall builds the call data of the endpoint "getActionSignerCount"
+func (client *Client) BuildGetActionSignerCountCall(actionId uint32) (string, error) {
+	return client.buildCall("getActionSignerCount", []abi.TypedValue{
+		{Value: actionId, Type: "u32"},
+	})
+}
+
+// DecodeGetActionSignerCountResults decodes the return data of the endpoint "getActionSignerCount"
+func (client *Client) DecodeGetActionSignerCountResults(returnData [][]byte) (uint32, error) {
+	var result0 uint32
+
+	err := client.decodeParts(returnData, []abi.TypedValue{
+		{Value: &result0, Type: "u32"},
+	})
+	return result0, err
+}
+
+// DecodeStartPerformActionEvent decodes the event "startPerformAction", given the topics (the first one being the event identifier) and the data parts of a log event
+func (client *Client) DecodeStartPerformActionEvent(topics [][]byte, dataParts [][]byte) (*StartPerformActionEvent, error) {
+	if len(topics) == 0 || string(topics[0]) != "startPerformAction" {
+		return nil, fmt.Errorf("cannot decode event %s: unexpected identifier", "startPerformAction")
+	}
+
+	event := &StartPerformActionEvent{}
+
+	err := client.decodeParts(topics[1:], []abi.TypedValue{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode topics of event %s: %w", "startPerformAction", err)
+	}
+
+	err = client.decodeParts(dataParts, []abi.TypedValue{
+		{Value: &event.Data, Type: "ActionFullInfo"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode data of event %s: %w", "startPerformAction", err)
+	}
+
+	return event, nil
+}
+
+// DecodePerformChangeUserEvent decodes the event "performChangeUser", given the topics (the first one being the event identifier) and the data parts of a log event
+func (client *Client) DecodePerformChangeUserEvent(topics [][]byte, dataParts [][]byte) (*PerformChangeUserEvent, error) {
+	if len(topics) == 0 || string(topics[0]) != "performChangeUser" {
+		return nil, fmt.Errorf("cannot decode event %s: unexpected identifier", "performChangeUser")
+	}
+
+	event := &PerformChangeUserEvent{}
+
+	err := client.decodeParts(topics[1:], []abi.TypedValue{
+		{Value: &event.ActionId, Type: "u32"},
+		{Value: &event.ChangedUser, Type: "Address"},
+		{Value: &event.OldRole, Type: "UserRole"},
+		{Value: &event.NewRole, Type: "UserRole"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode topics of event %s: %w", "performChangeUser", err)
+	}
+
+	err = client.decodeParts(dataParts, []abi.TypedValue{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode data of event %s: %w", "performChangeUser", err)
+	}
+
+	return event, nil
+}
+
+func (client *Client) buildCall(functionName string, arguments []abi.TypedValue) (string, error) {
+	parts, err := client.encodeArguments(arguments)
+	if err != nil {
+		return "", fmt.Errorf("cannot build call of %s: %w", functionName, err)
+	}
+
+	callData := strings.Builder{}
+	callData.WriteString(functionName)
+
+	for _, part := range parts {
+		callData.WriteString("@")
+		callData.WriteString(hex.EncodeToString(part))
+	}
+
+	return callData.String(), nil
+}
+
+func (client *Client) encodeArguments(arguments []abi.TypedValue) ([][]byte, error) {
+	values, err := abi.BindArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.serializer.SerializeToParts(values)
+}
+
+func (client *Client) decodeParts(parts [][]byte, destinations []abi.TypedValue) error {
+	values, err := abi.BindResults(destinations)
+	if err != nil {
+		return err
+	}
+
+	return client.serializer.DeserializeParts(parts, values)
+}
+
+func bindVariant(name string, variant any) []abi.Field {
+	// Binding a non-nil pointer never fails.
+	value, _ := abi.Bind(variant)
+
+	return []abi.Field{
+		{
+			Name:  name,
+			Value: value,
+		},
+	}
+}
+
+// MultiU32U32 is the Go representation of "multi<u32,u32>"
+type MultiU32U32 struct {
+	Item0 uint32
+	Item1 uint32
+}
@@ -0,0 +1,113 @@
+package multisig
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	client, err := NewClient()
+	require.NoError(t, err)
+
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	bobPubKey, _ := hex.DecodeString("8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8")
+	oneQuintillion := big.NewInt(0).SetUint64(1_000_000_000_000_000_000)
+	gasLimit := uint64(15000000)
+
+	t.Run("build call of proposeBatch", func(t *testing.T) {
+		callData, err := client.BuildProposeBatchCall([]Action{
+			{
+				Discriminant: ActionSendTransferExecuteRewa,
+				SendTransferExecuteRewa: &ActionSendTransferExecuteRewaVariant{
+					Field0: CallActionData{
+						To:           alicePubKey,
+						RewaAmount:   oneQuintillion,
+						OptGasLimit:  &gasLimit,
+						EndpointName: []byte("example"),
+						Arguments:    [][]byte{{0x03, 0x42}, {0x07, 0x43}},
+					},
+				},
+			},
+			{
+				Discriminant: ActionChangeQuorum,
+				ChangeQuorum: &ActionChangeQuorumVariant{Field0: 7},
+			},
+		})
+
+		expected := strings.Join([]string{
+			"proposeBatch",
+			"05|0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1|000000080de0b6b3a7640000|010000000000e4e1c0|000000076578616d706c65|00000002000000020342000000020743",
+			"04|00000007",
+		}, "@")
+		// Drop the delimiters (were added for readability)
+		expected = strings.Replace(expected, "|", "", -1)
+
+		require.NoError(t, err)
+		require.Equal(t, expected, callData)
+	})
+
+	t.Run("build call of getPendingActionFullInfo", func(t *testing.T) {
+		callData, err := client.BuildGetPendingActionFullInfoCall(nil)
+		require.NoError(t, err)
+		require.Equal(t, "getPendingActionFullInfo", callData)
+
+		callData, err = client.BuildGetPendingActionFullInfoCall(&MultiU32U32{Item0: 1, Item1: 2})
+		require.NoError(t, err)
+		require.Equal(t, "getPendingActionFullInfo@01@02", callData)
+	})
+
+	t.Run("decode results of getPendingActionFullInfo", func(t *testing.T) {
+		dataHex := strings.Join([]string{
+			"0000002A",
+			"0000002A",
+			"05|0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1|000000080de0b6b3a7640000|010000000000e4e1c0|000000076578616d706c65|00000002000000020342000000020743",
+			"00000002|0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1|8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8",
+		}, "")
+		// Drop the delimiters (were added for readability)
+		data, _ := hex.DecodeString(strings.Replace(dataHex, "|", "", -1))
+
+		results, err := client.DecodeGetPendingActionFullInfoResults([][]byte{data})
+		require.NoError(t, err)
+		require.Equal(t, []ActionFullInfo{
+			{
+				ActionId: 42,
+				GroupId:  42,
+				ActionData: Action{
+					Discriminant: ActionSendTransferExecuteRewa,
+					SendTransferExecuteRewa: &ActionSendTransferExecuteRewaVariant{
+						Field0: CallActionData{
+							To:           alicePubKey,
+							RewaAmount:   oneQuintillion,
+							OptGasLimit:  &gasLimit,
+							EndpointName: []byte("example"),
+							Arguments:    [][]byte{{0x03, 0x42}, {0x07, 0x43}},
+						},
+					},
+				},
+				Signers: [][]byte{alicePubKey, bobPubKey},
+			},
+		}, results)
+	})
+
+	t.Run("decode event performChangeUser", func(t *testing.T) {
+		topics := [][]byte{[]byte("performChangeUser"), {0x07}, bobPubKey, {}, {0x02}}
+
+		event, err := client.DecodePerformChangeUserEvent(topics, nil)
+		require.NoError(t, err)
+		require.Equal(t, &PerformChangeUserEvent{
+			ActionId:    7,
+			ChangedUser: bobPubKey,
+			OldRole:     UserRoleNone,
+			NewRole:     UserRoleBoardMember,
+		}, event)
+	})
+
+	t.Run("decode event with unexpected identifier", func(t *testing.T) {
+		_, err := client.DecodePerformChangeUserEvent([][]byte{[]byte("foobar")}, nil)
+		require.ErrorContains(t, err, "cannot decode event performChangeUser: unexpected identifier")
+	})
+}
@@ -0,0 +1,74 @@
+package abigen
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// reservedParameterNames are names which cannot be used for the parameters of the generated functions
+var reservedParameterNames = map[string]struct{}{
+	"client":     {},
+	"err":        {},
+	"returnData": {},
+	"topics":     {},
+	"dataParts":  {},
+	"abi":        {},
+	"big":        {},
+	"any":        {},
+}
+
+// toExportedName converts a name found in an ABI (e.g. "action_id", "utf-8 string", "0") into an exported Go identifier
+func toExportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	builder := strings.Builder{}
+	for _, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+
+	result := builder.String()
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		return "Field" + result
+	}
+
+	return result
+}
+
+// toParameterName converts a name found in an ABI into an unexported Go identifier, usable as a function parameter
+func toParameterName(name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("arg%d", index)
+	}
+
+	runes := []rune(toExportedName(name))
+	runes[0] = unicode.ToLower(runes[0])
+	result := string(runes)
+
+	_, isReserved := reservedParameterNames[result]
+	if isReserved || token.IsKeyword(result) {
+		return result + "Arg"
+	}
+
+	return result
+}
+
+// toArrayLength extracts the length of a fixed-size array out of its ABI type name (e.g. 32, for "array32")
+func toArrayLength(name string) (int, bool) {
+	if !strings.HasPrefix(name, "array") {
+		return 0, false
+	}
+
+	length, err := strconv.ParseUint(strings.TrimPrefix(name, "array"), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int(length), true
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/TerraDharitri/drt-go-sdk-abi/abi"
+	"github.com/TerraDharitri/drt-go-sdk-abi/abigen"
+)
+
+// abigen generates Go types and a typed client out of a contract's ABI file (*.abi.json).
+// Usage:
+//
+//	abigen --abi=./adder.abi.json --package=adder --out=./adder/adder.go
+func main() {
+	abiPath := flag.String("abi", "", "path of the ABI file (*.abi.json)")
+	packageName := flag.String("package", "", "name of the Go package of the generated code")
+	outputPath := flag.String("out", "", "path of the generated Go file (if missing, the code is written to the standard output)")
+	flag.Parse()
+
+	err := run(*abiPath, *packageName, *outputPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "abigen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath string, packageName string, outputPath string) error {
+	if abiPath == "" {
+		return fmt.Errorf("the path of the ABI file must be provided (--abi)")
+	}
+
+	definition, err := abi.LoadAbiDefinitionFromFile(abiPath)
+	if err != nil {
+		return err
+	}
+
+	generator, err := abigen.NewGenerator(abigen.ArgsNewGenerator{
+		PackageName: packageName,
+	})
+	if err != nil {
+		return err
+	}
+
+	code, err := generator.Generate(definition)
+	if err != nil {
+		return err
+	}
+
+	if outputPath == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return os.WriteFile(outputPath, code, 0644)
+}
@@ -4,11 +4,12 @@ go 1.20
 
 require (
 	github.com/TerraDharitri/drt-go-bigint v1.0.0
+	github.com/btcsuite/btcd/btcutil v1.1.3
 	github.com/stretchr/testify v1.7.1
 )
 
 require (
-	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
@@ -15,6 +15,9 @@ import (
 
 	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/config"
 	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/factory"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/notifier"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
 )
 
 var log = logger.GetOrCreate("eth-chain-sovereign-notifier")
@@ -76,20 +79,62 @@ func startNotifier(ctx *cli.Context) error {
 		}
 	}
 
-	wsClient, err := factory.CreateWSETHClientNotifier(cfg)
+	statusMetrics := status.NewStatusMetrics()
+	connector, err := factory.CreateETHClientConnector(cfg.ClientConfig, statusMetrics)
 	if err != nil {
 		return fmt.Errorf("cannot create sovereign notifier, error: %w", err)
 	}
 
+	var statusServer factory.StatusServer
+	if len(cfg.StatusConfig.Address) > 0 {
+		statusServer, err = factory.CreateStatusServer(cfg.StatusConfig, statusMetrics)
+		if err != nil {
+			return fmt.Errorf("cannot create status server, error: %w", err)
+		}
+
+		err = statusServer.Start()
+		if err != nil {
+			return fmt.Errorf("cannot start status server, error: %w", err)
+		}
+		defer closeStatusServer(statusServer)
+	}
+
+	var outportSender factory.OutportSender
+	var handler process.BlockEventsHandler = notifier.NewLogBlockEventsHandler()
+	if len(cfg.OutportConfig.Url) > 0 {
+		outportSender, err = factory.CreateOutportSender(cfg.OutportConfig)
+		if err != nil {
+			return fmt.Errorf("cannot create outport sender, error: %w", err)
+		}
+
+		handler = outportSender
+	}
+
+	ethNotifier, err := factory.CreateETHNotifier(cfg, connector, handler, statusMetrics)
+	if err != nil {
+		closeOutportSender(outportSender)
+		return fmt.Errorf("cannot create eth notifier, error: %w", err)
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
 
 	log.Info("starting ws client...")
 
+	err = ethNotifier.Start()
+	if err != nil {
+		closeOutportSender(outportSender)
+		return fmt.Errorf("cannot start eth notifier, error: %w", err)
+	}
+
 	<-interrupt
 	log.Info("closing app at user's signal")
 
-	wsClient.Close()
+	// The sender is closed first, so that a block stuck in retries does not prevent the notifier from closing.
+	closeOutportSender(outportSender)
+
+	err = ethNotifier.Close()
+	log.LogIfError(err)
 
 	if withLogFile {
 		err = logFile.Close()
@@ -98,6 +143,20 @@ func startNotifier(ctx *cli.Context) error {
 	return nil
 }
 
+func closeOutportSender(outportSender factory.OutportSender) {
+	if outportSender == nil {
+		return
+	}
+
+	err := outportSender.Close()
+	log.LogIfError(err)
+}
+
+func closeStatusServer(statusServer factory.StatusServer) {
+	err := statusServer.Close()
+	log.LogIfError(err)
+}
+
 func loadConfig(filepath string) (config.Config, error) {
 	cfg := config.Config{}
 	err := core.LoadTomlFile(&cfg, filepath)
@@ -4,15 +4,56 @@ package config
 type Config struct {
 	SubscribedEvents []SubscribedEvent `toml:"subscribed_events"`
 	ClientConfig     ClientConfig      `toml:"client_config"`
+	NotifierConfig   NotifierConfig    `toml:"notifier_config"`
+	OutportConfig    OutportConfig     `toml:"outport_config"`
+	StatusConfig     StatusConfig      `toml:"status_config"`
 }
 
-// SubscribedEvent holds subscribed events config
+// SubscribedEvent holds subscribed events config.
+// The identifier names the event within the notifications, while the signature (e.g. "Deposit(address,uint256)")
+// is used to filter the logs by their first topic. If the signature is empty, all logs of the addresses are relayed.
 type SubscribedEvent struct {
 	Identifier string   `toml:"identifier"`
+	Signature  string   `toml:"signature"`
 	Addresses  []string `toml:"addresses"`
 }
 
-// ClientConfig holds client web sockets config
+// ClientConfig holds client web sockets config.
+// The url is the primary endpoint, the fallback urls being used, in turn, whenever the connection is lost.
+// The connection is considered lost if no block header is received within the head timeout.
 type ClientConfig struct {
-	Url string `toml:"url"`
+	Url                          string   `toml:"url"`
+	FallbackUrls                 []string `toml:"fallback_urls"`
+	ReconnectInitialBackoffInSec uint32   `toml:"reconnect_initial_backoff_in_sec"`
+	ReconnectMaxBackoffInSec     uint32   `toml:"reconnect_max_backoff_in_sec"`
+	HeadTimeoutInSec             uint32   `toml:"head_timeout_in_sec"`
+}
+
+// NotifierConfig holds the config of the blocks processing.
+// A block is relayed once it has the configured number of blocks on top of it. The last relayed blocks (within the
+// retraction window) are remembered, and persisted in the checkpoint file, so that they can be retracted on a reorg and
+// so that a restarted notifier resumes from the last relayed block. If the checkpoint path is empty, nothing is persisted.
+type NotifierConfig struct {
+	ConfirmationDepth uint64 `toml:"confirmation_depth"`
+	RetractionWindow  uint64 `toml:"retraction_window"`
+	CheckpointPath    string `toml:"checkpoint_path"`
+	BackfillBatchSize uint64 `toml:"backfill_batch_size"`
+}
+
+// OutportConfig holds the config of the websocket connection towards the sovereign chain, which uses the outport
+// websocket protocol of the node (the version being sent as metadata of each message).
+// If the url is empty, notifications are only logged.
+type OutportConfig struct {
+	Url                     string `toml:"url"`
+	MarshallerType          string `toml:"marshaller_type"`
+	WithAcknowledge         bool   `toml:"with_acknowledge"`
+	AcknowledgeTimeoutInSec uint32 `toml:"acknowledge_timeout_in_sec"`
+	RetryDurationInSec      uint32 `toml:"retry_duration_in_sec"`
+	Version                 uint32 `toml:"version"`
+}
+
+// StatusConfig holds the config of the HTTP server exposing the status metrics.
+// If the address is empty, the server is not started.
+type StatusConfig struct {
+	Address string `toml:"address"`
 }
@@ -0,0 +1,27 @@
+package data
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockEvents holds the subscribed events emitted within an Ethereum block, together with the block's header info.
+// The raw header is the RLP encoding of the Ethereum block header.
+type BlockEvents struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Timestamp  uint64
+	RawHeader  []byte
+	Events     []*Event
+}
+
+// Event holds a subscribed event (contract log), in the order of emission within the block
+type Event struct {
+	Identifier string
+	Address    common.Address
+	Topics     []common.Hash
+	Data       []byte
+	TxHash     common.Hash
+	TxIndex    uint
+	LogIndex   uint
+}
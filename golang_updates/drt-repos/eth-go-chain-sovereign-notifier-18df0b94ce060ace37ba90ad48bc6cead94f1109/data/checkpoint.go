@@ -0,0 +1,18 @@
+package data
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockInfo identifies an Ethereum block
+type BlockInfo struct {
+	Number     uint64      `json:"number"`
+	Hash       common.Hash `json:"hash"`
+	ParentHash common.Hash `json:"parentHash"`
+}
+
+// Checkpoint holds the most recently relayed blocks, in ascending order; the last one is the last fully relayed block.
+// The previous ones are kept so that a reorg happening while the notifier is stopped can be detected (and retracted).
+type Checkpoint struct {
+	RelayedBlocks []*BlockInfo `json:"relayedBlocks"`
+}
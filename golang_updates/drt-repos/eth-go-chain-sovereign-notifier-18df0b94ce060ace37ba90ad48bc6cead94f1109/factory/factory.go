@@ -1,11 +1,110 @@
 package factory
 
 import (
+	"context"
+	"time"
+
+	wsData "github.com/TerraDharitri/drt-go-chain-communication/websocket/data"
+	wsFactory "github.com/TerraDharitri/drt-go-chain-communication/websocket/factory"
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	marshalFactory "github.com/TerraDharitri/drt-go-chain-core/marshal/factory"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+
 	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/config"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/checkpoint"
 	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/client"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/notifier"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/sender"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
 )
 
-// CreateWSETHClientNotifier creates a ws eth client notifier
-func CreateWSETHClientNotifier(cfg config.Config) (ETHClient, error) {
-	return client.NewClient(cfg.ClientConfig.Url)
+// outportHostMode is the mode of the outport websocket host: the notifier connects, as the node does, to the sovereign chain's server
+const outportHostMode = "client"
+
+var log = logger.GetOrCreate("factory")
+
+// CreateETHClientConnector creates a connector of ws eth clients, failing over across the configured endpoints
+func CreateETHClientConnector(cfg config.ClientConfig, statusHandler core.AppStatusHandler) (process.EthClientConnector, error) {
+	urls := append([]string{cfg.Url}, cfg.FallbackUrls...)
+
+	return client.NewFailoverConnector(client.ArgsFailoverConnector{
+		Urls:           urls,
+		InitialBackoff: time.Second * time.Duration(cfg.ReconnectInitialBackoffInSec),
+		MaxBackoff:     time.Second * time.Duration(cfg.ReconnectMaxBackoffInSec),
+		Dial: func(ctx context.Context, url string) (process.EthClient, error) {
+			return client.NewClient(ctx, url)
+		},
+		StatusHandler: statusHandler,
+	})
+}
+
+// CreateETHNotifier creates an eth notifier which subscribes (through the clients of the provided connector) to the configured events
+func CreateETHNotifier(
+	cfg config.Config,
+	connector process.EthClientConnector,
+	handler process.BlockEventsHandler,
+	statusHandler core.AppStatusHandler,
+) (ETHNotifier, error) {
+	checkpointStorer, err := createCheckpointStorer(cfg.NotifierConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifier.NewEthNotifier(notifier.ArgsEthNotifier{
+		Connector:         connector,
+		Handler:           handler,
+		CheckpointStorer:  checkpointStorer,
+		StatusHandler:     statusHandler,
+		SubscribedEvents:  cfg.SubscribedEvents,
+		ConfirmationDepth: cfg.NotifierConfig.ConfirmationDepth,
+		RetractionWindow:  cfg.NotifierConfig.RetractionWindow,
+		BackfillBatchSize: cfg.NotifierConfig.BackfillBatchSize,
+		HeadTimeout:       time.Second * time.Duration(cfg.ClientConfig.HeadTimeoutInSec),
+	})
+}
+
+func createCheckpointStorer(cfg config.NotifierConfig) (process.CheckpointStorer, error) {
+	if len(cfg.CheckpointPath) == 0 {
+		return checkpoint.NewDisabledCheckpointStorer(), nil
+	}
+
+	return checkpoint.NewFileCheckpointStorer(cfg.CheckpointPath)
+}
+
+// CreateOutportSender creates a sender which delivers the block events to the sovereign chain over the outport websocket
+func CreateOutportSender(cfg config.OutportConfig) (OutportSender, error) {
+	marshaller, err := marshalFactory.NewMarshalizer(cfg.MarshallerType)
+	if err != nil {
+		return nil, err
+	}
+
+	senderHost, err := wsFactory.CreateWebSocketHost(wsFactory.ArgsWebSocketHost{
+		WebSocketConfig: wsData.WebSocketConfig{
+			URL:                        cfg.Url,
+			WithAcknowledge:            cfg.WithAcknowledge,
+			Mode:                       outportHostMode,
+			RetryDurationInSec:         int(cfg.RetryDurationInSec),
+			AcknowledgeTimeoutInSec:    int(cfg.AcknowledgeTimeoutInSec),
+			BlockingAckOnError:         false,
+			DropMessagesIfNoConnection: false,
+			Version:                    cfg.Version,
+		},
+		Marshaller: marshaller,
+		Log:        log,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sender.NewWsSender(sender.ArgsWsSender{
+		Marshaller:    marshaller,
+		SenderHost:    senderHost,
+		RetryDuration: time.Second * time.Duration(cfg.RetryDurationInSec),
+	})
+}
+
+// CreateStatusServer creates an HTTP server exposing the status metrics
+func CreateStatusServer(cfg config.StatusConfig, metricsGetter status.MetricsGetter) (StatusServer, error) {
+	return status.NewStatusServer(cfg.Address, metricsGetter)
 }
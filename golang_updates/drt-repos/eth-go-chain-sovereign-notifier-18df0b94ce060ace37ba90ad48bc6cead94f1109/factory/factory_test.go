@@ -0,0 +1,232 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	wsData "github.com/TerraDharitri/drt-go-chain-communication/websocket/data"
+	wsFactory "github.com/TerraDharitri/drt-go-chain-communication/websocket/factory"
+	"github.com/TerraDharitri/drt-go-chain-core/data/outport"
+	"github.com/TerraDharitri/drt-go-chain-core/marshal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/config"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+type receivedPayload struct {
+	payload []byte
+	topic   string
+	version uint32
+}
+
+// payloadHandlerStub is the receiving side of the outport protocol, as plugged into the sovereign chain's server
+type payloadHandlerStub struct {
+	mut              sync.Mutex
+	received         []*receivedPayload
+	numFailuresLeft  int
+	receivedPayloads chan struct{}
+}
+
+func newPayloadHandlerStub(numFailures int) *payloadHandlerStub {
+	return &payloadHandlerStub{
+		numFailuresLeft:  numFailures,
+		receivedPayloads: make(chan struct{}, 100),
+	}
+}
+
+// ProcessPayload -
+func (stub *payloadHandlerStub) ProcessPayload(payload []byte, topic string, version uint32) error {
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	stub.received = append(stub.received, &receivedPayload{payload: payload, topic: topic, version: version})
+	stub.receivedPayloads <- struct{}{}
+
+	if stub.numFailuresLeft > 0 {
+		stub.numFailuresLeft--
+		return errors.New("cannot process payload")
+	}
+
+	return nil
+}
+
+func (stub *payloadHandlerStub) getReceived() []*receivedPayload {
+	stub.mut.Lock()
+	defer stub.mut.Unlock()
+
+	return append([]*receivedPayload{}, stub.received...)
+}
+
+// Close -
+func (stub *payloadHandlerStub) Close() error {
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *payloadHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func getFreePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// startOutportServer starts the server side of the outport websocket protocol, as the sovereign chain does
+func startOutportServer(t *testing.T, port int, handler *payloadHandlerStub) {
+	serverHost, err := wsFactory.CreateWebSocketHost(wsFactory.ArgsWebSocketHost{
+		WebSocketConfig: wsData.WebSocketConfig{
+			URL:                     fmt.Sprintf("127.0.0.1:%d", port),
+			WithAcknowledge:         true,
+			Mode:                    "server",
+			RetryDurationInSec:      1,
+			AcknowledgeTimeoutInSec: 1,
+			BlockingAckOnError:      true,
+		},
+		Marshaller: &marshal.JsonMarshalizer{},
+		Log:        log,
+	})
+	require.Nil(t, err)
+
+	err = serverHost.SetPayloadHandler(handler)
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = serverHost.Close()
+	})
+}
+
+func createOutportConfig(port int) config.OutportConfig {
+	return config.OutportConfig{
+		Url:                     fmt.Sprintf("ws://127.0.0.1:%d", port),
+		MarshallerType:          "json",
+		WithAcknowledge:         true,
+		AcknowledgeTimeoutInSec: 1,
+		RetryDurationInSec:      1,
+		Version:                 1,
+	}
+}
+
+func waitForPayloads(t *testing.T, handler *payloadHandlerStub, numPayloads int) {
+	for i := 0; i < numPayloads; i++ {
+		select {
+		case <-handler.receivedPayloads:
+		case <-time.After(time.Second * 20):
+			require.Fail(t, "timeout waiting for the outport payloads")
+		}
+	}
+}
+
+func TestCreateOutportSender_InteropWithOutportServer(t *testing.T) {
+	t.Parallel()
+
+	blockEvents := &data.BlockEvents{
+		Number:    42,
+		Hash:      common.HexToHash("0x42"),
+		RawHeader: []byte("header"),
+		Events: []*data.Event{
+			{Identifier: "deposit", Address: common.HexToAddress("0x01"), TxHash: common.HexToHash("0xaa"), Data: []byte("data")},
+		},
+	}
+
+	t.Run("should deliver the notifications, in order, once acknowledged", func(t *testing.T) {
+		t.Parallel()
+
+		port := getFreePort(t)
+		handler := newPayloadHandlerStub(0)
+		startOutportServer(t, port, handler)
+
+		outportSender, err := CreateOutportSender(createOutportConfig(port))
+		require.Nil(t, err)
+		defer func() {
+			_ = outportSender.Close()
+		}()
+
+		err = outportSender.HandleBlockEvents(blockEvents)
+		require.Nil(t, err)
+		err = outportSender.HandleRetractedBlock(&data.BlockInfo{Number: 42, Hash: blockEvents.Hash})
+		require.Nil(t, err)
+
+		waitForPayloads(t, handler, 2)
+		received := handler.getReceived()
+		require.Len(t, received, 2)
+
+		require.Equal(t, outport.TopicSaveBlock, received[0].topic)
+		require.Equal(t, uint32(1), received[0].version)
+		outportBlock := &outport.OutportBlock{}
+		err = (&marshal.JsonMarshalizer{}).Unmarshal(outportBlock, received[0].payload)
+		require.Nil(t, err)
+		require.Equal(t, blockEvents.Hash.Bytes(), outportBlock.BlockData.HeaderHash)
+		require.Equal(t, []byte("header"), outportBlock.BlockData.HeaderBytes)
+		require.Equal(t, uint64(42), outportBlock.HighestFinalBlockNonce)
+		require.Equal(t, []byte("deposit"), outportBlock.TransactionPool.Logs[0].Log.Events[0].Identifier)
+
+		require.Equal(t, outport.TopicRevertIndexedBlock, received[1].topic)
+		blockData := &outport.BlockData{}
+		err = (&marshal.JsonMarshalizer{}).Unmarshal(blockData, received[1].payload)
+		require.Nil(t, err)
+		require.Equal(t, blockEvents.Hash.Bytes(), blockData.HeaderHash)
+	})
+	t.Run("should send again when not acknowledged in time", func(t *testing.T) {
+		t.Parallel()
+
+		port := getFreePort(t)
+		// The server does not acknowledge the payloads it fails to process, so the first attempt times out.
+		handler := newPayloadHandlerStub(1)
+		startOutportServer(t, port, handler)
+
+		outportSender, err := CreateOutportSender(createOutportConfig(port))
+		require.Nil(t, err)
+		defer func() {
+			_ = outportSender.Close()
+		}()
+
+		err = outportSender.HandleBlockEvents(blockEvents)
+		require.Nil(t, err)
+
+		received := handler.getReceived()
+		require.GreaterOrEqual(t, len(received), 2)
+		for _, payload := range received {
+			require.Equal(t, outport.TopicSaveBlock, payload.topic)
+			require.Equal(t, received[0].payload, payload.payload)
+		}
+	})
+	t.Run("should wait for the server to be started", func(t *testing.T) {
+		t.Parallel()
+
+		port := getFreePort(t)
+		outportSender, err := CreateOutportSender(createOutportConfig(port))
+		require.Nil(t, err)
+		defer func() {
+			_ = outportSender.Close()
+		}()
+
+		handleErr := make(chan error, 1)
+		go func() {
+			handleErr <- outportSender.HandleBlockEvents(blockEvents)
+		}()
+
+		time.Sleep(time.Second * 2)
+		handler := newPayloadHandlerStub(0)
+		startOutportServer(t, port, handler)
+
+		waitForPayloads(t, handler, 1)
+		select {
+		case err = <-handleErr:
+			require.Nil(t, err)
+		case <-time.After(time.Second * 20):
+			require.Fail(t, "timeout waiting for the notification to be acknowledged")
+		}
+	})
+}
@@ -1,6 +1,25 @@
 package factory
 
-// ETHClient defines what a websocket client should do
-type ETHClient interface {
-	Close()
+import (
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+// ETHNotifier defines what an eth notifier should do
+type ETHNotifier interface {
+	Start() error
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// OutportSender defines what a sender of block events towards the sovereign chain should do
+type OutportSender interface {
+	process.BlockEventsHandler
+	Close() error
+}
+
+// StatusServer defines what a server of the status metrics should do
+type StatusServer interface {
+	Start() error
+	Close() error
+	IsInterfaceNil() bool
 }
@@ -6,8 +6,10 @@ go 1.20
 
 require (
 	github.com/ethereum/go-ethereum v1.12.0
+	github.com/TerraDharitri/drt-go-chain-communication v1.2.0
 	github.com/TerraDharitri/drt-go-chain-core v1.3.1
 	github.com/TerraDharitri/drt-go-chain-logger v1.0.15
+	github.com/stretchr/testify v1.10.0
 	github.com/urfave/cli v1.22.9
 )
 
@@ -30,7 +32,6 @@ require (
 	github.com/rogpeppe/go-internal v1.12.0 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/shirou/gopsutil v3.21.4-0.20210419000835-c7a38de76ee5+incompatible // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
 	golang.org/x/crypto v0.35.0 // indirect
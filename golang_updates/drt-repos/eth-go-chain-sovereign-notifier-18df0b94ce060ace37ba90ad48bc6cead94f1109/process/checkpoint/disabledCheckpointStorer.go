@@ -0,0 +1,27 @@
+package checkpoint
+
+import (
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+type disabledCheckpointStorer struct{}
+
+// NewDisabledCheckpointStorer creates a checkpoint storer which does not persist anything
+func NewDisabledCheckpointStorer() *disabledCheckpointStorer {
+	return &disabledCheckpointStorer{}
+}
+
+// LoadCheckpoint returns an empty checkpoint
+func (storer *disabledCheckpointStorer) LoadCheckpoint() (*data.Checkpoint, error) {
+	return &data.Checkpoint{}, nil
+}
+
+// SaveCheckpoint does nothing
+func (storer *disabledCheckpointStorer) SaveCheckpoint(_ *data.Checkpoint) error {
+	return nil
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (storer *disabledCheckpointStorer) IsInterfaceNil() bool {
+	return storer == nil
+}
@@ -0,0 +1,77 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+const (
+	directoryPermissions = 0755
+	filePermissions      = 0644
+	tempFileSuffix       = ".tmp"
+)
+
+type fileCheckpointStorer struct {
+	path string
+}
+
+// NewFileCheckpointStorer creates a storer which persists the checkpoint as a JSON file
+func NewFileCheckpointStorer(path string) (*fileCheckpointStorer, error) {
+	if len(path) == 0 {
+		return nil, process.ErrEmptyPath
+	}
+
+	return &fileCheckpointStorer{
+		path: path,
+	}, nil
+}
+
+// LoadCheckpoint reads the checkpoint from the file. If the file does not exist, an empty checkpoint is returned
+func (storer *fileCheckpointStorer) LoadCheckpoint() (*data.Checkpoint, error) {
+	content, err := os.ReadFile(storer.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &data.Checkpoint{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &data.Checkpoint{}
+	err = json.Unmarshal(content, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// SaveCheckpoint writes the checkpoint to the file. The file is replaced atomically, so that a crash cannot corrupt it
+func (storer *fileCheckpointStorer) SaveCheckpoint(checkpoint *data.Checkpoint) error {
+	content, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(storer.path), directoryPermissions)
+	if err != nil {
+		return err
+	}
+
+	tempPath := storer.path + tempFileSuffix
+	err = os.WriteFile(tempPath, content, filePermissions)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, storer.path)
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (storer *fileCheckpointStorer) IsInterfaceNil() bool {
+	return storer == nil
+}
@@ -0,0 +1,84 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+func TestNewFileCheckpointStorer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty path should error", func(t *testing.T) {
+		t.Parallel()
+
+		storer, err := NewFileCheckpointStorer("")
+		require.Equal(t, process.ErrEmptyPath, err)
+		require.Nil(t, storer)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		storer, err := NewFileCheckpointStorer(filepath.Join(t.TempDir(), "checkpoint.json"))
+		require.Nil(t, err)
+		require.False(t, storer.IsInterfaceNil())
+	})
+}
+
+func TestFileCheckpointStorer_LoadCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file should return an empty checkpoint", func(t *testing.T) {
+		t.Parallel()
+
+		storer, _ := NewFileCheckpointStorer(filepath.Join(t.TempDir(), "checkpoint.json"))
+		checkpoint, err := storer.LoadCheckpoint()
+		require.Nil(t, err)
+		require.Empty(t, checkpoint.RelayedBlocks)
+	})
+	t.Run("corrupted file should error", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "checkpoint.json")
+		err := os.WriteFile(path, []byte("{"), filePermissions)
+		require.Nil(t, err)
+
+		storer, _ := NewFileCheckpointStorer(path)
+		checkpoint, err := storer.LoadCheckpoint()
+		require.NotNil(t, err)
+		require.Nil(t, checkpoint)
+	})
+}
+
+func TestFileCheckpointStorer_SaveCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "db", "checkpoint.json")
+	storer, _ := NewFileCheckpointStorer(path)
+
+	checkpoint := &data.Checkpoint{
+		RelayedBlocks: []*data.BlockInfo{
+			{Number: 1, Hash: common.HexToHash("0x01"), ParentHash: common.HexToHash("0x00")},
+			{Number: 2, Hash: common.HexToHash("0x02"), ParentHash: common.HexToHash("0x01")},
+		},
+	}
+	err := storer.SaveCheckpoint(checkpoint)
+	require.Nil(t, err)
+
+	checkpoint.RelayedBlocks = checkpoint.RelayedBlocks[1:]
+	err = storer.SaveCheckpoint(checkpoint)
+	require.Nil(t, err)
+
+	_, err = os.Stat(path + tempFileSuffix)
+	require.True(t, os.IsNotExist(err))
+
+	loadedCheckpoint, err := storer.LoadCheckpoint()
+	require.Nil(t, err)
+	require.Equal(t, checkpoint, loadedCheckpoint)
+}
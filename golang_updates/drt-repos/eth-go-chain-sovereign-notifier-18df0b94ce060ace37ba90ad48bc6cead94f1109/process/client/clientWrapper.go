@@ -1,6 +1,12 @@
 package client
 
 import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -10,8 +16,8 @@ type clientWrapper struct {
 
 // NewClient creates a new instance of clientWrapper with the specified URL.
 // It establishes a connection to the Ethereum client using the provided URL.
-func NewClient(url string) (*clientWrapper, error) {
-	client, err := ethclient.Dial(url)
+func NewClient(ctx context.Context, url string) (*clientWrapper, error) {
+	client, err := ethclient.DialContext(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +27,31 @@ func NewClient(url string) (*clientWrapper, error) {
 	}, nil
 }
 
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+func (cw *clientWrapper) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return cw.client.SubscribeNewHead(ctx, ch)
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query
+func (cw *clientWrapper) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return cw.client.SubscribeFilterLogs(ctx, query, ch)
+}
+
+// HeaderByHash returns the block header with the given hash
+func (cw *clientWrapper) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return cw.client.HeaderByHash(ctx, hash)
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is nil, the latest known header is returned
+func (cw *clientWrapper) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return cw.client.HeaderByNumber(ctx, number)
+}
+
+// FilterLogs executes a filter query
+func (cw *clientWrapper) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return cw.client.FilterLogs(ctx, query)
+}
+
 // Close closes the underlying eth client connection
 func (cw *clientWrapper) Close() {
 	cw.client.Close()
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+var log = logger.GetOrCreate("process/client")
+
+// DialFunc defines the function used to connect to an endpoint
+type DialFunc func(ctx context.Context, url string) (process.EthClient, error)
+
+// ArgsFailoverConnector holds the arguments needed to create a failover connector
+type ArgsFailoverConnector struct {
+	Urls           []string
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Dial           DialFunc
+	StatusHandler  core.AppStatusHandler
+}
+
+type failoverConnector struct {
+	urls           []string
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	dial           DialFunc
+	statusHandler  core.AppStatusHandler
+
+	mut           sync.Mutex
+	urlIndex      int
+	backoff       time.Duration
+	connectedOnce bool
+}
+
+// NewFailoverConnector creates a connector which cycles through the endpoints: each (re)connection, as well as each
+// failed attempt, moves to the next endpoint. The attempts are delayed by an exponential backoff, which is reset once
+// a connection is reported healthy.
+func NewFailoverConnector(args ArgsFailoverConnector) (*failoverConnector, error) {
+	err := checkFailoverConnectorArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &failoverConnector{
+		urls:           args.Urls,
+		initialBackoff: args.InitialBackoff,
+		maxBackoff:     args.MaxBackoff,
+		dial:           args.Dial,
+		statusHandler:  args.StatusHandler,
+		backoff:        args.InitialBackoff,
+	}, nil
+}
+
+func checkFailoverConnectorArgs(args ArgsFailoverConnector) error {
+	if len(args.Urls) == 0 {
+		return process.ErrNoEndpoints
+	}
+	for _, url := range args.Urls {
+		if len(url) == 0 {
+			return process.ErrEmptyUrl
+		}
+	}
+	if args.InitialBackoff <= 0 {
+		return fmt.Errorf("%w for initial backoff: %v", process.ErrInvalidValue, args.InitialBackoff)
+	}
+	if args.MaxBackoff < args.InitialBackoff {
+		return fmt.Errorf("%w for max backoff: %v", process.ErrInvalidValue, args.MaxBackoff)
+	}
+	if args.Dial == nil {
+		return process.ErrNilDialer
+	}
+	if check.IfNil(args.StatusHandler) {
+		return process.ErrNilStatusHandler
+	}
+
+	return nil
+}
+
+// Connect connects to one of the endpoints, retrying until it succeeds or the context is done.
+// Except for the first connection, the previous connection is considered lost, so the next endpoint is used.
+func (fc *failoverConnector) Connect(ctx context.Context) (process.EthClient, error) {
+	fc.mut.Lock()
+	defer fc.mut.Unlock()
+
+	shouldWait := fc.connectedOnce
+	for {
+		if shouldWait {
+			fc.urlIndex = (fc.urlIndex + 1) % len(fc.urls)
+
+			err := fc.waitBackoff(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		shouldWait = true
+
+		url := fc.urls[fc.urlIndex]
+		client, err := fc.dial(ctx, url)
+		if err != nil {
+			log.Warn("cannot connect to the Ethereum node", "url", url, "error", err)
+			continue
+		}
+
+		log.Info("connected to the Ethereum node", "url", url)
+		fc.connectedOnce = true
+		fc.statusHandler.SetStringValue(process.MetricConnectedEndpoint, url)
+
+		return client, nil
+	}
+}
+
+func (fc *failoverConnector) waitBackoff(ctx context.Context) error {
+	log.Debug("waiting before connecting", "backoff", fc.backoff)
+
+	timer := time.NewTimer(fc.backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	fc.backoff *= 2
+	if fc.backoff > fc.maxBackoff {
+		fc.backoff = fc.maxBackoff
+	}
+
+	return nil
+}
+
+// NotifyHealthy resets the backoff
+func (fc *failoverConnector) NotifyHealthy() {
+	fc.mut.Lock()
+	fc.backoff = fc.initialBackoff
+	fc.mut.Unlock()
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (fc *failoverConnector) IsInterfaceNil() bool {
+	return fc == nil
+}
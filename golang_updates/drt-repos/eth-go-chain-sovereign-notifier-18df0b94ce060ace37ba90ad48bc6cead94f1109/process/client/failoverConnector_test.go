@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/testscommon"
+)
+
+const (
+	testInitialBackoff = 10 * time.Millisecond
+	testMaxBackoff     = 40 * time.Millisecond
+)
+
+type dialAttempt struct {
+	url       string
+	timestamp time.Time
+}
+
+// dialRecorder records the dial attempts, failing the first ones
+type dialRecorder struct {
+	attempts        []*dialAttempt
+	numFailuresLeft int
+}
+
+func (recorder *dialRecorder) dial(_ context.Context, url string) (process.EthClient, error) {
+	recorder.attempts = append(recorder.attempts, &dialAttempt{url: url, timestamp: time.Now()})
+	if recorder.numFailuresLeft > 0 {
+		recorder.numFailuresLeft--
+		return nil, errors.New("connection refused")
+	}
+
+	return &testscommon.EthClientStub{}, nil
+}
+
+func (recorder *dialRecorder) urls() []string {
+	urls := make([]string, 0, len(recorder.attempts))
+	for _, attempt := range recorder.attempts {
+		urls = append(urls, attempt.url)
+	}
+
+	return urls
+}
+
+func createMockArgsFailoverConnector() ArgsFailoverConnector {
+	return ArgsFailoverConnector{
+		Urls:           []string{"ws://first", "ws://second", "ws://third"},
+		InitialBackoff: testInitialBackoff,
+		MaxBackoff:     testMaxBackoff,
+		Dial: func(ctx context.Context, url string) (process.EthClient, error) {
+			return &testscommon.EthClientStub{}, nil
+		},
+		StatusHandler: status.NewStatusMetrics(),
+	}
+}
+
+func TestNewFailoverConnector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no endpoints should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.Urls = nil
+		connector, err := NewFailoverConnector(args)
+		require.Equal(t, process.ErrNoEndpoints, err)
+		require.Nil(t, connector)
+	})
+	t.Run("empty url should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.Urls = []string{"ws://first", ""}
+		connector, err := NewFailoverConnector(args)
+		require.Equal(t, process.ErrEmptyUrl, err)
+		require.Nil(t, connector)
+	})
+	t.Run("invalid backoff should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.InitialBackoff = 0
+		connector, err := NewFailoverConnector(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+		require.Nil(t, connector)
+
+		args = createMockArgsFailoverConnector()
+		args.MaxBackoff = testInitialBackoff - 1
+		connector, err = NewFailoverConnector(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+		require.Nil(t, connector)
+	})
+	t.Run("nil dialer should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.Dial = nil
+		connector, err := NewFailoverConnector(args)
+		require.Equal(t, process.ErrNilDialer, err)
+		require.Nil(t, connector)
+	})
+	t.Run("nil status handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.StatusHandler = nil
+		connector, err := NewFailoverConnector(args)
+		require.Equal(t, process.ErrNilStatusHandler, err)
+		require.Nil(t, connector)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		connector, err := NewFailoverConnector(createMockArgsFailoverConnector())
+		require.Nil(t, err)
+		require.False(t, connector.IsInterfaceNil())
+	})
+}
+
+func TestFailoverConnector_Connect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("first connection should use the first endpoint, without waiting", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.InitialBackoff = time.Hour
+		args.MaxBackoff = time.Hour
+		recorder := &dialRecorder{}
+		args.Dial = recorder.dial
+		connector, _ := NewFailoverConnector(args)
+
+		client, err := connector.Connect(context.Background())
+		require.Nil(t, err)
+		require.NotNil(t, client)
+		require.Equal(t, []string{"ws://first"}, recorder.urls())
+
+		metrics := args.StatusHandler.(status.MetricsGetter).GetMetrics()
+		require.Equal(t, "ws://first", metrics[process.MetricConnectedEndpoint])
+	})
+	t.Run("failed attempts should fail over to the next endpoints, with an exponential backoff", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		recorder := &dialRecorder{numFailuresLeft: 4}
+		args.Dial = recorder.dial
+		connector, _ := NewFailoverConnector(args)
+
+		_, err := connector.Connect(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, []string{"ws://first", "ws://second", "ws://third", "ws://first", "ws://second"}, recorder.urls())
+
+		// The backoff doubles after each attempt, up to the max backoff
+		expectedBackoffs := []time.Duration{testInitialBackoff, 2 * testInitialBackoff, testMaxBackoff, testMaxBackoff}
+		for i, expectedBackoff := range expectedBackoffs {
+			elapsed := recorder.attempts[i+1].timestamp.Sub(recorder.attempts[i].timestamp)
+			require.GreaterOrEqual(t, elapsed, expectedBackoff)
+		}
+
+		metrics := args.StatusHandler.(status.MetricsGetter).GetMetrics()
+		require.Equal(t, "ws://second", metrics[process.MetricConnectedEndpoint])
+	})
+	t.Run("reconnection should use the next endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		recorder := &dialRecorder{}
+		args.Dial = recorder.dial
+		connector, _ := NewFailoverConnector(args)
+
+		for i := 0; i < 4; i++ {
+			_, err := connector.Connect(context.Background())
+			require.Nil(t, err)
+		}
+		require.Equal(t, []string{"ws://first", "ws://second", "ws://third", "ws://first"}, recorder.urls())
+	})
+	t.Run("context done while waiting should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFailoverConnector()
+		args.InitialBackoff = time.Hour
+		args.MaxBackoff = time.Hour
+		recorder := &dialRecorder{numFailuresLeft: 1}
+		args.Dial = recorder.dial
+		connector, _ := NewFailoverConnector(args)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		client, err := connector.Connect(ctx)
+		require.Equal(t, context.DeadlineExceeded, err)
+		require.Nil(t, client)
+		require.Len(t, recorder.attempts, 1)
+	})
+}
+
+func TestFailoverConnector_NotifyHealthyShouldResetTheBackoff(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsFailoverConnector()
+	recorder := &dialRecorder{numFailuresLeft: 3}
+	args.Dial = recorder.dial
+	connector, _ := NewFailoverConnector(args)
+
+	_, err := connector.Connect(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, testMaxBackoff, connector.backoff)
+
+	connector.NotifyHealthy()
+	require.Equal(t, testInitialBackoff, connector.backoff)
+
+	_, err = connector.Connect(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, 2*testInitialBackoff, connector.backoff)
+}
@@ -0,0 +1,60 @@
+package process
+
+import "errors"
+
+// ErrNilEthClientConnector signals that a nil eth client connector has been provided
+var ErrNilEthClientConnector = errors.New("nil eth client connector")
+
+// ErrNilBlockEventsHandler signals that a nil block events handler has been provided
+var ErrNilBlockEventsHandler = errors.New("nil block events handler")
+
+// ErrNoSubscribedEvents signals that no subscribed events have been provided
+var ErrNoSubscribedEvents = errors.New("no subscribed events")
+
+// ErrEmptyIdentifier signals that a subscribed event has an empty identifier
+var ErrEmptyIdentifier = errors.New("empty identifier")
+
+// ErrInvalidAddress signals that an invalid address has been provided
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ErrNotifierAlreadyStarted signals that the notifier has already been started
+var ErrNotifierAlreadyStarted = errors.New("notifier already started")
+
+// ErrNilMarshaller signals that a nil marshaller has been provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrEmptyUrl signals that an empty url has been provided
+var ErrEmptyUrl = errors.New("empty url")
+
+// ErrInvalidValue signals that an invalid value has been provided
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrSenderClosed signals that the sender has been closed
+var ErrSenderClosed = errors.New("sender closed")
+
+// ErrNilSenderHost signals that a nil sender host has been provided
+var ErrNilSenderHost = errors.New("nil sender host")
+
+// ErrNilCheckpointStorer signals that a nil checkpoint storer has been provided
+var ErrNilCheckpointStorer = errors.New("nil checkpoint storer")
+
+// ErrEmptyPath signals that an empty path has been provided
+var ErrEmptyPath = errors.New("empty path")
+
+// ErrReorgTooDeep signals that a reorg dropped blocks older than the ones remembered by the notifier
+var ErrReorgTooDeep = errors.New("reorg too deep")
+
+// ErrNilStatusHandler signals that a nil status handler has been provided
+var ErrNilStatusHandler = errors.New("nil status handler")
+
+// ErrNoEndpoints signals that no endpoints have been provided
+var ErrNoEndpoints = errors.New("no endpoints")
+
+// ErrNilDialer signals that a nil dialer has been provided
+var ErrNilDialer = errors.New("nil dialer")
+
+// ErrHeadTimeout signals that no block header has been received in time
+var ErrHeadTimeout = errors.New("no block header received in time")
+
+// ErrSubscriptionClosed signals that a subscription has been closed by the Ethereum node
+var ErrSubscriptionClosed = errors.New("subscription closed")
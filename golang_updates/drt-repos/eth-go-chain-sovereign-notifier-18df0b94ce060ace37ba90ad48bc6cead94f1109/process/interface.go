@@ -0,0 +1,60 @@
+package process
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+// EthClientHandler defines the subset of the Ethereum client used by the notifier.
+// It is satisfied both by the websocket client and by go-ethereum's simulated backend.
+type EthClientHandler interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// EthClient defines an Ethereum client over a connection, which is closed once the client is no longer used
+type EthClient interface {
+	EthClientHandler
+	Close()
+}
+
+// EthClientConnector defines what a provider of connected Ethereum clients should do.
+// Connect blocks until a connection is established (or the context is done), while NotifyHealthy signals that the
+// last provided connection works as expected.
+type EthClientConnector interface {
+	Connect(ctx context.Context) (EthClient, error)
+	NotifyHealthy()
+	IsInterfaceNil() bool
+}
+
+// BlockEventsHandler defines what a receiver of per-block notifications should do.
+// A retracted block is a previously handled block which was dropped from the canonical chain by a reorg.
+type BlockEventsHandler interface {
+	HandleBlockEvents(blockEvents *data.BlockEvents) error
+	HandleRetractedBlock(block *data.BlockInfo) error
+	IsInterfaceNil() bool
+}
+
+// CheckpointStorer defines what a storer of the notifier's checkpoint should do
+type CheckpointStorer interface {
+	LoadCheckpoint() (*data.Checkpoint, error)
+	SaveCheckpoint(checkpoint *data.Checkpoint) error
+	IsInterfaceNil() bool
+}
+
+// SenderHost defines the websocket host through which the outport payloads are sent.
+// Send returns once the payload is delivered (and acknowledged, if required).
+type SenderHost interface {
+	Send(payload []byte, topic string) error
+	Close() error
+	IsInterfaceNil() bool
+}
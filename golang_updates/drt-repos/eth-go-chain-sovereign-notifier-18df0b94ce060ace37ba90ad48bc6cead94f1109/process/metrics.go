@@ -0,0 +1,30 @@
+package process
+
+const (
+	// MetricConnectedEndpoint is the metric holding the url of the Ethereum node the notifier is (or was last) connected to
+	MetricConnectedEndpoint = "connected_endpoint"
+
+	// MetricIsConnected is the metric signaling whether the notifier is connected and subscribed
+	MetricIsConnected = "is_connected"
+
+	// MetricNumReconnections is the metric holding the number of times the connection was lost and re-established
+	MetricNumReconnections = "num_reconnections"
+
+	// MetricLastHeadNumber is the metric holding the number of the last received block header
+	MetricLastHeadNumber = "last_head_number"
+
+	// MetricLastHeadReceivedTimestamp is the metric holding the unix timestamp at which the last block header was received
+	MetricLastHeadReceivedTimestamp = "last_head_received_timestamp"
+
+	// MetricLastRelayedBlockNumber is the metric holding the number of the last relayed block
+	MetricLastRelayedBlockNumber = "last_relayed_block_number"
+
+	// MetricNumRelayedBlocks is the metric holding the number of relayed blocks
+	MetricNumRelayedBlocks = "num_relayed_blocks"
+
+	// MetricNumRelayedEvents is the metric holding the number of relayed events
+	MetricNumRelayedEvents = "num_relayed_events"
+
+	// MetricNumRetractedBlocks is the metric holding the number of retracted blocks
+	MetricNumRetractedBlocks = "num_retracted_blocks"
+)
@@ -0,0 +1,192 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+// maxRangeFetchAttempts is the number of times a range of blocks is fetched while reorgs happen, before falling back
+// to fetching the events of each block by the block's hash
+const maxRangeFetchAttempts = 3
+
+// fetchedBlockEvents holds the events fetched for a block number, within a range of blocks
+type fetchedBlockEvents struct {
+	hash         common.Hash
+	events       []*data.Event
+	inconsistent bool
+}
+
+// backfill processes the blocks produced since the last known block (e.g. while the notifier was stopped or
+// disconnected), up to the current head. The events are fetched in batches of blocks, through ranged log queries.
+func (en *ethNotifier) backfill(ctx context.Context) error {
+	tipNumber, found := en.tipNumber()
+	if !found {
+		return nil
+	}
+
+	head, err := en.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w while fetching the head", err)
+	}
+
+	headNumber := head.Number.Uint64()
+	if headNumber <= tipNumber {
+		return nil
+	}
+
+	log.Info("backfilling blocks", "last known", tipNumber, "head", headNumber)
+
+	for fromNumber := tipNumber + 1; fromNumber <= headNumber; fromNumber += en.backfillBatchSize {
+		toNumber := fromNumber + en.backfillBatchSize - 1
+		if toNumber > headNumber {
+			toNumber = headNumber
+		}
+
+		err = en.backfillRange(ctx, fromNumber, toNumber)
+		if err != nil {
+			return err
+		}
+
+		log.Debug("backfilled blocks", "from", fromNumber, "to", toNumber)
+	}
+
+	return nil
+}
+
+func (en *ethNotifier) backfillRange(ctx context.Context, fromNumber uint64, toNumber uint64) error {
+	headers, eventsByNumber, isConsistent, err := en.fetchRange(ctx, fromNumber, toNumber)
+	if err != nil {
+		return err
+	}
+
+	for _, header := range headers {
+		tracked := &trackedHeader{
+			header: header,
+			hash:   header.Hash(),
+			source: eventsPrefetched,
+			events: make([]*data.Event, 0),
+		}
+
+		// If the logs were fetched from another fork than the header (a reorg happened meanwhile), the events are
+		// fetched again, by the block's hash, when the block is relayed. A block without logs is known to have no
+		// events only if the range was consistent, otherwise its logs might have been fetched from another fork.
+		fetched, found := eventsByNumber[tracked.number()]
+		switch {
+		case found && !fetched.inconsistent && fetched.hash == tracked.hash:
+			tracked.events = fetched.events
+		case found || !isConsistent:
+			tracked.source = eventsToFetch
+		}
+
+		err = en.addHeader(ctx, tracked)
+		if err != nil {
+			return err
+		}
+
+		err = en.relayConfirmed(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchRange fetches the headers of a range of blocks, then their events. The range is consistent if its headers are
+// linked and its last header is still canonical after the events were fetched: the events were then fetched from the
+// same fork as the headers.
+// Otherwise (a reorg happened meanwhile), the range is fetched again, up to a maximum number of attempts.
+func (en *ethNotifier) fetchRange(ctx context.Context, fromNumber uint64, toNumber uint64) ([]*types.Header, map[uint64]*fetchedBlockEvents, bool, error) {
+	for attempt := 1; ; attempt++ {
+		headers, err := en.fetchRangeHeaders(ctx, fromNumber, toNumber)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		eventsByNumber, err := en.fetchRangeEvents(ctx, fromNumber, toNumber)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		lastHeader, err := en.client.HeaderByNumber(ctx, new(big.Int).SetUint64(toNumber))
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("%w while fetching the header of block %d", err, toNumber)
+		}
+
+		isConsistent := areLinked(headers) && lastHeader.Hash() == headers[len(headers)-1].Hash()
+		if isConsistent || attempt == maxRangeFetchAttempts {
+			return headers, eventsByNumber, isConsistent, nil
+		}
+
+		log.Debug("reorg while backfilling blocks, fetching them again", "from", fromNumber, "to", toNumber, "attempt", attempt)
+	}
+}
+
+func areLinked(headers []*types.Header) bool {
+	for i := 1; i < len(headers); i++ {
+		if headers[i].ParentHash != headers[i-1].Hash() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (en *ethNotifier) fetchRangeHeaders(ctx context.Context, fromNumber uint64, toNumber uint64) ([]*types.Header, error) {
+	headers := make([]*types.Header, 0, toNumber-fromNumber+1)
+	for number := fromNumber; number <= toNumber; number++ {
+		header, err := en.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, fmt.Errorf("%w while fetching the header of block %d", err, number)
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers, nil
+}
+
+// fetchRangeEvents fetches the subscribed events of a range of blocks, grouped by block number
+func (en *ethNotifier) fetchRangeEvents(ctx context.Context, fromNumber uint64, toNumber uint64) (map[uint64]*fetchedBlockEvents, error) {
+	eventsByNumber := make(map[uint64]*fetchedBlockEvents)
+
+	for _, filter := range en.filters {
+		query := ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromNumber),
+			ToBlock:   new(big.Int).SetUint64(toNumber),
+			Addresses: filter.query.Addresses,
+			Topics:    filter.query.Topics,
+		}
+
+		logs, err := en.client.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("%w while fetching the logs of event %s, in blocks %d-%d", err, filter.identifier, fromNumber, toNumber)
+		}
+
+		for _, ethLog := range logs {
+			if ethLog.Removed {
+				continue
+			}
+
+			fetched, found := eventsByNumber[ethLog.BlockNumber]
+			if !found {
+				fetched = &fetchedBlockEvents{hash: ethLog.BlockHash}
+				eventsByNumber[ethLog.BlockNumber] = fetched
+			}
+			if fetched.hash != ethLog.BlockHash {
+				fetched.inconsistent = true
+			}
+
+			fetched.events = append(fetched.events, createEvent(filter.identifier, ethLog))
+		}
+	}
+
+	return eventsByNumber, nil
+}
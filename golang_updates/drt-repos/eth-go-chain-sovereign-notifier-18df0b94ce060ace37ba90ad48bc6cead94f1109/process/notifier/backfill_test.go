@@ -0,0 +1,161 @@
+package notifier
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/checkpoint"
+)
+
+// createRestartableArgs deploys a deposit contract and creates the arguments of notifiers subscribed to it, sharing
+// the same checkpoint file
+func createRestartableArgs(t *testing.T, chain *simulatedChain, client *simulatedClient, recorder *notifierRecorder) (ArgsEthNotifier, common.Address) {
+	depositContract := chain.deployEmitter(depositSignature)
+	connector := &countingConnector{client: client}
+	args := createTestArgs(connector.stub(), recorder.handler(), createDepositSubscribedEvents(depositContract))
+	args.CheckpointStorer, _ = checkpoint.NewFileCheckpointStorer(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	return args, depositContract
+}
+
+// restartNotifier starts a new notifier, with the checkpoint of the previous one, relaying to a new recorder
+func restartNotifier(t *testing.T, args ArgsEthNotifier) *notifierRecorder {
+	recorder := newNotifierRecorder()
+	args.Handler = recorder.handler()
+	startNotifier(t, args)
+
+	return recorder
+}
+
+func TestEthNotifier_BackfillsTheBlocksProducedWhileStopped(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	recorder := newNotifierRecorder()
+	args, depositContract := createRestartableArgs(t, chain, client, recorder)
+	args.BackfillBatchSize = 2
+	notifier := startNotifier(t, args)
+
+	lastRelayed := chain.commit()
+	unconfirmed := chain.commit()
+	nextBlockWithNumber(t, recorder, lastRelayed.Number.Uint64())
+	require.Nil(t, notifier.Close())
+
+	chain.emit(depositContract, []byte("deposit while stopped"))
+	withEvent := chain.commit()
+	headers := append([]*types.Header{unconfirmed, withEvent}, chain.commitEmpty(3)...)
+
+	recorder = restartNotifier(t, args)
+
+	// The blocks are relayed once, from the first block which was not relayed, up to the confirmed ones
+	for _, header := range headers[:len(headers)-1] {
+		blockEvents := recorder.nextBlock(t)
+		require.Equal(t, header.Number.Uint64(), blockEvents.Number)
+		require.Equal(t, header.Hash(), blockEvents.Hash)
+		if header == withEvent {
+			require.Len(t, blockEvents.Events, 1)
+			require.Equal(t, []byte("deposit while stopped"), blockEvents.Events[0].Data)
+		} else {
+			require.Empty(t, blockEvents.Events)
+		}
+	}
+	recorder.requireNoBlock(t)
+
+	chain.commit()
+	require.Equal(t, headers[len(headers)-1].Hash(), recorder.nextBlock(t).Hash)
+}
+
+func TestEthNotifier_RetractsOnRestartTheBlocksDroppedWhileStopped(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	recorder := newNotifierRecorder()
+	args, depositContract := createRestartableArgs(t, chain, client, recorder)
+	notifier := startNotifier(t, args)
+
+	ancestor := chain.commit()
+	dropped := chain.commitEmpty(3)
+	nextBlockWithNumber(t, recorder, ancestor.Number.Uint64())
+	require.Equal(t, dropped[0].Hash(), recorder.nextBlock(t).Hash)
+	require.Equal(t, dropped[1].Hash(), recorder.nextBlock(t).Hash)
+	require.Nil(t, notifier.Close())
+
+	chain.fork(ancestor.Hash())
+	chain.emit(depositContract, []byte("fork deposit"))
+	fork := []*types.Header{chain.commit()}
+	fork = append(fork, chain.commitEmpty(3)...)
+
+	recorder = restartNotifier(t, args)
+
+	require.Equal(t, dropped[1].Hash(), recorder.nextRetracted(t).Hash)
+	require.Equal(t, dropped[0].Hash(), recorder.nextRetracted(t).Hash)
+
+	blockEvents := recorder.nextBlock(t)
+	require.Equal(t, fork[0].Hash(), blockEvents.Hash)
+	require.Len(t, blockEvents.Events, 1)
+	require.Equal(t, []byte("fork deposit"), blockEvents.Events[0].Data)
+	require.Equal(t, fork[1].Hash(), recorder.nextBlock(t).Hash)
+	require.Equal(t, fork[2].Hash(), recorder.nextBlock(t).Hash)
+	recorder.requireNoBlock(t)
+}
+
+func TestEthNotifier_BackfillsTheCanonicalBlocksWhenAReorgHappensWhileFetchingThem(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	recorder := newNotifierRecorder()
+	args, depositContract := createRestartableArgs(t, chain, client, recorder)
+	notifier := startNotifier(t, args)
+
+	lastRelayed := chain.commit()
+	ancestor := chain.commit()
+	nextBlockWithNumber(t, recorder, lastRelayed.Number.Uint64())
+	require.Nil(t, notifier.Close())
+
+	chain.emit(depositContract, []byte("dropped deposit"))
+	chain.commit()
+	chain.commit()
+
+	// The chain is reorganized after the headers of the range were fetched, before their logs are fetched
+	var fork []*types.Header
+	var reorgOnce sync.Once
+	client.filterLogsCalled = func(query ethereum.FilterQuery, logs []types.Log) []types.Log {
+		if query.FromBlock == nil {
+			return logs
+		}
+
+		reorgOnce.Do(func() {
+			chain.fork(ancestor.Hash())
+			chain.emit(depositContract, []byte("fork deposit"))
+			fork = append(fork, chain.commit())
+			fork = append(fork, chain.commitEmpty(2)...)
+		})
+
+		return logs
+	}
+
+	recorder = restartNotifier(t, args)
+
+	require.Equal(t, ancestor.Hash(), recorder.nextBlock(t).Hash)
+	blockEvents := recorder.nextBlock(t)
+	require.Equal(t, fork[0].Hash(), blockEvents.Hash)
+	require.Len(t, blockEvents.Events, 1)
+	require.Equal(t, []byte("fork deposit"), blockEvents.Events[0].Data)
+	require.Equal(t, fork[1].Hash(), recorder.nextBlock(t).Hash)
+	recorder.requireNoBlock(t)
+
+	select {
+	case retracted := <-recorder.retracted:
+		require.Fail(t, "unexpected retracted block", "number %d", retracted.Number)
+	default:
+	}
+}
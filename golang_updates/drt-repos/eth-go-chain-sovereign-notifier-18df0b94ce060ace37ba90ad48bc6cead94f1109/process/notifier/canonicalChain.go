@@ -0,0 +1,314 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+type eventsSource int
+
+const (
+	// eventsFromSubscriptions marks a header received live: its events are collected from the logs subscriptions
+	eventsFromSubscriptions eventsSource = iota
+	// eventsPrefetched marks a header whose events were fetched together with it (on backfill)
+	eventsPrefetched
+	// eventsToFetch marks a header whose events are fetched when it is relayed (e.g. an ancestor fetched on a reorg)
+	eventsToFetch
+)
+
+// trackedHeader is a block of the canonical chain which was not relayed yet
+type trackedHeader struct {
+	header *types.Header
+	hash   common.Hash
+	source eventsSource
+	events []*data.Event
+}
+
+func (tracked *trackedHeader) number() uint64 {
+	return tracked.header.Number.Uint64()
+}
+
+// The canonical chain, as known by the notifier, is made of the relayed blocks (within the retraction window)
+// followed by the unconfirmed ones, all of them being linked by their parent hashes.
+
+func (en *ethNotifier) lowestKnownNumber() (uint64, bool) {
+	if len(en.relayed) > 0 {
+		return en.relayed[0].Number, true
+	}
+	if len(en.unconfirmed) > 0 {
+		return en.unconfirmed[0].number(), true
+	}
+
+	return 0, false
+}
+
+func (en *ethNotifier) lastRelayed() (*data.BlockInfo, bool) {
+	if len(en.relayed) == 0 {
+		return nil, false
+	}
+
+	return en.relayed[len(en.relayed)-1], true
+}
+
+func (en *ethNotifier) tipNumber() (uint64, bool) {
+	if len(en.unconfirmed) > 0 {
+		return en.unconfirmed[len(en.unconfirmed)-1].number(), true
+	}
+
+	lastRelayed, found := en.lastRelayed()
+	if !found {
+		return 0, false
+	}
+
+	return lastRelayed.Number, true
+}
+
+func (en *ethNotifier) knownHashAt(number uint64) (common.Hash, bool) {
+	for _, tracked := range en.unconfirmed {
+		if tracked.number() == number {
+			return tracked.hash, true
+		}
+	}
+	for _, relayed := range en.relayed {
+		if relayed.Number == number {
+			return relayed.Hash, true
+		}
+	}
+
+	return common.Hash{}, false
+}
+
+// addHeader appends a header to the canonical chain. If the header does not extend the chain's tip, the chain is
+// reorganized: the known blocks which are not ancestors of the header are dropped (the relayed ones being retracted),
+// while the missing ancestors of the header are fetched and appended before it.
+func (en *ethNotifier) addHeader(ctx context.Context, tracked *trackedHeader) error {
+	lowestNumber, hasKnownBlocks := en.lowestKnownNumber()
+	if !hasKnownBlocks {
+		en.unconfirmed = append(en.unconfirmed, tracked)
+		return nil
+	}
+
+	number := tracked.number()
+	if number < lowestNumber {
+		log.Debug("ignoring outdated block header", "number", number, "hash", tracked.hash.Hex())
+		return nil
+	}
+
+	knownHash, found := en.knownHashAt(number)
+	if found && knownHash == tracked.hash {
+		return nil
+	}
+
+	segment, ancestorNumber, err := en.linkToKnownChain(ctx, tracked, lowestNumber)
+	if errors.Is(err, process.ErrReorgTooDeep) {
+		log.Error("the known blocks were dropped by a reorg, tracking restarts from the received block",
+			"number", number, "hash", tracked.hash.Hex(), "lowest known number", lowestNumber)
+		en.relayed = nil
+		en.unconfirmed = []*trackedHeader{tracked}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	en.rollback(ancestorNumber)
+	en.unconfirmed = append(en.unconfirmed, segment...)
+
+	return nil
+}
+
+// linkToKnownChain walks back from the header, fetching its ancestors, until reaching a known block. It returns
+// the headers to be appended (oldest first) and the number of their common ancestor with the known chain.
+func (en *ethNotifier) linkToKnownChain(ctx context.Context, tracked *trackedHeader, lowestNumber uint64) ([]*trackedHeader, uint64, error) {
+	segment := []*trackedHeader{tracked}
+	current := tracked.header
+
+	for {
+		number := current.Number.Uint64()
+		if number <= lowestNumber {
+			return nil, 0, process.ErrReorgTooDeep
+		}
+
+		knownHash, found := en.knownHashAt(number - 1)
+		if found && knownHash == current.ParentHash {
+			break
+		}
+
+		parent, err := en.client.HeaderByHash(ctx, current.ParentHash)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w while fetching the header %s", err, current.ParentHash.Hex())
+		}
+
+		segment = append(segment, &trackedHeader{
+			header: parent,
+			hash:   current.ParentHash,
+			source: eventsToFetch,
+		})
+		current = parent
+	}
+
+	for i, j := 0, len(segment)-1; i < j; i, j = i+1, j-1 {
+		segment[i], segment[j] = segment[j], segment[i]
+	}
+
+	return segment, current.Number.Uint64() - 1, nil
+}
+
+// rollback drops the known blocks newer than the common ancestor, retracting the relayed ones (newest first)
+func (en *ethNotifier) rollback(ancestorNumber uint64) {
+	numDropped := 0
+	for len(en.unconfirmed) > 0 && en.unconfirmed[len(en.unconfirmed)-1].number() > ancestorNumber {
+		en.unconfirmed = en.unconfirmed[:len(en.unconfirmed)-1]
+		numDropped++
+	}
+
+	numRetracted := 0
+	for len(en.relayed) > 0 && en.relayed[len(en.relayed)-1].Number > ancestorNumber {
+		retracted := en.relayed[len(en.relayed)-1]
+		en.relayed = en.relayed[:len(en.relayed)-1]
+		numRetracted++
+
+		err := en.handler.HandleRetractedBlock(retracted)
+		if err != nil {
+			log.Error("cannot handle retracted block", "number", retracted.Number, "hash", retracted.Hash.Hex(), "error", err)
+		}
+		en.statusHandler.Increment(process.MetricNumRetractedBlocks)
+	}
+
+	if numDropped == 0 && numRetracted == 0 {
+		return
+	}
+
+	log.Info("reorg detected", "common ancestor", ancestorNumber, "num dropped blocks", numDropped, "num retracted blocks", numRetracted)
+	if numRetracted > 0 {
+		en.saveCheckpoint()
+	}
+}
+
+// relayConfirmed relays, in order, the unconfirmed blocks which have enough blocks on top of them
+func (en *ethNotifier) relayConfirmed(ctx context.Context) error {
+	for len(en.unconfirmed) > 0 {
+		tipNumber, _ := en.tipNumber()
+		oldest := en.unconfirmed[0]
+		if oldest.number()+en.confirmationDepth > tipNumber {
+			return nil
+		}
+
+		err := en.relay(ctx, oldest)
+		if err != nil {
+			return err
+		}
+
+		en.unconfirmed = en.unconfirmed[1:]
+	}
+
+	return nil
+}
+
+func (en *ethNotifier) relay(ctx context.Context, tracked *trackedHeader) error {
+	events, err := en.getEvents(ctx, tracked)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LogIndex < events[j].LogIndex
+	})
+
+	rawHeader, err := rlp.EncodeToBytes(tracked.header)
+	if err != nil {
+		return fmt.Errorf("%w while encoding the header of block %d", err, tracked.number())
+	}
+
+	blockEvents := &data.BlockEvents{
+		Number:     tracked.number(),
+		Hash:       tracked.hash,
+		ParentHash: tracked.header.ParentHash,
+		Timestamp:  tracked.header.Time,
+		RawHeader:  rawHeader,
+		Events:     events,
+	}
+
+	err = en.handler.HandleBlockEvents(blockEvents)
+	if err != nil {
+		return fmt.Errorf("%w while handling the events of block %d", err, tracked.number())
+	}
+
+	en.relayed = append(en.relayed, &data.BlockInfo{
+		Number:     blockEvents.Number,
+		Hash:       blockEvents.Hash,
+		ParentHash: blockEvents.ParentHash,
+	})
+	if uint64(len(en.relayed)) > en.retractionWindow {
+		en.relayed = en.relayed[uint64(len(en.relayed))-en.retractionWindow:]
+	}
+
+	en.saveCheckpoint()
+	en.removeStaleLogs(blockEvents.Number)
+
+	en.statusHandler.SetUInt64Value(process.MetricLastRelayedBlockNumber, blockEvents.Number)
+	en.statusHandler.Increment(process.MetricNumRelayedBlocks)
+	en.statusHandler.AddUint64(process.MetricNumRelayedEvents, uint64(len(events)))
+
+	return nil
+}
+
+func (en *ethNotifier) getEvents(ctx context.Context, tracked *trackedHeader) ([]*data.Event, error) {
+	switch tracked.source {
+	case eventsFromSubscriptions:
+		blockLogs, found := en.pendingLogs[tracked.hash]
+		if !found {
+			return make([]*data.Event, 0), nil
+		}
+		return blockLogs.events, nil
+	case eventsPrefetched:
+		return tracked.events, nil
+	default:
+		return en.fetchBlockEvents(ctx, tracked.hash)
+	}
+}
+
+// fetchBlockEvents fetches the subscribed events of a block, by the block's hash
+func (en *ethNotifier) fetchBlockEvents(ctx context.Context, hash common.Hash) ([]*data.Event, error) {
+	events := make([]*data.Event, 0)
+	for _, filter := range en.filters {
+		blockHash := hash
+		query := ethereum.FilterQuery{
+			BlockHash: &blockHash,
+			Addresses: filter.query.Addresses,
+			Topics:    filter.query.Topics,
+		}
+
+		logs, err := en.client.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("%w while fetching the logs of event %s, in block %s", err, filter.identifier, hash.Hex())
+		}
+
+		for _, ethLog := range logs {
+			if !ethLog.Removed {
+				events = append(events, createEvent(filter.identifier, ethLog))
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func (en *ethNotifier) saveCheckpoint() {
+	err := en.checkpointStorer.SaveCheckpoint(&data.Checkpoint{
+		RelayedBlocks: en.relayed,
+	})
+	if err != nil {
+		log.Error("cannot save the checkpoint", "error", err)
+	}
+}
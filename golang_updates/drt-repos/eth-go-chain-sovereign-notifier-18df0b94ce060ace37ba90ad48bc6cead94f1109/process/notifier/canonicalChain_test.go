@@ -0,0 +1,123 @@
+package notifier
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/checkpoint"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
+)
+
+func TestEthNotifier_RelaysOnlyTheConfirmedBlocks(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	connector := &countingConnector{client: client}
+	args := createTestArgs(connector.stub(), recorder.handler(), createDepositSubscribedEvents(depositContract))
+	args.ConfirmationDepth = 3
+	startNotifier(t, args)
+
+	chain.emit(depositContract, []byte("deposit"))
+	header := chain.commit()
+	client.waitForDeliveredLogs(t, 1)
+	chain.commitEmpty(2)
+
+	// The block has only 2 blocks on top of it
+	recorder.requireNoBlockFrom(t, header.Number.Uint64())
+
+	chain.commit()
+	blockEvents := nextBlockWithNumber(t, recorder, header.Number.Uint64())
+	require.Equal(t, header.Hash(), blockEvents.Hash)
+	require.Len(t, blockEvents.Events, 1)
+	require.Equal(t, []byte("deposit"), blockEvents.Events[0].Data)
+	recorder.requireNoBlock(t)
+}
+
+func TestEthNotifier_RetractsTheRelayedBlocksDroppedByAReorg(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	connector := &countingConnector{client: client}
+	args := createTestArgs(connector.stub(), recorder.handler(), createDepositSubscribedEvents(depositContract))
+	startNotifier(t, args)
+
+	ancestor := chain.commit()
+	chain.emit(depositContract, []byte("dropped deposit"))
+	dropped := []*types.Header{chain.commit()}
+	client.waitForDeliveredLogs(t, 1)
+	dropped = append(dropped, chain.commitEmpty(2)...)
+
+	nextBlockWithNumber(t, recorder, ancestor.Number.Uint64())
+	for _, header := range dropped[:2] {
+		relayed := recorder.nextBlock(t)
+		require.Equal(t, header.Hash(), relayed.Hash)
+	}
+
+	// The fork becomes canonical once it is longer than the known chain
+	chain.fork(ancestor.Hash())
+	chain.emit(depositContract, []byte("fork deposit"))
+	fork := []*types.Header{chain.commit()}
+	fork = append(fork, chain.commitEmpty(3)...)
+
+	require.Equal(t, dropped[1].Hash(), recorder.nextRetracted(t).Hash)
+	require.Equal(t, dropped[0].Hash(), recorder.nextRetracted(t).Hash)
+
+	// The events of the fork blocks are fetched by the blocks' hashes
+	blockEvents := recorder.nextBlock(t)
+	require.Equal(t, fork[0].Hash(), blockEvents.Hash)
+	require.Len(t, blockEvents.Events, 1)
+	require.Equal(t, []byte("fork deposit"), blockEvents.Events[0].Data)
+	for _, header := range fork[1:3] {
+		blockEvents = recorder.nextBlock(t)
+		require.Equal(t, header.Number.Uint64(), blockEvents.Number)
+		require.Equal(t, header.Hash(), blockEvents.Hash)
+		require.Empty(t, blockEvents.Events)
+	}
+	recorder.requireNoBlock(t)
+
+	metrics := args.StatusHandler.(status.MetricsGetter).GetMetrics()
+	require.Equal(t, uint64(2), metrics[process.MetricNumRetractedBlocks])
+}
+
+func TestEthNotifier_SavesTheRelayedBlocksInTheCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	connector := &countingConnector{client: &simulatedClient{SimulatedBackend: chain.backend}}
+	args := createTestArgs(connector.stub(), recorder.handler(), createDepositSubscribedEvents(depositContract))
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	args.CheckpointStorer, _ = checkpoint.NewFileCheckpointStorer(checkpointPath)
+	args.RetractionWindow = 2
+	notifier := startNotifier(t, args)
+
+	headers := chain.commitEmpty(4)
+	nextBlockWithNumber(t, recorder, headers[0].Number.Uint64())
+	recorder.nextBlock(t)
+	recorder.nextBlock(t)
+	require.Nil(t, notifier.Close())
+
+	// Only the blocks within the retraction window are kept
+	storer, _ := checkpoint.NewFileCheckpointStorer(checkpointPath)
+	savedCheckpoint, err := storer.LoadCheckpoint()
+	require.Nil(t, err)
+	require.Equal(t, []*data.BlockInfo{
+		{Number: headers[1].Number.Uint64(), Hash: headers[1].Hash(), ParentHash: headers[0].Hash()},
+		{Number: headers[2].Number.Uint64(), Hash: headers[2].Hash(), ParentHash: headers[1].Hash()},
+	}, savedCheckpoint.RelayedBlocks)
+}
@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
+)
+
+func TestEthNotifier_ReconnectsWhenNoHeadIsReceivedInTime(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	connector := &countingConnector{client: &simulatedClient{SimulatedBackend: chain.backend}}
+	args := createTestArgs(connector.stub(), recorder.handler(), createDepositSubscribedEvents(depositContract))
+	args.HeadTimeout = 100 * time.Millisecond
+	startNotifier(t, args)
+
+	require.Eventually(t, func() bool {
+		return connector.getNumConnections() >= 3
+	}, testTimeout, 10*time.Millisecond)
+
+	metrics := args.StatusHandler.(status.MetricsGetter).GetMetrics()
+	numReconnections, _ := metrics[process.MetricNumReconnections].(uint64)
+	require.GreaterOrEqual(t, numReconnections, uint64(2))
+}
+
+func TestEthNotifier_ReceivedHeadsShouldKeepTheConnection(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	connector := &countingConnector{client: &simulatedClient{SimulatedBackend: chain.backend}}
+	stub := connector.stub()
+	healthyNotifications := make(chan struct{}, 100)
+	stub.NotifyHealthyCalled = func() {
+		healthyNotifications <- struct{}{}
+	}
+	args := createTestArgs(stub, recorder.handler(), createDepositSubscribedEvents(depositContract))
+	args.HeadTimeout = 500 * time.Millisecond
+	startNotifier(t, args)
+
+	for i := 0; i < 10; i++ {
+		chain.commit()
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	require.Equal(t, 1, connector.getNumConnections())
+	require.GreaterOrEqual(t, len(healthyNotifications), 10)
+}
+
+func TestEthNotifier_RelaysTheBlocksProducedWhileTheConnectionWasStalled(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	connector := &countingConnector{client: client}
+	stub := connector.stub()
+	connect := stub.ConnectCalled
+	stub.ConnectCalled = func(ctx context.Context) (process.EthClient, error) {
+		// The heads are delivered again on the new connection
+		client.muteHeads(false)
+		return connect(ctx)
+	}
+	args := createTestArgs(stub, recorder.handler(), createDepositSubscribedEvents(depositContract))
+	args.HeadTimeout = 300 * time.Millisecond
+	startNotifier(t, args)
+
+	lastRelayed := chain.commit()
+	unconfirmed := chain.commit()
+	nextBlockWithNumber(t, recorder, lastRelayed.Number.Uint64())
+
+	client.muteHeads(true)
+	chain.emit(depositContract, []byte("deposit while stalled"))
+	withEvent := chain.commit()
+	chain.commit()
+
+	require.Equal(t, unconfirmed.Hash(), recorder.nextBlock(t).Hash)
+	blockEvents := recorder.nextBlock(t)
+	require.Equal(t, withEvent.Hash(), blockEvents.Hash)
+	require.Len(t, blockEvents.Events, 1)
+	require.Equal(t, []byte("deposit while stalled"), blockEvents.Events[0].Data)
+	recorder.requireNoBlock(t)
+
+	require.Equal(t, 2, connector.getNumConnections())
+	metrics := args.StatusHandler.(status.MetricsGetter).GetMetrics()
+	require.Equal(t, uint64(1), metrics[process.MetricNumReconnections])
+}
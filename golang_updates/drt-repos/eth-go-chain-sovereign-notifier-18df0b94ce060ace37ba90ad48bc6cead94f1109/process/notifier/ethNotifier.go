@@ -0,0 +1,501 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core"
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/config"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+var log = logger.GetOrCreate("process/notifier")
+
+const headersChannelSize = 100
+const logsChannelSize = 1000
+
+// ArgsEthNotifier holds the arguments needed to create an eth notifier
+type ArgsEthNotifier struct {
+	Connector         process.EthClientConnector
+	Handler           process.BlockEventsHandler
+	CheckpointStorer  process.CheckpointStorer
+	StatusHandler     core.AppStatusHandler
+	SubscribedEvents  []config.SubscribedEvent
+	ConfirmationDepth uint64
+	RetractionWindow  uint64
+	BackfillBatchSize uint64
+	HeadTimeout       time.Duration
+}
+
+type subscribedFilter struct {
+	identifier string
+	query      ethereum.FilterQuery
+}
+
+// matches checks whether a log is selected by the filter's query (by address and by the first topic)
+func (filter *subscribedFilter) matches(ethLog types.Log) bool {
+	if len(filter.query.Addresses) > 0 && !containsAddress(filter.query.Addresses, ethLog.Address) {
+		return false
+	}
+	if len(filter.query.Topics) == 0 {
+		return true
+	}
+
+	return len(ethLog.Topics) > 0 && ethLog.Topics[0] == filter.query.Topics[0][0]
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, candidate := range addresses {
+		if candidate == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+type pendingBlockLogs struct {
+	number uint64
+	events []*data.Event
+}
+
+func (blockLogs *pendingBlockLogs) contains(identifier string, ethLog types.Log) bool {
+	for _, event := range blockLogs.events {
+		if event.Identifier == identifier && event.TxHash == ethLog.TxHash && event.LogIndex == ethLog.Index {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ethNotifier struct {
+	connector         process.EthClientConnector
+	handler           process.BlockEventsHandler
+	checkpointStorer  process.CheckpointStorer
+	statusHandler     core.AppStatusHandler
+	filters           []*subscribedFilter
+	confirmationDepth uint64
+	retractionWindow  uint64
+	backfillBatchSize uint64
+	headTimeout       time.Duration
+
+	mutState sync.Mutex
+	cancel   func()
+	wg       sync.WaitGroup
+
+	relayed     []*data.BlockInfo
+	unconfirmed []*trackedHeader
+	pendingLogs map[common.Hash]*pendingBlockLogs
+
+	// client is the client of the current connection, only used by the processing goroutine
+	client process.EthClientHandler
+}
+
+// NewEthNotifier creates a notifier which subscribes to new block headers and to the configured contract logs.
+// Each log is correlated with its block, and a block (with all its subscribed events) is passed to the handler
+// once it has the configured number of confirmations. Reorgs are detected by tracking the parent hashes: the
+// relayed blocks which get dropped from the canonical chain are retracted. The relayed blocks are checkpointed,
+// so that a restarted notifier relays the blocks produced meanwhile before the new ones.
+// If the connection is lost (or no header is received in time), the notifier reconnects (through the connector)
+// and relays the blocks produced meanwhile, before resubscribing.
+func NewEthNotifier(args ArgsEthNotifier) (*ethNotifier, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := createFilters(args.SubscribedEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := args.CheckpointStorer.LoadCheckpoint()
+	if err != nil {
+		return nil, fmt.Errorf("%w while loading the checkpoint", err)
+	}
+
+	return &ethNotifier{
+		connector:         args.Connector,
+		handler:           args.Handler,
+		checkpointStorer:  args.CheckpointStorer,
+		statusHandler:     args.StatusHandler,
+		filters:           filters,
+		confirmationDepth: args.ConfirmationDepth,
+		retractionWindow:  args.RetractionWindow,
+		backfillBatchSize: args.BackfillBatchSize,
+		headTimeout:       args.HeadTimeout,
+		relayed:           checkpoint.RelayedBlocks,
+		pendingLogs:       make(map[common.Hash]*pendingBlockLogs),
+	}, nil
+}
+
+func checkArgs(args ArgsEthNotifier) error {
+	if check.IfNil(args.Connector) {
+		return process.ErrNilEthClientConnector
+	}
+	if check.IfNil(args.Handler) {
+		return process.ErrNilBlockEventsHandler
+	}
+	if check.IfNil(args.CheckpointStorer) {
+		return process.ErrNilCheckpointStorer
+	}
+	if check.IfNil(args.StatusHandler) {
+		return process.ErrNilStatusHandler
+	}
+	if len(args.SubscribedEvents) == 0 {
+		return process.ErrNoSubscribedEvents
+	}
+	// Live blocks are relayed only after the next block is received, once all their logs are known.
+	if args.ConfirmationDepth < 1 {
+		return fmt.Errorf("%w for confirmation depth: %d", process.ErrInvalidValue, args.ConfirmationDepth)
+	}
+	if args.RetractionWindow < 1 {
+		return fmt.Errorf("%w for retraction window: %d", process.ErrInvalidValue, args.RetractionWindow)
+	}
+	if args.BackfillBatchSize < 1 {
+		return fmt.Errorf("%w for backfill batch size: %d", process.ErrInvalidValue, args.BackfillBatchSize)
+	}
+	if args.HeadTimeout <= 0 {
+		return fmt.Errorf("%w for head timeout: %v", process.ErrInvalidValue, args.HeadTimeout)
+	}
+
+	return nil
+}
+
+func createFilters(subscribedEvents []config.SubscribedEvent) ([]*subscribedFilter, error) {
+	filters := make([]*subscribedFilter, 0, len(subscribedEvents))
+
+	for _, subscribedEvent := range subscribedEvents {
+		if len(subscribedEvent.Identifier) == 0 {
+			return nil, process.ErrEmptyIdentifier
+		}
+
+		addresses := make([]common.Address, 0, len(subscribedEvent.Addresses))
+		for _, address := range subscribedEvent.Addresses {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("%w: %s, for event %s", process.ErrInvalidAddress, address, subscribedEvent.Identifier)
+			}
+
+			addresses = append(addresses, common.HexToAddress(address))
+		}
+
+		query := ethereum.FilterQuery{
+			Addresses: addresses,
+		}
+		if len(subscribedEvent.Signature) > 0 {
+			query.Topics = [][]common.Hash{{crypto.Keccak256Hash([]byte(subscribedEvent.Signature))}}
+		}
+
+		filters = append(filters, &subscribedFilter{
+			identifier: subscribedEvent.Identifier,
+			query:      query,
+		})
+	}
+
+	return filters, nil
+}
+
+// Start starts connecting, subscribing to new block headers and to the configured logs, and processing them
+func (en *ethNotifier) Start() error {
+	en.mutState.Lock()
+	defer en.mutState.Unlock()
+
+	if en.cancel != nil {
+		return process.ErrNotifierAlreadyStarted
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	en.cancel = cancel
+
+	en.wg.Add(1)
+	go func() {
+		defer en.wg.Done()
+
+		en.run(ctx)
+	}()
+
+	log.Info("eth notifier started", "num subscribed events", len(en.filters))
+
+	return nil
+}
+
+func (en *ethNotifier) run(ctx context.Context) {
+	for {
+		err := en.runConnection(ctx)
+		en.statusHandler.SetStringValue(process.MetricIsConnected, "false")
+		if ctx.Err() != nil {
+			log.Debug("eth notifier is closing...")
+			return
+		}
+
+		log.Warn("connection to the Ethereum node lost, reconnecting", "error", err)
+		en.statusHandler.Increment(process.MetricNumReconnections)
+	}
+}
+
+// runConnection connects, catches up with the chain and processes the subscriptions, until the connection is lost
+func (en *ethNotifier) runConnection(ctx context.Context) error {
+	client, err := en.connector.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	connectionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	en.client = client
+	subscriptions := make([]ethereum.Subscription, 0, len(en.filters)+1)
+	var wgErrorWatchers sync.WaitGroup
+	defer func() {
+		cancel()
+		unsubscribeAll(subscriptions)
+		wgErrorWatchers.Wait()
+	}()
+
+	// The logs subscriptions are established first, so that the logs of any block received live are received as well.
+	// All of them deliver into the same channel, so that the logs are processed in the order in which they are received.
+	logs := make(chan types.Log, logsChannelSize)
+	subscriptionErrors := make(chan error, len(en.filters))
+	for _, filter := range en.filters {
+		subscription, errSubscribe := en.subscribeToLogs(connectionCtx, filter, logs, subscriptionErrors, &wgErrorWatchers)
+		if errSubscribe != nil {
+			return fmt.Errorf("%w while subscribing to logs of event %s", errSubscribe, filter.identifier)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	headers := make(chan *types.Header, headersChannelSize)
+	headersSubscription, err := client.SubscribeNewHead(connectionCtx, headers)
+	if err != nil {
+		return fmt.Errorf("%w while subscribing to new heads", err)
+	}
+	subscriptions = append(subscriptions, headersSubscription)
+
+	en.statusHandler.SetStringValue(process.MetricIsConnected, "true")
+
+	// The subscriptions are already established, so that nothing produced during the backfill is missed.
+	en.markTipEventsToFetch()
+	err = en.backfill(connectionCtx)
+	if err != nil {
+		if connectionCtx.Err() != nil {
+			return err
+		}
+		log.Error("cannot backfill the blocks produced since the last known block", "error", err)
+	}
+
+	return en.processLoop(connectionCtx, headers, headersSubscription.Err(), logs, subscriptionErrors)
+}
+
+// markTipEventsToFetch marks the newest known block (if received live) to have its events fetched when relayed,
+// since some of its logs might have been missed, if the previous connection was lost before they were received
+func (en *ethNotifier) markTipEventsToFetch() {
+	if len(en.unconfirmed) == 0 {
+		return
+	}
+
+	tip := en.unconfirmed[len(en.unconfirmed)-1]
+	if tip.source == eventsFromSubscriptions {
+		tip.source = eventsToFetch
+	}
+}
+
+// subscribeToLogs subscribes to the logs of a filter; the errors of the subscription are forwarded to the processing loop
+func (en *ethNotifier) subscribeToLogs(
+	ctx context.Context,
+	filter *subscribedFilter,
+	logs chan<- types.Log,
+	subscriptionErrors chan<- error,
+	wgErrorWatchers *sync.WaitGroup,
+) (ethereum.Subscription, error) {
+	subscription, err := en.client.SubscribeFilterLogs(ctx, filter.query, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	wgErrorWatchers.Add(1)
+	go func() {
+		defer wgErrorWatchers.Done()
+
+		select {
+		case <-ctx.Done():
+		case err := <-subscription.Err():
+			if err == nil {
+				err = process.ErrSubscriptionClosed
+			}
+			subscriptionErrors <- fmt.Errorf("%w in logs subscription of event %s", err, filter.identifier)
+		}
+	}()
+
+	return subscription, nil
+}
+
+// processLoop processes the received headers and logs, until a subscription fails or no header is received in time
+func (en *ethNotifier) processLoop(
+	ctx context.Context,
+	headers <-chan *types.Header,
+	headersErrors <-chan error,
+	logs <-chan types.Log,
+	subscriptionErrors <-chan error,
+) error {
+	watchdog := time.NewTimer(en.headTimeout)
+	defer watchdog.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-watchdog.C:
+			return fmt.Errorf("%w: %v", process.ErrHeadTimeout, en.headTimeout)
+		case err := <-headersErrors:
+			if err == nil {
+				err = process.ErrSubscriptionClosed
+			}
+			return fmt.Errorf("%w in new heads subscription", err)
+		case err := <-subscriptionErrors:
+			return err
+		case header := <-headers:
+			if !watchdog.Stop() {
+				<-watchdog.C
+			}
+			watchdog.Reset(en.headTimeout)
+
+			en.connector.NotifyHealthy()
+			en.processQueuedLogs(logs)
+			en.processHeader(ctx, header)
+		case ethLog := <-logs:
+			en.processLog(ethLog)
+		}
+	}
+}
+
+func (en *ethNotifier) processHeader(ctx context.Context, header *types.Header) {
+	hash := header.Hash()
+	log.Trace("received block header", "number", header.Number.Uint64(), "hash", hash.Hex())
+	en.statusHandler.SetUInt64Value(process.MetricLastHeadNumber, header.Number.Uint64())
+	en.statusHandler.SetInt64Value(process.MetricLastHeadReceivedTimestamp, time.Now().Unix())
+
+	err := en.addHeader(ctx, &trackedHeader{
+		header: header,
+		hash:   hash,
+		source: eventsFromSubscriptions,
+	})
+	if err != nil {
+		log.Error("cannot process block header", "number", header.Number.Uint64(), "hash", hash.Hex(), "error", err)
+		return
+	}
+
+	err = en.relayConfirmed(ctx)
+	if err != nil {
+		log.Error("cannot relay confirmed blocks", "error", err)
+	}
+}
+
+// processQueuedLogs processes the logs received before a header, so that a block's logs are known when the blocks
+// on top of it are processed
+func (en *ethNotifier) processQueuedLogs(logs <-chan types.Log) {
+	for {
+		select {
+		case ethLog := <-logs:
+			en.processLog(ethLog)
+		default:
+			return
+		}
+	}
+}
+
+// processLog adds the log as an event of its block, once for each subscribed event it matches (a log matching more
+// subscriptions is received once from each of them)
+func (en *ethNotifier) processLog(ethLog types.Log) {
+	if ethLog.Removed {
+		en.removeLog(ethLog)
+		return
+	}
+
+	blockLogs, found := en.pendingLogs[ethLog.BlockHash]
+	if !found {
+		blockLogs = &pendingBlockLogs{number: ethLog.BlockNumber}
+		en.pendingLogs[ethLog.BlockHash] = blockLogs
+	}
+
+	for _, filter := range en.filters {
+		if filter.matches(ethLog) && !blockLogs.contains(filter.identifier, ethLog) {
+			blockLogs.events = append(blockLogs.events, createEvent(filter.identifier, ethLog))
+		}
+	}
+}
+
+func (en *ethNotifier) removeLog(ethLog types.Log) {
+	blockLogs, found := en.pendingLogs[ethLog.BlockHash]
+	if !found {
+		return
+	}
+
+	events := make([]*data.Event, 0, len(blockLogs.events))
+	for _, event := range blockLogs.events {
+		if event.TxHash != ethLog.TxHash || event.LogIndex != ethLog.Index {
+			events = append(events, event)
+		}
+	}
+	blockLogs.events = events
+}
+
+// removeStaleLogs drops the logs of blocks which cannot be relayed anymore (already relayed, or orphaned by a reorg)
+func (en *ethNotifier) removeStaleLogs(lastRelayedNumber uint64) {
+	for hash, blockLogs := range en.pendingLogs {
+		if blockLogs.number <= lastRelayedNumber {
+			delete(en.pendingLogs, hash)
+		}
+	}
+}
+
+// Close stops processing and unsubscribes
+func (en *ethNotifier) Close() error {
+	en.mutState.Lock()
+	defer en.mutState.Unlock()
+
+	if en.cancel == nil {
+		return nil
+	}
+
+	en.cancel()
+	en.wg.Wait()
+	en.cancel = nil
+
+	return nil
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (en *ethNotifier) IsInterfaceNil() bool {
+	return en == nil
+}
+
+func createEvent(identifier string, ethLog types.Log) *data.Event {
+	return &data.Event{
+		Identifier: identifier,
+		Address:    ethLog.Address,
+		Topics:     ethLog.Topics,
+		Data:       ethLog.Data,
+		TxHash:     ethLog.TxHash,
+		TxIndex:    ethLog.TxIndex,
+		LogIndex:   ethLog.Index,
+	}
+}
+
+func unsubscribeAll(subscriptions []ethereum.Subscription) {
+	for _, subscription := range subscriptions {
+		subscription.Unsubscribe()
+	}
+}
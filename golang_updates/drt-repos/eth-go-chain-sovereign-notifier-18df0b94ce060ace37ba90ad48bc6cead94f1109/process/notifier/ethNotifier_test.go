@@ -0,0 +1,262 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/config"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/testscommon"
+)
+
+func createMockArgsEthNotifier() ArgsEthNotifier {
+	subscribedEvents := createTestSubscribedEvents(common.HexToAddress("0x01"), common.HexToAddress("0x02"))
+
+	return createTestArgs(&testscommon.EthClientConnectorStub{}, &testscommon.BlockEventsHandlerStub{}, subscribedEvents)
+}
+
+func TestNewEthNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil connector should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.Connector = nil
+		notifier, err := NewEthNotifier(args)
+		require.Equal(t, process.ErrNilEthClientConnector, err)
+		require.Nil(t, notifier)
+	})
+	t.Run("nil handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.Handler = nil
+		notifier, err := NewEthNotifier(args)
+		require.Equal(t, process.ErrNilBlockEventsHandler, err)
+		require.Nil(t, notifier)
+	})
+	t.Run("nil checkpoint storer should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.CheckpointStorer = nil
+		notifier, err := NewEthNotifier(args)
+		require.Equal(t, process.ErrNilCheckpointStorer, err)
+		require.Nil(t, notifier)
+	})
+	t.Run("nil status handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.StatusHandler = nil
+		notifier, err := NewEthNotifier(args)
+		require.Equal(t, process.ErrNilStatusHandler, err)
+		require.Nil(t, notifier)
+	})
+	t.Run("no subscribed events should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.SubscribedEvents = nil
+		notifier, err := NewEthNotifier(args)
+		require.Equal(t, process.ErrNoSubscribedEvents, err)
+		require.Nil(t, notifier)
+	})
+	t.Run("empty identifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.SubscribedEvents = []config.SubscribedEvent{{Signature: depositSignature}}
+		notifier, err := NewEthNotifier(args)
+		require.Equal(t, process.ErrEmptyIdentifier, err)
+		require.Nil(t, notifier)
+	})
+	t.Run("invalid address should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.SubscribedEvents = []config.SubscribedEvent{{Identifier: "deposit", Addresses: []string{"0x0g"}}}
+		notifier, err := NewEthNotifier(args)
+		require.True(t, errors.Is(err, process.ErrInvalidAddress))
+		require.Nil(t, notifier)
+	})
+	t.Run("invalid values should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEthNotifier()
+		args.ConfirmationDepth = 0
+		_, err := NewEthNotifier(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+
+		args = createMockArgsEthNotifier()
+		args.RetractionWindow = 0
+		_, err = NewEthNotifier(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+
+		args = createMockArgsEthNotifier()
+		args.BackfillBatchSize = 0
+		_, err = NewEthNotifier(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+
+		args = createMockArgsEthNotifier()
+		args.HeadTimeout = 0
+		_, err = NewEthNotifier(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+	})
+	t.Run("checkpoint loading error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsEthNotifier()
+		args.CheckpointStorer = &testscommon.CheckpointStorerStub{
+			LoadCheckpointCalled: func() (*data.Checkpoint, error) {
+				return nil, expectedErr
+			},
+		}
+		notifier, err := NewEthNotifier(args)
+		require.True(t, errors.Is(err, expectedErr))
+		require.Nil(t, notifier)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := NewEthNotifier(createMockArgsEthNotifier())
+		require.Nil(t, err)
+		require.False(t, notifier.IsInterfaceNil())
+	})
+}
+
+func TestEthNotifier_StartClose(t *testing.T) {
+	t.Parallel()
+
+	notifier, _ := NewEthNotifier(createMockArgsEthNotifier())
+
+	err := notifier.Start()
+	require.Nil(t, err)
+
+	err = notifier.Start()
+	require.Equal(t, process.ErrNotifierAlreadyStarted, err)
+
+	require.Nil(t, notifier.Close())
+	require.Nil(t, notifier.Close())
+}
+
+func TestEthNotifier_RelaysSubscribedEventsPerBlock(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+	transferContract := chain.deployEmitter(transferSignature)
+	// Emits the subscribed signature, but is not one of the subscribed addresses
+	otherContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	client := &simulatedClient{SimulatedBackend: chain.backend}
+	connector := &countingConnector{client: client}
+	args := createTestArgs(connector.stub(), recorder.handler(), createTestSubscribedEvents(depositContract, transferContract))
+	startNotifier(t, args)
+
+	parent := chain.commit()
+	firstDeposit := chain.emit(depositContract, []byte("first deposit"))
+	chain.emit(otherContract, []byte("other deposit"))
+	transfer := chain.emit(transferContract, []byte("transfer"))
+	secondDeposit := chain.emit(depositContract, []byte("second deposit"))
+	header := chain.commit()
+	client.waitForDeliveredLogs(t, 3)
+	chain.commit()
+
+	blockEvents := nextBlockWithNumber(t, recorder, header.Number.Uint64())
+	require.Equal(t, header.Hash(), blockEvents.Hash)
+	require.Equal(t, parent.Hash(), blockEvents.ParentHash)
+	require.Equal(t, header.Time, blockEvents.Timestamp)
+
+	decodedHeader := &types.Header{}
+	err := rlp.DecodeBytes(blockEvents.RawHeader, decodedHeader)
+	require.Nil(t, err)
+	require.Equal(t, header.Hash(), decodedHeader.Hash())
+
+	depositTopic := crypto.Keccak256Hash([]byte(depositSignature))
+	transferTopic := crypto.Keccak256Hash([]byte(transferSignature))
+	require.Equal(t, []*data.Event{
+		{
+			Identifier: "deposit",
+			Address:    depositContract,
+			Topics:     []common.Hash{depositTopic},
+			Data:       []byte("first deposit"),
+			TxHash:     firstDeposit,
+			TxIndex:    0,
+			LogIndex:   0,
+		},
+		{
+			Identifier: "transfer",
+			Address:    transferContract,
+			Topics:     []common.Hash{transferTopic},
+			Data:       []byte("transfer"),
+			TxHash:     transfer,
+			TxIndex:    2,
+			LogIndex:   2,
+		},
+		{
+			Identifier: "deposit",
+			Address:    depositContract,
+			Topics:     []common.Hash{depositTopic},
+			Data:       []byte("second deposit"),
+			TxHash:     secondDeposit,
+			TxIndex:    3,
+			LogIndex:   3,
+		},
+	}, blockEvents.Events)
+}
+
+func TestEthNotifier_RelaysEachNewHead(t *testing.T) {
+	t.Parallel()
+
+	chain := newSimulatedChain(t)
+	depositContract := chain.deployEmitter(depositSignature)
+
+	recorder := newNotifierRecorder()
+	connector := &countingConnector{client: &simulatedClient{SimulatedBackend: chain.backend}}
+	args := createTestArgs(connector.stub(), recorder.handler(), createDepositSubscribedEvents(depositContract))
+	startNotifier(t, args)
+
+	headers := chain.commitEmpty(4)
+
+	previous := nextBlockWithNumber(t, recorder, headers[0].Number.Uint64())
+	require.Equal(t, headers[0].Hash(), previous.Hash)
+	require.NotNil(t, previous.Events)
+	require.Empty(t, previous.Events)
+	for _, header := range headers[1:3] {
+		blockEvents := recorder.nextBlock(t)
+		require.Equal(t, header.Number.Uint64(), blockEvents.Number)
+		require.Equal(t, header.Hash(), blockEvents.Hash)
+		require.Equal(t, previous.Hash, blockEvents.ParentHash)
+		previous = blockEvents
+	}
+
+	// The last block is relayed once the next block is received
+	recorder.requireNoBlock(t)
+
+	metrics := args.StatusHandler.(status.MetricsGetter).GetMetrics()
+	require.Equal(t, headers[3].Number.Uint64(), metrics[process.MetricLastHeadNumber])
+	require.Equal(t, headers[2].Number.Uint64(), metrics[process.MetricLastRelayedBlockNumber])
+}
+
+// nextBlockWithNumber skips the blocks relayed before the provided one (e.g. the block received right after subscribing)
+func nextBlockWithNumber(t *testing.T, recorder *notifierRecorder, number uint64) *data.BlockEvents {
+	for {
+		blockEvents := recorder.nextBlock(t)
+		if blockEvents.Number >= number {
+			require.Equal(t, number, blockEvents.Number)
+			return blockEvents
+		}
+	}
+}
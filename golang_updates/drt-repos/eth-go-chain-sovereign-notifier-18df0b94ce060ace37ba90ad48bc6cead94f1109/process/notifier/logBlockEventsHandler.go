@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+type logBlockEventsHandler struct{}
+
+// NewLogBlockEventsHandler creates a block events handler which only logs the notifications
+func NewLogBlockEventsHandler() *logBlockEventsHandler {
+	return &logBlockEventsHandler{}
+}
+
+// HandleBlockEvents logs the block and its events
+func (handler *logBlockEventsHandler) HandleBlockEvents(blockEvents *data.BlockEvents) error {
+	log.Info("block events",
+		"number", blockEvents.Number,
+		"hash", blockEvents.Hash.Hex(),
+		"timestamp", blockEvents.Timestamp,
+		"num events", len(blockEvents.Events),
+	)
+
+	for _, event := range blockEvents.Events {
+		log.Debug("event",
+			"identifier", event.Identifier,
+			"address", event.Address.Hex(),
+			"tx hash", event.TxHash.Hex(),
+			"log index", event.LogIndex,
+		)
+	}
+
+	return nil
+}
+
+// HandleRetractedBlock logs the retracted block
+func (handler *logBlockEventsHandler) HandleRetractedBlock(block *data.BlockInfo) error {
+	log.Warn("retracted block",
+		"number", block.Number,
+		"hash", block.Hash.Hex(),
+	)
+
+	return nil
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (handler *logBlockEventsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
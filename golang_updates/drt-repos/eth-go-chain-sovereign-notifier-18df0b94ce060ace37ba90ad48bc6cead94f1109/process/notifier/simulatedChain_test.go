@@ -0,0 +1,411 @@
+package notifier
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/config"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/checkpoint"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process/status"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/testscommon"
+)
+
+const (
+	depositSignature  = "Deposit(address,uint256)"
+	transferSignature = "Transfer(address,uint256)"
+	testTimeout       = 10 * time.Second
+	callGasLimit      = 100_000
+	deployGasLimit    = 200_000
+)
+
+// simulatedChain is an in-memory Ethereum chain, on which contracts emitting logs are deployed and called
+type simulatedChain struct {
+	t       *testing.T
+	backend *backends.SimulatedBackend
+	key     *ecdsa.PrivateKey
+	sender  common.Address
+	signer  types.Signer
+	heads   chan *types.Header
+}
+
+func newSimulatedChain(t *testing.T) *simulatedChain {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	balance := new(big.Int).Mul(big.NewInt(1_000_000_000_000_000_000), big.NewInt(1000))
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{sender: {Balance: balance}}, 30_000_000)
+	heads := make(chan *types.Header, headersChannelSize)
+	headsSubscription, err := backend.SubscribeNewHead(context.Background(), heads)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		headsSubscription.Unsubscribe()
+		_ = backend.Close()
+	})
+
+	return &simulatedChain{
+		t:       t,
+		backend: backend,
+		key:     key,
+		sender:  sender,
+		signer:  types.LatestSigner(backend.Blockchain().Config()),
+		heads:   heads,
+	}
+}
+
+// emitterCode returns the creation code of a contract which, when called, emits a log with the provided topic,
+// having the call data as data
+func emitterCode(topic common.Hash) []byte {
+	// CALLDATACOPY(0, 0, CALLDATASIZE), LOG1(0, CALLDATASIZE, topic), STOP
+	runtime := []byte{0x36, 0x60, 0x00, 0x60, 0x00, 0x37, 0x7f}
+	runtime = append(runtime, topic.Bytes()...)
+	runtime = append(runtime, 0x36, 0x60, 0x00, 0xa1, 0x00)
+
+	// CODECOPY(0, len(creation), len(runtime)), RETURN(0, len(runtime))
+	creation := []byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}
+
+	return append(creation, runtime...)
+}
+
+// deployEmitter deploys (and commits) a contract emitting logs for the provided event signature
+func (chain *simulatedChain) deployEmitter(signature string) common.Address {
+	nonce, err := chain.backend.PendingNonceAt(context.Background(), chain.sender)
+	require.Nil(chain.t, err)
+
+	chain.sendTx(nil, emitterCode(crypto.Keccak256Hash([]byte(signature))), deployGasLimit)
+	chain.commit()
+
+	return crypto.CreateAddress(chain.sender, nonce)
+}
+
+// emit calls an emitter contract, in the pending block
+func (chain *simulatedChain) emit(contract common.Address, logData []byte) common.Hash {
+	return chain.sendTx(&contract, logData, callGasLimit)
+}
+
+func (chain *simulatedChain) sendTx(to *common.Address, txData []byte, gasLimit uint64) common.Hash {
+	ctx := context.Background()
+	nonce, err := chain.backend.PendingNonceAt(ctx, chain.sender)
+	require.Nil(chain.t, err)
+	gasPrice, err := chain.backend.SuggestGasPrice(ctx)
+	require.Nil(chain.t, err)
+	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(2))
+
+	var tx *types.Transaction
+	if to == nil {
+		tx = types.NewContractCreation(nonce, big.NewInt(0), gasLimit, gasPrice, txData)
+	} else {
+		tx = types.NewTransaction(nonce, *to, big.NewInt(0), gasLimit, gasPrice, txData)
+	}
+
+	signedTx, err := types.SignTx(tx, chain.signer, chain.key)
+	require.Nil(chain.t, err)
+	err = chain.backend.SendTransaction(ctx, signedTx)
+	require.Nil(chain.t, err)
+
+	return signedTx.Hash()
+}
+
+// commit commits the pending block, returning its header. If the block becomes the head, its head event is awaited,
+// so that the subscriptions made afterwards do not receive it
+func (chain *simulatedChain) commit() *types.Header {
+	hash := chain.backend.Commit()
+
+	header, err := chain.backend.HeaderByHash(context.Background(), hash)
+	require.Nil(chain.t, err)
+
+	head, err := chain.backend.HeaderByNumber(context.Background(), nil)
+	require.Nil(chain.t, err)
+	if head.Hash() == hash {
+		chain.waitHead(hash)
+	}
+
+	return header
+}
+
+func (chain *simulatedChain) waitHead(hash common.Hash) {
+	for {
+		select {
+		case head := <-chain.heads:
+			if head.Hash() == hash {
+				return
+			}
+		case <-time.After(testTimeout):
+			require.Fail(chain.t, "timeout waiting for the head event")
+			return
+		}
+	}
+}
+
+// commitEmpty commits the provided number of empty blocks, returning their headers
+func (chain *simulatedChain) commitEmpty(numBlocks int) []*types.Header {
+	headers := make([]*types.Header, 0, numBlocks)
+	for i := 0; i < numBlocks; i++ {
+		headers = append(headers, chain.commit())
+	}
+
+	return headers
+}
+
+// fork makes the next committed blocks build on top of the provided block
+func (chain *simulatedChain) fork(parent common.Hash) {
+	err := chain.backend.Fork(context.Background(), parent)
+	require.Nil(chain.t, err)
+}
+
+// simulatedClient exposes the simulated chain as a client of a connection, counting the logs delivered through the
+// subscriptions and optionally intercepting the logs queries. The delivery of new heads can be muted, as if the
+// connection were stalled.
+type simulatedClient struct {
+	*backends.SimulatedBackend
+	filterLogsCalled func(query ethereum.FilterQuery, logs []types.Log) []types.Log
+	numDeliveredLogs int64
+	headsMuted       int32
+}
+
+// SubscribeNewHead -
+func (client *simulatedClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	backendHeads := make(chan *types.Header)
+	subscription, err := client.SimulatedBackend.SubscribeNewHead(ctx, backendHeads)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer subscription.Unsubscribe()
+		for {
+			select {
+			case header := <-backendHeads:
+				if atomic.LoadInt32(&client.headsMuted) == 1 {
+					continue
+				}
+				select {
+				case ch <- header:
+				case <-quit:
+					return nil
+				}
+			case err := <-subscription.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func (client *simulatedClient) muteHeads(isMuted bool) {
+	var value int32
+	if isMuted {
+		value = 1
+	}
+	atomic.StoreInt32(&client.headsMuted, value)
+}
+
+// SubscribeFilterLogs -
+func (client *simulatedClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	backendLogs := make(chan types.Log)
+	subscription, err := client.SimulatedBackend.SubscribeFilterLogs(ctx, query, backendLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer subscription.Unsubscribe()
+		for {
+			select {
+			case ethLog := <-backendLogs:
+				select {
+				case ch <- ethLog:
+					atomic.AddInt64(&client.numDeliveredLogs, 1)
+				case <-quit:
+					return nil
+				}
+			case err := <-subscription.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// waitForDeliveredLogs waits until the provided number of logs was delivered, so that the next blocks are committed
+// after the logs of the previous ones were received
+func (client *simulatedClient) waitForDeliveredLogs(t *testing.T, numLogs int64) {
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&client.numDeliveredLogs) >= numLogs
+	}, testTimeout, time.Millisecond)
+}
+
+// FilterLogs -
+func (client *simulatedClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := client.SimulatedBackend.FilterLogs(ctx, query)
+	if err != nil || client.filterLogsCalled == nil {
+		return logs, err
+	}
+
+	return client.filterLogsCalled(query, logs), nil
+}
+
+// Close does not close the simulated chain, which outlives the connections
+func (client *simulatedClient) Close() {
+}
+
+// notifierRecorder records what the notifier relays and retracts
+type notifierRecorder struct {
+	blocks    chan *data.BlockEvents
+	retracted chan *data.BlockInfo
+}
+
+func newNotifierRecorder() *notifierRecorder {
+	return &notifierRecorder{
+		blocks:    make(chan *data.BlockEvents, 100),
+		retracted: make(chan *data.BlockInfo, 100),
+	}
+}
+
+func (recorder *notifierRecorder) handler() *testscommon.BlockEventsHandlerStub {
+	return &testscommon.BlockEventsHandlerStub{
+		HandleBlockEventsCalled: func(blockEvents *data.BlockEvents) error {
+			recorder.blocks <- blockEvents
+			return nil
+		},
+		HandleRetractedBlockCalled: func(block *data.BlockInfo) error {
+			recorder.retracted <- block
+			return nil
+		},
+	}
+}
+
+func (recorder *notifierRecorder) nextBlock(t *testing.T) *data.BlockEvents {
+	select {
+	case blockEvents := <-recorder.blocks:
+		return blockEvents
+	case <-time.After(testTimeout):
+		require.Fail(t, "timeout waiting for a relayed block")
+		return nil
+	}
+}
+
+func (recorder *notifierRecorder) nextRetracted(t *testing.T) *data.BlockInfo {
+	select {
+	case block := <-recorder.retracted:
+		return block
+	case <-time.After(testTimeout):
+		require.Fail(t, "timeout waiting for a retracted block")
+		return nil
+	}
+}
+
+// requireNoBlock checks that no block is relayed for a while
+func (recorder *notifierRecorder) requireNoBlock(t *testing.T) {
+	select {
+	case blockEvents := <-recorder.blocks:
+		require.Fail(t, "unexpected relayed block", "number %d", blockEvents.Number)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// requireNoBlockFrom checks that no block having at least the provided number is relayed for a while
+func (recorder *notifierRecorder) requireNoBlockFrom(t *testing.T, number uint64) {
+	timeout := time.After(200 * time.Millisecond)
+	for {
+		select {
+		case blockEvents := <-recorder.blocks:
+			require.Less(t, blockEvents.Number, number, "unexpected relayed block")
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// countingConnector connects to the simulated chain, counting the connections
+type countingConnector struct {
+	mut            sync.Mutex
+	client         *simulatedClient
+	numConnections int
+}
+
+func (connector *countingConnector) stub() *testscommon.EthClientConnectorStub {
+	return &testscommon.EthClientConnectorStub{
+		ConnectCalled: func(ctx context.Context) (process.EthClient, error) {
+			connector.mut.Lock()
+			defer connector.mut.Unlock()
+
+			connector.numConnections++
+			return connector.client, nil
+		},
+	}
+}
+
+func (connector *countingConnector) getNumConnections() int {
+	connector.mut.Lock()
+	defer connector.mut.Unlock()
+
+	return connector.numConnections
+}
+
+func createTestSubscribedEvents(depositContract common.Address, transferContract common.Address) []config.SubscribedEvent {
+	return []config.SubscribedEvent{
+		{Identifier: "deposit", Signature: depositSignature, Addresses: []string{depositContract.Hex()}},
+		{Identifier: "transfer", Addresses: []string{transferContract.Hex()}},
+	}
+}
+
+func createDepositSubscribedEvents(depositContract common.Address) []config.SubscribedEvent {
+	return []config.SubscribedEvent{
+		{Identifier: "deposit", Signature: depositSignature, Addresses: []string{depositContract.Hex()}},
+	}
+}
+
+func createTestArgs(connector process.EthClientConnector, handler process.BlockEventsHandler, subscribedEvents []config.SubscribedEvent) ArgsEthNotifier {
+	return ArgsEthNotifier{
+		Connector:         connector,
+		Handler:           handler,
+		CheckpointStorer:  checkpoint.NewDisabledCheckpointStorer(),
+		StatusHandler:     status.NewStatusMetrics(),
+		SubscribedEvents:  subscribedEvents,
+		ConfirmationDepth: 1,
+		RetractionWindow:  10,
+		BackfillBatchSize: 100,
+		HeadTimeout:       time.Minute,
+	}
+}
+
+// startNotifier starts a notifier and waits for it to be subscribed
+func startNotifier(t *testing.T, args ArgsEthNotifier) *ethNotifier {
+	notifier, err := NewEthNotifier(args)
+	require.Nil(t, err)
+
+	err = notifier.Start()
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = notifier.Close()
+	})
+
+	waitConnected(t, args.StatusHandler.(status.MetricsGetter))
+
+	return notifier
+}
+
+func waitConnected(t *testing.T, metricsGetter status.MetricsGetter) {
+	require.Eventually(t, func() bool {
+		return metricsGetter.GetMetrics()[process.MetricIsConnected] == "true"
+	}, testTimeout, 10*time.Millisecond)
+}
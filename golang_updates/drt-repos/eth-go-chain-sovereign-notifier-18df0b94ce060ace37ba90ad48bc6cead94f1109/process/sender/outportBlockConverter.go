@@ -0,0 +1,75 @@
+package sender
+
+import (
+	"encoding/hex"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/outport"
+	"github.com/TerraDharitri/drt-go-chain-core/data/transaction"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+// ethereumHeaderType is the header type of the outport blocks created out of Ethereum blocks
+const ethereumHeaderType = "EthereumHeader"
+
+// convertToOutportBlock converts the events of an Ethereum block into an outport block, as consumed by the sovereign chain:
+//   - the block data holds the (RLP encoded) Ethereum header and its hash
+//   - the transaction pool holds one log for each Ethereum transaction, with its events in order of emission
+func convertToOutportBlock(blockEvents *data.BlockEvents) *outport.OutportBlock {
+	return &outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderBytes: blockEvents.RawHeader,
+			HeaderType:  ethereumHeaderType,
+			HeaderHash:  blockEvents.Hash.Bytes(),
+		},
+		TransactionPool: &outport.TransactionPool{
+			Logs: convertToLogs(blockEvents.Events),
+		},
+		HighestFinalBlockNonce: blockEvents.Number,
+		HighestFinalBlockHash:  blockEvents.Hash.Bytes(),
+	}
+}
+
+// convertToRevertedBlockData creates the block data of a revert, identifying the retracted Ethereum block by its hash
+func convertToRevertedBlockData(block *data.BlockInfo) *outport.BlockData {
+	return &outport.BlockData{
+		HeaderType: ethereumHeaderType,
+		HeaderHash: block.Hash.Bytes(),
+	}
+}
+
+func convertToLogs(events []*data.Event) []*outport.LogData {
+	logs := make([]*outport.LogData, 0)
+	logsByTxHash := make(map[string]*outport.LogData)
+
+	for _, event := range events {
+		txHash := hex.EncodeToString(event.TxHash.Bytes())
+
+		logData, found := logsByTxHash[txHash]
+		if !found {
+			logData = &outport.LogData{
+				TxHash: txHash,
+				Log: &transaction.Log{
+					Address: event.Address.Bytes(),
+					Events:  make([]*transaction.Event, 0),
+				},
+			}
+			logsByTxHash[txHash] = logData
+			logs = append(logs, logData)
+		}
+
+		topics := make([][]byte, 0, len(event.Topics))
+		for _, topic := range event.Topics {
+			topics = append(topics, topic.Bytes())
+		}
+
+		logData.Log.Events = append(logData.Log.Events, &transaction.Event{
+			Address:    event.Address.Bytes(),
+			Identifier: []byte(event.Identifier),
+			Topics:     topics,
+			Data:       event.Data,
+		})
+	}
+
+	return logs
+}
@@ -0,0 +1,135 @@
+package sender
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	"github.com/TerraDharitri/drt-go-chain-core/data/outport"
+	"github.com/TerraDharitri/drt-go-chain-core/marshal"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+var log = logger.GetOrCreate("process/sender")
+
+// ArgsWsSender holds the arguments needed to create a websocket sender
+type ArgsWsSender struct {
+	Marshaller    marshal.Marshalizer
+	SenderHost    process.SenderHost
+	RetryDuration time.Duration
+}
+
+type wsSender struct {
+	marshaller    marshal.Marshalizer
+	senderHost    process.SenderHost
+	retryDuration time.Duration
+
+	closeChan chan struct{}
+	closeOnce sync.Once
+}
+
+// NewWsSender creates a sender which delivers the block events to the sovereign chain, as outport blocks, through the
+// provided websocket host (the outport host used by the node and the indexers). Each block is sent until it is
+// delivered (and acknowledged, if the host requires it).
+func NewWsSender(args ArgsWsSender) (*wsSender, error) {
+	err := checkWsSenderArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wsSender{
+		marshaller:    args.Marshaller,
+		senderHost:    args.SenderHost,
+		retryDuration: args.RetryDuration,
+		closeChan:     make(chan struct{}),
+	}, nil
+}
+
+func checkWsSenderArgs(args ArgsWsSender) error {
+	if check.IfNil(args.Marshaller) {
+		return process.ErrNilMarshaller
+	}
+	if check.IfNil(args.SenderHost) {
+		return process.ErrNilSenderHost
+	}
+	if args.RetryDuration <= 0 {
+		return fmt.Errorf("%w for retry duration: %v", process.ErrInvalidValue, args.RetryDuration)
+	}
+
+	return nil
+}
+
+// HandleBlockEvents converts the block events into an outport block and sends it, retrying until it is delivered or the sender is closed
+func (ws *wsSender) HandleBlockEvents(blockEvents *data.BlockEvents) error {
+	message, err := ws.marshaller.Marshal(convertToOutportBlock(blockEvents))
+	if err != nil {
+		return err
+	}
+
+	return ws.sendWithRetry(outport.TopicSaveBlock, message, blockEvents.Number)
+}
+
+// HandleRetractedBlock sends a revert of the (previously sent) block, retrying until it is delivered or the sender is closed
+func (ws *wsSender) HandleRetractedBlock(block *data.BlockInfo) error {
+	message, err := ws.marshaller.Marshal(convertToRevertedBlockData(block))
+	if err != nil {
+		return err
+	}
+
+	return ws.sendWithRetry(outport.TopicRevertIndexedBlock, message, block.Number)
+}
+
+// sendWithRetry sends a message until it is delivered, so that the notifications are delivered in order
+func (ws *wsSender) sendWithRetry(topic string, message []byte, blockNumber uint64) error {
+	for {
+		if ws.isClosed() {
+			return process.ErrSenderClosed
+		}
+
+		err := ws.senderHost.Send(message, topic)
+		if err == nil {
+			log.Debug("sent notification", "topic", topic, "block number", blockNumber)
+			return nil
+		}
+		if ws.isClosed() {
+			return process.ErrSenderClosed
+		}
+
+		log.Warn("cannot send notification, will retry", "topic", topic, "block number", blockNumber, "error", err, "retry in", ws.retryDuration)
+
+		select {
+		case <-time.After(ws.retryDuration):
+		case <-ws.closeChan:
+			return process.ErrSenderClosed
+		}
+	}
+}
+
+func (ws *wsSender) isClosed() bool {
+	select {
+	case <-ws.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops any retry in progress and closes the websocket host
+func (ws *wsSender) Close() error {
+	var err error
+	ws.closeOnce.Do(func() {
+		close(ws.closeChan)
+		err = ws.senderHost.Close()
+	})
+
+	return err
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (ws *wsSender) IsInterfaceNil() bool {
+	return ws == nil
+}
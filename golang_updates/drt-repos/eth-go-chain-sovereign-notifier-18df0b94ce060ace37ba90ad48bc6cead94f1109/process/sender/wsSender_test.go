@@ -0,0 +1,187 @@
+package sender
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/data/outport"
+	"github.com/TerraDharitri/drt-go-chain-core/marshal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/testscommon"
+)
+
+func createMockArgsWsSender() ArgsWsSender {
+	return ArgsWsSender{
+		Marshaller:    &marshal.JsonMarshalizer{},
+		SenderHost:    &testscommon.SenderHostStub{},
+		RetryDuration: time.Millisecond,
+	}
+}
+
+func TestNewWsSender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWsSender()
+		args.Marshaller = nil
+		sender, err := NewWsSender(args)
+		require.Equal(t, process.ErrNilMarshaller, err)
+		require.Nil(t, sender)
+	})
+	t.Run("nil sender host should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWsSender()
+		args.SenderHost = nil
+		sender, err := NewWsSender(args)
+		require.Equal(t, process.ErrNilSenderHost, err)
+		require.Nil(t, sender)
+	})
+	t.Run("invalid retry duration should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWsSender()
+		args.RetryDuration = 0
+		sender, err := NewWsSender(args)
+		require.True(t, errors.Is(err, process.ErrInvalidValue))
+		require.Nil(t, sender)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		sender, err := NewWsSender(createMockArgsWsSender())
+		require.Nil(t, err)
+		require.False(t, sender.IsInterfaceNil())
+	})
+}
+
+func TestWsSender_HandleBlockEvents(t *testing.T) {
+	t.Parallel()
+
+	blockEvents := &data.BlockEvents{
+		Number:    7,
+		Hash:      common.HexToHash("0x07"),
+		RawHeader: []byte("header"),
+		Events: []*data.Event{
+			{Identifier: "deposit", Address: common.HexToAddress("0x01"), TxHash: common.HexToHash("0xaa"), Data: []byte("data")},
+		},
+	}
+
+	t.Run("should send the outport block on the save block topic", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWsSender()
+		var sentPayload []byte
+		args.SenderHost = &testscommon.SenderHostStub{
+			SendCalled: func(payload []byte, topic string) error {
+				require.Equal(t, outport.TopicSaveBlock, topic)
+				sentPayload = payload
+				return nil
+			},
+		}
+		sender, _ := NewWsSender(args)
+
+		err := sender.HandleBlockEvents(blockEvents)
+		require.Nil(t, err)
+
+		outportBlock := &outport.OutportBlock{}
+		err = args.Marshaller.Unmarshal(outportBlock, sentPayload)
+		require.Nil(t, err)
+		require.Equal(t, []byte("header"), outportBlock.BlockData.HeaderBytes)
+		require.Equal(t, blockEvents.Hash.Bytes(), outportBlock.BlockData.HeaderHash)
+		require.Equal(t, uint64(7), outportBlock.HighestFinalBlockNonce)
+		require.Len(t, outportBlock.TransactionPool.Logs, 1)
+		require.Equal(t, []byte("deposit"), outportBlock.TransactionPool.Logs[0].Log.Events[0].Identifier)
+		require.Equal(t, []byte("data"), outportBlock.TransactionPool.Logs[0].Log.Events[0].Data)
+	})
+	t.Run("should retry the same payload until delivered", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWsSender()
+		sentPayloads := make([][]byte, 0)
+		args.SenderHost = &testscommon.SenderHostStub{
+			SendCalled: func(payload []byte, topic string) error {
+				sentPayloads = append(sentPayloads, payload)
+				if len(sentPayloads) < 3 {
+					return errors.New("acknowledge timeout")
+				}
+				return nil
+			},
+		}
+		sender, _ := NewWsSender(args)
+
+		err := sender.HandleBlockEvents(blockEvents)
+		require.Nil(t, err)
+		require.Len(t, sentPayloads, 3)
+		require.Equal(t, sentPayloads[0], sentPayloads[1])
+		require.Equal(t, sentPayloads[0], sentPayloads[2])
+	})
+	t.Run("close should stop the retries", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWsSender()
+		args.RetryDuration = time.Hour
+		numCloseCalls := 0
+		sendCalled := make(chan struct{}, 1)
+		args.SenderHost = &testscommon.SenderHostStub{
+			SendCalled: func(payload []byte, topic string) error {
+				sendCalled <- struct{}{}
+				return errors.New("no connection")
+			},
+			CloseCalled: func() error {
+				numCloseCalls++
+				return nil
+			},
+		}
+		sender, _ := NewWsSender(args)
+
+		var handleErr error
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handleErr = sender.HandleBlockEvents(blockEvents)
+		}()
+
+		<-sendCalled
+		require.Nil(t, sender.Close())
+		require.Nil(t, sender.Close())
+		wg.Wait()
+
+		require.Equal(t, process.ErrSenderClosed, handleErr)
+		require.Equal(t, 1, numCloseCalls)
+		require.Equal(t, process.ErrSenderClosed, sender.HandleBlockEvents(blockEvents))
+	})
+}
+
+func TestWsSender_HandleRetractedBlock(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsWsSender()
+	var sentPayload []byte
+	args.SenderHost = &testscommon.SenderHostStub{
+		SendCalled: func(payload []byte, topic string) error {
+			require.Equal(t, outport.TopicRevertIndexedBlock, topic)
+			sentPayload = payload
+			return nil
+		},
+	}
+	sender, _ := NewWsSender(args)
+
+	err := sender.HandleRetractedBlock(&data.BlockInfo{Number: 7, Hash: common.HexToHash("0x07")})
+	require.Nil(t, err)
+
+	blockData := &outport.BlockData{}
+	err = args.Marshaller.Unmarshal(blockData, sentPayload)
+	require.Nil(t, err)
+	require.Equal(t, ethereumHeaderType, blockData.HeaderType)
+	require.Equal(t, common.HexToHash("0x07").Bytes(), blockData.HeaderHash)
+}
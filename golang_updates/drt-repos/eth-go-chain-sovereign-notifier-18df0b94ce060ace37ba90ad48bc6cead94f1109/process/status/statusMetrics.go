@@ -0,0 +1,85 @@
+package status
+
+import (
+	"sync"
+)
+
+type statusMetrics struct {
+	mut     sync.RWMutex
+	metrics map[string]interface{}
+}
+
+// NewStatusMetrics creates a status handler which keeps the metrics in memory, to be exposed by the status server
+func NewStatusMetrics() *statusMetrics {
+	return &statusMetrics{
+		metrics: make(map[string]interface{}),
+	}
+}
+
+// Increment increments the value of the metric
+func (sm *statusMetrics) Increment(key string) {
+	sm.AddUint64(key, 1)
+}
+
+// AddUint64 adds the value to the metric
+func (sm *statusMetrics) AddUint64(key string, val uint64) {
+	sm.mut.Lock()
+	defer sm.mut.Unlock()
+
+	current, _ := sm.metrics[key].(uint64)
+	sm.metrics[key] = current + val
+}
+
+// Decrement decrements the value of the metric, if positive
+func (sm *statusMetrics) Decrement(key string) {
+	sm.mut.Lock()
+	defer sm.mut.Unlock()
+
+	current, _ := sm.metrics[key].(uint64)
+	if current > 0 {
+		sm.metrics[key] = current - 1
+	}
+}
+
+// SetInt64Value sets the value of the metric
+func (sm *statusMetrics) SetInt64Value(key string, value int64) {
+	sm.setValue(key, value)
+}
+
+// SetUInt64Value sets the value of the metric
+func (sm *statusMetrics) SetUInt64Value(key string, value uint64) {
+	sm.setValue(key, value)
+}
+
+// SetStringValue sets the value of the metric
+func (sm *statusMetrics) SetStringValue(key string, value string) {
+	sm.setValue(key, value)
+}
+
+func (sm *statusMetrics) setValue(key string, value interface{}) {
+	sm.mut.Lock()
+	sm.metrics[key] = value
+	sm.mut.Unlock()
+}
+
+// GetMetrics returns a copy of all the metrics
+func (sm *statusMetrics) GetMetrics() map[string]interface{} {
+	sm.mut.RLock()
+	defer sm.mut.RUnlock()
+
+	metrics := make(map[string]interface{}, len(sm.metrics))
+	for key, value := range sm.metrics {
+		metrics[key] = value
+	}
+
+	return metrics
+}
+
+// Close does nothing
+func (sm *statusMetrics) Close() {
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (sm *statusMetrics) IsInterfaceNil() bool {
+	return sm == nil
+}
@@ -0,0 +1,36 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewStatusMetrics()
+	require.False(t, metrics.IsInterfaceNil())
+	require.Empty(t, metrics.GetMetrics())
+
+	metrics.Increment("counter")
+	metrics.Increment("counter")
+	metrics.AddUint64("counter", 3)
+	metrics.Decrement("counter")
+	metrics.Decrement("zero counter")
+	metrics.SetInt64Value("int64", -7)
+	metrics.SetUInt64Value("uint64", 7)
+	metrics.SetStringValue("string", "value")
+
+	currentMetrics := metrics.GetMetrics()
+	require.Equal(t, map[string]interface{}{
+		"counter": uint64(4),
+		"int64":   int64(-7),
+		"uint64":  uint64(7),
+		"string":  "value",
+	}, currentMetrics)
+
+	// The returned metrics are a copy
+	currentMetrics["string"] = "changed"
+	require.Equal(t, "value", metrics.GetMetrics()["string"])
+}
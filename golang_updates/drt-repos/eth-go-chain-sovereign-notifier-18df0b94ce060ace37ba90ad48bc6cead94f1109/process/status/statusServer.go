@@ -0,0 +1,102 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/TerraDharitri/drt-go-chain-core/core/check"
+	logger "github.com/TerraDharitri/drt-go-chain-logger"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+var log = logger.GetOrCreate("process/status")
+
+const (
+	statusPath      = "/status"
+	shutdownTimeout = 5 * time.Second
+)
+
+// MetricsGetter defines what a provider of the status metrics should do
+type MetricsGetter interface {
+	GetMetrics() map[string]interface{}
+	IsInterfaceNil() bool
+}
+
+type statusServer struct {
+	server        *http.Server
+	metricsGetter MetricsGetter
+}
+
+// NewStatusServer creates an HTTP server exposing the status metrics, as JSON, at /status
+func NewStatusServer(address string, metricsGetter MetricsGetter) (*statusServer, error) {
+	if len(address) == 0 {
+		return nil, process.ErrEmptyUrl
+	}
+	if check.IfNil(metricsGetter) {
+		return nil, process.ErrNilStatusHandler
+	}
+
+	ss := &statusServer{
+		metricsGetter: metricsGetter,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(statusPath, ss.handleStatus)
+	ss.server = &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	return ss, nil
+}
+
+// Start starts listening; the requests are served on a separate goroutine
+func (ss *statusServer) Start() error {
+	listener, err := net.Listen("tcp", ss.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		errServe := ss.server.Serve(listener)
+		if !errors.Is(errServe, http.ErrServerClosed) {
+			log.Error("status server stopped", "error", errServe)
+		}
+	}()
+
+	log.Info("status server started", "address", listener.Addr().String())
+
+	return nil
+}
+
+func (ss *statusServer) handleStatus(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(writer).Encode(ss.metricsGetter.GetMetrics())
+	if err != nil {
+		log.Debug("cannot write the status response", "error", err)
+	}
+}
+
+// Close stops the server
+func (ss *statusServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return ss.server.Shutdown(ctx)
+}
+
+// IsInterfaceNil checks if the underlying pointer is nil
+func (ss *statusServer) IsInterfaceNil() bool {
+	return ss == nil
+}
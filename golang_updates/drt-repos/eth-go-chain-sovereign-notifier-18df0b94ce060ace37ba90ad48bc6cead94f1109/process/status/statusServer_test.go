@@ -0,0 +1,90 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+func getFreeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestNewStatusServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty address should error", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := NewStatusServer("", NewStatusMetrics())
+		require.Equal(t, process.ErrEmptyUrl, err)
+		require.Nil(t, server)
+	})
+	t.Run("nil metrics getter should error", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := NewStatusServer("127.0.0.1:0", nil)
+		require.Equal(t, process.ErrNilStatusHandler, err)
+		require.Nil(t, server)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := NewStatusServer("127.0.0.1:0", NewStatusMetrics())
+		require.Nil(t, err)
+		require.False(t, server.IsInterfaceNil())
+	})
+}
+
+func TestStatusServer_ServesTheMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewStatusMetrics()
+	metrics.SetStringValue(process.MetricIsConnected, "true")
+	metrics.SetUInt64Value(process.MetricLastRelayedBlockNumber, 42)
+	metrics.Increment(process.MetricNumReconnections)
+
+	address := getFreeAddress(t)
+	server, _ := NewStatusServer(address, metrics)
+	err := server.Start()
+	require.Nil(t, err)
+	defer func() {
+		_ = server.Close()
+	}()
+
+	url := fmt.Sprintf("http://%s%s", address, statusPath)
+	response, err := http.Get(url)
+	require.Nil(t, err)
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+	served := make(map[string]interface{})
+	err = json.NewDecoder(response.Body).Decode(&served)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		process.MetricIsConnected:            "true",
+		process.MetricLastRelayedBlockNumber: float64(42),
+		process.MetricNumReconnections:       float64(1),
+	}, served)
+
+	postResponse, err := http.Post(url, "application/json", nil)
+	require.Nil(t, err)
+	_ = postResponse.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, postResponse.StatusCode)
+}
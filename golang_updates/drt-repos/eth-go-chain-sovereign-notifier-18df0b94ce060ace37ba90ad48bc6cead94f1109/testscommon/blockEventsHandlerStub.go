@@ -0,0 +1,34 @@
+package testscommon
+
+import (
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+// BlockEventsHandlerStub -
+type BlockEventsHandlerStub struct {
+	HandleBlockEventsCalled    func(blockEvents *data.BlockEvents) error
+	HandleRetractedBlockCalled func(block *data.BlockInfo) error
+}
+
+// HandleBlockEvents -
+func (stub *BlockEventsHandlerStub) HandleBlockEvents(blockEvents *data.BlockEvents) error {
+	if stub.HandleBlockEventsCalled != nil {
+		return stub.HandleBlockEventsCalled(blockEvents)
+	}
+
+	return nil
+}
+
+// HandleRetractedBlock -
+func (stub *BlockEventsHandlerStub) HandleRetractedBlock(block *data.BlockInfo) error {
+	if stub.HandleRetractedBlockCalled != nil {
+		return stub.HandleRetractedBlockCalled(block)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *BlockEventsHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,34 @@
+package testscommon
+
+import (
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/data"
+)
+
+// CheckpointStorerStub -
+type CheckpointStorerStub struct {
+	LoadCheckpointCalled func() (*data.Checkpoint, error)
+	SaveCheckpointCalled func(checkpoint *data.Checkpoint) error
+}
+
+// LoadCheckpoint -
+func (stub *CheckpointStorerStub) LoadCheckpoint() (*data.Checkpoint, error) {
+	if stub.LoadCheckpointCalled != nil {
+		return stub.LoadCheckpointCalled()
+	}
+
+	return &data.Checkpoint{}, nil
+}
+
+// SaveCheckpoint -
+func (stub *CheckpointStorerStub) SaveCheckpoint(checkpoint *data.Checkpoint) error {
+	if stub.SaveCheckpointCalled != nil {
+		return stub.SaveCheckpointCalled(checkpoint)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *CheckpointStorerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,36 @@
+package testscommon
+
+import (
+	"context"
+
+	"github.com/TerraDharitri/eth-chain-sovereign-notifier-go/process"
+)
+
+// EthClientConnectorStub -
+type EthClientConnectorStub struct {
+	ConnectCalled       func(ctx context.Context) (process.EthClient, error)
+	NotifyHealthyCalled func()
+}
+
+// Connect -
+func (stub *EthClientConnectorStub) Connect(ctx context.Context) (process.EthClient, error) {
+	if stub.ConnectCalled != nil {
+		return stub.ConnectCalled(ctx)
+	}
+
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+// NotifyHealthy -
+func (stub *EthClientConnectorStub) NotifyHealthy() {
+	if stub.NotifyHealthyCalled != nil {
+		stub.NotifyHealthyCalled()
+	}
+}
+
+// IsInterfaceNil -
+func (stub *EthClientConnectorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,72 @@
+package testscommon
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EthClientStub -
+type EthClientStub struct {
+	SubscribeNewHeadCalled    func(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	SubscribeFilterLogsCalled func(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	HeaderByHashCalled        func(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumberCalled      func(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogsCalled          func(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	CloseCalled               func()
+}
+
+// SubscribeNewHead -
+func (stub *EthClientStub) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if stub.SubscribeNewHeadCalled != nil {
+		return stub.SubscribeNewHeadCalled(ctx, ch)
+	}
+
+	return nil, nil
+}
+
+// SubscribeFilterLogs -
+func (stub *EthClientStub) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if stub.SubscribeFilterLogsCalled != nil {
+		return stub.SubscribeFilterLogsCalled(ctx, query, ch)
+	}
+
+	return nil, nil
+}
+
+// HeaderByHash -
+func (stub *EthClientStub) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	if stub.HeaderByHashCalled != nil {
+		return stub.HeaderByHashCalled(ctx, hash)
+	}
+
+	return nil, nil
+}
+
+// HeaderByNumber -
+func (stub *EthClientStub) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if stub.HeaderByNumberCalled != nil {
+		return stub.HeaderByNumberCalled(ctx, number)
+	}
+
+	return nil, nil
+}
+
+// FilterLogs -
+func (stub *EthClientStub) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if stub.FilterLogsCalled != nil {
+		return stub.FilterLogsCalled(ctx, query)
+	}
+
+	return nil, nil
+}
+
+// Close -
+func (stub *EthClientStub) Close() {
+	if stub.CloseCalled != nil {
+		stub.CloseCalled()
+	}
+}
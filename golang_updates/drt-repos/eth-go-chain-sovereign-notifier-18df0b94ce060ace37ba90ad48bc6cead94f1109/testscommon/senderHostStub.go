@@ -0,0 +1,30 @@
+package testscommon
+
+// SenderHostStub -
+type SenderHostStub struct {
+	SendCalled  func(payload []byte, topic string) error
+	CloseCalled func() error
+}
+
+// Send -
+func (stub *SenderHostStub) Send(payload []byte, topic string) error {
+	if stub.SendCalled != nil {
+		return stub.SendCalled(payload, topic)
+	}
+
+	return nil
+}
+
+// Close -
+func (stub *SenderHostStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *SenderHostStub) IsInterfaceNil() bool {
+	return stub == nil
+}